@@ -1,12 +1,26 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
+
+	"quicktodo/internal/models"
+
+	"github.com/gorilla/websocket"
 )
 
 // TestCLIIntegration tests the main CLI commands end-to-end
@@ -284,4 +298,3555 @@ func TestCLIErrorHandling(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+// TestCLIVersionJSON tests the structured version output
+func TestCLIVersionJSON(t *testing.T) {
+	binaryPath := "./quicktodo"
+
+	cmd := exec.Command(binaryPath, "version", "--json")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Version command failed: %v, output: %s", err, output)
+	}
+
+	var info map[string]interface{}
+	if err := json.Unmarshal(output, &info); err != nil {
+		t.Fatalf("Failed to parse JSON version output: %v, output: %s", err, output)
+	}
+
+	for _, field := range []string{"version", "build_time", "go_version", "commit"} {
+		if _, ok := info[field].(string); !ok {
+			t.Errorf("Expected string field '%s' in version output: %s", field, output)
+		}
+	}
+
+	cmd = exec.Command(binaryPath, "version", "--short")
+	output, err = cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Version --short failed: %v, output: %s", err, output)
+	}
+
+	if strings.TrimSpace(string(output)) != info["version"] {
+		t.Errorf("Expected --short to print '%v', got '%s'", info["version"], output)
+	}
+}
+
+// setupCLIProject builds the binary into a temp dir and initializes a project
+// there with an isolated HOME, returning the binary path, project dir and env
+func setupCLIProject(t *testing.T) (string, string, []string) {
+	t.Helper()
+
+	projectDir := t.TempDir()
+	binaryPath := filepath.Join(t.TempDir(), "quicktodo")
+	buildCmd := exec.Command("go", "build", "-o", binaryPath)
+	if output, err := buildCmd.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v, output: %s", err, output)
+	}
+
+	env := append(os.Environ(), "HOME="+t.TempDir())
+	cmd := exec.Command(binaryPath, "init", "cli-test")
+	cmd.Dir = projectDir
+	cmd.Env = env
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Init failed: %v, output: %s", err, output)
+	}
+
+	return binaryPath, projectDir, env
+}
+
+// runCLI runs the binary in dir with the given stdin and returns its output
+func runCLI(t *testing.T, binaryPath, dir string, env []string, stdin string, args ...string) ([]byte, error) {
+	t.Helper()
+
+	cmd := exec.Command(binaryPath, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = strings.NewReader(stdin)
+	return cmd.CombinedOutput()
+}
+
+// cliHome returns the isolated HOME set in a CLI test environment
+func cliHome(env []string) string {
+	var home string
+	for _, entry := range env {
+		if strings.HasPrefix(entry, "HOME=") {
+			home = strings.TrimPrefix(entry, "HOME=")
+		}
+	}
+	return home
+}
+
+// TestCLIStdinJSON tests creating and editing tasks from JSON on stdin
+func TestCLIStdinJSON(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	output, err := runCLI(t, binaryPath, dir, env,
+		`{"title":"Piped task","priority":"high","tags":["agent"],"due_date":"2030-05-01","assigned_to":"bot"}`,
+		"create-task", "--stdin", "--json")
+	if err != nil {
+		t.Fatalf("create-task --stdin failed: %v, output: %s", err, output)
+	}
+
+	var created struct {
+		Task map[string]interface{} `json:"task"`
+	}
+	if err := json.Unmarshal(output, &created); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if created.Task["title"] != "Piped task" || created.Task["priority"] != "high" || created.Task["assigned_to"] != "bot" {
+		t.Errorf("Unexpected created task: %v", created.Task)
+	}
+	if created.Task["due_date"] == nil {
+		t.Errorf("Expected due_date on created task: %v", created.Task)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, `{"status":"in_progress","due_date":""}`, "edit-task", "1", "--stdin", "--json")
+	if err != nil {
+		t.Fatalf("edit-task --stdin failed: %v, output: %s", err, output)
+	}
+
+	var edited struct {
+		Task map[string]interface{} `json:"task"`
+	}
+	if err := json.Unmarshal(output, &edited); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if edited.Task["status"] != "in_progress" || edited.Task["title"] != "Piped task" {
+		t.Errorf("Unexpected edited task: %v", edited.Task)
+	}
+	if _, ok := edited.Task["due_date"]; ok {
+		t.Errorf("Expected due_date to be cleared: %v", edited.Task)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, `{"priority":"high"}`, "create-task", "--stdin"); err == nil {
+		t.Errorf("Expected create-task --stdin without title to fail, output: %s", output)
+	}
+}
+
+// TestCLIMarkCompletedWithNote tests that the closing note and resolution persist
+func TestCLIMarkCompletedWithNote(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Flaky test"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "mark-completed", "1", "--note", "shipped in v1.2", "--resolution", "wontfix", "--json")
+	if err != nil {
+		t.Fatalf("mark-completed failed: %v, output: %s", err, output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "1", "--json")
+	if err != nil {
+		t.Fatalf("display-task failed: %v, output: %s", err, output)
+	}
+	var displayed struct {
+		Task map[string]interface{} `json:"task"`
+	}
+	if err := json.Unmarshal(output, &displayed); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if displayed.Task["status"] != "done" || displayed.Task["resolution"] != "wontfix" || displayed.Task["completed_at"] == nil {
+		t.Errorf("Expected done/wontfix with completed_at, got %v", displayed.Task)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "task-history", "1", "--json")
+	if err != nil {
+		t.Fatalf("task-history failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "shipped in v1.2") {
+		t.Errorf("Expected closing note in history: %s", output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "mark-completed", "1", "--resolution", "maybe"); err == nil {
+		t.Errorf("Expected invalid resolution to fail, output: %s", output)
+	}
+}
+
+// TestCLIProjectMarker tests that init writes a marker and nested dirs find the project
+func TestCLIProjectMarker(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if _, err := os.Stat(filepath.Join(dir, ".quicktodo", "project.json")); err != nil {
+		t.Fatalf("Expected init to create .quicktodo marker: %v", err)
+	}
+
+	nested := filepath.Join(dir, "src", "internal")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create nested dir: %v", err)
+	}
+
+	output, err := runCLI(t, binaryPath, nested, env, "", "create-task", "From subdir", "--json")
+	if err != nil {
+		t.Fatalf("create-task from nested dir failed: %v, output: %s", err, output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--json")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "From subdir") {
+		t.Errorf("Expected task created from nested dir in project: %s", output)
+	}
+}
+
+// TestCLILockContention tests that a busy lock reports its holder and how to release it
+func TestCLILockContention(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	home := cliHome(env)
+
+	// Hold the project lock as this (live) test process
+	hostname, _ := os.Hostname()
+	lockDir := filepath.Join(home, ".config", "quicktodo", "locks")
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		t.Fatalf("Failed to create lock directory: %v", err)
+	}
+	lockPath := filepath.Join(lockDir, "cli-test.lock")
+	content := fmt.Sprintf("%d\n%s\n%s\n", os.Getpid(), time.Now().Add(-42*time.Second).Format(time.RFC3339), hostname)
+	if err := os.WriteFile(lockPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write lock file: %v", err)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Blocked", "--wait", "300ms")
+	if err == nil {
+		t.Fatalf("Expected create-task to fail while the lock is held, output: %s", output)
+	}
+	for _, expected := range []string{
+		fmt.Sprintf("PID %d", os.Getpid()),
+		"held for 4",
+		"quicktodo locks --release cli-test",
+	} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf("Expected contention output to contain %q, got: %s", expected, output)
+		}
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "locks", "--release", "cli-test")
+	if err != nil {
+		t.Fatalf("locks --release failed: %v, output: %s", err, output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "create-task", "Unblocked", "--wait", "300ms")
+	if err != nil {
+		t.Fatalf("Expected create-task to succeed after release: %v, output: %s", err, output)
+	}
+}
+
+// TestCLILocksListAndClean tests listing lock holders' commands and cleaning stale locks
+func TestCLILocksListAndClean(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	// A live holder that has kept the lock past stale_timeout
+	hostname, _ := os.Hostname()
+	lockDir := filepath.Join(cliHome(env), ".config", "quicktodo", "locks")
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		t.Fatalf("Failed to create lock directory: %v", err)
+	}
+	content := fmt.Sprintf("%d\n%s\n%s\nquicktodo import tasks.json\n/work/app\n", os.Getpid(), time.Now().Add(-10*time.Minute).Format(time.RFC3339), hostname)
+	if err := os.WriteFile(filepath.Join(lockDir, "cli-test.lock"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write lock file: %v", err)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "locks")
+	if err != nil {
+		t.Fatalf("locks failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "quicktodo import tasks.json in /work/app") || !strings.Contains(string(output), "[stale]") {
+		t.Errorf("Expected the holder's command and staleness, got:\n%s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "locks", "--clean", "--json")
+	if err != nil {
+		t.Fatalf("locks --clean failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Removed []string `json:"removed"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if len(result.Removed) != 1 || result.Removed[0] != "cli-test" {
+		t.Errorf("Expected the stale lock to be removed, got %s", output)
+	}
+	if _, err := os.Stat(filepath.Join(lockDir, "cli-test.lock")); !os.IsNotExist(err) {
+		t.Errorf("Expected the lock file to be gone, got %v", err)
+	}
+}
+
+// TestCLIOverdueAndCompletedSince tests the standup views on list-tasks
+func TestCLIOverdueAndCompletedSince(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	steps := []struct {
+		stdin string
+		args  []string
+	}{
+		{`{"title":"Late","due_date":"2000-01-01","priority":"high"}`, []string{"create-task", "--stdin"}},
+		{`{"title":"Upcoming","due_date":"2999-01-01"}`, []string{"create-task", "--stdin"}},
+		{"", []string{"create-task", "Finished"}},
+		{"", []string{"mark-completed", "3"}},
+	}
+	for _, step := range steps {
+		if output, err := runCLI(t, binaryPath, dir, env, step.stdin, step.args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", step.args, err, output)
+		}
+	}
+
+	listTitles := func(args ...string) []string {
+		output, err := runCLI(t, binaryPath, dir, env, "", append([]string{"list-tasks", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("list-tasks %v failed: %v, output: %s", args, err, output)
+		}
+		var result struct {
+			Tasks []struct {
+				Title string `json:"title"`
+			} `json:"tasks"`
+		}
+		if err := json.Unmarshal(output, &result); err != nil {
+			t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+		}
+		var titles []string
+		for _, task := range result.Tasks {
+			titles = append(titles, task.Title)
+		}
+		return titles
+	}
+
+	if titles := listTitles("--overdue"); len(titles) != 1 || titles[0] != "Late" {
+		t.Errorf("Expected only the late task to be overdue, got %v", titles)
+	}
+	if titles := listTitles("--overdue", "--priority", "low"); len(titles) != 0 {
+		t.Errorf("Expected --overdue to compose with --priority, got %v", titles)
+	}
+	if titles := listTitles("--completed-since", "1h", "--active"); len(titles) != 1 || titles[0] != "Finished" {
+		t.Errorf("Expected the finished task in --completed-since, got %v", titles)
+	}
+}
+
+// TestCLIInitProjectDocTemplate tests QUICKTODO.md generation from a custom template
+func TestCLIInitProjectDocTemplate(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	// The default template is used when none is configured
+	defaultDoc, err := os.ReadFile(filepath.Join(dir, "QUICKTODO.md"))
+	if err != nil {
+		t.Fatalf("Expected init to generate QUICKTODO.md: %v", err)
+	}
+	if !strings.Contains(string(defaultDoc), "**Project:** cli-test") {
+		t.Errorf("Expected default doc to name the project, got:\n%s", defaultDoc)
+	}
+
+	home := cliHome(env)
+	dataDir := filepath.Join(home, ".config", "quicktodo")
+	template := "# {{.ProjectName}} tasks\n\nTrack work with quicktodo (data in {{.DataDir}}).\n"
+	if err := os.WriteFile(filepath.Join(dataDir, "QUICKTODO.md.tmpl"), []byte(template), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	customDir := t.TempDir()
+	if output, err := runCLI(t, binaryPath, customDir, env, "", "init", "templated"); err != nil {
+		t.Fatalf("init failed: %v, output: %s", err, output)
+	}
+	doc, err := os.ReadFile(filepath.Join(customDir, "QUICKTODO.md"))
+	if err != nil {
+		t.Fatalf("Expected init to generate QUICKTODO.md: %v", err)
+	}
+	expected := "# templated tasks\n\nTrack work with quicktodo (data in " + dataDir + ").\n"
+	if string(doc) != expected {
+		t.Errorf("Unexpected generated doc:\n%s\nexpected:\n%s", doc, expected)
+	}
+
+	noDocDir := t.TempDir()
+	if output, err := runCLI(t, binaryPath, noDocDir, env, "", "init", "undocumented", "--no-doc"); err != nil {
+		t.Fatalf("init --no-doc failed: %v, output: %s", err, output)
+	}
+	if _, err := os.Stat(filepath.Join(noDocDir, "QUICKTODO.md")); !os.IsNotExist(err) {
+		t.Error("Expected --no-doc to skip QUICKTODO.md")
+	}
+}
+
+// TestCLIWIPLimits tests warning and --strict blocking at a WIP limit
+func TestCLIWIPLimits(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	home := cliHome(env)
+	configPath := filepath.Join(home, ".config", "quicktodo", "config.json")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	cfg["wip_limits"] = map[string]int{"in_progress": 1}
+	data, _ = json.Marshal(cfg)
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", title); err != nil {
+			t.Fatalf("create-task failed: %v, output: %s", err, output)
+		}
+	}
+
+	// Under the limit: no warning
+	output, err := runCLI(t, binaryPath, dir, env, "", "mark-in-progress", "1", "--strict")
+	if err != nil {
+		t.Fatalf("Expected move under the limit to succeed: %v, output: %s", err, output)
+	}
+	if strings.Contains(string(output), "WIP limit") {
+		t.Errorf("Expected no WIP warning under the limit, got: %s", output)
+	}
+
+	// At the limit: --strict refuses
+	output, err = runCLI(t, binaryPath, dir, env, "", "mark-in-progress", "2", "--strict")
+	if err == nil {
+		t.Fatalf("Expected --strict to refuse a move into a full status, output: %s", output)
+	}
+	if !strings.Contains(string(output), "in_progress is at its WIP limit (1/1)") {
+		t.Errorf("Expected WIP limit error, got: %s", output)
+	}
+
+	// At the limit without --strict: warns but moves
+	output, err = runCLI(t, binaryPath, dir, env, "", "set-task-status", "3", "in_progress", "--json")
+	if err != nil {
+		t.Fatalf("Expected move without --strict to succeed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Warning: in_progress is at its WIP limit") || !strings.Contains(string(output), `"wip_warning"`) {
+		t.Errorf("Expected WIP warning, got: %s", output)
+	}
+}
+
+// TestCLIAttachments tests attaching and detaching links
+func TestCLIAttachments(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Ship login"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "attach", "1", "https://github.com/org/repo/pull/42", "--name", "pr", "--json")
+	if err != nil {
+		t.Fatalf("attach failed: %v, output: %s", err, output)
+	}
+	var attached struct {
+		Attachment map[string]interface{} `json:"attachment"`
+	}
+	if err := json.Unmarshal(output, &attached); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if attached.Attachment["name"] != "pr" || attached.Attachment["url"] != "https://github.com/org/repo/pull/42" {
+		t.Errorf("Unexpected attachment: %v", attached.Attachment)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "attach", "1", "ftp//nope"); err == nil {
+		t.Errorf("Expected invalid URL to be rejected, output: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "1")
+	if err != nil {
+		t.Fatalf("display-task failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "pr: https://github.com/org/repo/pull/42") {
+		t.Errorf("Expected display-task to list the attachment, got: %s", output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "detach", "1", "pr"); err != nil {
+		t.Fatalf("detach failed: %v, output: %s", err, output)
+	}
+	output, _ = runCLI(t, binaryPath, dir, env, "", "display-task", "1")
+	if strings.Contains(string(output), "Attachments:") {
+		t.Errorf("Expected attachment to be removed, got: %s", output)
+	}
+}
+
+// TestCLIGitFriendlyDatabase tests that no-op saves leave a git-friendly database unchanged
+func TestCLIGitFriendlyDatabase(t *testing.T) {
+	binaryPath, _, env := setupCLIProject(t)
+
+	dir := t.TempDir()
+	if output, err := runCLI(t, binaryPath, dir, env, "", "init", "git-db", "--git-friendly"); err != nil {
+		t.Fatalf("init failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Committed task"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	dbPath := filepath.Join(cliHome(env), ".config", "quicktodo", "projects", "git-db.json")
+	before, err := os.ReadFile(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to read database: %v", err)
+	}
+
+	// Editing a field to its current value saves without changing anything
+	if output, err := runCLI(t, binaryPath, dir, env, "", "edit-task", "1", "--title", "Committed task"); err != nil {
+		t.Fatalf("edit-task failed: %v, output: %s", err, output)
+	}
+	after, err := os.ReadFile(dbPath)
+	if err != nil {
+		t.Fatalf("Failed to read database: %v", err)
+	}
+	if string(before) != string(after) {
+		t.Errorf("Expected an unchanged git-friendly database, got:\n%s\n---\n%s", before, after)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--json")
+	if err != nil || !strings.Contains(string(output), "Committed task") {
+		t.Errorf("Expected git-friendly database to load: %v, output: %s", err, output)
+	}
+}
+
+func TestCLIFindSimilar(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Fix login bug"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Fix the login bug", "--find-similar", "--json")
+	if err == nil {
+		t.Fatalf("Expected near-duplicate to be rejected under --json, output: %s", output)
+	}
+	var rejected struct {
+		Success      bool `json:"success"`
+		SimilarTasks []struct {
+			Task struct {
+				ID int `json:"id"`
+			} `json:"task"`
+		} `json:"similar_tasks"`
+	}
+	if err := json.Unmarshal(output, &rejected); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if rejected.Success || len(rejected.SimilarTasks) != 1 || rejected.SimilarTasks[0].Task.ID != 1 {
+		t.Errorf("Expected task #1 as the only candidate, got: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "n\n", "create-task", "fix login-bug", "--find-similar")
+	if err == nil || !strings.Contains(string(output), "#1 [pending] Fix login bug") {
+		t.Errorf("Expected declined prompt to list task #1 and fail, err: %v, output: %s", err, output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "y\n", "create-task", "Fix the login bug", "--find-similar"); err != nil {
+		t.Fatalf("Expected confirmed create to succeed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Write release notes", "--find-similar"); err != nil {
+		t.Fatalf("Expected unrelated title to be created without prompting: %v, output: %s", err, output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "1")
+	if err != nil {
+		t.Fatalf("display-task failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Similar tasks:") || !strings.Contains(string(output), "#2 [pending] Fix the login bug") {
+		t.Errorf("Expected display-task to list task #2 as similar, got: %s", output)
+	}
+	if strings.Contains(string(output), "Write release notes") {
+		t.Errorf("Expected unrelated task not to be listed, got: %s", output)
+	}
+}
+
+func TestCLIDisplayTaskSnippet(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Fix login bug", "--description", "Users cannot log in", "--priority", "high"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "display-task", "1", "--format", "markdown")
+	if err != nil {
+		t.Fatalf("display-task --format markdown failed: %v, output: %s", err, output)
+	}
+	if !strings.HasPrefix(string(output), "### #1 Fix login bug\n\n`pending` · `high priority`\n\nUsers cannot log in\n") {
+		t.Errorf("Unexpected Markdown snippet: %s", output)
+	}
+
+	snippetPath := filepath.Join(dir, "task.txt")
+	if output, err := runCLI(t, binaryPath, dir, env, "", "display-task", "1", "--format", "slack", "--output-file", snippetPath); err != nil {
+		t.Fatalf("display-task --output-file failed: %v, output: %s", err, output)
+	}
+	data, err := os.ReadFile(snippetPath)
+	if err != nil {
+		t.Fatalf("Failed to read snippet file: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "*#1 Fix login bug*\n") || !strings.Contains(string(data), "*Project:* cli-test\n") {
+		t.Errorf("Unexpected Slack snippet: %s", data)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "display-task", "1", "--format", "html"); err == nil {
+		t.Errorf("Expected unsupported format to be rejected, output: %s", output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "display-task", "1", "--output-file", snippetPath); err == nil {
+		t.Errorf("Expected --output-file without --format to be rejected, output: %s", output)
+	}
+}
+
+func TestCLIClockSkewTolerance(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Skewed"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	home := cliHome(env)
+	dbPath := filepath.Join(home, ".config", "quicktodo", "projects", "cli-test.json")
+	setSkew := func(skew time.Duration) {
+		t.Helper()
+		data, err := os.ReadFile(dbPath)
+		if err != nil {
+			t.Fatalf("Failed to read project database: %v", err)
+		}
+		var db map[string]interface{}
+		if err := json.Unmarshal(data, &db); err != nil {
+			t.Fatalf("Failed to parse project database: %v", err)
+		}
+		task := db["tasks"].([]interface{})[0].(map[string]interface{})
+		created, _ := time.Parse(time.RFC3339Nano, task["created_at"].(string))
+		task["updated_at"] = created.Add(-skew).Format(time.RFC3339Nano)
+		data, _ = json.Marshal(db)
+		if err := os.WriteFile(dbPath, data, 0644); err != nil {
+			t.Fatalf("Failed to write project database: %v", err)
+		}
+	}
+
+	// Skew within the default tolerance still loads
+	setSkew(3 * time.Second)
+	if output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks"); err != nil {
+		t.Fatalf("Expected skew within tolerance to load: %v, output: %s", err, output)
+	}
+
+	setSkew(time.Minute)
+	if output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks"); err == nil {
+		t.Fatalf("Expected skew beyond tolerance to be rejected, output: %s", output)
+	}
+
+	// A larger tolerance with lenient loading repairs the task on save
+	configPath := filepath.Join(home, ".config", "quicktodo", "config.json")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	cfg["clock_skew_tolerance"] = 120
+	cfg["lenient_load"] = true
+	data, _ = json.Marshal(cfg)
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Another"); err != nil {
+		t.Fatalf("Expected lenient load to succeed: %v, output: %s", err, output)
+	}
+	data, _ = os.ReadFile(dbPath)
+	var repaired struct {
+		Tasks []struct {
+			CreatedAt time.Time `json:"created_at"`
+			UpdatedAt time.Time `json:"updated_at"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal(data, &repaired); err != nil {
+		t.Fatalf("Failed to parse project database: %v", err)
+	}
+	if !repaired.Tasks[0].UpdatedAt.Equal(repaired.Tasks[0].CreatedAt) {
+		t.Errorf("Expected updated_at repaired to created_at, got %s vs %s", repaired.Tasks[0].UpdatedAt, repaired.Tasks[0].CreatedAt)
+	}
+}
+
+func TestCLIFocus(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, title := range []string{"First", "Second"} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", title); err != nil {
+			t.Fatalf("create-task failed: %v, output: %s", err, output)
+		}
+	}
+
+	// Without a focus, commands still need an ID
+	if output, err := runCLI(t, binaryPath, dir, env, "", "mark-in-progress", "--agent-id", "agent-a"); err == nil {
+		t.Errorf("Expected mark-in-progress without ID or focus to fail, output: %s", output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "focus", "99"); err == nil {
+		t.Errorf("Expected focusing a missing task to fail, output: %s", output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "focus", "2", "--agent-id", "agent-a"); err != nil {
+		t.Fatalf("focus failed: %v, output: %s", err, output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "focus", "show", "--agent-id", "agent-a", "--json")
+	if err != nil {
+		t.Fatalf("focus show failed: %v, output: %s", err, output)
+	}
+	var shown struct {
+		Focused bool `json:"focused"`
+		TaskID  int  `json:"task_id"`
+	}
+	if err := json.Unmarshal(output, &shown); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if !shown.Focused || shown.TaskID != 2 {
+		t.Errorf("Expected focus on task #2, got: %s", output)
+	}
+
+	// Focus is per agent
+	if output, _ := runCLI(t, binaryPath, dir, env, "", "focus", "--agent-id", "agent-b"); !strings.Contains(string(output), "No focused task") {
+		t.Errorf("Expected agent-b to have no focus, got: %s", output)
+	}
+
+	// Commands default to the focused task
+	if output, err := runCLI(t, binaryPath, dir, env, "", "mark-in-progress", "--agent-id", "agent-a"); err != nil {
+		t.Fatalf("mark-in-progress on focus failed: %v, output: %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "--agent-id", "agent-a")
+	if err != nil {
+		t.Fatalf("display-task on focus failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Task #2") || !strings.Contains(string(output), "Status: in_progress") {
+		t.Errorf("Expected display-task to show focused task #2 in progress, got: %s", output)
+	}
+	output, _ = runCLI(t, binaryPath, dir, env, "", "display-task", "1", "--agent-id", "agent-a")
+	if !strings.Contains(string(output), "Status: pending") {
+		t.Errorf("Expected an explicit ID to override the focus, got: %s", output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "focus", "clear", "--agent-id", "agent-a"); err != nil {
+		t.Fatalf("focus clear failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "mark-completed", "--agent-id", "agent-a"); err == nil {
+		t.Errorf("Expected mark-completed to need an ID after clearing focus, output: %s", output)
+	}
+}
+
+// setCLIConfig sets a top-level field in the isolated config used by runCLI
+func setCLIConfig(t *testing.T, env []string, key string, value interface{}) {
+	t.Helper()
+
+	configPath := filepath.Join(cliHome(env), ".config", "quicktodo", "config.json")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	cfg[key] = value
+	data, _ = json.Marshal(cfg)
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+}
+
+func TestCLIAutoAssignOnStart(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Unassigned"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Claimed", "--agent-id", "agent-b"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Default off"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	type statusChange struct {
+		AutoAssigned string `json:"auto_assigned"`
+		Task         struct {
+			AssignedTo string `json:"assigned_to"`
+		} `json:"task"`
+	}
+	start := func(args ...string) statusChange {
+		t.Helper()
+		output, err := runCLI(t, binaryPath, dir, env, "", append(args, "--json")...)
+		if err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+		var change statusChange
+		if err := json.Unmarshal(output, &change); err != nil {
+			t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+		}
+		return change
+	}
+
+	// Off by default
+	if change := start("mark-in-progress", "3", "--agent-id", "agent-a"); change.Task.AssignedTo != "" || change.AutoAssigned != "" {
+		t.Errorf("Expected no auto-assignment by default, got %+v", change)
+	}
+
+	setCLIConfig(t, env, "auto_assign_on_start", true)
+
+	if change := start("set-task-status", "1", "in_progress", "--agent-id", "agent-a"); change.Task.AssignedTo != "agent-a" || change.AutoAssigned != "agent-a" {
+		t.Errorf("Expected task #1 auto-assigned to agent-a, got %+v", change)
+	}
+	if change := start("mark-in-progress", "2", "--agent-id", "agent-a"); change.Task.AssignedTo != "agent-b" || change.AutoAssigned != "" {
+		t.Errorf("Expected task #2 to stay assigned to agent-b, got %+v", change)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "task-history", "1")
+	if err != nil {
+		t.Fatalf("task-history failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "assigned_to") {
+		t.Errorf("Expected the auto-assignment in the task history, got: %s", output)
+	}
+}
+
+func TestCLINoSync(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "sync", "--enable"); err != nil {
+		t.Fatalf("sync --enable failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Synced"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	todoPath := filepath.Join(cliHome(env), ".config", "quicktodo", "ai_todos.json")
+	before, err := os.ReadFile(todoPath)
+	if err != nil {
+		t.Fatalf("Expected sync to write the TODO file: %v", err)
+	}
+	if !strings.Contains(string(before), "#1 Synced") {
+		t.Fatalf("Expected the created task in the TODO file, got: %s", before)
+	}
+
+	for _, args := range [][]string{
+		{"create-task", "Bulk fixup", "--no-sync"},
+		{"edit-task", "1", "--title", "Renamed", "--no-sync"},
+		{"mark-completed", "1", "--no-sync"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	after, err := os.ReadFile(todoPath)
+	if err != nil {
+		t.Fatalf("Failed to read TODO file: %v", err)
+	}
+	if string(after) != string(before) {
+		t.Errorf("Expected --no-sync to leave the TODO file unchanged.\nBefore: %s\nAfter: %s", before, after)
+	}
+
+	// Without the flag, changes sync again
+	if output, err := runCLI(t, binaryPath, dir, env, "", "mark-in-progress", "2"); err != nil {
+		t.Fatalf("mark-in-progress failed: %v, output: %s", err, output)
+	}
+	after, _ = os.ReadFile(todoPath)
+	if string(after) == string(before) {
+		t.Error("Expected a change without --no-sync to update the TODO file")
+	}
+}
+
+func TestCLITaskHistoryPaging(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "History task"},
+		{"mark-in-progress", "1"},
+		{"edit-task", "1", "--title", "Renamed"},
+		{"mark-completed", "1", "--note", "shipped"},
+		{"mark-pending", "1"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "task-history", "1", "--field", "status", "--limit", "2", "--offset", "1", "--json")
+	if err != nil {
+		t.Fatalf("task-history failed: %v, output: %s", err, output)
+	}
+	var page struct {
+		EventCount int `json:"event_count"`
+		Total      int `json:"total"`
+		Events     []struct {
+			Field string `json:"field"`
+			After string `json:"after"`
+		} `json:"events"`
+	}
+	if err := json.Unmarshal(output, &page); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if page.Total != 3 || page.EventCount != 2 || page.Events[0].After != "done" || page.Events[1].After != "pending" {
+		t.Errorf("Expected status events 2-3 of 3, got: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "task-history", "1", "--limit", "2")
+	if err != nil {
+		t.Fatalf("task-history failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Showing events 1-2 of 8") {
+		t.Errorf("Expected a paging summary, got: %s", output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "task-history", "1", "--field", "stauts"); err == nil {
+		t.Errorf("Expected an unknown field to be rejected, output: %s", output)
+	}
+}
+
+func TestCLIDiff(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	// Nothing has been saved over yet
+	if output, err := runCLI(t, binaryPath, dir, env, "", "diff"); err == nil {
+		t.Fatalf("Expected diff without backups to fail, output: %s", output)
+	}
+
+	for _, args := range [][]string{
+		{"create-task", "Keep"},
+		{"create-task", "Rename me"},
+		{"edit-task", "2", "--title", "Renamed"},
+		{"create-task", "Added later"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "diff", "--list", "--json")
+	if err != nil {
+		t.Fatalf("diff --list failed: %v, output: %s", err, output)
+	}
+	var list struct {
+		Backups []string `json:"backups"`
+	}
+	if err := json.Unmarshal(output, &list); err != nil {
+		t.Fatalf("Failed to parse backup list: %v, output: %s", err, output)
+	}
+	if len(list.Backups) < 3 {
+		t.Fatalf("Expected a backup per save after the first, got %v", list.Backups)
+	}
+
+	// The newest backup holds everything but the last task
+	output, err = runCLI(t, binaryPath, dir, env, "", "diff")
+	if err != nil {
+		t.Fatalf("diff failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "+ #3 Added later") || !strings.Contains(string(output), "1 added, 0 removed, 0 modified") {
+		t.Errorf("Expected task #3 as the only change, got: %s", output)
+	}
+
+	// The second newest backup predates the rename
+	first, second := list.Backups[len(list.Backups)-2], list.Backups[len(list.Backups)-1]
+	output, err = runCLI(t, binaryPath, dir, env, "", "diff", first, second, "--json")
+	if err != nil {
+		t.Fatalf("diff between backups failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Diff struct {
+			Modified []struct {
+				ID      int `json:"id"`
+				Changes []struct {
+					Field string `json:"field"`
+					After string `json:"after"`
+				} `json:"changes"`
+			} `json:"modified"`
+		} `json:"diff"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse diff: %v, output: %s", err, output)
+	}
+	if result.From != first || result.To != second {
+		t.Errorf("Expected %s -> %s, got %s -> %s", first, second, result.From, result.To)
+	}
+	if len(result.Diff.Modified) != 1 || result.Diff.Modified[0].Changes[0].After != "Renamed" {
+		t.Errorf("Expected the rename of task #2, got %+v", result.Diff.Modified)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "diff", "no-such-backup"); err == nil {
+		t.Errorf("Expected an unknown backup to fail, output: %s", output)
+	}
+}
+
+func TestCLICreateTaskInitialStatus(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, status := range []string{"pending", "in_progress", "done"} {
+		output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Backfilled "+status, "--status", status, "--json")
+		if err != nil {
+			t.Fatalf("create-task --status %s failed: %v, output: %s", status, err, output)
+		}
+
+		var created struct {
+			Task map[string]interface{} `json:"task"`
+		}
+		if err := json.Unmarshal(output, &created); err != nil {
+			t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+		}
+		if created.Task["status"] != status {
+			t.Errorf("Expected status %s, got %v", status, created.Task["status"])
+		}
+		if _, completed := created.Task["completed_at"]; completed != (status == "done") {
+			t.Errorf("Expected completed_at only for done tasks, got %v for %s", created.Task["completed_at"], status)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Started", "-s", "IN_PROGRESS")
+	if err != nil {
+		t.Fatalf("create-task -s failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Created task #4: Started (in_progress)") {
+		t.Errorf("Expected the initial status in the output, got: %s", output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Bad", "--status", "sideways"); err == nil {
+		t.Errorf("Expected an invalid status to fail, output: %s", output)
+	}
+}
+
+func TestCLIStatusAliases(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Aliased", "--priority", "HI"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	for _, tt := range []struct {
+		input string
+		want  string
+	}{
+		{"wip", "in_progress"},
+		{"Completed", "done"},
+		{"todo", "pending"},
+		{"in-progress", "in_progress"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", "set-task-status", "1", tt.input); err != nil {
+			t.Fatalf("set-task-status %s failed: %v, output: %s", tt.input, err, output)
+		}
+
+		output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--status", tt.input, "--priority", "hi", "--json")
+		if err != nil {
+			t.Fatalf("list-tasks --status %s failed: %v, output: %s", tt.input, err, output)
+		}
+		var listed struct {
+			Tasks []map[string]interface{} `json:"tasks"`
+		}
+		if err := json.Unmarshal(output, &listed); err != nil {
+			t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+		}
+		if len(listed.Tasks) != 1 || listed.Tasks[0]["status"] != tt.want || listed.Tasks[0]["priority"] != "high" {
+			t.Errorf("Expected %q stored as %s, got %v", tt.input, tt.want, listed.Tasks)
+		}
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "set-task-status", "1", "sideways"); err == nil {
+		t.Errorf("Expected an unknown status to fail, output: %s", output)
+	}
+}
+
+func TestCLIChecklist(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Ship release"},
+		{"check-add", "1", "Tag version"},
+		{"check-add", "1", "Publish notes"},
+		{"check-toggle", "1", "1"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "display-task", "1")
+	if err != nil {
+		t.Fatalf("display-task failed: %v, output: %s", err, output)
+	}
+	for _, want := range []string{"Checklist (1/2):", "1. [x] Tag version", "2. [ ] Publish notes"} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("Expected %q in display-task output, got: %s", want, output)
+		}
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "1", "--json")
+	if err != nil {
+		t.Fatalf("display-task --json failed: %v, output: %s", err, output)
+	}
+	var displayed struct {
+		Task struct {
+			Checklist         []map[string]interface{} `json:"checklist"`
+			ChecklistProgress map[string]int           `json:"checklist_progress"`
+		} `json:"task"`
+	}
+	if err := json.Unmarshal(output, &displayed); err != nil {
+		t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+	}
+	if len(displayed.Task.Checklist) != 2 || displayed.Task.ChecklistProgress["done"] != 1 || displayed.Task.ChecklistProgress["total"] != 2 {
+		t.Errorf("Expected 2 items with 1 done, got %+v", displayed.Task)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "check-toggle", "1", "3"); err == nil {
+		t.Errorf("Expected an out-of-range index to fail, output: %s", output)
+	}
+}
+
+func TestCLIAssignRotation(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	teamFile := filepath.Join(dir, "team.txt")
+	if err := os.WriteFile(teamFile, []byte("# on call\nalice\n\nbob\ncarol\n"), 0644); err != nil {
+		t.Fatalf("Failed to write assignee file: %v", err)
+	}
+
+	var got []string
+	for i := 1; i <= 4; i++ {
+		if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", fmt.Sprintf("Task %d", i)); err != nil {
+			t.Fatalf("create-task failed: %v, output: %s", err, output)
+		}
+
+		output, err := runCLI(t, binaryPath, dir, env, "", "assign", strconv.Itoa(i), "--rotate", "--assignee-file", teamFile, "--json")
+		if err != nil {
+			t.Fatalf("assign --rotate failed: %v, output: %s", err, output)
+		}
+		var assigned struct {
+			AssignedTo string `json:"assigned_to"`
+		}
+		if err := json.Unmarshal(output, &assigned); err != nil {
+			t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+		}
+		got = append(got, assigned.AssignedTo)
+	}
+
+	want := []string{"alice", "bob", "carol", "alice"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected rotation %v, got %v", want, got)
+		}
+	}
+
+	// A direct assignment leaves the rotation where it was
+	if output, err := runCLI(t, binaryPath, dir, env, "", "assign", "1", "dave"); err != nil || !strings.Contains(string(output), "Assigned task #1 to dave") {
+		t.Fatalf("assign failed: %v, output: %s", err, output)
+	}
+	output, err := runCLI(t, binaryPath, dir, env, "", "assign", "2", "--rotate", "--assignee-file", teamFile)
+	if err != nil || !strings.Contains(string(output), "to bob (rotation)") {
+		t.Errorf("Expected the rotation to continue with bob, got: %s (%v)", output, err)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "assign", "1", "--rotate"); err == nil {
+		t.Errorf("Expected --rotate without --assignee-file to fail, output: %s", output)
+	}
+}
+
+func TestCLIListTasksFlatJSON(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	// An empty project is an empty array, not null
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--flat-json")
+	if err != nil {
+		t.Fatalf("list-tasks --flat-json failed: %v, output: %s", err, output)
+	}
+	if strings.TrimSpace(string(output)) != "[]" {
+		t.Errorf("Expected an empty array, got: %s", output)
+	}
+
+	for _, args := range [][]string{
+		{"create-task", "First", "--priority", "high"},
+		{"create-task", "Second"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--flat-json")
+	if err != nil {
+		t.Fatalf("list-tasks --flat-json failed: %v, output: %s", err, output)
+	}
+	var tasks []models.Task
+	if err := json.Unmarshal(output, &tasks); err != nil {
+		t.Fatalf("Expected a top-level task array: %v, output: %s", err, output)
+	}
+	if len(tasks) != 2 || tasks[0].Title != "First" || tasks[0].Priority != models.PriorityHigh {
+		t.Errorf("Unexpected tasks: %+v", tasks)
+	}
+
+	// Filters still apply
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--priority", "high", "--flat-json")
+	if err != nil {
+		t.Fatalf("list-tasks --flat-json failed: %v, output: %s", err, output)
+	}
+	tasks = nil
+	if err := json.Unmarshal(output, &tasks); err != nil || len(tasks) != 1 {
+		t.Errorf("Expected one high priority task, got %s (%v)", output, err)
+	}
+}
+
+// TestCLIExportImportState tests round-tripping all projects through a state directory
+func TestCLIExportImportState(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+	stateDir := filepath.Join(t.TempDir(), "state")
+
+	for _, args := range [][]string{
+		{"create-task", "First", "--priority", "high"},
+		{"create-task", "Second", "--description", "Round trip"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "export-state", "--dir", stateDir); err != nil {
+		t.Fatalf("export-state failed: %v, output: %s", err, output)
+	}
+	projectFile := filepath.Join(stateDir, "projects", "cli-test.json")
+	first, err := os.ReadFile(projectFile)
+	if err != nil {
+		t.Fatalf("Expected a project file: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(stateDir, "registry.json")); err != nil {
+		t.Fatalf("Expected a registry file: %v", err)
+	}
+
+	// Exporting unchanged data gives the same bytes
+	if output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks"); err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "export-state", "--dir", stateDir); err != nil {
+		t.Fatalf("export-state failed: %v, output: %s", err, output)
+	}
+	second, err := os.ReadFile(projectFile)
+	if err != nil {
+		t.Fatalf("Failed to read project file: %v", err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("Expected a stable export, got:\n%s\nthen:\n%s", first, second)
+	}
+
+	// Import into an empty data directory
+	freshEnv := append(os.Environ(), "HOME="+t.TempDir())
+	output, err := runCLI(t, binaryPath, dir, freshEnv, "", "import-state", "--dir", stateDir, "--json")
+	if err != nil {
+		t.Fatalf("import-state failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Imported   []string `json:"imported"`
+		Registered []string `json:"registered"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if len(result.Imported) != 1 || len(result.Registered) != 1 || result.Registered[0] != "cli-test" {
+		t.Errorf("Unexpected import result: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, freshEnv, "", "list-tasks", "--flat-json")
+	if err != nil {
+		t.Fatalf("list-tasks after import failed: %v, output: %s", err, output)
+	}
+	var tasks []models.Task
+	if err := json.Unmarshal(output, &tasks); err != nil {
+		t.Fatalf("Failed to parse tasks: %v, output: %s", err, output)
+	}
+	if len(tasks) != 2 || tasks[0].Title != "First" || tasks[1].Title != "Second" || tasks[1].Description != "Round trip" {
+		t.Errorf("Unexpected tasks after import: %+v", tasks)
+	}
+
+	// The imported project keeps numbering where the export left off
+	output, err = runCLI(t, binaryPath, dir, freshEnv, "", "create-task", "Third")
+	if err != nil || !strings.Contains(string(output), "#3") {
+		t.Errorf("Expected task #3 after import, got %s (%v)", output, err)
+	}
+}
+
+// TestCLIPaths tests that paths reports the data dir chosen in the config file
+func TestCLIPaths(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "paths", "--json")
+	if err != nil {
+		t.Fatalf("paths failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Paths struct {
+			Config        struct{ Path string } `json:"config"`
+			DataDir       struct{ Path string } `json:"data_dir"`
+			DataDirSource string                `json:"data_dir_source"`
+			Project       *struct {
+				Name     string `json:"name"`
+				Database struct {
+					Path   string `json:"path"`
+					Exists bool   `json:"exists"`
+				} `json:"database"`
+			} `json:"project"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	defaultDir := filepath.Join(cliHome(env), ".config", "quicktodo")
+	if result.Paths.DataDir.Path != defaultDir || result.Paths.DataDirSource != "default" {
+		t.Errorf("Expected the default data dir %s, got %s", defaultDir, output)
+	}
+	if result.Paths.Config.Path != filepath.Join(defaultDir, "config.json") {
+		t.Errorf("Unexpected config path: %s", output)
+	}
+	if result.Paths.Project == nil || result.Paths.Project.Name != "cli-test" || !result.Paths.Project.Database.Exists {
+		t.Errorf("Expected the cli-test project with an existing database, got %s", output)
+	}
+
+	// A data_dir override moves everything but the config file
+	dataDir := t.TempDir()
+	setCLIConfig(t, env, "data_dir", dataDir)
+	output, err = runCLI(t, binaryPath, t.TempDir(), env, "", "env")
+	if err != nil {
+		t.Fatalf("env failed: %v, output: %s", err, output)
+	}
+	for _, want := range []string{
+		filepath.Join(defaultDir, "config.json"),
+		dataDir + " (data_dir in config file)",
+		filepath.Join(dataDir, "projects.json"),
+		"not a registered project",
+	} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+// TestCLIEnvOverrides tests running against a config and data dir chosen
+// through the environment, leaving the home directory alone
+func TestCLIEnvOverrides(t *testing.T) {
+	binaryPath, _, env := setupCLIProject(t)
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	dataDir := t.TempDir()
+	isolated := append(append([]string{}, env...), "QUICKTODO_CONFIG="+configPath, "QUICKTODO_DATA_DIR="+dataDir, "QUICKTODO_DEFAULT_PRIORITY=high")
+
+	projectDir := t.TempDir()
+	if output, err := runCLI(t, binaryPath, projectDir, isolated, "", "init", "isolated"); err != nil {
+		t.Fatalf("init failed: %v, output: %s", err, output)
+	}
+	output, err := runCLI(t, binaryPath, projectDir, isolated, "", "create-task", "Isolated task", "--json")
+	if err != nil || !strings.Contains(string(output), `"priority": "high"`) {
+		t.Errorf("Expected a high priority task from QUICKTODO_DEFAULT_PRIORITY, got: %v, %s", err, output)
+	}
+
+	if _, err := os.Stat(filepath.Join(dataDir, "projects", "isolated.json")); err != nil {
+		t.Errorf("Expected the project database in QUICKTODO_DATA_DIR: %v", err)
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		t.Errorf("Expected the config file at QUICKTODO_CONFIG: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(cliHome(env), ".config", "quicktodo", "projects", "isolated.json")); err == nil {
+		t.Error("Expected nothing to be written to the home data dir")
+	}
+
+	output, err = runCLI(t, binaryPath, projectDir, isolated, "", "paths")
+	if err != nil || !strings.Contains(string(output), dataDir+" (QUICKTODO_DATA_DIR)") || !strings.Contains(string(output), configPath) {
+		t.Errorf("Expected paths to report the overrides, got: %v, %s", err, output)
+	}
+}
+
+// TestCLIDueDates tests setting, clearing and filtering on due dates
+func TestCLIDueDates(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Late", "--due", "2020-01-01"},
+		{"create-task", "Upcoming", "--due", "+3d"},
+		{"create-task", "Undated"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Bad", "--due", "soon")
+	if err == nil || !strings.Contains(string(output), "invalid due date") {
+		t.Errorf("Expected an invalid due date error, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--flat-json")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	var tasks []models.Task
+	if err := json.Unmarshal(output, &tasks); err != nil {
+		t.Fatalf("Failed to parse tasks: %v, output: %s", err, output)
+	}
+	if len(tasks) != 3 || tasks[1].DueDate == nil || !tasks[1].DueDate.After(time.Now()) {
+		t.Fatalf("Expected a future due date on task #2, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.Contains(line, "OVERDUE") != strings.Contains(line, "Late") {
+			t.Errorf("Expected only the late task marked overdue: %q", line)
+		}
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--overdue", "--flat-json")
+	if err != nil {
+		t.Fatalf("list-tasks --overdue failed: %v, output: %s", err, output)
+	}
+	tasks = nil
+	if err := json.Unmarshal(output, &tasks); err != nil || len(tasks) != 1 || tasks[0].Title != "Late" {
+		t.Errorf("Expected only the late task, got %s (%v)", output, err)
+	}
+
+	// Clearing the due date takes the task off the overdue list
+	if output, err := runCLI(t, binaryPath, dir, env, "", "edit-task", "1", "--due", "none"); err != nil {
+		t.Fatalf("edit-task --due none failed: %v, output: %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--overdue", "--flat-json")
+	if err != nil || strings.TrimSpace(string(output)) != "[]" {
+		t.Errorf("Expected no overdue tasks after clearing, got %s (%v)", output, err)
+	}
+}
+
+// TestCLIAssignments tests the per-assignee open task summary and limit
+func TestCLIAssignments(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "One", "--agent-id", "busy"},
+		{"create-task", "Two", "--agent-id", "busy"},
+		{"create-task", "Three", "--agent-id", "busy"},
+		{"create-task", "Four", "--agent-id", "idle"},
+		{"create-task", "Five"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+	setCLIConfig(t, env, "max_open_per_assignee", 2)
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "assignments", "--json")
+	if err != nil {
+		t.Fatalf("assignments failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Limit      int                    `json:"limit"`
+		Assignees  []*models.AssigneeLoad `json:"assignees"`
+		Unassigned int                    `json:"unassigned"`
+		OverLimit  []string               `json:"over_limit"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if result.Limit != 2 || len(result.Assignees) != 2 || result.Assignees[0].Assignee != "busy" || result.Assignees[0].Open != 3 {
+		t.Errorf("Unexpected assignments: %s", output)
+	}
+	if len(result.OverLimit) != 1 || result.OverLimit[0] != "busy" || result.Unassigned != 1 {
+		t.Errorf("Expected busy over the limit and one unassigned task, got %s", output)
+	}
+
+	// --max-open overrides the config
+	output, err = runCLI(t, binaryPath, dir, env, "", "assignments", "--max-open", "5")
+	if err != nil {
+		t.Fatalf("assignments --max-open failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "(limit 5)") || strings.Contains(string(output), "over limit") {
+		t.Errorf("Expected nobody over a limit of 5, got:\n%s", output)
+	}
+
+	// Done tasks are counted apart, and --assigned-to shows one assignee
+	if output, err := runCLI(t, binaryPath, dir, env, "", "set-task-status", "1", "done"); err != nil {
+		t.Fatalf("set-task-status failed: %v, output: %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "assignments", "--assigned-to", "busy", "--json")
+	if err != nil {
+		t.Fatalf("assignments --assigned-to failed: %v, output: %s", err, output)
+	}
+	var single struct {
+		Assignee *models.AssigneeLoad      `json:"assignee"`
+		Tasks    map[string][]*models.Task `json:"tasks"`
+	}
+	if err := json.Unmarshal(output, &single); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if single.Assignee.Open != 2 || single.Assignee.Done != 1 || single.Assignee.OverLimit {
+		t.Errorf("Expected busy with 2 open and 1 done task, got %s", output)
+	}
+	for _, tasks := range single.Tasks {
+		if len(tasks) != 3 {
+			t.Errorf("Expected busy's 3 tasks, got %s", output)
+		}
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "assignments")
+	if err != nil {
+		t.Fatalf("assignments failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "1 done") || !strings.Contains(string(output), "(unassigned)") {
+		t.Errorf("Expected done counts and the unassigned bucket, got:\n%s", output)
+	}
+}
+
+// TestCLIBulkSetTaskStatus tests changing several tasks at once with partial failures
+func TestCLIBulkSetTaskStatus(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, title := range []string{"One", "Two", "Three"} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", title); err != nil {
+			t.Fatalf("create-task failed: %v, output: %s", err, output)
+		}
+	}
+
+	// Missing and invalid IDs fail without stopping the valid ones
+	output, err := runCLI(t, binaryPath, dir, env, "", "set-task-status", "1", "abc", "3", "99", "done", "--json")
+	if err == nil {
+		t.Errorf("Expected a non-zero exit with failed IDs, output: %s", output)
+	}
+	var result struct {
+		Success bool `json:"success"`
+		Updated int  `json:"updated"`
+		Failed  int  `json:"failed"`
+		Results []struct {
+			Input     string `json:"input"`
+			ID        int    `json:"id"`
+			Success   bool   `json:"success"`
+			Error     string `json:"error"`
+			OldStatus string `json:"old_status"`
+			NewStatus string `json:"new_status"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Expected a single JSON object: %v, output: %s", err, output)
+	}
+	if result.Success || result.Updated != 2 || result.Failed != 2 || len(result.Results) != 4 {
+		t.Fatalf("Unexpected bulk result: %s", output)
+	}
+	for i, want := range []bool{true, false, true, false} {
+		if result.Results[i].Success != want {
+			t.Errorf("Result %d (%s): expected success=%v, got %+v", i, result.Results[i].Input, want, result.Results[i])
+		}
+	}
+	if result.Results[0].OldStatus != "pending" || result.Results[0].NewStatus != "done" {
+		t.Errorf("Unexpected status change: %+v", result.Results[0])
+	}
+	if !strings.Contains(result.Results[3].Error, "not found") {
+		t.Errorf("Expected a not found error for #99, got %q", result.Results[3].Error)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--flat-json", "--all")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	var tasks []models.Task
+	if err := json.Unmarshal(output, &tasks); err != nil {
+		t.Fatalf("Failed to parse tasks: %v, output: %s", err, output)
+	}
+	done := 0
+	for _, task := range tasks {
+		if task.Status == models.StatusDone {
+			done++
+			if task.ID == 2 {
+				t.Error("Task #2 was not listed but changed")
+			}
+		}
+	}
+	if done != 2 {
+		t.Errorf("Expected 2 done tasks, got %d", done)
+	}
+
+	// A fully valid bulk change succeeds
+	output, err = runCLI(t, binaryPath, dir, env, "", "set-task-status", "1", "2", "wip")
+	if err != nil || !strings.Contains(string(output), "Updated 2 of 2 task(s)") {
+		t.Errorf("Expected both tasks updated, got %s (%v)", output, err)
+	}
+}
+
+// TestCLIStartDates tests start dates, the --scheduled view, and start/due validation
+func TestCLIStartDates(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Later", "--start", "+7d"},
+		{"create-task", "Started", "--start", "2020-01-01"},
+		{"create-task", "Anytime"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--scheduled", "--flat-json")
+	if err != nil {
+		t.Fatalf("list-tasks --scheduled failed: %v, output: %s", err, output)
+	}
+	var tasks []models.Task
+	if err := json.Unmarshal(output, &tasks); err != nil {
+		t.Fatalf("Failed to parse tasks: %v, output: %s", err, output)
+	}
+	if len(tasks) != 2 || tasks[0].ID == 1 || tasks[1].ID == 1 {
+		t.Errorf("Expected the future task hidden, got %s", output)
+	}
+
+	// Start after due is rejected on create and edit
+	output, err = runCLI(t, binaryPath, dir, env, "", "create-task", "Backwards", "--start", "+5d", "--due", "+1d")
+	if err == nil || !strings.Contains(string(output), "after due date") {
+		t.Errorf("Expected start after due to be rejected, got %s", output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "edit-task", "1", "--due", "+1d")
+	if err == nil || !strings.Contains(string(output), "after due date") {
+		t.Errorf("Expected a due date before the start to be rejected, got %s", output)
+	}
+
+	// Clearing the start date makes the task actionable
+	if output, err := runCLI(t, binaryPath, dir, env, "", "edit-task", "1", "--start", "none"); err != nil {
+		t.Fatalf("edit-task --start none failed: %v, output: %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--scheduled", "--flat-json")
+	if err != nil {
+		t.Fatalf("list-tasks --scheduled failed: %v, output: %s", err, output)
+	}
+	tasks = nil
+	if err := json.Unmarshal(output, &tasks); err != nil || len(tasks) != 3 {
+		t.Errorf("Expected all tasks after clearing the start date, got %s (%v)", output, err)
+	}
+}
+
+func TestCLIDependencies(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Build"},
+		{"create-task", "Test"},
+		{"create-task", "Deploy", "--depends-on", "1,2"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	// Unknown IDs and cycles are refused
+	output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Announce", "--depends-on", "9")
+	if err == nil || !strings.Contains(string(output), "#9 not found") {
+		t.Errorf("Expected unknown dependency to be rejected, got %s", output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "edit-task", "1", "--depends-on", "3")
+	if err == nil || !strings.Contains(string(output), "cycle") {
+		t.Errorf("Expected dependency cycle to be rejected, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "3")
+	if err != nil {
+		t.Fatalf("display-task failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Blocked by: #1, #2") {
+		t.Errorf("Expected task #3 shown as blocked, got %s", output)
+	}
+
+	// Completion waits for the dependencies unless forced
+	output, err = runCLI(t, binaryPath, dir, env, "", "mark-completed", "3")
+	if err == nil || !strings.Contains(string(output), "blocked by unfinished task(s) #1, #2") {
+		t.Errorf("Expected blocked task completion to be refused, got %s", output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "set-task-status", "1", "2", "done"); err != nil {
+		t.Fatalf("set-task-status failed: %v, output: %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "3", "--json")
+	if err != nil {
+		t.Fatalf("display-task --json failed: %v, output: %s", err, output)
+	}
+	var detail struct {
+		Blocked bool `json:"blocked"`
+	}
+	if err := json.Unmarshal(output, &detail); err != nil || detail.Blocked {
+		t.Errorf("Expected task #3 unblocked, got %s (%v)", output, err)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "mark-completed", "3"); err != nil {
+		t.Fatalf("mark-completed failed once unblocked: %v, output: %s", err, output)
+	}
+
+	// --force completes a blocked task, and "none" clears dependencies
+	for _, args := range [][]string{
+		{"create-task", "Follow-up"},
+		{"create-task", "Retro", "--depends-on", "4"},
+		{"mark-completed", "5", "--force"},
+		{"edit-task", "5", "--depends-on", "none"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--flat-json", "--all")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	var tasks []models.Task
+	if err := json.Unmarshal(output, &tasks); err != nil {
+		t.Fatalf("Failed to parse tasks: %v, output: %s", err, output)
+	}
+	for _, task := range tasks {
+		if task.ID == 5 && (task.Status != models.StatusDone || len(task.DependsOn) != 0) {
+			t.Errorf("Expected task #5 done without dependencies, got %+v", task)
+		}
+	}
+}
+
+func TestCLITaskAge(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Fresh"},
+		{"create-task", "Finished", "--status", "done"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "task-age", "--open", "--json")
+	if err != nil {
+		t.Fatalf("task-age failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Total   int `json:"total"`
+		Buckets []struct {
+			Label   string `json:"label"`
+			Count   int    `json:"count"`
+			TaskIDs []int  `json:"task_ids"`
+		} `json:"buckets"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+	}
+	if result.Total != 1 || len(result.Buckets) != 4 || result.Buckets[0].Count != 1 || result.Buckets[0].TaskIDs[0] != 1 {
+		t.Errorf("Expected only the open task in the <1d bucket, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "task-age")
+	if err != nil {
+		t.Fatalf("task-age failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "<1d") || !strings.Contains(string(output), "█ 2") {
+		t.Errorf("Expected a histogram with both tasks under a day old, got %s", output)
+	}
+}
+
+func TestCLIEditTaskStatus(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+	setCLIConfig(t, env, "wip_limits", map[string]int{"in_progress": 1})
+
+	for _, title := range []string{"Draft", "Other"} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", title); err != nil {
+			t.Fatalf("create-task failed: %v, output: %s", err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "edit-task", "1", "--title", "Final", "--status", "wip", "--json")
+	if err != nil {
+		t.Fatalf("edit-task failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Task models.Task `json:"task"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+	}
+	if result.Task.Title != "Final" || result.Task.Status != models.StatusInProgress {
+		t.Errorf("Expected title and status updated together, got %+v", result.Task)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "task-history", "1")
+	if err != nil {
+		t.Fatalf("task-history failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "title") || !strings.Contains(string(output), "status") {
+		t.Errorf("Expected title and status changes in the history, got: %s", output)
+	}
+
+	// The WIP limit applies as in set-task-status
+	output, err = runCLI(t, binaryPath, dir, env, "", "edit-task", "2", "--status", "in_progress", "--strict")
+	if err == nil || !strings.Contains(string(output), "in_progress is at its WIP limit (1/1)") {
+		t.Errorf("Expected --strict to refuse the status change, got: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "edit-task", "2", "--status", "bogus")
+	if err == nil || !strings.Contains(string(output), "invalid status") {
+		t.Errorf("Expected an invalid status to be rejected, got: %s", output)
+	}
+}
+
+func TestCLIExportMarkdown(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Write changelog", "--priority", "high"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "TODO.md")
+	if output, err := runCLI(t, binaryPath, dir, env, "", "export", "--format", "markdown", "-o", outputFile); err != nil {
+		t.Fatalf("export failed: %v, output: %s", err, output)
+	}
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read exported file: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# cli-test\n") || !strings.Contains(string(data), "- [ ] 🔴 #1 Write changelog") {
+		t.Errorf("Unexpected export:\n%s", data)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "export", "--format", "html")
+	if err == nil || !strings.Contains(string(output), "unsupported format 'html'") {
+		t.Errorf("Expected an unsupported format error, got: %s", output)
+	}
+}
+
+func TestCLIExportImportCSV(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Fix login, again", "--description", "Line one\nLine \"two\"", "--priority", "high"},
+		{"create-task", "Ship it", "--status", "done"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	csvPath := filepath.Join(t.TempDir(), "tasks.csv")
+	if output, err := runCLI(t, binaryPath, dir, env, "", "export", "--format", "csv", "-o", csvPath); err != nil {
+		t.Fatalf("export failed: %v, output: %s", err, output)
+	}
+
+	// Add a row that can't be imported
+	data, err := os.ReadFile(csvPath)
+	if err != nil {
+		t.Fatalf("Failed to read export: %v", err)
+	}
+	data = append(data, []byte("99,Broken,,pending,urgent,,,\n")...)
+	if err := os.WriteFile(csvPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "import", "--format", "csv", csvPath, "--json")
+	if err != nil {
+		t.Fatalf("import failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		ImportedCount int `json:"imported_count"`
+		SkippedCount  int `json:"skipped_count"`
+		Skipped       []struct {
+			Row    int    `json:"row"`
+			Reason string `json:"reason"`
+		} `json:"skipped"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+	}
+	if result.ImportedCount != 2 || result.SkippedCount != 1 || result.Skipped[0].Reason != "invalid priority 'urgent'" {
+		t.Errorf("Expected 2 rows imported and 1 skipped, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--flat-json", "--all")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	var tasks []models.Task
+	if err := json.Unmarshal(output, &tasks); err != nil {
+		t.Fatalf("Failed to parse tasks: %v, output: %s", err, output)
+	}
+	imported := make(map[int]models.Task)
+	for _, task := range tasks {
+		imported[task.ID] = task
+	}
+	if len(imported) != 4 {
+		t.Fatalf("Expected 4 tasks after import, got %s", output)
+	}
+	if task := imported[3]; task.Title != "Fix login, again" || task.Description != "Line one\nLine \"two\"" || task.Priority != models.PriorityHigh {
+		t.Errorf("Expected task #1 copied as #3, got %+v", task)
+	}
+	if task := imported[4]; task.Title != "Ship it" || task.Status != models.StatusDone {
+		t.Errorf("Expected task #2 copied as #4, got %+v", task)
+	}
+}
+
+func TestCLIAssumeYes(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Fix the login bug"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	// Stdin says no, but the prompt must not read it
+	output, err := runCLI(t, binaryPath, dir, env, "n\n", "create-task", "fix login-bug", "--find-similar", "--assume-yes")
+	if err != nil {
+		t.Fatalf("Expected --assume-yes to skip the prompt: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "creating the task anyway") {
+		t.Errorf("Expected the similar tasks to be reported, got: %s", output)
+	}
+
+	envYes := append(append([]string(nil), env...), "QUICKTODO_ASSUME_YES=1")
+	output, err = runCLI(t, binaryPath, dir, envYes, "n\n", "create-task", "Fix login bug", "--find-similar", "--json")
+	if err != nil {
+		t.Fatalf("Expected QUICKTODO_ASSUME_YES to skip the check: %v, output: %s", err, output)
+	}
+
+	// Without it the answer is read as usual
+	envNo := append(append([]string(nil), env...), "QUICKTODO_ASSUME_YES=0")
+	output, err = runCLI(t, binaryPath, dir, envNo, "n\n", "create-task", "Fix login bugs", "--find-similar")
+	if err == nil || !strings.Contains(string(output), "Task not created") {
+		t.Errorf("Expected the prompt to be answered no, got: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--flat-json", "--all")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	var tasks []models.Task
+	if err := json.Unmarshal(output, &tasks); err != nil || len(tasks) != 3 {
+		t.Errorf("Expected 3 tasks, got %s (%v)", output, err)
+	}
+}
+
+func TestCLIArchive(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Old work", "--status", "done"},
+		{"create-task", "Open work"},
+		{"create-task", "Also done", "--status", "done"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	// Nothing was completed a month ago yet
+	output, err := runCLI(t, binaryPath, dir, env, "", "archive")
+	if err != nil || !strings.Contains(string(output), "No done tasks") {
+		t.Errorf("Expected nothing archived by default, got: %s (%v)", output, err)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "archive", "--before", "0", "--json")
+	if err != nil {
+		t.Fatalf("archive failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		ArchivedCount int `json:"archived_count"`
+		Remaining     int `json:"remaining"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil || result.ArchivedCount != 2 || result.Remaining != 1 {
+		t.Errorf("Expected 2 tasks archived and 1 remaining, got %s (%v)", output, err)
+	}
+	if _, err := os.Stat(filepath.Join(cliHome(env), ".config", "quicktodo", "archive", "cli-test.json")); err != nil {
+		t.Errorf("Expected the archive file in the data directory: %v", err)
+	}
+
+	listIDs := func(args ...string) []int {
+		t.Helper()
+		output, err := runCLI(t, binaryPath, dir, env, "", append([]string{"list-tasks", "--flat-json", "--all"}, args...)...)
+		if err != nil {
+			t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+		}
+		var tasks []models.Task
+		if err := json.Unmarshal(output, &tasks); err != nil {
+			t.Fatalf("Failed to parse tasks: %v, output: %s", err, output)
+		}
+		ids := make([]int, len(tasks))
+		for i, task := range tasks {
+			ids[i] = task.ID
+		}
+		return ids
+	}
+
+	if ids := listIDs(); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("Expected only task #2 active, got %v", ids)
+	}
+	if ids := listIDs("--include-archived"); len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Errorf("Expected all three tasks with --include-archived, got %v", ids)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "unarchive", "3"); err != nil {
+		t.Fatalf("unarchive failed: %v, output: %s", err, output)
+	}
+	if ids := listIDs(); len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Errorf("Expected tasks #2 and #3 active after unarchive, got %v", ids)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "unarchive", "3")
+	if err == nil || !strings.Contains(string(output), "not archived") {
+		t.Errorf("Expected unarchiving twice to fail, got: %s", output)
+	}
+}
+
+func TestCLIStats(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "First", "--priority", "high"},
+		{"create-task", "Second"},
+		{"create-task", "Third", "--status", "done"},
+		{"set-task-status", "2", "in_progress"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "stats", "--json")
+	if err != nil {
+		t.Fatalf("stats failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Summary struct {
+			TaskCount         int     `json:"task_count"`
+			CompletionPercent float64 `json:"completion_percent"`
+			OldestOpenTask    struct {
+				ID int `json:"id"`
+			} `json:"oldest_open_task"`
+			LastUpdatedTask struct {
+				ID int `json:"id"`
+			} `json:"last_updated_task"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+	}
+	if result.Summary.TaskCount != 3 || result.Summary.OldestOpenTask.ID != 1 || result.Summary.LastUpdatedTask.ID != 2 {
+		t.Errorf("Unexpected summary: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "stats")
+	if err != nil {
+		t.Fatalf("stats failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "33% (1 of 3 done)") || !strings.Contains(string(output), "Oldest open task:   #1 First") {
+		t.Errorf("Unexpected stats output: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "stats", "--all")
+	if err != nil {
+		t.Fatalf("stats --all failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "cli-test") || !strings.Contains(string(output), "TOTAL") {
+		t.Errorf("Expected a table with the project and totals, got: %s", output)
+	}
+}
+
+func TestCLIReminders(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Due soon", "--due", "+1d"},
+		{"create-task", "Overdue", "--due", "2020-01-01"},
+		{"create-task", "Due later", "--due", "+30d"},
+		{"create-task", "Undated"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	// Without a task_due hook there is nothing to send reminders with
+	if output, err := runCLI(t, binaryPath, dir, env, "", "reminders"); err == nil {
+		t.Errorf("Expected reminders to fail without a hook, got: %s", output)
+	}
+
+	sentLog := filepath.Join(t.TempDir(), "sent.log")
+	setCLIConfig(t, env, "hooks", map[string]string{"task_due": "echo $QUICKTODO_TASK_ID >> " + sentLog})
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "reminders", "--within", "72h", "--dry-run")
+	if err != nil {
+		t.Fatalf("reminders --dry-run failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Would remind: cli-test #2 Overdue") || !strings.Contains(string(output), "#1 Due soon") || strings.Contains(string(output), "Due later") {
+		t.Errorf("Unexpected dry run output: %s", output)
+	}
+	if _, err := os.Stat(sentLog); err == nil {
+		t.Error("Expected a dry run not to run the hook")
+	}
+
+	for i := 0; i < 2; i++ {
+		output, err = runCLI(t, binaryPath, dir, env, "", "reminders", "--within", "72h", "--json")
+		if err != nil {
+			t.Fatalf("reminders failed: %v, output: %s", err, output)
+		}
+	}
+	var result struct {
+		Reminders []interface{} `json:"reminders"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+	}
+	if len(result.Reminders) != 0 {
+		t.Errorf("Expected nothing left to remind on the second run, got %s", output)
+	}
+
+	sent, err := os.ReadFile(sentLog)
+	if err != nil {
+		t.Fatalf("Expected the hook to run: %v", err)
+	}
+	if string(sent) != "2\n1\n" {
+		t.Errorf("Expected exactly one reminder each for tasks 2 and 1, got %q", sent)
+	}
+}
+
+func TestCLIRecurringTask(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Yearly", "--recurrence", "yearly"); err == nil {
+		t.Errorf("Expected an invalid recurrence to be rejected, got: %s", output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Weekly review", "--due", "2025-03-07", "--recurrence", "weekly"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "mark-completed", "1", "--json")
+	if err != nil {
+		t.Fatalf("mark-completed failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Task     models.Task  `json:"task"`
+		NextTask *models.Task `json:"next_task"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+	}
+	if result.Task.Status != models.StatusDone || result.NextTask == nil {
+		t.Fatalf("Expected #1 done and a next occurrence, got %s", output)
+	}
+	next := result.NextTask
+	if next.ID != 2 || next.Status != models.StatusPending || next.Recurrence != models.RecurrenceWeekly || next.DueDate.Local().Format("2006-01-02") != "2025-03-14" {
+		t.Errorf("Expected pending weekly task #2 due 2025-03-14, got %+v", next)
+	}
+
+	// Completing an already completed task doesn't spawn another one
+	if output, err := runCLI(t, binaryPath, dir, env, "", "mark-completed", "1"); err != nil {
+		t.Fatalf("mark-completed failed: %v, output: %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--json")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	if strings.Contains(string(output), `"id": 3`) {
+		t.Errorf("Expected no third task, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "2")
+	if err != nil || !strings.Contains(string(output), "Repeats: weekly") {
+		t.Errorf("Expected display-task to show the recurrence, got: %v, %s", err, output)
+	}
+}
+
+func TestCLIListTasksHTML(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Fix <login> bug", "--priority", "high"},
+		{"create-task", "Write docs"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--format", "html")
+	if err != nil {
+		t.Fatalf("list-tasks --format html failed: %v, output: %s", err, output)
+	}
+	html := string(output)
+	if !strings.HasPrefix(html, `<div class="quicktodo-tasks">`) || !strings.Contains(html, "<caption>cli-test (2 tasks)</caption>") {
+		t.Errorf("Expected an HTML table fragment, got:\n%s", html)
+	}
+	if !strings.Contains(html, `<tr class="task status-pending priority-high">`) || !strings.Contains(html, "Fix &lt;login&gt; bug") {
+		t.Errorf("Expected a styled, escaped row, got:\n%s", html)
+	}
+
+	outputFile := filepath.Join(t.TempDir(), "tasks.html")
+	if output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--format", "html", "--output-file", outputFile); err != nil {
+		t.Fatalf("list-tasks --output-file failed: %v, output: %s", err, output)
+	}
+	written, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Expected the output file to be written: %v", err)
+	}
+	if string(written) != html {
+		t.Errorf("Expected the file to hold the same HTML, got:\n%s", written)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--output-file", outputFile); err == nil {
+		t.Errorf("Expected --output-file without --format html to fail, got: %s", output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--format", "pdf"); err == nil {
+		t.Errorf("Expected an unsupported format to fail, got: %s", output)
+	}
+}
+
+func TestCLIListTasksTruncateDescription(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	description := strings.Repeat("very long description ", 10) + "END"
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Wordy", "--description", description); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--truncate-description", "20")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "very long descriptio…") || strings.Contains(string(output), "END") {
+		t.Errorf("Expected a truncated description, got:\n%s", output)
+	}
+
+	// The config default applies unless the flag is given
+	setCLIConfig(t, env, "truncate_description", 10)
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks")
+	if err != nil || !strings.Contains(string(output), "very long…") {
+		t.Errorf("Expected the configured truncation, got: %v, %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--truncate-description", "0")
+	if err != nil || !strings.Contains(string(output), "END") {
+		t.Errorf("Expected --truncate-description 0 to show the full text, got: %v, %s", err, output)
+	}
+
+	// JSON and display-task always have the full text
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--json")
+	if err != nil || !strings.Contains(string(output), "END") {
+		t.Errorf("Expected the full description in JSON, got: %v, %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "1")
+	if err != nil || !strings.Contains(string(output), "END") {
+		t.Errorf("Expected the full description in display-task, got: %v, %s", err, output)
+	}
+}
+
+func TestCLIListTasksDoneLast(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Finished first", "--status", "done"},
+		{"create-task", "Still open"},
+		{"create-task", "Finished too", "--status", "done"},
+		{"create-task", "Also open", "--status", "in_progress"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	listIDs := func(args ...string) []int {
+		t.Helper()
+		output, err := runCLI(t, binaryPath, dir, env, "", append([]string{"list-tasks", "--flat-json"}, args...)...)
+		if err != nil {
+			t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+		}
+		var tasks []struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(output, &tasks); err != nil {
+			t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+		}
+		ids := make([]int, len(tasks))
+		for i, task := range tasks {
+			ids[i] = task.ID
+		}
+		return ids
+	}
+
+	if got := fmt.Sprint(listIDs("--done-last")); got != "[2 4 1 3]" {
+		t.Errorf("Expected open tasks before done ones, got %s", got)
+	}
+
+	// The config default applies unless the flag turns it off
+	setCLIConfig(t, env, "done_last_by_default", true)
+	if got := fmt.Sprint(listIDs()); got != "[2 4 1 3]" {
+		t.Errorf("Expected done_last_by_default to apply, got %s", got)
+	}
+	if got := fmt.Sprint(listIDs("--done-last=false")); got != "[1 2 3 4]" {
+		t.Errorf("Expected --done-last=false to keep ID order, got %s", got)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	text := string(output)
+	if strings.Index(text, "Still open") > strings.Index(text, "Finished first") || strings.Index(text, "Also open") > strings.Index(text, "Finished too") {
+		t.Errorf("Expected done tasks at the bottom of the listing, got: %s", text)
+	}
+}
+
+func TestCLIProjectsPruneEmpty(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Keep me"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+	emptyDir := t.TempDir()
+	if output, err := runCLI(t, binaryPath, emptyDir, env, "", "init", "throwaway"); err != nil {
+		t.Fatalf("init failed: %v, output: %s", err, output)
+	}
+	emptyDB := filepath.Join(cliHome(env), ".config", "quicktodo", "projects", "throwaway.json")
+	if _, err := os.Stat(emptyDB); err != nil {
+		t.Fatalf("Expected the empty project's database at %s: %v", emptyDB, err)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "projects", "prune-empty", "--dry-run")
+	if err != nil {
+		t.Fatalf("prune-empty --dry-run failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Would remove: throwaway") || strings.Contains(string(output), "cli-test") {
+		t.Errorf("Expected only the empty project listed, got: %s", output)
+	}
+
+	// Declining the confirmation removes nothing
+	if output, err := runCLI(t, binaryPath, dir, env, "n\n", "projects", "prune-empty"); err == nil {
+		t.Errorf("Expected declining to fail, got: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "y\n", "projects", "prune-empty", "--delete-data", "--json")
+	if err != nil {
+		t.Fatalf("prune-empty failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), `"name": "throwaway"`) {
+		t.Errorf("Expected throwaway to be removed, got: %s", output)
+	}
+	if _, err := os.Stat(emptyDB); !os.IsNotExist(err) {
+		t.Errorf("Expected --delete-data to delete the database, got %v", err)
+	}
+
+	// The remaining project still works and nothing else is empty
+	if output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks"); err != nil || !strings.Contains(string(output), "Keep me") {
+		t.Errorf("Expected the non-empty project to stay registered: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, emptyDir, env, "", "list-tasks"); err == nil {
+		t.Errorf("Expected the pruned project to be unregistered, got: %s", output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "projects", "prune-empty", "-y")
+	if err != nil || !strings.Contains(string(output), "No empty projects found") {
+		t.Errorf("Expected nothing left to prune: %v, output: %s", err, output)
+	}
+}
+
+func TestCLIDefaultTags(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "project-info", "--add-default-tag", "backend", "--add-default-tag", "api", "--json")
+	if err != nil {
+		t.Fatalf("project-info failed: %v, output: %s", err, output)
+	}
+	var info struct {
+		DefaultTags []string `json:"default_tags"`
+	}
+	if err := json.Unmarshal(output, &info); err != nil {
+		t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+	}
+	if strings.Join(info.DefaultTags, ",") != "backend,api" {
+		t.Fatalf("Expected default tags backend,api, got %v", info.DefaultTags)
+	}
+
+	createTags := func(stdin string, args ...string) []string {
+		t.Helper()
+		output, err := runCLI(t, binaryPath, dir, env, stdin, append([]string{"create-task", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("create-task failed: %v, output: %s", err, output)
+		}
+		var result struct {
+			Task models.Task `json:"task"`
+		}
+		if err := json.Unmarshal(output, &result); err != nil {
+			t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+		}
+		return result.Task.Tags
+	}
+
+	if tags := createTags("", "Add endpoint"); strings.Join(tags, ",") != "backend,api" {
+		t.Errorf("Expected the default tags, got %v", tags)
+	}
+	if tags := createTags(`{"title":"Tune queries","tags":["db","api"]}`, "--stdin"); strings.Join(tags, ",") != "db,api,backend" {
+		t.Errorf("Expected own tags followed by missing defaults, got %v", tags)
+	}
+	if tags := createTags("", "Update README", "--no-default-tags"); len(tags) != 0 {
+		t.Errorf("Expected --no-default-tags to skip the defaults, got %v", tags)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "project-info", "--remove-default-tag", "api")
+	if err != nil || !strings.Contains(string(output), "Default tags: backend\n") {
+		t.Errorf("Expected only backend to remain, got: %v, %s", err, output)
+	}
+}
+
+func TestCLISummary(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Finished work", "--status", "done"},
+		{"create-task", "Current work", "--status", "in_progress"},
+		{"create-task", "Missed deadline", "--due", "2020-01-01"},
+		{"create-task", "Someday"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%s failed: %v, output: %s", args[0], err, output)
+		}
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "summary", "--output-file", "standup.md"); err == nil {
+		t.Errorf("Expected --output-file without --markdown to fail, got: %s", output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "summary", "--markdown", "--output-file", "standup.md"); err != nil {
+		t.Fatalf("summary failed: %v, output: %s", err, output)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "standup.md"))
+	if err != nil {
+		t.Fatalf("Failed to read summary: %v", err)
+	}
+	markdown := string(data)
+	for _, section := range []string{
+		"### Completed since yesterday\n\n- #1 Finished work\n",
+		"### In progress\n\n- #2 Current work\n",
+		"### Blocked/overdue\n\n- #3 Missed deadline — overdue since 2020-01-01\n",
+	} {
+		if !strings.Contains(markdown, section) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", section, markdown)
+		}
+	}
+	if strings.Contains(markdown, "Someday") {
+		t.Errorf("Expected pending tasks to be left out, got:\n%s", markdown)
+	}
+
+	// A --since in the future leaves nothing completed
+	output, err := runCLI(t, binaryPath, dir, env, "", "summary", "--since", "2999-01-01", "--json")
+	if err != nil {
+		t.Fatalf("summary failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Summary models.StandupReport `json:"summary"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse output: %v, output: %s", err, output)
+	}
+	if len(result.Summary.Completed) != 0 || len(result.Summary.InProgress) != 1 || len(result.Summary.Blocked) != 1 {
+		t.Errorf("Unexpected summary: %s", output)
+	}
+}
+
+func TestCLIComment(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Investigate flaky test"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "comment", "1", "Fails on CI only", "--agent-id", "claude"); err != nil {
+		t.Fatalf("comment failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "comment", "1", "Caused by a timezone", "--agent-id", "reviewer"); err != nil {
+		t.Fatalf("comment failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "comment", "2", "No such task"); err == nil {
+		t.Errorf("Expected commenting on a missing task to fail, got: %s", output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "display-task", "1")
+	if err != nil {
+		t.Fatalf("display-task failed: %v, output: %s", err, output)
+	}
+	text := string(output)
+	first := strings.Index(text, "claude: Fails on CI only")
+	second := strings.Index(text, "reviewer: Caused by a timezone")
+	if !strings.Contains(text, "Comments (2):") || first < 0 || second < first {
+		t.Errorf("Expected both comments in order, got:\n%s", text)
+	}
+}
+
+// TestCLIStartStop tests tracking time on a task
+func TestCLIStartStop(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Timed task"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "stop", "1"); err == nil || !strings.Contains(string(output), "is not running") {
+		t.Errorf("Expected stopping an idle task to fail, got: %s", output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "start", "1", "--json")
+	if err != nil {
+		t.Fatalf("start failed: %v, output: %s", err, output)
+	}
+	var started struct {
+		Task *models.Task `json:"task"`
+	}
+	if err := json.Unmarshal(output, &started); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if started.Task.Status != models.StatusInProgress || len(started.Task.TimeEntries) != 1 {
+		t.Errorf("Expected the started task in progress with a running entry, got %s", output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "start", "1"); err == nil || !strings.Contains(string(output), "already running") {
+		t.Errorf("Expected starting a running task to fail, got: %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "stop", "1", "--json")
+	if err != nil {
+		t.Fatalf("stop failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), `"total_time_seconds"`) || !strings.Contains(string(output), `"end"`) {
+		t.Errorf("Expected the closed entry and total time, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "1")
+	if err != nil {
+		t.Fatalf("display-task failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Time tracked: less than a minute over 1 session(s)") {
+		t.Errorf("Expected the tracked time, got:\n%s", output)
+	}
+}
+
+// TestCLINext tests picking the next actionable task
+func TestCLINext(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "next", "--json")
+	if err != nil {
+		t.Fatalf("next failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), `"actionable": false`) || !strings.Contains(string(output), `"task": null`) {
+		t.Errorf("Expected nothing actionable in an empty project, got %s", output)
+	}
+
+	for _, args := range [][]string{
+		{"create-task", "Low", "--priority", "low"},
+		{"create-task", "Other agent's", "--priority", "high", "--agent-id", "other"},
+		{"create-task", "Medium", "--priority", "medium"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "next", "--json")
+	if err != nil {
+		t.Fatalf("next failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Actionable bool         `json:"actionable"`
+		Task       *models.Task `json:"task"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if !result.Actionable || result.Task == nil || result.Task.Title != "Medium" {
+		t.Errorf("Expected the unassigned medium task, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "next", "--agent-id", "other")
+	if err != nil {
+		t.Fatalf("next --agent-id failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "Other agent's") {
+		t.Errorf("Expected the agent's own high-priority task, got:\n%s", output)
+	}
+}
+
+// TestCLISyncPull tests applying status changes made in the AI TODO file
+func TestCLISyncPull(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"sync", "--enable"},
+		{"create-task", "Finished by the agent"},
+		{"create-task", "Untouched"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	// The agent completes task 1 in its TODO list and adds an unknown item
+	todoPath := filepath.Join(cliHome(env), ".config", "quicktodo", "ai_todos.json")
+	data, err := os.ReadFile(todoPath)
+	if err != nil {
+		t.Fatalf("Failed to read TODO file: %v", err)
+	}
+	var items map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		t.Fatalf("Failed to parse TODO file: %v, data: %s", err, data)
+	}
+	if items["cli-test-1"] == nil {
+		t.Fatalf("Expected task 1 in the TODO file, got %s", data)
+	}
+	items["cli-test-1"]["status"] = "completed"
+	items["cli-test-7"] = map[string]interface{}{"id": "cli-test-7", "status": "completed", "project_name": "cli-test"}
+	data, _ = json.Marshal(items)
+	if err := os.WriteFile(todoPath, data, 0644); err != nil {
+		t.Fatalf("Failed to write TODO file: %v", err)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "sync", "--pull", "--json")
+	if err != nil {
+		t.Fatalf("sync --pull failed: %v, output: %s", err, output)
+	}
+	var result struct {
+		Changes []struct {
+			TaskID    int           `json:"task_id"`
+			NewStatus models.Status `json:"new_status"`
+		} `json:"changes"`
+		Missing []string `json:"missing"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if len(result.Changes) != 1 || result.Changes[0].TaskID != 1 || result.Changes[0].NewStatus != models.StatusDone {
+		t.Errorf("Expected task 1 to be completed, got %s", output)
+	}
+	if len(result.Missing) != 1 || result.Missing[0] != "cli-test-7" {
+		t.Errorf("Expected the unknown item to be reported, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "display-task", "1", "--json")
+	if err != nil {
+		t.Fatalf("display-task failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), `"status": "done"`) {
+		t.Errorf("Expected task 1 to be saved as done, got %s", output)
+	}
+}
+
+// TestCLISyncFormat tests writing and printing the TODO list as Markdown
+func TestCLISyncFormat(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"sync", "--enable", "--format", "markdown"},
+		{"create-task", "Write docs"},
+		{"set-task-status", "1", "done"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(cliHome(env), ".config", "quicktodo", "ai_todos.json"))
+	if err != nil {
+		t.Fatalf("Failed to read TODO file: %v", err)
+	}
+	if !strings.Contains(string(data), "- [x] #1 Write docs <!-- quicktodo:cli-test-1 priority:medium -->") {
+		t.Errorf("Expected the TODO file as a Markdown checklist, got:\n%s", data)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "sync", "--format", "generic")
+	if err != nil {
+		t.Fatalf("sync --format failed: %v, output: %s", err, output)
+	}
+	var items []map[string]interface{}
+	if err := json.Unmarshal(output, &items); err != nil || len(items) != 1 || items[0]["status"] != "completed" {
+		t.Errorf("Expected a generic JSON array, got %s (%v)", output, err)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "sync", "--format", "yaml"); err == nil {
+		t.Errorf("Expected an unknown format to fail, got: %s", output)
+	}
+}
+
+// TestCLIConfigWebhooks tests managing webhooks and that task changes reach them
+func TestCLIConfigWebhooks(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	received := make(chan map[string]interface{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]interface{}
+		json.Unmarshal(body, &payload)
+		received <- payload
+	}))
+	defer server.Close()
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "config", "webhooks", "add", "not-a-url"); err == nil {
+		t.Errorf("Expected an invalid URL to be rejected, got: %s", output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "config", "webhooks", "add", server.URL); err != nil {
+		t.Fatalf("config webhooks add failed: %v, output: %s", err, output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "config", "webhooks", "add", server.URL); err == nil {
+		t.Errorf("Expected a duplicate webhook to be rejected, got: %s", output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "config", "webhooks", "list", "--json")
+	if err != nil {
+		t.Fatalf("config webhooks list failed: %v, output: %s", err, output)
+	}
+	var list struct {
+		Webhooks []string `json:"webhooks"`
+	}
+	if err := json.Unmarshal(output, &list); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if len(list.Webhooks) != 1 || list.Webhooks[0] != server.URL {
+		t.Errorf("Expected the webhook to be listed, got %s", output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Ship it"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+	select {
+	case payload := <-received:
+		if payload["type"] != "task_created" || payload["project"] != "cli-test" {
+			t.Errorf("Expected a task_created notification for cli-test, got %v", payload)
+		}
+		if text, _ := payload["text"].(string); !strings.Contains(text, "#1 Ship it") {
+			t.Errorf("Expected a summary naming the task, got %q", text)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the webhook to be called")
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "config", "webhooks", "remove", server.URL); err != nil {
+		t.Fatalf("config webhooks remove failed: %v, output: %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "config", "webhooks", "list")
+	if err != nil || !strings.Contains(string(output), "No webhooks configured") {
+		t.Errorf("Expected no webhooks after remove, got: %s (%v)", output, err)
+	}
+}
+
+// TestCLIProjectRegistryCommands tests list-projects, remove-project and
+// cleanup-projects
+func TestCLIProjectRegistryCommands(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Only task"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+	otherDir := t.TempDir()
+	if output, err := runCLI(t, binaryPath, otherDir, env, "", "init", "other"); err != nil {
+		t.Fatalf("init failed: %v, output: %s", err, output)
+	}
+	goneDir := filepath.Join(t.TempDir(), "gone")
+	if err := os.Mkdir(goneDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if output, err := runCLI(t, binaryPath, goneDir, env, "", "init", "gone"); err != nil {
+		t.Fatalf("init failed: %v, output: %s", err, output)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-projects", "--json")
+	if err != nil {
+		t.Fatalf("list-projects failed: %v, output: %s", err, output)
+	}
+	var list struct {
+		Projects []struct {
+			Name            string `json:"name"`
+			TaskCount       int    `json:"task_count"`
+			LastAccessedAge string `json:"last_accessed_age"`
+		} `json:"projects"`
+	}
+	if err := json.Unmarshal(output, &list); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	counts := map[string]int{}
+	for _, project := range list.Projects {
+		counts[project.Name] = project.TaskCount
+		if project.LastAccessedAge == "" {
+			t.Errorf("Expected a last accessed age for %s", project.Name)
+		}
+	}
+	if len(list.Projects) != 3 || counts["cli-test"] != 1 || counts["other"] != 0 {
+		t.Errorf("Expected the three projects with their task counts, got %s", output)
+	}
+
+	// A locked project can't be removed
+	hostname, _ := os.Hostname()
+	lockDir := filepath.Join(cliHome(env), ".config", "quicktodo", "locks")
+	lockPath := filepath.Join(lockDir, "other.lock")
+	content := fmt.Sprintf("%d\n%s\n%s\n", os.Getpid(), time.Now().Format(time.RFC3339), hostname)
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		t.Fatalf("Failed to create lock directory: %v", err)
+	}
+	if err := os.WriteFile(lockPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write lock file: %v", err)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "remove-project", "other"); err == nil || !strings.Contains(string(output), "is locked") {
+		t.Errorf("Expected removing a locked project to fail, got: %s (%v)", output, err)
+	}
+	os.Remove(lockPath)
+
+	otherDB := filepath.Join(cliHome(env), ".config", "quicktodo", "projects", "other.json")
+	if output, err := runCLI(t, binaryPath, dir, env, "", "remove-project", "other"); err != nil {
+		t.Fatalf("remove-project failed: %v, output: %s", err, output)
+	}
+	if _, err := os.Stat(otherDB); err != nil {
+		t.Errorf("Expected the database to be kept without --purge: %v", err)
+	}
+	if output, err := runCLI(t, binaryPath, otherDir, env, "", "list-tasks"); err == nil {
+		t.Errorf("Expected the removed project to be unregistered, got: %s", output)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "remove-project", "other"); err == nil {
+		t.Errorf("Expected removing an unregistered project to fail, got: %s", output)
+	}
+
+	if err := os.RemoveAll(goneDir); err != nil {
+		t.Fatalf("Failed to delete project directory: %v", err)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "cleanup-projects", "--json")
+	if err != nil {
+		t.Fatalf("cleanup-projects failed: %v, output: %s", err, output)
+	}
+	var cleanup struct {
+		Removed []string `json:"removed"`
+	}
+	if err := json.Unmarshal(output, &cleanup); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if len(cleanup.Removed) != 1 || cleanup.Removed[0] != "gone" {
+		t.Errorf("Expected only the deleted directory's project removed, got %s", output)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "y\n", "remove-project", "cli-test", "--purge"); err != nil {
+		t.Fatalf("remove-project --purge failed: %v, output: %s", err, output)
+	}
+	if _, err := os.Stat(filepath.Join(cliHome(env), ".config", "quicktodo", "projects", "cli-test.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected --purge to delete the database, got %v", err)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-projects")
+	if err != nil || !strings.Contains(string(output), "No registered projects") {
+		t.Errorf("Expected no projects left: %v, output: %s", err, output)
+	}
+}
+
+// TestCLIServeShutdown tests that serve tells WebSocket clients it is going
+// away and exits promptly on Ctrl+C
+func TestCLIServeShutdown(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	var output bytes.Buffer
+	cmd := exec.Command(binaryPath, "serve", "--host", "127.0.0.1", "--port", strconv.Itoa(port))
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start serve: %v", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	defer cmd.Process.Kill()
+
+	url := fmt.Sprintf("ws://127.0.0.1:%d/ws", port)
+	var conn *websocket.Conn
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if conn, _, err = websocket.DefaultDialer.Dial(url, nil); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Failed to connect to serve: %v, output: %s", err, output.String())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	defer conn.Close()
+
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		t.Fatalf("Failed to interrupt serve: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseGoingAway {
+		t.Errorf("Expected a going-away close frame, got %v", err)
+	}
+
+	select {
+	case err := <-exited:
+		if err != nil {
+			t.Errorf("Expected serve to exit cleanly, got %v, output: %s", err, output.String())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("serve did not exit after Ctrl+C, output: %s", output.String())
+	}
+	if !strings.Contains(output.String(), "Server stopped") {
+		t.Errorf("Expected serve to report stopping, got: %s", output.String())
+	}
+}
+
+// TestCLICriticalPriority tests creating, filtering and displaying critical tasks
+func TestCLICriticalPriority(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	steps := [][]string{
+		{"create-task", "Routine", "--priority", "high"},
+		{"create-task", "Site down", "--priority", "crit"},
+		{"create-task", "Data loss", "--priority", "critical"},
+	}
+	for _, args := range steps {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--priority", "critical", "--json")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	var listed struct {
+		Tasks []models.Task `json:"tasks"`
+	}
+	if err := json.Unmarshal(output, &listed); err != nil {
+		t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+	}
+	if len(listed.Tasks) != 2 || listed.Tasks[0].Title != "Site down" || listed.Tasks[1].Title != "Data loss" {
+		t.Errorf("Expected only the two critical tasks, got %s", output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--verbose")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "🚨 Data loss") || !strings.Contains(string(output), "🔴 Routine") {
+		t.Errorf("Expected a distinct indicator for critical tasks, got:\n%s", output)
+	}
+	if !strings.Contains(string(output), "2 critical, 1 high") {
+		t.Errorf("Expected critical in the summary, got:\n%s", output)
+	}
+}
+
+func TestCLICancelReopen(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	steps := [][]string{
+		{"create-task", "Abandoned idea"},
+		{"create-task", "Shipped feature"},
+		{"cancel", "1", "--note", "superseded"},
+		{"mark-completed", "2"},
+	}
+	for _, args := range steps {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--verbose")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	if !strings.Contains(string(output), "0 pending, 0 in progress, 1 done, 1 cancelled") {
+		t.Errorf("Expected cancelled in the summary, got:\n%s", output)
+	}
+
+	// Open tasks can't be reopened
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Still open"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+	output, err = runCLI(t, binaryPath, dir, env, "", "reopen", "3")
+	if err == nil || !strings.Contains(string(output), "is not done or cancelled") {
+		t.Errorf("Expected reopening an open task to fail, got %v, output: %s", err, output)
+	}
+
+	for _, id := range []string{"1", "2"} {
+		output, err := runCLI(t, binaryPath, dir, env, "", "reopen", id, "--json")
+		if err != nil {
+			t.Fatalf("reopen %s failed: %v, output: %s", id, err, output)
+		}
+		var result struct {
+			Task      models.Task `json:"task"`
+			OldStatus string      `json:"old_status"`
+		}
+		if err := json.Unmarshal(output, &result); err != nil {
+			t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+		}
+		if result.Task.Status != models.StatusPending || result.Task.CompletedAt != nil || result.OldStatus == string(models.StatusPending) {
+			t.Errorf("Expected task #%s to be reopened as pending, got %s", id, output)
+		}
+	}
+}
+
+// TestCLIListTasksWatch tests that list-tasks --watch redraws the filtered
+// list when the project changes and exits cleanly on Ctrl+C
+func TestCLIListTasksWatch(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Already there", "--priority", "high"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	cmd := exec.Command(binaryPath, "list-tasks", "--watch", "--priority", "high")
+	cmd.Dir = dir
+	cmd.Env = env
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("Failed to open stdout: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start list-tasks --watch: %v", err)
+	}
+	defer cmd.Process.Kill()
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	waitFor := func(want string) {
+		t.Helper()
+		timeout := time.After(10 * time.Second)
+		for {
+			select {
+			case line, open := <-lines:
+				if !open {
+					t.Fatalf("list-tasks --watch stopped before printing %q", want)
+				}
+				if strings.Contains(line, want) {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("Timed out waiting for %q", want)
+			}
+		}
+	}
+
+	waitFor("Found 1 task(s)")
+	waitFor("Watching for changes")
+
+	for _, args := range [][]string{
+		{"create-task", "Filtered out", "--priority", "low"},
+		{"create-task", "Newly urgent", "--priority", "high"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+	waitFor("Found 2 task(s)")
+	waitFor("Watching for changes")
+
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		t.Fatalf("Failed to interrupt list-tasks --watch: %v", err)
+	}
+	for range lines {
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Errorf("Expected list-tasks --watch to exit cleanly, got %v", err)
+	}
+}
+
+// TestCLIJSONErrors tests that failures are printed as JSON errors with stable
+// codes on stdout under --json, and as plain text on stderr otherwise
+func TestCLIJSONErrors(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Only task"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+
+	run := func(dir string, args ...string) (stdout, stderr string, err error) {
+		t.Helper()
+		var outBuf, errBuf bytes.Buffer
+		cmd := exec.Command(binaryPath, args...)
+		cmd.Dir = dir
+		cmd.Env = env
+		cmd.Stdout = &outBuf
+		cmd.Stderr = &errBuf
+		err = cmd.Run()
+		return outBuf.String(), errBuf.String(), err
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		args    []string
+		code    string
+		message string
+		hint    string
+	}{
+		{"missing task", dir, []string{"display-task", "99"}, "TASK_NOT_FOUND", "task #99 not found", ""},
+		{"bad status", dir, []string{"set-task-status", "1", "bogus"}, "INVALID_STATUS", "invalid status 'bogus'. Valid statuses: pending, in_progress, done, cancelled", ""},
+		{"bad task ID", dir, []string{"mark-completed", "abc"}, "INVALID_TASK_ID", "invalid task ID 'abc'. Task ID must be a number.", ""},
+		{"reopen open task", dir, []string{"reopen", "1"}, "INVALID_STATE", "task #1 is not done or cancelled (status: pending)", ""},
+		{"unregistered directory", t.TempDir(), []string{"list-tasks"}, "PROJECT_NOT_FOUND", "current directory is not a registered project", "Run 'quicktodo initialize-project' first"},
+		{"unknown flag", dir, []string{"list-tasks", "--bogus"}, "INVALID_ARGUMENT", "unknown flag: --bogus", ""},
+		{"sync outside a project", t.TempDir(), []string{"sync", "--full-sync"}, "PROJECT_NOT_FOUND", "current directory is not a registered project", "Run 'quicktodo initialize-project' first"},
+		{"serve on a bad port", dir, []string{"serve", "--port", "70000"}, "INVALID_ARGUMENT", "invalid port 70000: must be between 1 and 65535", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, _, err := run(tt.dir, append(tt.args, "--json")...)
+			if err == nil {
+				t.Fatalf("Expected %v to fail, output: %s", tt.args, stdout)
+			}
+			var result struct {
+				Success *bool `json:"success"`
+				Error   struct {
+					Code    string `json:"code"`
+					Message string `json:"message"`
+					Hint    string `json:"hint"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+				t.Fatalf("Expected a JSON error on stdout: %v, output: %s", err, stdout)
+			}
+			if result.Success == nil || *result.Success {
+				t.Errorf("Expected success false, got: %s", stdout)
+			}
+			if result.Error.Code != tt.code || result.Error.Message != tt.message || result.Error.Hint != tt.hint {
+				t.Errorf("Expected %s %q (hint %q), got: %s", tt.code, tt.message, tt.hint, stdout)
+			}
+		})
+	}
+
+	// Without --json the error stays plain text on stderr
+	stdout, stderr, err := run(dir, "display-task", "99")
+	if err == nil || stdout != "" || stderr != "Error: task #99 not found\n" {
+		t.Errorf("Expected the plain error on stderr, got err %v, stdout %q, stderr %q", err, stdout, stderr)
+	}
+}
+
+// TestCLIUndo tests that undo reverts the logged operations one by one, most
+// recent first, including tasks moved to the archive
+func TestCLIUndo(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	steps := [][]string{
+		{"create-task", "Fix login", "--priority", "low"},
+		{"create-task", "Ship release"},
+		{"edit-task", "1", "--title", "Fix login page", "--priority", "high"},
+		{"mark-completed", "2"},
+		{"archive", "--before", "0s"},
+	}
+	for _, args := range steps {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	type undoResult struct {
+		Operation string `json:"operation"`
+		Reverted  []struct {
+			TaskID int          `json:"task_id"`
+			Change string       `json:"change"`
+			Task   *models.Task `json:"task"`
+		} `json:"reverted"`
+		Remaining int `json:"remaining"`
+	}
+	undo := func() undoResult {
+		t.Helper()
+		output, err := runCLI(t, binaryPath, dir, env, "", "undo", "--json")
+		if err != nil {
+			t.Fatalf("undo failed: %v, output: %s", err, output)
+		}
+		var result undoResult
+		if err := json.Unmarshal(output, &result); err != nil || len(result.Reverted) != 1 {
+			t.Fatalf("Expected one reverted task, got %v, output: %s", err, output)
+		}
+		return result
+	}
+
+	// The archived task comes back and leaves the archive
+	result := undo()
+	if result.Operation != "archive --before 0s" || result.Reverted[0].Change != "deleted" || result.Reverted[0].Task.Status != models.StatusDone || result.Remaining != 4 {
+		t.Errorf("Expected the archive to be undone, got %+v", result)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "unarchive", "2"); err == nil {
+		t.Errorf("Expected task #2 to have left the archive, got: %s", output)
+	}
+
+	result = undo()
+	if task := result.Reverted[0].Task; task.ID != 2 || task.Status != models.StatusPending || task.CompletedAt != nil {
+		t.Errorf("Expected task #2 back to pending, got %+v", task)
+	}
+
+	result = undo()
+	if task := result.Reverted[0].Task; task.Title != "Fix login" || task.Priority != models.PriorityLow {
+		t.Errorf("Expected the title and priority of task #1 reverted, got %+v", task)
+	}
+
+	undo()
+	result = undo()
+	if result.Reverted[0].Change != "created" || result.Reverted[0].Task != nil || result.Remaining != 0 {
+		t.Errorf("Expected the first task to be removed, got %+v", result)
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--json")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	var listed struct {
+		Tasks []models.Task `json:"tasks"`
+	}
+	if err := json.Unmarshal(output, &listed); err != nil || len(listed.Tasks) != 0 {
+		t.Errorf("Expected every task to be undone, got %v, output: %s", err, output)
+	}
+
+	output, _ = runCLI(t, binaryPath, dir, env, "", "undo", "--json")
+	if !strings.Contains(string(output), `"code": "NOT_FOUND"`) {
+		t.Errorf("Expected nothing left to undo, got: %s", output)
+	}
+}
+
+// TestCLIListTasksSort tests that --sort and --reverse order both the human
+// and the JSON output, and that unknown fields are rejected
+func TestCLIListTasksSort(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "Write docs", "--priority", "low"},
+		{"create-task", "Fix crash", "--priority", "critical"},
+		{"create-task", "Add tests", "--priority", "medium"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	listedIDs := func(args ...string) []int {
+		t.Helper()
+		output, err := runCLI(t, binaryPath, dir, env, "", append([]string{"list-tasks", "--json"}, args...)...)
+		if err != nil {
+			t.Fatalf("list-tasks %v failed: %v, output: %s", args, err, output)
+		}
+		var result struct {
+			Tasks []models.Task `json:"tasks"`
+		}
+		if err := json.Unmarshal(output, &result); err != nil {
+			t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+		}
+		var ids []int
+		for _, task := range result.Tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{nil, "[1 2 3]"},
+		{[]string{"--reverse"}, "[3 2 1]"},
+		{[]string{"--sort", "title"}, "[3 2 1]"},
+		{[]string{"--sort", "priority"}, "[2 3 1]"},
+		{[]string{"--sort", "priority", "--reverse"}, "[1 3 2]"},
+	} {
+		if got := fmt.Sprint(listedIDs(tc.args...)); got != tc.want {
+			t.Errorf("list-tasks %v: expected %s, got %s", tc.args, tc.want, got)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--sort", "title")
+	if err != nil {
+		t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+	}
+	text := string(output)
+	if !(strings.Index(text, "Add tests") < strings.Index(text, "Fix crash") && strings.Index(text, "Fix crash") < strings.Index(text, "Write docs")) {
+		t.Errorf("Expected the human output sorted by title, got:\n%s", text)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--sort", "size", "--json")
+	if err == nil || !strings.Contains(string(output), `"code": "INVALID_ARGUMENT"`) {
+		t.Errorf("Expected an unknown sort field to be rejected, got %v, output: %s", err, output)
+	}
+}
+
+// TestCLIEditorAndDescriptionSources tests reading a task's title and
+// description from $EDITOR, stdin and a file
+func TestCLIEditorAndDescriptionSources(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	// The "editor" records the template it was given and replaces it
+	scripts := t.TempDir()
+	writeEditor := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(scripts, name)
+		script := "#!/bin/sh\ncp \"$1\" " + filepath.Join(scripts, name+".seen") + "\nprintf '" + content + "' > \"$1\"\n"
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			t.Fatalf("Failed to write editor script: %v", err)
+		}
+		return path
+	}
+	withEditor := func(path string) []string {
+		return append(append([]string{}, env...), "EDITOR="+path, "VISUAL=")
+	}
+	display := func(id string) models.Task {
+		t.Helper()
+		output, err := runCLI(t, binaryPath, dir, env, "", "display-task", id, "--json")
+		if err != nil {
+			t.Fatalf("display-task failed: %v, output: %s", err, output)
+		}
+		var result struct {
+			Task models.Task `json:"task"`
+		}
+		if err := json.Unmarshal(output, &result); err != nil {
+			t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+		}
+		return result.Task
+	}
+
+	editor := writeEditor("create", "# comment\\n\\nPlan the migration\\n\\nStep one\\nStep two  \\n\\n# Lines starting with # are ignored\\n")
+	if output, err := runCLI(t, binaryPath, dir, withEditor(editor), "", "create-task", "--edit"); err != nil {
+		t.Fatalf("create-task --edit failed: %v, output: %s", err, output)
+	}
+	if task := display("1"); task.Title != "Plan the migration" || task.Description != "Step one\nStep two" {
+		t.Errorf("Expected the edited title and description, got %q, %q", task.Title, task.Description)
+	}
+
+	if output, err := runCLI(t, binaryPath, dir, env, "Piped title\nPiped description\n", "create-task", "-"); err != nil {
+		t.Fatalf("create-task - failed: %v, output: %s", err, output)
+	}
+	if task := display("2"); task.Title != "Piped title" || task.Description != "Piped description" {
+		t.Errorf("Expected the title and description from stdin, got %q, %q", task.Title, task.Description)
+	}
+
+	notes := filepath.Join(scripts, "notes.md")
+	if err := os.WriteFile(notes, []byte("\nFrom a file\n\n"), 0644); err != nil {
+		t.Fatalf("Failed to write description file: %v", err)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Documented", "--description-file", notes); err != nil {
+		t.Fatalf("create-task --description-file failed: %v, output: %s", err, output)
+	}
+	if task := display("3"); task.Description != "From a file" {
+		t.Errorf("Expected the description from the file, got %q", task.Description)
+	}
+
+	// edit-task --edit starts from the task's current text
+	editor = writeEditor("edit", "Retitled\\n")
+	if output, err := runCLI(t, binaryPath, dir, withEditor(editor), "", "edit-task", "1", "--edit"); err != nil {
+		t.Fatalf("edit-task --edit failed: %v, output: %s", err, output)
+	}
+	seen, err := os.ReadFile(filepath.Join(scripts, "edit.seen"))
+	if err != nil || !strings.HasPrefix(string(seen), "Plan the migration\n\nStep one\nStep two\n") {
+		t.Errorf("Expected the editor to start from the current text, got %q, %v", seen, err)
+	}
+	if task := display("1"); task.Title != "Retitled" || task.Description != "" {
+		t.Errorf("Expected the title replaced and the description cleared, got %q, %q", task.Title, task.Description)
+	}
+
+	// An empty title aborts
+	editor = writeEditor("empty", "# nothing\\n")
+	output, err := runCLI(t, binaryPath, dir, withEditor(editor), "", "create-task", "--edit")
+	if err == nil || !strings.Contains(string(output), "task title cannot be empty") {
+		t.Errorf("Expected an empty title to be refused, got %v, output: %s", err, output)
+	}
+}
+
+// TestCLIBackupRestore tests forcing a backup and rolling the database back
+// to a named backup, to the newest one, and undoing a restore
+func TestCLIBackupRestore(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	if output, err := runCLI(t, binaryPath, dir, env, "", "create-task", "Original"); err != nil {
+		t.Fatalf("create-task failed: %v, output: %s", err, output)
+	}
+	output, err := runCLI(t, binaryPath, dir, env, "", "backup", "--json")
+	if err != nil {
+		t.Fatalf("backup failed: %v, output: %s", err, output)
+	}
+	var backup struct {
+		Backup string `json:"backup"`
+	}
+	if err := json.Unmarshal(output, &backup); err != nil || backup.Backup == "" {
+		t.Fatalf("Expected a backup name, got %v, output: %s", err, output)
+	}
+
+	for _, args := range [][]string{
+		{"create-task", "Added later"},
+		{"edit-task", "1", "--title", "Renamed"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	titles := func() string {
+		t.Helper()
+		output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--json")
+		if err != nil {
+			t.Fatalf("list-tasks failed: %v, output: %s", err, output)
+		}
+		var result struct {
+			Tasks []models.Task `json:"tasks"`
+		}
+		if err := json.Unmarshal(output, &result); err != nil {
+			t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+		}
+		var titles []string
+		for _, task := range result.Tasks {
+			titles = append(titles, task.Title)
+		}
+		return strings.Join(titles, ", ")
+	}
+
+	// Restoring from another directory needs the project name
+	output, err = runCLI(t, binaryPath, t.TempDir(), env, "", "restore", "cli-test", backup.Backup)
+	if err != nil || !strings.Contains(string(output), "1 removed, 1 changed") {
+		t.Fatalf("restore failed: %v, output: %s", err, output)
+	}
+	if got := titles(); got != "Original" {
+		t.Errorf("Expected the database as backed up, got %q", got)
+	}
+
+	// The replaced database was backed up first, so the newest backup undoes the restore
+	if output, err := runCLI(t, binaryPath, dir, env, "", "restore", "cli-test"); err != nil {
+		t.Fatalf("restore failed: %v, output: %s", err, output)
+	}
+	if got := titles(); got != "Renamed, Added later" {
+		t.Errorf("Expected the database before the restore, got %q", got)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "undo"); err != nil {
+		t.Fatalf("undo failed: %v, output: %s", err, output)
+	}
+	if got := titles(); got != "Original" {
+		t.Errorf("Expected undo to revert the restore, got %q", got)
+	}
+
+	// max_backups (5 by default) bounds forced backups too
+	for i := 0; i < 6; i++ {
+		if output, err := runCLI(t, binaryPath, dir, env, "", "backup"); err != nil {
+			t.Fatalf("backup failed: %v, output: %s", err, output)
+		}
+	}
+	entries, err := os.ReadDir(filepath.Join(cliHome(env), ".config", "quicktodo", "backups", "cli-test"))
+	if err != nil || len(entries) != 5 {
+		t.Errorf("Expected 5 backups kept, got %d, %v", len(entries), err)
+	}
+
+	output, _ = runCLI(t, binaryPath, dir, env, "", "restore", "cli-test", "20000101T000000.000000000Z", "--json")
+	if !strings.Contains(string(output), `"code": "NOT_FOUND"`) {
+		t.Errorf("Expected an unknown backup to be reported, got: %s", output)
+	}
+
+	// Backup names can't reach outside the backup directory
+	for _, name := range []string{"../../projects/cli-test", "..", `x\y`} {
+		output, _ = runCLI(t, binaryPath, dir, env, "", "restore", "cli-test", name, "--json")
+		if !strings.Contains(string(output), `"code": "INVALID_ARGUMENT"`) {
+			t.Errorf("Expected backup name %q to be rejected, got: %s", name, output)
+		}
+	}
+}
+
+// TestCLIRestoreKeepsIDsMovingForward tests that a restore doesn't hand out
+// the IDs of tasks created after the backup again
+func TestCLIRestoreKeepsIDsMovingForward(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	createTask := func(title string) int {
+		t.Helper()
+		output, err := runCLI(t, binaryPath, dir, env, "", "create-task", title, "--json")
+		if err != nil {
+			t.Fatalf("create-task failed: %v, output: %s", err, output)
+		}
+		var result struct {
+			Task models.Task `json:"task"`
+		}
+		if err := json.Unmarshal(output, &result); err != nil {
+			t.Fatalf("Failed to parse JSON response: %v, output: %s", err, output)
+		}
+		return result.Task.ID
+	}
+
+	createTask("Before the backup")
+	if output, err := runCLI(t, binaryPath, dir, env, "", "backup"); err != nil {
+		t.Fatalf("backup failed: %v, output: %s", err, output)
+	}
+	if id := createTask("After the backup"); id != 2 {
+		t.Fatalf("Expected task #2, got #%d", id)
+	}
+	if output, err := runCLI(t, binaryPath, dir, env, "", "restore", "cli-test"); err != nil {
+		t.Fatalf("restore failed: %v, output: %s", err, output)
+	}
+
+	if id := createTask("After the restore"); id != 3 {
+		t.Errorf("Expected the restore to keep #2 retired and create #3, got #%d", id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cliHome(env), ".config", "quicktodo", "projects", "cli-test.json"))
+	if err != nil {
+		t.Fatalf("Failed to read project database: %v", err)
+	}
+	var db models.ProjectDatabase
+	if err := json.Unmarshal(data, &db); err != nil {
+		t.Fatalf("Failed to parse project database: %v", err)
+	}
+	if db.Version <= 3 {
+		t.Errorf("Expected the version to keep growing across the restore, got %d", db.Version)
+	}
+}
+
+// TestCLIListTasksLimitSummary tests --limit truncating the sorted list and
+// --summary counting only the tasks that would be shown
+func TestCLIListTasksLimitSummary(t *testing.T) {
+	binaryPath, dir, env := setupCLIProject(t)
+
+	for _, args := range [][]string{
+		{"create-task", "First", "--priority", "high"},
+		{"create-task", "Second", "--priority", "high"},
+		{"create-task", "Third", "--priority", "low"},
+		{"mark-completed", "1"},
+	} {
+		if output, err := runCLI(t, binaryPath, dir, env, "", args...); err != nil {
+			t.Fatalf("%v failed: %v, output: %s", args, err, output)
+		}
+	}
+
+	output, err := runCLI(t, binaryPath, dir, env, "", "list-tasks", "--limit", "2", "--reverse")
+	if err != nil {
+		t.Fatalf("list-tasks --limit failed: %v, output: %s", err, output)
+	}
+	text := string(output)
+	if !strings.Contains(text, "Showing the first 2 of 3 task(s)") || !strings.Contains(text, "1 more task(s) not shown") ||
+		!strings.Contains(text, "Third") || strings.Contains(text, "First") {
+		t.Errorf("Expected the last two tasks and a truncation notice, got:\n%s", text)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--limit", "2", "--json")
+	if err != nil {
+		t.Fatalf("list-tasks --limit --json failed: %v, output: %s", err, output)
+	}
+	var listed struct {
+		TaskCount  int           `json:"task_count"`
+		TotalCount int           `json:"total_count"`
+		Truncated  bool          `json:"truncated"`
+		Tasks      []models.Task `json:"tasks"`
+	}
+	if err := json.Unmarshal(output, &listed); err != nil || listed.TaskCount != 2 || listed.TotalCount != 3 || !listed.Truncated || len(listed.Tasks) != 2 {
+		t.Errorf("Expected 2 of 3 tasks marked truncated, got %v, output: %s", err, output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--summary")
+	if err != nil {
+		t.Fatalf("list-tasks --summary failed: %v, output: %s", err, output)
+	}
+	text = string(output)
+	if !strings.Contains(text, "Status: 2 pending, 0 in progress, 1 done, 0 cancelled") || strings.Contains(text, "Second") {
+		t.Errorf("Expected only the counts, got:\n%s", text)
+	}
+
+	// The limit applies first, then the summary
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--summary", "--limit", "2", "--json")
+	if err != nil {
+		t.Fatalf("list-tasks --summary --json failed: %v, output: %s", err, output)
+	}
+	var summarized struct {
+		Truncated bool                   `json:"truncated"`
+		Summary   models.ProjectSummary `json:"summary"`
+		Tasks     []models.Task          `json:"tasks"`
+	}
+	if err := json.Unmarshal(output, &summarized); err != nil || !summarized.Truncated || summarized.Tasks != nil ||
+		summarized.Summary.TaskCount != 2 || summarized.Summary.CompletedTasks != 1 || summarized.Summary.PriorityCounts[models.PriorityHigh] != 2 {
+		t.Errorf("Expected the summary of the first two tasks, got %v, output: %s", err, output)
+	}
+
+	output, err = runCLI(t, binaryPath, dir, env, "", "list-tasks", "--limit", "-1")
+	if err == nil || !strings.Contains(string(output), "--limit cannot be negative") {
+		t.Errorf("Expected a negative limit to be rejected, got %v, output: %s", err, output)
+	}
+}
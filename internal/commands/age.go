@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// ageHistogramWidth is the length of the longest bar in the task-age histogram
+const ageHistogramWidth = 40
+
+var taskAgeOpenOnly bool
+
+// taskAgeCmd represents the task-age command
+var taskAgeCmd = &cobra.Command{
+	Use:     "task-age",
+	Aliases: []string{"tasks-by-age"},
+	Short:   "Show how old the tasks in the current project are",
+	Long: `Count the tasks in the current project by how long ago they were created
+(<1d, 1-7d, 7-30d, >30d) and print the counts as a histogram, to spot work
+that has been lingering.
+
+With --open, done tasks are left out.
+
+Examples:
+  quicktodo task-age
+  quicktodo task-age --open
+  quicktodo tasks-by-age --open --json`,
+	Args: cobra.NoArgs,
+	Run:  runTaskAge,
+}
+
+func runTaskAge(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Load project database
+	projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectInfo.Name))
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	buckets := models.BucketTasksByAge(projectDB.Tasks, time.Now(), taskAgeOpenOnly)
+
+	if jsonOutput {
+		total := 0
+		for _, bucket := range buckets {
+			total += bucket.Count
+		}
+		output := map[string]interface{}{
+			"success": true,
+			"project": map[string]interface{}{
+				"name": projectInfo.Name,
+				"path": projectInfo.Path,
+			},
+			"open_only": taskAgeOpenOnly,
+			"total":     total,
+			"buckets":   buckets,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	scope := "tasks"
+	if taskAgeOpenOnly {
+		scope = "open tasks"
+	}
+	fmt.Printf("Age of %s in %s:\n\n", scope, projectInfo.Name)
+	fmt.Print(formatAgeHistogram(buckets))
+}
+
+// formatAgeHistogram renders one line per bucket with a bar scaled to the
+// largest count
+func formatAgeHistogram(buckets []models.AgeBucket) string {
+	largest := 0
+	for _, bucket := range buckets {
+		if bucket.Count > largest {
+			largest = bucket.Count
+		}
+	}
+
+	var b strings.Builder
+	for _, bucket := range buckets {
+		bar := 0
+		if largest > 0 {
+			bar = bucket.Count * ageHistogramWidth / largest
+		}
+		if bar == 0 && bucket.Count > 0 {
+			bar = 1
+		}
+		fmt.Fprintf(&b, "  %-6s %s %d\n", bucket.Label, strings.Repeat("█", bar), bucket.Count)
+	}
+	return b.String()
+}
+
+func init() {
+	taskAgeCmd.Flags().BoolVar(&taskAgeOpenOnly, "open", false, "Only count tasks that aren't done")
+
+	RootCmd.AddCommand(taskAgeCmd)
+}
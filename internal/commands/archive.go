@@ -0,0 +1,292 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"quicktodo/internal/notify"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var archiveBefore time.Duration
+
+// archiveCmd represents the archive command
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move old done tasks out of the active project",
+	Long: `Move done tasks completed more than --before ago out of the project database
+and into the project's archive file (archive/<project>.json in the data
+directory), so they no longer clutter list-tasks.
+
+Archived tasks keep their IDs. Show them with list-tasks --include-archived and
+bring one back with unarchive.
+
+Examples:
+  quicktodo archive
+  quicktodo archive --before 168h
+  quicktodo archive --before 0 --json`,
+	Args: cobra.NoArgs,
+	Run:  runArchive,
+}
+
+// unarchiveCmd represents the unarchive command
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive <id>",
+	Short: "Restore an archived task to the project",
+	Long: `Move a task from the project's archive back into the project database under
+its original ID.
+
+Examples:
+  quicktodo unarchive 12
+  quicktodo unarchive 12 --json`,
+	Args: cobra.ExactArgs(1),
+	Run:  runUnarchive,
+}
+
+func runArchive(cmd *cobra.Command, args []string) {
+	if archiveBefore < 0 {
+		exitWithError(codeInvalidArgument, "Error: --before cannot be negative")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registryPath := cfg.GetProjectsPath()
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Update last accessed time
+	if err := registry.UpdateLastAccessed(projectInfo.Name); err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update last accessed time: %v\n", err)
+		}
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database and archive
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+	archivePath := cfg.GetProjectArchivePath(projectInfo.Name)
+	archive, err := database.LoadTaskArchive(archivePath, projectInfo.Name)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading archive: %v", err)
+	}
+
+	archived := projectDB.ArchiveDoneTasks(time.Now().UTC().Add(-archiveBefore))
+	if len(archived) > 0 {
+		// Save the archive first: if saving the database then fails, the
+		// tasks are in both files rather than in neither
+		archive.Add(archived)
+		if err := archive.Save(archivePath); err != nil {
+			exitWithError(codeStorageError, "Error saving archive: %v", err)
+		}
+		if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+			exitWithError(codeStorageError, "Error saving project database: %v", err)
+		}
+
+		// Save updated registry
+		if err := registry.Save(registryPath); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save registry: %v\n", err)
+		}
+
+		for _, task := range archived {
+			if err := notify.NotifyTaskDeleted(cfg, task.ID, task.Title, projectInfo.Name); err != nil && verbose {
+				fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+			}
+		}
+	}
+
+	if jsonOutput {
+		ids := make([]int, len(archived))
+		for i, task := range archived {
+			ids[i] = task.ID
+		}
+		output := map[string]interface{}{
+			"success":        true,
+			"project":        projectInfo.Name,
+			"archived_count": len(archived),
+			"archived_ids":   ids,
+			"archive_total":  len(archive.Tasks),
+			"remaining":      len(projectDB.Tasks),
+			"archive_path":   archivePath,
+		}
+		printArchiveJSON(output)
+		return
+	}
+
+	if len(archived) == 0 {
+		fmt.Printf("No done tasks completed more than %s ago to archive\n", formatDuration(archiveBefore))
+		return
+	}
+	fmt.Printf("Archived %d task(s) from %s (%d in archive, %d remaining)\n", len(archived), projectInfo.Name, len(archive.Tasks), len(projectDB.Tasks))
+	if verbose {
+		for _, task := range archived {
+			fmt.Printf("  #%d %s\n", task.ID, task.Title)
+		}
+	}
+}
+
+func runUnarchive(cmd *cobra.Command, args []string) {
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil || taskID <= 0 {
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'. Task ID must be a positive number.", args[0])
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registryPath := cfg.GetProjectsPath()
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Update last accessed time
+	if err := registry.UpdateLastAccessed(projectInfo.Name); err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update last accessed time: %v\n", err)
+		}
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database and archive
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+	archivePath := cfg.GetProjectArchivePath(projectInfo.Name)
+	archive, err := database.LoadTaskArchive(archivePath, projectInfo.Name)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading archive: %v", err)
+	}
+
+	task, found := archive.Get(taskID)
+	if !found {
+		exitWithError(codeNotFound, "Error: task #%d is not archived", taskID)
+	}
+	if err := projectDB.RestoreTask(task); err != nil {
+		exitWithError(codeStorageError, "Error restoring task: %v", err)
+	}
+
+	// Save the database first, for the same reason archive saves the archive first
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
+	}
+	archive.Remove(taskID)
+	if err := archive.Save(archivePath); err != nil {
+		exitWithError(codeStorageError, "Error saving archive: %v", err)
+	}
+
+	// Save updated registry
+	if err := registry.Save(registryPath); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save registry: %v\n", err)
+	}
+
+	// Notify web server of the restored task
+	if err := notify.NotifyTaskCreated(cfg, task, projectInfo.Name); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":       true,
+			"project":       projectInfo.Name,
+			"task":          task,
+			"archive_total": len(archive.Tasks),
+		}
+		printArchiveJSON(output)
+		return
+	}
+
+	fmt.Printf("Restored task #%d: %s\n", task.ID, task.Title)
+}
+
+// loadArchivedTasks returns the archived tasks of a project that match filter
+func loadArchivedTasks(cfg *config.Config, projectName string, filter *models.TaskFilter) ([]*models.Task, error) {
+	archive, err := database.LoadTaskArchive(cfg.GetProjectArchivePath(projectName), projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*models.Task
+	for _, task := range archive.Tasks {
+		if filter == nil || filter.Matches(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func printArchiveJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	archiveCmd.Flags().DurationVar(&archiveBefore, "before", 30*24*time.Hour, "Only archive tasks completed longer ago than this")
+
+	RootCmd.AddCommand(archiveCmd)
+	RootCmd.AddCommand(unarchiveCmd)
+}
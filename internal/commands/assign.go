@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/notify"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	assignRotate       bool
+	assignAssigneeFile string
+)
+
+// assignCmd represents the assign command
+var assignCmd = &cobra.Command{
+	Use:   "assign <id> [assignee]",
+	Short: "Assign a task to someone",
+	Long: `Assign a task to the given assignee, or to the next person in a rotation.
+
+With --rotate, the assignee is taken round-robin from --assignee-file, a text
+file with one assignee per line (blank lines and lines starting with # are
+ignored). Each project keeps its own place in the rotation, so successive
+assigns spread work evenly across the list.
+
+Examples:
+  quicktodo assign 3 alice
+  quicktodo assign 4 --rotate --assignee-file team.txt
+  quicktodo assign 5 --rotate --assignee-file team.txt --json`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runAssign,
+}
+
+func runAssign(cmd *cobra.Command, args []string) {
+	// Parse task ID
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'", args[0])
+	}
+
+	// Validate the assignee source before taking any locks
+	var assignees []string
+	if assignRotate {
+		if len(args) > 1 {
+			exitWithError(codeInvalidArgument, "Error: an assignee argument cannot be combined with --rotate")
+		}
+		if assignAssigneeFile == "" {
+			exitWithError(codeInvalidArgument, "Error: --rotate requires --assignee-file")
+		}
+		assignees, err = readAssigneeFile(assignAssigneeFile)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+	} else {
+		if assignAssigneeFile != "" {
+			exitWithError(codeInvalidArgument, "Error: --assignee-file requires --rotate")
+		}
+		if len(args) < 2 || strings.TrimSpace(args[1]) == "" {
+			exitWithError(codeInvalidArgument, "Error: an assignee is required (or use --rotate)")
+		}
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	// Find task
+	task, err := projectDB.GetTask(taskID)
+	if err != nil {
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
+	}
+
+	// Pick the assignee; the rotation only advances once the task is saved
+	var rotation *database.RotationState
+	var assignee string
+	if assignRotate {
+		rotation, err = database.LoadRotationState(cfg.GetRotationPath())
+		if err != nil {
+			exitWithError(codeStorageError, "Error loading rotation: %v", err)
+		}
+		assignee, err = rotation.Next(projectInfo.Name, assignees)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+	} else {
+		assignee = strings.TrimSpace(args[1])
+	}
+
+	before := task.Clone()
+	task.AssignTo(assignee)
+	projectDB.RecordTaskChanges(before, task, currentActor())
+
+	// Save project database
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
+	}
+
+	if rotation != nil {
+		if err := rotation.Save(cfg.GetRotationPath()); err != nil {
+			exitWithError(codeStorageError, "Error saving rotation: %v", err)
+		}
+	}
+
+	// Sync to TODO list if enabled
+	syncToTodoList(task, projectInfo.Name, "edit", cfg)
+
+	// Notify web server of task update
+	if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+	}
+
+	// Output result
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":     true,
+			"assigned_to": assignee,
+			"rotated":     assignRotate,
+			"task":        task,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	if assignRotate {
+		fmt.Printf("Assigned task #%d to %s (rotation)\n", task.ID, assignee)
+		return
+	}
+	fmt.Printf("Assigned task #%d to %s\n", task.ID, assignee)
+}
+
+// readAssigneeFile reads one assignee per line, skipping blank lines and
+// # comments
+func readAssigneeFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open assignee file: %w", err)
+	}
+	defer file.Close()
+
+	var assignees []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		assignees = append(assignees, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read assignee file: %w", err)
+	}
+
+	if len(assignees) == 0 {
+		return nil, fmt.Errorf("assignee file %s lists no assignees", path)
+	}
+	return assignees, nil
+}
+
+func init() {
+	assignCmd.Flags().BoolVar(&assignRotate, "rotate", false, "Assign to the next person in the rotation from --assignee-file")
+	assignCmd.Flags().StringVar(&assignAssigneeFile, "assignee-file", "", "File listing the rotation's assignees, one per line")
+
+	RootCmd.AddCommand(assignCmd)
+}
@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	assignmentsAllProjects bool
+	assignmentsMaxOpen     int
+	assignmentsAssignedTo  string
+)
+
+// assignmentsCmd represents the assignments command
+var assignmentsCmd = &cobra.Command{
+	Use:   "assignments",
+	Short: "Show who is working on what",
+	Long: `Summarize the tasks held by each assignee in the current project, or across
+every registered project with --all-projects, to see who is working on what
+and spot agents that have been given more work than they can handle. Each
+assignee is listed with their open (pending or in-progress) and done task
+counts, followed by the tasks nobody is assigned to.
+
+--assigned-to shows a single assignee instead, with their counts and the
+tasks assigned to them.
+
+Assignees holding more than max_open_per_assignee open tasks (from the config,
+or --max-open) are flagged as over the limit. A limit of 0 disables the check.
+
+Examples:
+  quicktodo assignments
+  quicktodo assignments --assigned-to claude
+  quicktodo assignments --all-projects --max-open 5
+  quicktodo assignments --json`,
+	Args: cobra.NoArgs,
+	Run:  runAssignments,
+}
+
+func runAssignments(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	limit := cfg.MaxOpenPerAssignee
+	if cmd.Flags().Changed("max-open") {
+		if assignmentsMaxOpen < 0 {
+			exitWithError(codeInvalidArgument, "Error: --max-open cannot be negative")
+		}
+		limit = assignmentsMaxOpen
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	var projects []*database.ProjectInfo
+	if assignmentsAllProjects {
+		for _, projectInfo := range registry.ListProjects() {
+			projects = append(projects, projectInfo)
+		}
+		sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	} else {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			exitWithError(codeInternalError, "Error getting current directory: %v", err)
+		}
+
+		projectInfo, exists := registry.GetProjectByPath(currentDir)
+		if !exists {
+			exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first, or use --all-projects")
+		}
+		projects = append(projects, projectInfo)
+	}
+
+	// Projects whose database can't be loaded are skipped, as in search
+	databases := make(map[string]*models.ProjectDatabase)
+	for _, projectInfo := range projects {
+		projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectInfo.Name))
+		if err != nil {
+			if verbose {
+				fmt.Fprintf(os.Stderr, "Warning: skipping project %s: %v\n", projectInfo.Name, err)
+			}
+			continue
+		}
+		databases[projectInfo.Name] = projectDB
+	}
+
+	names := make([]string, 0, len(databases))
+	for name := range databases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if assignmentsAssignedTo != "" {
+		load, tasks := models.BuildAssigneeLoad(assignmentsAssignedTo, databases, limit)
+		if jsonOutput {
+			printAssignmentsJSON(map[string]interface{}{
+				"success":  true,
+				"projects": names,
+				"limit":    limit,
+				"assignee": load,
+				"tasks":    tasks,
+			})
+			return
+		}
+
+		outputAssigneeHuman(load, tasks, names, limit)
+		return
+	}
+
+	report := models.BuildAssignmentReport(databases, limit)
+
+	if jsonOutput {
+		printAssignmentsJSON(map[string]interface{}{
+			"success":         true,
+			"projects":        names,
+			"limit":           report.Limit,
+			"assignees":       report.Assignees,
+			"unassigned":      report.Unassigned,
+			"unassigned_load": report.UnassignedLoad,
+			"over_limit":      report.OverLimit,
+		})
+		return
+	}
+
+	outputAssignmentsHuman(report, projects)
+}
+
+func printAssignmentsJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func outputAssignmentsHuman(report *models.AssignmentReport, projects []*database.ProjectInfo) {
+	scope := "all projects"
+	if !assignmentsAllProjects && len(projects) == 1 {
+		scope = projects[0].Name
+	}
+	if report.Limit > 0 {
+		fmt.Printf("Tasks by assignee in %s (limit %d):\n\n", scope, report.Limit)
+	} else {
+		fmt.Printf("Tasks by assignee in %s:\n\n", scope)
+	}
+
+	if len(report.Assignees) == 0 {
+		fmt.Println("  No tasks are assigned")
+	}
+	for _, load := range report.Assignees {
+		line := "  " + formatAssigneeLoad(load.Assignee, load)
+		if assignmentsAllProjects && len(load.Projects) > 1 {
+			line += fmt.Sprintf(" across %d projects", len(load.Projects))
+		}
+		if load.OverLimit {
+			line += fmt.Sprintf("  ⚠️  %d over limit", load.Open-report.Limit)
+		}
+		fmt.Println(line)
+	}
+	if unassigned := report.UnassignedLoad; unassigned.Open > 0 || unassigned.Done > 0 || unassigned.Cancelled > 0 {
+		fmt.Println("  " + formatAssigneeLoad("(unassigned)", unassigned))
+	}
+
+	if len(report.OverLimit) > 0 {
+		fmt.Printf("\nOver the limit: %s\n", strings.Join(report.OverLimit, ", "))
+	}
+}
+
+// outputAssigneeHuman prints one assignee's counts, then their tasks grouped
+// by project
+func outputAssigneeHuman(load *models.AssigneeLoad, tasks map[string][]*models.Task, names []string, limit int) {
+	line := formatAssigneeLoad(load.Assignee, load)
+	if load.OverLimit {
+		line += fmt.Sprintf("  ⚠️  %d over limit", load.Open-limit)
+	}
+	fmt.Println(line)
+
+	if len(tasks) == 0 {
+		fmt.Printf("\nNo tasks are assigned to %s\n", load.Assignee)
+		return
+	}
+	for _, name := range names {
+		if len(tasks[name]) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s:\n", name)
+		for _, task := range tasks[name] {
+			displayTask(task)
+		}
+	}
+}
+
+// formatAssigneeLoad is a load's counts, labelled with name
+func formatAssigneeLoad(name string, load *models.AssigneeLoad) string {
+	line := fmt.Sprintf("%-20s %3d open", name, load.Open)
+	if load.InProgress > 0 {
+		line += fmt.Sprintf(" (%d in progress)", load.InProgress)
+	}
+	line += fmt.Sprintf(", %d done", load.Done)
+	if load.Cancelled > 0 {
+		line += fmt.Sprintf(", %d cancelled", load.Cancelled)
+	}
+	return line
+}
+
+func init() {
+	assignmentsCmd.Flags().BoolVar(&assignmentsAllProjects, "all-projects", false, "Count open tasks across every registered project")
+	assignmentsCmd.Flags().StringVar(&assignmentsAssignedTo, "assigned-to", "", "Show only the tasks assigned to this agent")
+	assignmentsCmd.Flags().IntVar(&assignmentsMaxOpen, "max-open", 0, "Open tasks allowed per assignee (default: config max_open_per_assignee; 0 for no limit)")
+
+	RootCmd.AddCommand(assignmentsCmd)
+}
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"quicktodo/internal/notify"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var attachName string
+
+// attachCmd represents the attach command
+var attachCmd = &cobra.Command{
+	Use:   "attach <id> <url>",
+	Short: "Link a URL to a task",
+	Long: `Attach a link to a task, such as a pull request, design doc or screenshot.
+
+Only the link is stored. The attachment is named after the last part of the
+URL unless --name is given; names must be unique within a task and are used to
+detach it again.
+
+Examples:
+  quicktodo attach 1 https://github.com/org/repo/pull/42 --name pr
+  quicktodo attach 3 https://docs.example.com/design/auth.md
+  quicktodo attach 5 file:///home/me/screenshots/bug.png --json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		url := args[1]
+		runAttachmentChange(args[0], "Attached", func(task *models.Task) (*models.Attachment, error) {
+			return task.AddAttachment(attachName, url)
+		})
+	},
+}
+
+// detachCmd represents the detach command
+var detachCmd = &cobra.Command{
+	Use:   "detach <id> <name>",
+	Short: "Remove a linked URL from a task",
+	Long: `Remove an attachment from a task by name.
+
+Examples:
+  quicktodo detach 1 pr
+  quicktodo detach 3 auth.md --json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[1]
+		runAttachmentChange(args[0], "Detached", func(task *models.Task) (*models.Attachment, error) {
+			return task.RemoveAttachment(name)
+		})
+	},
+}
+
+// runAttachmentChange applies an attachment change to a task under the
+// project lock and reports the affected attachment
+func runAttachmentChange(taskIDStr, verb string, change func(task *models.Task) (*models.Attachment, error)) {
+	// Parse task ID
+	taskID, err := strconv.Atoi(taskIDStr)
+	if err != nil {
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'", taskIDStr)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	// Find task
+	task, err := projectDB.GetTask(taskID)
+	if err != nil {
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
+	}
+
+	before := task.Clone()
+	attachment, err := change(task)
+	if err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+	projectDB.RecordTaskChanges(before, task, currentActor())
+
+	// Save project database
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
+	}
+
+	// Notify web server of task update
+	if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+	}
+
+	// Output result
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":    true,
+			"attachment": attachment,
+			"task":       task,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("%s %s (%s) on task #%d: %s\n", verb, attachment.Name, attachment.URL, task.ID, task.Title)
+}
+
+func init() {
+	attachCmd.Flags().StringVar(&attachName, "name", "", "Name for the attachment (defaults to the last part of the URL)")
+
+	RootCmd.AddCommand(attachCmd)
+	RootCmd.AddCommand(detachCmd)
+}
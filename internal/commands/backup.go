@@ -0,0 +1,241 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/notify"
+
+	"github.com/spf13/cobra"
+)
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup [project]",
+	Short: "Back up a project database now",
+	Long: `Copy a project's database into its backup directory now, whatever
+create_backups and backup_every_n_writes say, then remove the oldest backups
+beyond max_backups. Without a project name, the current directory's project
+is backed up.
+
+Backups are also taken automatically before the database is saved; see
+'diff --list' for the existing ones and 'restore' to roll back to one.
+
+Examples:
+  quicktodo backup
+  quicktodo backup my-project --json`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runBackup,
+}
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore <project> [backup]",
+	Short: "Roll a project database back to a backup",
+	Long: `Replace a project's database with one of its backups, the newest unless a
+backup is named. Backups are named by their UTC timestamp; list them with
+'diff --list' and compare one with the current database with 'diff <backup>'.
+
+The database being replaced is backed up first (with create_backups on), and
+the restore is recorded for undo, so it can be rolled back as well.
+
+Examples:
+  quicktodo restore my-project
+  quicktodo restore my-project 20240102T150405.000000000Z --json`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runRestore,
+}
+
+func runBackup(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	projectInfo := resolveNamedProject(cfg, args)
+
+	// Acquire lock for project, so the copy isn't taken mid-save
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	name, err := database.BackupProjectDatabase(cfg.DataDir, projectInfo.Name, cfg.GetProjectDatabasePath(projectInfo.Name), cfg.MaxBackups)
+	if err != nil {
+		exitWithError(codeStorageError, "Error backing up project database: %v", err)
+	}
+	if name == "" {
+		exitWithError(codeNotFound, "Error: project %s has no database to back up", projectInfo.Name)
+	}
+
+	backups, err := database.ListBackups(cfg.DataDir, projectInfo.Name)
+	if err != nil {
+		exitWithError(codeStorageError, "Error listing backups: %v", err)
+	}
+
+	if jsonOutput {
+		printBackupJSON(map[string]interface{}{
+			"success":      true,
+			"project":      projectInfo.Name,
+			"backup":       name,
+			"path":         database.BackupPath(cfg.DataDir, projectInfo.Name, name),
+			"backup_count": len(backups),
+		})
+		return
+	}
+
+	fmt.Printf("💾 Backed up %s as %s\n", projectInfo.Name, name)
+	fmt.Printf("%d backup(s) kept in %s\n", len(backups), database.BackupDir(cfg.DataDir, projectInfo.Name))
+}
+
+// resolveNamedProject returns the project named in args, or the current
+// directory's project
+func resolveNamedProject(cfg *config.Config, args []string) *database.ProjectInfo {
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	if len(args) > 0 {
+		info, exists := registry.GetProjectByName(args[0])
+		if !exists {
+			exitWithError(codeProjectNotFound, "Error: project '%s' is not registered\nRun 'quicktodo list-projects' to see registered projects", args[0])
+		}
+		return info
+	}
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+	info, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo init' first, or name a project")
+	}
+	return info
+}
+
+func runRestore(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	projectInfo := resolveNamedProject(cfg, args[:1])
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Pick the backup before saving, which may add a newer one
+	backups, err := database.ListBackups(cfg.DataDir, projectInfo.Name)
+	if err != nil {
+		exitWithError(codeStorageError, "Error listing backups: %v", err)
+	}
+	var name string
+	if len(args) > 1 {
+		name = args[1]
+		if err := database.ValidateBackupName(name); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v\nRun 'quicktodo diff --list' in the project to see available backups", err)
+		}
+		if _, err := os.Stat(database.BackupPath(cfg.DataDir, projectInfo.Name, name)); os.IsNotExist(err) {
+			exitWithError(codeNotFound, "Error: backup '%s' not found\nRun 'quicktodo diff --list' in the project to see available backups", name)
+		}
+	} else {
+		if len(backups) == 0 {
+			exitWithError(codeNotFound, "Error: project %s has no backups to restore", projectInfo.Name)
+		}
+		name = backups[len(backups)-1]
+	}
+
+	backupDB, err := loadProjectDatabase(cfg, database.BackupPath(cfg.DataDir, projectInfo.Name, name))
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading backup %s: %v", name, err)
+	}
+
+	// Keep the current database to report what the restore changes
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	currentDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		if _, statErr := os.Stat(dbPath); !os.IsNotExist(statErr) {
+			exitWithError(codeStorageError, "Error loading project database: %v", err)
+		}
+		currentDB = nil
+	}
+
+	// Keep moving forward: IDs handed out since the backup must not be reused,
+	// and the version must grow for BackupDue to keep counting writes
+	if currentDB != nil {
+		backupDB.NextID = max(backupDB.NextID, currentDB.NextID)
+		backupDB.Version = currentDB.Version + 1
+	}
+
+	if err := saveProjectDatabase(cfg, backupDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
+	}
+
+	// Notify web server of the restored tasks
+	var diff *database.Diff
+	if currentDB != nil {
+		diff = database.DiffDatabases(currentDB, backupDB)
+		for _, task := range diff.Added {
+			if err := notify.NotifyTaskCreated(cfg, task, projectInfo.Name); err != nil && verbose {
+				fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+			}
+		}
+		for _, task := range diff.Removed {
+			if err := notify.NotifyTaskDeleted(cfg, task.ID, task.Title, projectInfo.Name); err != nil && verbose {
+				fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+			}
+		}
+		for _, modified := range diff.Modified {
+			task, _ := backupDB.GetTask(modified.ID)
+			if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
+				fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+			}
+		}
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":    true,
+			"project":    projectInfo.Name,
+			"backup":     name,
+			"task_count": len(backupDB.Tasks),
+		}
+		if diff != nil {
+			output["diff"] = diff
+		}
+		printBackupJSON(output)
+		return
+	}
+
+	fmt.Printf("⏪ Restored %s from backup %s (%d task(s))\n", projectInfo.Name, name, len(backupDB.Tasks))
+	if diff != nil {
+		fmt.Printf("%d task(s) added, %d removed, %d changed\n", len(diff.Added), len(diff.Removed), len(diff.Modified))
+	}
+}
+
+func printBackupJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	RootCmd.AddCommand(backupCmd)
+	RootCmd.AddCommand(restoreCmd)
+}
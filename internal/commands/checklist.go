@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"quicktodo/internal/notify"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// checkAddCmd represents the check-add command
+var checkAddCmd = &cobra.Command{
+	Use:   "check-add <task-id> <text>",
+	Short: "Add a checklist item to a task",
+	Long: `Add an unchecked item to a task's checklist.
+
+Checklist items are lightweight steps within a single task; they have no
+status, owner or history of their own. display-task shows them numbered, and
+the number is used to toggle an item with check-toggle.
+
+Examples:
+  quicktodo check-add 3 "Write migration"
+  quicktodo check-add 3 "Update docs" --json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		text := args[1]
+		runChecklistChange(args[0], func(task *models.Task) (*models.ChecklistItem, error) {
+			return task.AddChecklistItem(text)
+		})
+	},
+}
+
+// checkToggleCmd represents the check-toggle command
+var checkToggleCmd = &cobra.Command{
+	Use:   "check-toggle <task-id> <index>",
+	Short: "Check or uncheck a checklist item",
+	Long: `Flip a checklist item between done and not done. Items are numbered from 1
+in the order shown by display-task.
+
+Examples:
+  quicktodo check-toggle 3 1
+  quicktodo check-toggle 3 2 --json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		index, err := strconv.Atoi(args[1])
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: invalid checklist index '%s'", args[1])
+		}
+		runChecklistChange(args[0], func(task *models.Task) (*models.ChecklistItem, error) {
+			return task.ToggleChecklistItem(index)
+		})
+	},
+}
+
+// runChecklistChange applies a checklist change to a task under the project
+// lock and reports the affected item and the checklist progress
+func runChecklistChange(taskIDStr string, change func(task *models.Task) (*models.ChecklistItem, error)) {
+	// Parse task ID
+	taskID, err := strconv.Atoi(taskIDStr)
+	if err != nil {
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'", taskIDStr)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	// Find task
+	task, err := projectDB.GetTask(taskID)
+	if err != nil {
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
+	}
+
+	before := task.Clone()
+	item, err := change(task)
+	if err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+	projectDB.RecordTaskChanges(before, task, currentActor())
+
+	// Save project database
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
+	}
+
+	// Notify web server of task update
+	if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+	}
+
+	progress := task.ChecklistProgress()
+
+	// Output result
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":            true,
+			"item":               item,
+			"checklist_progress": progress,
+			"task":               task,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	mark := " "
+	if item.Done {
+		mark = "x"
+	}
+	fmt.Printf("[%s] %s on task #%d: %s (%d/%d done)\n", mark, item.Text, task.ID, task.Title, progress.Done, progress.Total)
+}
+
+func init() {
+	RootCmd.AddCommand(checkAddCmd)
+	RootCmd.AddCommand(checkToggleCmd)
+}
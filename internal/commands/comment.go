@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/notify"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// commentCmd represents the comment command
+var commentCmd = &cobra.Command{
+	Use:   "comment <id> <text>",
+	Short: "Add a comment to a task",
+	Long: `Append a comment to a task. Comments keep context that would otherwise be lost
+by editing the description: each one records its author and when it was added,
+and display-task lists them in order.
+
+The author is the --agent-id when given, otherwise the current user.
+
+Examples:
+  quicktodo comment 3 "Blocked on the API keys, asked ops"
+  quicktodo comment 3 "Tried the retry approach, too slow" --agent-id claude --json`,
+	Args: cobra.ExactArgs(2),
+	Run:  runComment,
+}
+
+func runComment(cmd *cobra.Command, args []string) {
+	// Parse task ID
+	taskID, err := strconv.Atoi(args[0])
+	if err != nil {
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'", args[0])
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	// Find task
+	task, err := projectDB.GetTask(taskID)
+	if err != nil {
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
+	}
+
+	comment, err := task.AddComment(currentActor(), args[1])
+	if err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+
+	// Save project database
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
+	}
+
+	// Notify web server of task update
+	if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+	}
+
+	// Output result
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success": true,
+			"comment": comment,
+			"task":    task,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("💬 Commented on task #%d: %s (%d comment(s))\n", task.ID, task.Title, len(task.Comments))
+}
+
+func init() {
+	RootCmd.AddCommand(commentCmd)
+}
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	compactDryRun           bool
+	compactOlderThan        time.Duration
+	compactArchiveOlderThan time.Duration
+)
+
+// compactCmd represents the compact command
+var compactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Reclaim space used by backups, notifications, the undo journal and the archive",
+	Long: `Prune residue that accumulates in the data directory over time:
+
+- backups beyond max_backups for each project
+- notification files written while no web server was running
+- temporary files left behind by interrupted writes
+- operations in each project's undo journal beyond max_undo_operations
+- with --archive-older-than, archived tasks completed longer ago than that
+
+Notification and temporary files younger than --older-than are kept so that
+in-flight writes are not disturbed.
+
+Examples:
+  quicktodo compact
+  quicktodo compact --dry-run
+  quicktodo compact --archive-older-than 2160h
+  quicktodo compact --older-than 24h --json`,
+	Args: cobra.NoArgs,
+	Run:  runCompact,
+}
+
+func runCompact(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	result, err := database.Compact(database.CompactOptions{
+		DataDir:    cfg.DataDir,
+		MaxBackups: cfg.MaxBackups,
+		MinAge:     compactOlderThan,
+		DryRun:     compactDryRun,
+
+		MaxUndoOperations: cfg.MaxUndoOperations,
+		ArchiveRetention:  compactArchiveOlderThan,
+		Locks:             database.NewLockManager(cfg.DataDir+"/locks", cfg.LockTimeout),
+	})
+	if err != nil {
+		exitWithError(codeStorageError, "Error compacting data directory: %v", err)
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success": true,
+			"result":  result,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	verb := "Removed"
+	if compactDryRun {
+		verb = "Would remove"
+	}
+
+	for _, category := range result.Categories {
+		switch category.Name {
+		case database.CompactUndoJournal:
+			fmt.Printf("%-15s %d operation(s) from %d file(s), %s\n", category.Name+":", category.Entries, len(category.Files), formatBytes(category.ReclaimedBytes))
+		case database.CompactArchive:
+			fmt.Printf("%-15s %d task(s) from %d file(s), %s\n", category.Name+":", category.Entries, len(category.Files), formatBytes(category.ReclaimedBytes))
+		default:
+			fmt.Printf("%-15s %d file(s), %s\n", category.Name+":", len(category.Files), formatBytes(category.ReclaimedBytes))
+		}
+		if verbose {
+			for _, file := range category.Files {
+				fmt.Printf("  %s\n", file)
+			}
+		}
+	}
+	fmt.Printf("%s %d file(s) and %d journal/archive entries, reclaiming %s\n", verb, result.FileCount, result.EntryCount, formatBytes(result.ReclaimedBytes))
+}
+
+// formatBytes renders a byte count in human-readable units
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	compactCmd.Flags().BoolVar(&compactDryRun, "dry-run", false, "Show what would be removed without deleting anything")
+	compactCmd.Flags().DurationVar(&compactOlderThan, "older-than", time.Hour, "Only remove notification and temporary files older than this")
+	compactCmd.Flags().DurationVar(&compactArchiveOlderThan, "archive-older-than", 0, "Drop archived tasks completed longer ago than this (0 keeps them all)")
+
+	RootCmd.AddCommand(compactCmd)
+}
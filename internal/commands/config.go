@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"quicktodo/internal/config"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// configCmd groups the subcommands that edit the configuration file
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage QuickTodo configuration",
+}
+
+// configWebhooksCmd groups the webhook subcommands
+var configWebhooksCmd = &cobra.Command{
+	Use:   "webhooks",
+	Short: "Manage the webhooks notified of task changes",
+	Long: `Manage the URLs that task changes are POSTed to. Whenever a command creates,
+updates or deletes a task, each webhook receives the same notification JSON as
+the web server (type, data, project and timestamp), plus a one-line summary
+under "text" and "content" so Slack and Discord incoming webhooks can post it
+directly.
+
+Each webhook is tried up to 3 times with a short timeout. Failures never fail
+the command; run it with --verbose to see them.
+
+Examples:
+  quicktodo config webhooks add https://hooks.slack.com/services/T000/B000/XXXX
+  quicktodo config webhooks list
+  quicktodo config webhooks remove https://hooks.slack.com/services/T000/B000/XXXX`,
+}
+
+var configWebhooksAddCmd = &cobra.Command{
+	Use:   "add <url>",
+	Short: "Add a webhook",
+	Args:  cobra.ExactArgs(1),
+	Run:   runConfigWebhooksAdd,
+}
+
+var configWebhooksRemoveCmd = &cobra.Command{
+	Use:   "remove <url>",
+	Short: "Remove a webhook",
+	Args:  cobra.ExactArgs(1),
+	Run:   runConfigWebhooksRemove,
+}
+
+var configWebhooksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List webhooks",
+	Args:  cobra.NoArgs,
+	Run:   runConfigWebhooksList,
+}
+
+func runConfigWebhooksAdd(cmd *cobra.Command, args []string) {
+	url := strings.TrimSpace(args[0])
+	if err := config.ValidateWebhookURL(url); err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	if webhookIndex(cfg.Webhooks, url) >= 0 {
+		exitWithError(codeAlreadyExists, "Error: webhook '%s' is already configured", url)
+	}
+	cfg.Webhooks = append(cfg.Webhooks, url)
+
+	if err := cfg.Save(); err != nil {
+		exitWithError(codeConfigError, "Error saving configuration: %v", err)
+	}
+
+	if jsonOutput {
+		outputConfigJSON(map[string]interface{}{
+			"success":  true,
+			"url":      url,
+			"webhooks": cfg.Webhooks,
+		})
+		return
+	}
+
+	fmt.Printf("Added webhook %s\n", url)
+}
+
+func runConfigWebhooksRemove(cmd *cobra.Command, args []string) {
+	url := strings.TrimSpace(args[0])
+
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	index := webhookIndex(cfg.Webhooks, url)
+	if index < 0 {
+		exitWithError(codeNotFound, "Error: webhook '%s' not found", url)
+	}
+	cfg.Webhooks = append(cfg.Webhooks[:index], cfg.Webhooks[index+1:]...)
+
+	if err := cfg.Save(); err != nil {
+		exitWithError(codeConfigError, "Error saving configuration: %v", err)
+	}
+
+	if jsonOutput {
+		webhooks := cfg.Webhooks
+		if webhooks == nil {
+			webhooks = []string{}
+		}
+		outputConfigJSON(map[string]interface{}{
+			"success":  true,
+			"url":      url,
+			"removed":  true,
+			"webhooks": webhooks,
+		})
+		return
+	}
+
+	fmt.Printf("Removed webhook %s\n", url)
+}
+
+func runConfigWebhooksList(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	if jsonOutput {
+		webhooks := cfg.Webhooks
+		if webhooks == nil {
+			webhooks = []string{}
+		}
+		outputConfigJSON(map[string]interface{}{
+			"success":       true,
+			"webhook_count": len(webhooks),
+			"webhooks":      webhooks,
+		})
+		return
+	}
+
+	if len(cfg.Webhooks) == 0 {
+		fmt.Println("No webhooks configured")
+		return
+	}
+
+	for _, url := range cfg.Webhooks {
+		fmt.Println(url)
+	}
+}
+
+// webhookIndex returns the position of url among webhooks, or -1
+func webhookIndex(webhooks []string, url string) int {
+	for i, webhook := range webhooks {
+		if webhook == url {
+			return i
+		}
+	}
+	return -1
+}
+
+func outputConfigJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	configWebhooksCmd.AddCommand(configWebhooksAddCmd)
+	configWebhooksCmd.AddCommand(configWebhooksRemoveCmd)
+	configWebhooksCmd.AddCommand(configWebhooksListCmd)
+
+	configCmd.AddCommand(configWebhooksCmd)
+	RootCmd.AddCommand(configCmd)
+}
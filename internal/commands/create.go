@@ -7,10 +7,11 @@ import (
 	"path/filepath"
 	"quicktodo/internal/config"
 	"quicktodo/internal/database"
+	"quicktodo/internal/hooks"
 	"quicktodo/internal/models"
 	"quicktodo/internal/notify"
 	"quicktodo/internal/sync"
-	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -18,61 +19,177 @@ import (
 var (
 	taskDescription string
 	taskPriority    string
+	taskStatus      string
+	taskDue         string
+	taskStart       string
+	taskDependsOn   string
+	taskRecurrence  string
+	createFromStdin bool
+	findSimilar     bool
+	noDefaultTags   bool
+	createEdit      bool
+
+	taskDescriptionFile string
 )
 
 // createTaskCmd represents the create-task command
 var createTaskCmd = &cobra.Command{
-	Use:     "create-task <title>",
+	Use:     "create-task <title> | - | --edit | --stdin",
 	Aliases: []string{"new-task"},
 	Short:   "Add new task to current project",
 	Long: `Create a new task in the current project with the specified title.
 
 The command will auto-detect the current project from the working directory.
-You can optionally specify a description and priority for the task.
+You can optionally specify a description and priority for the task, and
+an initial status with --status to record work that is already underway or
+finished. Tasks created as done are marked completed at creation time.
+
+A due date is set with --due, either as YYYY-MM-DD or relative to today as
++Nd or +Nw (e.g. +3d for three days from now). --start takes the same forms
+and records when the task becomes actionable; list-tasks --scheduled hides
+tasks that haven't started yet. The start date can't be after the due date.
+
+--depends-on takes a comma-separated list of existing task IDs that must be
+done before this one; mark-completed refuses the task until they are.
+
+The project's default tags (see project-info --add-default-tag) are added to
+the task unless --no-default-tags is given.
+
+--recurrence makes the task repeat daily, weekly or monthly: completing it
+creates a new pending copy with its due date (and start date) moved forward by
+one interval, while the completed task stays done. A repeating task without a
+due date gets one an interval after it was completed.
+
+With --stdin, the task is read as a JSON object from standard input instead,
+using the same fields as the web API: title (required), description, priority,
+status, assigned_to, tags, start_date, due_date (RFC3339 or YYYY-MM-DD) and
+recurrence.
+
+Long descriptions are easier to write elsewhere: --description-file reads the
+description from a file, and --edit (or --description -) opens $EDITOR on the
+title and description, falling back to $VISUAL and then vi. A title of -
+reads the task from standard input instead, its first line being the title
+and the rest the description.
+
+With --find-similar, existing tasks with a similar title are listed first and
+you are asked to confirm before the task is created. With --json or --stdin
+there is no prompt: the command fails and reports the similar tasks instead.
+With --assume-yes (or QUICKTODO_ASSUME_YES=1) the task is created anyway.
 
 Examples:
   quicktodo create-task "Implement user authentication"
   quicktodo new-task "Fix login bug" --description "Users can't log in with email" --priority high
-  quicktodo create-task "Write documentation" --priority low`,
-	Args: cobra.ExactArgs(1),
+  quicktodo create-task "Write documentation" --priority low
+  quicktodo create-task "Migrate CI to new runners" --status done
+  quicktodo create-task "Send release notes" --due +3d
+  quicktodo create-task "Renew certificates" --start +2w --due +3w
+  quicktodo create-task "Deploy to production" --depends-on 3,5
+  quicktodo create-task "Review open PRs" --due +1d --recurrence daily
+  quicktodo create-task "Fix login bug on mobile" --find-similar
+  quicktodo create-task "Try the new editor" --no-default-tags
+  quicktodo create-task "Write the migration plan" --edit
+  quicktodo create-task "Investigate flaky test" --description-file notes.md
+  git log -1 --format=%B | quicktodo create-task -
+  echo '{"title":"Ship v2","tags":["release"],"due_date":"2025-01-31"}' | quicktodo create-task --stdin --json`,
+	Args: cobra.MaximumNArgs(1),
 	Run:  runCreateTask,
 }
 
 func runCreateTask(cmd *cobra.Command, args []string) {
-	title := strings.TrimSpace(args[0])
+	var patch *taskPatch
+	var title string
+
+	description := taskDescription
+	editing := createEdit || taskDescription == "-"
+	if editing {
+		description = ""
+	}
+	if taskDescriptionFile != "" {
+		if description != "" {
+			exitWithError(codeInvalidArgument, "Error: --description cannot be combined with --description-file")
+		}
+		var err error
+		if description, err = readDescriptionFile(taskDescriptionFile); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+	}
+
+	if createFromStdin {
+		if len(args) > 0 {
+			exitWithError(codeInvalidArgument, "Error: a title argument cannot be combined with --stdin")
+		}
+		if editing || taskDescriptionFile != "" {
+			exitWithError(codeInvalidArgument, "Error: --stdin cannot be combined with --edit or --description-file")
+		}
+
+		var err error
+		patch, err = readTaskPatch(os.Stdin)
+		if err == nil {
+			err = patch.validate(true)
+		}
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		title = models.SanitizeTitle(*patch.Title)
+	} else if len(args) == 1 && args[0] == "-" {
+		if editing {
+			exitWithError(codeInvalidArgument, "Error: a title read from stdin (-) cannot be combined with --edit")
+		}
+		stdinTitle, stdinDescription, err := readTaskText(os.Stdin)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		if stdinDescription != "" && description != "" {
+			exitWithError(codeInvalidArgument, "Error: the description was given both on stdin and with --description or --description-file")
+		}
+		title = models.SanitizeTitle(stdinTitle)
+		if stdinDescription != "" {
+			description = stdinDescription
+		}
+	} else {
+		if len(args) != 1 && !editing {
+			exitWithError(codeInvalidArgument, "Error: a task title is required (or use -, --edit or --stdin)")
+		}
+		if len(args) == 1 {
+			title = models.SanitizeTitle(args[0])
+		}
+	}
+
+	if editing {
+		editedTitle, editedDescription, err := editTaskText(title, description)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		title, description = models.SanitizeTitle(editedTitle), editedDescription
+	}
+
 	if title == "" {
-		fmt.Fprintf(os.Stderr, "Error: task title cannot be empty\n")
-		os.Exit(1)
+		exitWithError(codeInvalidArgument, "Error: task title cannot be empty")
 	}
 
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
 	}
 
 	// Get current directory
 	currentDir, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
 	}
 
 	// Load project registry
 	registryPath := cfg.GetProjectsPath()
 	registry, err := database.LoadProjectRegistry(registryPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project registry: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
 	}
 
 	// Find project for current directory
 	projectInfo, exists := registry.GetProjectByPath(currentDir)
 	if !exists {
-		fmt.Fprintf(os.Stderr, "Error: current directory is not a registered project\n")
-		fmt.Fprintf(os.Stderr, "Run 'quicktodo initialize-project' first\n")
-		os.Exit(1)
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
 	}
 
 	// Update last accessed time
@@ -83,25 +200,63 @@ func runCreateTask(cmd *cobra.Command, args []string) {
 	}
 
 	// Validate priority
-	priority := models.Priority(strings.ToLower(taskPriority))
+	priority := models.NormalizePriority(taskPriority)
 	if taskPriority != "" && !models.IsValidPriority(string(priority)) {
-		fmt.Fprintf(os.Stderr, "Error: invalid priority '%s'. Valid priorities: low, medium, high\n", taskPriority)
-		os.Exit(1)
+		exitWithError(codeInvalidPriority, "Error: invalid priority '%s'. Valid priorities: low, medium, high, critical", taskPriority)
 	}
 
 	if taskPriority == "" {
 		priority = models.Priority(cfg.DefaultPriority)
 	}
 
-	// Create lock manager
-	lockManager := database.NewLockManager(cfg.DataDir+"/locks", cfg.LockTimeout)
+	// Validate initial status
+	status := models.NormalizeStatus(taskStatus)
+	if taskStatus != "" && !models.IsValidStatus(string(status)) {
+		exitWithError(codeInvalidStatus, "Error: invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", taskStatus)
+	}
 
-	// Acquire lock for project
-	lockInfo, err := lockManager.AcquireLock(projectInfo.Name)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error acquiring project lock: %v\n", err)
-		os.Exit(1)
+	// Validate due date
+	var dueDate *time.Time
+	if taskDue != "" {
+		due, err := parseDueDate(taskDue)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		dueDate = &due
+	}
+
+	// Validate start date
+	var startDate *time.Time
+	if taskStart != "" {
+		start, err := parseStartDate(taskStart)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		startDate = &start
+	}
+
+	// Parse dependencies; they are checked against the database below
+	var dependsOn []int
+	if taskDependsOn != "" {
+		dependsOn, err = parseTaskIDList(taskDependsOn)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: invalid --depends-on: %v", err)
+		}
+	}
+
+	// Validate recurrence
+	recurrence := models.NormalizeRecurrence(taskRecurrence)
+	if !models.IsValidRecurrence(recurrence) {
+		exitWithError(codeInvalidArgument, "Error: invalid recurrence '%s'. Valid recurrences: daily, weekly, monthly", taskRecurrence)
+	}
+
+	// Check for likely duplicates before taking the lock, since this may prompt
+	if findSimilar {
+		confirmNoSimilarTasks(cfg, cfg.GetProjectDatabasePath(projectInfo.Name), title)
 	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
 	defer func() {
 		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
 			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
@@ -110,30 +265,69 @@ func runCreateTask(cmd *cobra.Command, args []string) {
 
 	// Load project database
 	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
-	projectDB, err := loadProjectDatabase(dbPath)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project database: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
 	}
 
 	// Create new task
-	task := models.NewTaskWithDetails(projectDB.NextID, title, taskDescription, priority)
+	task := models.NewTaskWithDetails(projectDB.NextID, title, description, priority)
+
+	// Start in the requested status
+	if taskStatus != "" {
+		if err := task.UpdateStatus(status); err != nil {
+			exitWithError(codeInvalidStatus, "Error: %v", err)
+		}
+	}
+
+	if dueDate != nil {
+		task.SetDueDate(dueDate)
+	}
+	if startDate != nil {
+		task.SetStartDate(startDate)
+	}
+	if recurrence != "" {
+		task.SetRecurrence(recurrence)
+	}
+	if dependsOn != nil {
+		if err := projectDB.ValidateDependencies(task.ID, dependsOn); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		task.SetDependsOn(dependsOn)
+	}
 
 	// Assign to agent if specified
 	if agentID != "" {
 		task.AssignTo(agentID)
 	}
 
+	// Apply the remaining fields from the stdin payload
+	if patch != nil {
+		if err := patch.apply(task); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+	}
+
+	if !noDefaultTags {
+		projectDB.ApplyDefaultTags(task)
+	}
+
+	if err := validateTaskText(cfg, task.Title, task.Description); err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+	if err := task.ValidateSchedule(); err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+
 	// Add task to database
 	if err := projectDB.AddTask(task); err != nil {
-		fmt.Fprintf(os.Stderr, "Error adding task: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error adding task: %v", err)
 	}
+	projectDB.RecordTaskCreated(task, currentActor())
 
 	// Save project database
-	if err := saveProjectDatabase(projectDB, dbPath); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving project database: %v\n", err)
-		os.Exit(1)
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
 	}
 
 	// Save updated registry
@@ -146,14 +340,21 @@ func runCreateTask(cmd *cobra.Command, args []string) {
 
 	// Notify web server of task creation
 	if err := notify.NotifyTaskCreated(cfg, task, projectInfo.Name); err != nil && verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to notify web server: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
 	}
 
+	// Run lifecycle hooks
+	runTaskHooks(cfg, hooks.EventTaskCreated, task, projectInfo.Name)
+
 	// Output result
 	if jsonOutput {
 		outputTaskJSON(task)
 	} else {
-		fmt.Printf("Created task #%d: %s\n", task.ID, task.Title)
+		if task.Status != models.StatusPending {
+			fmt.Printf("Created task #%d: %s (%s)\n", task.ID, task.Title, task.Status)
+		} else {
+			fmt.Printf("Created task #%d: %s\n", task.ID, task.Title)
+		}
 		if verbose {
 			fmt.Printf("Project: %s\n", projectInfo.Name)
 			fmt.Printf("Priority: %s\n", task.Priority)
@@ -168,30 +369,96 @@ func runCreateTask(cmd *cobra.Command, args []string) {
 	}
 }
 
-func loadProjectDatabase(filePath string) (*models.ProjectDatabase, error) {
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("project database file does not exist: %s", filePath)
+// confirmNoSimilarTasks lists existing tasks whose titles resemble title and
+// asks whether to create the task anyway. It exits if there are similar tasks
+// and the user declines, or when running non-interactively.
+func confirmNoSimilarTasks(cfg *config.Config, dbPath, title string) {
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	similar := projectDB.SimilarTasks(title, models.DefaultSimilarityThreshold)
+	if len(similar) == 0 {
+		return
+	}
+
+	// Assumed yes: create the task anyway
+	if nonInteractive() {
+		if !jsonOutput {
+			fmt.Fprintf(os.Stderr, "Warning: found %d similar task(s); creating the task anyway (assume-yes)\n", len(similar))
+		}
+		return
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":       false,
+			"error":         commandError{Code: codeAlreadyExists, Message: "similar tasks already exist"},
+			"similar_tasks": similar,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		os.Exit(1)
+	}
+
+	fmt.Fprintf(os.Stderr, "Found %d similar task(s):\n", len(similar))
+	for _, match := range similar {
+		fmt.Fprintf(os.Stderr, "  #%d [%s] %s (%.0f%% similar)\n", match.Task.ID, match.Task.Status, match.Task.Title, match.Score*100)
 	}
 
-	// Read file
-	data, err := os.ReadFile(filePath)
+	// Stdin already held the task JSON, so there is nothing to read an answer from
+	if createFromStdin {
+		exitWithError(codeAlreadyExists, "Error: similar tasks already exist; run without --find-similar to create the task anyway")
+	}
+
+	if confirm(fmt.Sprintf("Create \"%s\" anyway?", title)) {
+		return
+	}
+
+	exitWithError(codeCancelled, "Task not created")
+}
+
+// loadProjectDatabase reads and validates a project database, tolerating
+// (or, with lenient_load, repairing) clock skew up to the configured limit.
+// It also removes the leftovers of an interrupted save. Every command loads
+// databases through here, so they all see the same models.ProjectDatabase.
+func loadProjectDatabase(cfg *config.Config, filePath string) (*models.ProjectDatabase, error) {
+	// A temp file left by a save that died before its rename is never read;
+	// one older than a stale lock can't belong to a save still in progress
+	staleAge := time.Duration(cfg.StaleTimeout) * time.Minute
+	if removed, err := database.RemoveOrphanedTemp(filePath, staleAge); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	} else if removed && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: removed an interrupted write of %s\n", filePath)
+	}
+
+	store, err := database.OpenStore(cfg.Backend, filePath)
+	if err != nil {
+		return nil, err
+	}
+	db, err := store.Load()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read project database: %w", err)
+		return nil, err
 	}
 
-	// Unmarshal JSON
-	var db models.ProjectDatabase
-	if err := json.Unmarshal(data, &db); err != nil {
-		return nil, fmt.Errorf("failed to parse project database: %w", err)
+	if cfg.LenientLoad {
+		if repaired := db.RepairClockSkew(cfg.ClockSkew()); repaired > 0 && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: repaired clock skew in %d task(s)\n", repaired)
+		}
 	}
 
 	// Validate database
-	if err := db.Validate(); err != nil {
+	if err := db.ValidateWithClockSkew(cfg.ClockSkew()); err != nil {
 		return nil, fmt.Errorf("invalid project database: %w", err)
 	}
 
-	return &db, nil
+	return db, nil
 }
 
 func outputTaskJSON(task *models.Task) {
@@ -202,15 +469,19 @@ func outputTaskJSON(task *models.Task) {
 
 	data, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error formatting JSON output: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
 	}
 
 	fmt.Println(string(data))
 }
 
-// syncToTodoList syncs task changes to the AI TODO list if enabled
+// syncToTodoList syncs task changes to the AI TODO list if enabled, unless
+// --no-sync was given
 func syncToTodoList(task *models.Task, projectName, changeType string, cfg *config.Config) {
+	if noSync {
+		return
+	}
+
 	// Initialize sync manager
 	syncConfigPath := filepath.Join(cfg.DataDir, "sync_config.json")
 	syncManager, err := sync.NewTodoSyncManager(syncConfigPath)
@@ -238,9 +509,44 @@ func syncToTodoList(task *models.Task, projectName, changeType string, cfg *conf
 	}
 }
 
+// runTaskHooks runs the configured lifecycle hook for an event (best-effort)
+func runTaskHooks(cfg *config.Config, event string, task *models.Task, projectName string) {
+	if noHooks || len(cfg.Hooks) == 0 {
+		return
+	}
+
+	runner := hooks.NewRunner(cfg.Hooks)
+	if err := runner.Run(event, task, projectName); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// runStatusChangeHooks runs the status_changed hook, plus task_completed when
+// the task moved into done
+func runStatusChangeHooks(cfg *config.Config, oldStatus models.Status, task *models.Task, projectName string) {
+	if oldStatus == task.Status {
+		return
+	}
+
+	runTaskHooks(cfg, hooks.EventStatusChanged, task, projectName)
+	if task.IsComplete() {
+		runTaskHooks(cfg, hooks.EventTaskCompleted, task, projectName)
+	}
+}
+
 func init() {
 	createTaskCmd.Flags().StringVarP(&taskDescription, "description", "d", "", "Task description")
-	createTaskCmd.Flags().StringVarP(&taskPriority, "priority", "p", "", "Task priority (low, medium, high)")
+	createTaskCmd.Flags().StringVarP(&taskPriority, "priority", "p", "", "Task priority (low, medium, high, critical)")
+	createTaskCmd.Flags().StringVarP(&taskStatus, "status", "s", "", "Initial task status (pending, in_progress, done, cancelled)")
+	createTaskCmd.Flags().StringVar(&taskDue, "due", "", "Due date (YYYY-MM-DD, or +Nd/+Nw from today)")
+	createTaskCmd.Flags().StringVar(&taskStart, "start", "", "Start date before which the task isn't actionable (YYYY-MM-DD, or +Nd/+Nw from today)")
+	createTaskCmd.Flags().StringVar(&taskDependsOn, "depends-on", "", "Comma-separated IDs of tasks that must be done first")
+	createTaskCmd.Flags().StringVar(&taskRecurrence, "recurrence", "", "Repeat the task when it is completed (daily, weekly, monthly)")
+	createTaskCmd.Flags().BoolVar(&createFromStdin, "stdin", false, "Read the task as a JSON object from stdin")
+	createTaskCmd.Flags().BoolVar(&createEdit, "edit", false, "Write the title and description in $EDITOR")
+	createTaskCmd.Flags().StringVar(&taskDescriptionFile, "description-file", "", "Read the description from a file")
+	createTaskCmd.Flags().BoolVar(&findSimilar, "find-similar", false, "Check for tasks with a similar title and confirm before creating")
+	createTaskCmd.Flags().BoolVar(&noDefaultTags, "no-default-tags", false, "Don't add the project's default tags to the task")
 
 	RootCmd.AddCommand(createTaskCmd)
 }
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"os"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"testing"
+	"time"
+)
+
+func TestLoadProjectDatabaseAfterInterruptedSave(t *testing.T) {
+	cfg, _, projectName := newTestProject(t)
+	dbPath := cfg.GetProjectDatabasePath(projectName)
+
+	db, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	if err := db.AddTask(models.NewTask(db.NextID, "Survives the crash")); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if err := saveProjectDatabase(cfg, db, dbPath); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+
+	// Simulate a crash between writing the temp file and renaming it: the
+	// temp file is truncated and the rename never happens
+	tempPath := database.TempPath(dbPath)
+	if err := os.WriteFile(tempPath, []byte(`{"project": {"name": "`), 0644); err != nil {
+		t.Fatalf("Failed to write temporary file: %v", err)
+	}
+	old := time.Now().Add(-time.Duration(cfg.StaleTimeout+1) * time.Minute)
+	if err := os.Chtimes(tempPath, old, old); err != nil {
+		t.Fatalf("Failed to age temporary file: %v", err)
+	}
+
+	db, err = loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		t.Fatalf("Expected the original database to load, got %v", err)
+	}
+	if task, err := db.GetTask(1); err != nil || task.Title != "Survives the crash" {
+		t.Errorf("Expected the saved task to be intact, got %+v, %v", task, err)
+	}
+	if _, err := os.Stat(tempPath); !os.IsNotExist(err) {
+		t.Errorf("Expected the orphaned temp file to be removed, got %v", err)
+	}
+
+	// The next save still works
+	if err := saveProjectDatabase(cfg, db, dbPath); err != nil {
+		t.Errorf("Failed to save after recovery: %v", err)
+	}
+}
+
+func TestLoadProjectDatabaseMatchesStore(t *testing.T) {
+	cfg, _, projectName := newTestProject(t)
+	dbPath := cfg.GetProjectDatabasePath(projectName)
+
+	store := database.NewJSONStore(dbPath)
+	task := models.NewTask(1, "Shared")
+	if _, err := task.AddComment("alice", "Stored through the store"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if err := store.AddTask(task); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	db, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	loaded, err := db.GetTask(1)
+	if err != nil || len(loaded.Comments) != 1 {
+		t.Fatalf("Expected the command path to see the stored comment, got %+v, %v", loaded, err)
+	}
+
+	stored, err := store.GetTask(1)
+	if err != nil || stored.Comments[0].Text != loaded.Comments[0].Text {
+		t.Errorf("Expected the store and the command path to agree, got %+v, %v", stored, err)
+	}
+}
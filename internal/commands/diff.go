@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// currentDatabaseName labels the live project database in diff output
+const currentDatabaseName = "current"
+
+var diffList bool
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff [backup] [backup]",
+	Short: "Compare the project database with a backup",
+	Long: `Show which tasks were added, removed or modified between two versions of the
+current project's database, field by field.
+
+A backup of the database is kept each time it is saved, or every N writes
+with backup_every_n_writes (see also create_backups and max_backups in the
+configuration). Backups are named by their UTC timestamp; list them with
+--list.
+
+With no arguments the newest backup is compared with the current database.
+With one backup it is compared with the current database, and with two
+backups the first is compared with the second.
+
+Examples:
+  quicktodo diff
+  quicktodo diff --list
+  quicktodo diff 20240102T150405.000000000Z
+  quicktodo diff 20240101T090000.000000000Z 20240102T150405.000000000Z --json`,
+	Args: cobra.MaximumNArgs(2),
+	Run:  runDiff,
+}
+
+func runDiff(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	backups, err := database.ListBackups(cfg.DataDir, projectInfo.Name)
+	if err != nil {
+		exitWithError(codeStorageError, "Error listing backups: %v", err)
+	}
+
+	if diffList {
+		outputBackupList(projectInfo.Name, backups)
+		return
+	}
+
+	from, to := currentDatabaseName, currentDatabaseName
+	switch len(args) {
+	case 0:
+		if len(backups) == 0 {
+			exitWithError(codeNotFound, "Error: project %s has no backups to compare with", projectInfo.Name)
+		}
+		from = backups[len(backups)-1]
+	case 1:
+		from = args[0]
+	default:
+		from, to = args[0], args[1]
+	}
+
+	fromDB := loadDiffDatabase(cfg, projectInfo.Name, from)
+	toDB := loadDiffDatabase(cfg, projectInfo.Name, to)
+	diff := database.DiffDatabases(fromDB, toDB)
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success": true,
+			"project": projectInfo.Name,
+			"from":    from,
+			"to":      to,
+			"diff":    diff,
+		}
+		printDiffJSON(output)
+		return
+	}
+
+	outputDiffHuman(from, to, diff)
+}
+
+// loadDiffDatabase loads the current project database or one of its backups
+// by name, exiting on failure
+func loadDiffDatabase(cfg *config.Config, projectName, name string) *models.ProjectDatabase {
+	path := cfg.GetProjectDatabasePath(projectName)
+	if name != currentDatabaseName {
+		if err := database.ValidateBackupName(name); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v\nRun 'quicktodo diff --list' to see available backups", err)
+		}
+		path = database.BackupPath(cfg.DataDir, projectName, name)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			exitWithError(codeNotFound, "Error: backup '%s' not found\nRun 'quicktodo diff --list' to see available backups", name)
+		}
+	}
+
+	db, err := loadProjectDatabase(cfg, path)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading %s: %v", name, err)
+	}
+	return db
+}
+
+func outputBackupList(projectName string, backups []string) {
+	if jsonOutput {
+		if backups == nil {
+			backups = []string{}
+		}
+		output := map[string]interface{}{
+			"success": true,
+			"project": projectName,
+			"backups": backups,
+		}
+		printDiffJSON(output)
+		return
+	}
+
+	if len(backups) == 0 {
+		fmt.Printf("No backups for project %s\n", projectName)
+		return
+	}
+
+	fmt.Printf("Backups for project %s (oldest first):\n", projectName)
+	for _, name := range backups {
+		fmt.Printf("  %s\n", name)
+	}
+}
+
+func outputDiffHuman(from, to string, diff *database.Diff) {
+	fmt.Printf("Comparing %s with %s\n", from, to)
+
+	if diff.IsEmpty() {
+		fmt.Println("No differences")
+		return
+	}
+
+	fmt.Println()
+	for _, task := range diff.Added {
+		fmt.Printf("+ #%d %s\n", task.ID, task.Title)
+	}
+	for _, task := range diff.Removed {
+		fmt.Printf("- #%d %s\n", task.ID, task.Title)
+	}
+	for _, task := range diff.Modified {
+		fmt.Printf("~ #%d %s\n", task.ID, task.Title)
+		for _, change := range task.Changes {
+			fmt.Printf("    %s: %q -> %q\n", change.Field, change.Before, change.After)
+		}
+	}
+
+	fmt.Printf("\n%d added, %d removed, %d modified\n", len(diff.Added), len(diff.Removed), len(diff.Modified))
+}
+
+func printDiffJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffList, "list", false, "List the project's backups instead of comparing")
+
+	RootCmd.AddCommand(diffCmd)
+}
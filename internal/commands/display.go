@@ -6,72 +6,89 @@ import (
 	"os"
 	"quicktodo/internal/config"
 	"quicktodo/internal/database"
+	"quicktodo/internal/export"
 	"quicktodo/internal/models"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	displayFormat     string
+	displayOutputFile string
+)
+
 // displayTaskCmd represents the display-task command
 var displayTaskCmd = &cobra.Command{
-	Use:     "display-task <id>",
+	Use:     "display-task [id]",
 	Aliases: []string{"get-task"},
 	Short:   "Show detailed task information",
 	Long: `Display detailed information about a specific task by ID.
 
 The command will auto-detect the current project from the working directory
 and show comprehensive task details including metadata, timestamps, and status.
+Other tasks with a similar title are listed as possible duplicates. Without an
+ID, the task set with 'quicktodo focus' is shown.
+
+Use --format to render the task as a snippet for pasting into chat or a pull
+request: markdown for GitHub and similar, or slack for Slack messages. Add
+--output-file to write the snippet to a file instead of printing it.
 
 Examples:
   quicktodo display-task 1
   quicktodo get-task 5 --json
-  quicktodo display-task 3 --verbose`,
-	Args: cobra.ExactArgs(1),
+  quicktodo display-task 3 --verbose
+  quicktodo display-task 3 --format markdown
+  quicktodo display-task 3 --format slack --output-file task.txt`,
+	Args: cobra.MaximumNArgs(1),
 	Run:  runDisplayTask,
 }
 
 func runDisplayTask(cmd *cobra.Command, args []string) {
 	// Parse task ID
-	taskID, err := strconv.Atoi(args[0])
+	taskIDStr := taskIDArg(args)
+	taskID, err := strconv.Atoi(taskIDStr)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: invalid task ID '%s'. Task ID must be a number.\n", args[0])
-		os.Exit(1)
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'. Task ID must be a number.", taskIDStr)
 	}
 
 	if taskID <= 0 {
-		fmt.Fprintf(os.Stderr, "Error: task ID must be positive\n")
-		os.Exit(1)
+		exitWithError(codeInvalidTaskID, "Error: task ID must be positive")
+	}
+
+	if displayFormat != "" && jsonOutput {
+		exitWithError(codeInvalidArgument, "Error: --format cannot be combined with --json")
+	}
+	if displayOutputFile != "" && displayFormat == "" {
+		exitWithError(codeInvalidArgument, "Error: --output-file requires --format")
 	}
 
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
 	}
 
 	// Get current directory
 	currentDir, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
 	}
 
 	// Load project registry
 	registryPath := cfg.GetProjectsPath()
 	registry, err := database.LoadProjectRegistry(registryPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project registry: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
 	}
 
 	// Find project for current directory
 	projectInfo, exists := registry.GetProjectByPath(currentDir)
 	if !exists {
-		fmt.Fprintf(os.Stderr, "Error: current directory is not a registered project\n")
-		fmt.Fprintf(os.Stderr, "Run 'quicktodo initialize-project' first\n")
-		os.Exit(1)
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
 	}
 
 	// Update last accessed time
@@ -83,17 +100,15 @@ func runDisplayTask(cmd *cobra.Command, args []string) {
 
 	// Load project database
 	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
-	projectDB, err := loadProjectDatabase(dbPath)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project database: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
 	}
 
 	// Find task
 	task, err := projectDB.GetTask(taskID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: task #%d not found\n", taskID)
-		os.Exit(1)
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
 	}
 
 	// Save updated registry (for last accessed time)
@@ -101,15 +116,66 @@ func runDisplayTask(cmd *cobra.Command, args []string) {
 		fmt.Fprintf(os.Stderr, "Warning: failed to save registry: %v\n", err)
 	}
 
+	if displayFormat != "" {
+		outputTaskSnippet(task, projectInfo.Name)
+		return
+	}
+
+	similar := relatedTasks(projectDB, task)
+
+	// Dependencies that aren't closed yet block an open task
+	var blockedBy []int
+	if !task.IsClosed() {
+		blockedBy = projectDB.UnmetDependencies(task)
+	}
+
 	// Output result
 	if jsonOutput {
-		outputTaskDetailJSON(task, projectInfo)
+		outputTaskDetailJSON(task, projectInfo, similar, blockedBy)
 	} else {
-		outputTaskDetailHuman(task, projectInfo)
+		outputTaskDetailHuman(task, projectInfo, similar, blockedBy)
 	}
 }
 
-func outputTaskDetailJSON(task *models.Task, projectInfo *database.ProjectInfo) {
+// outputTaskSnippet renders the task with --format, printing it or writing it
+// to --output-file
+func outputTaskSnippet(task *models.Task, projectName string) {
+	snippet, err := export.RenderTask(strings.ToLower(displayFormat), task, projectName)
+	if err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+
+	if displayOutputFile == "" {
+		fmt.Print(snippet)
+		return
+	}
+
+	if err := os.WriteFile(displayOutputFile, []byte(snippet), 0644); err != nil {
+		exitWithError(codeStorageError, "Error writing output file: %v", err)
+	}
+	fmt.Printf("Wrote task #%d to %s\n", task.ID, displayOutputFile)
+}
+
+// maxRelatedTasks caps how many similar tasks display-task lists
+const maxRelatedTasks = 5
+
+// relatedTasks finds other tasks whose titles are similar enough to task's to
+// be possible duplicates
+func relatedTasks(projectDB *models.ProjectDatabase, task *models.Task) []models.SimilarTask {
+	var related []models.SimilarTask
+	for _, match := range projectDB.SimilarTasks(task.Title, models.DefaultSimilarityThreshold) {
+		if match.Task.ID == task.ID {
+			continue
+		}
+		related = append(related, match)
+		if len(related) == maxRelatedTasks {
+			break
+		}
+	}
+	return related
+}
+
+func outputTaskDetailJSON(task *models.Task, projectInfo *database.ProjectInfo, similar []models.SimilarTask, blockedBy []int) {
 	output := map[string]interface{}{
 		"success": true,
 		"project": map[string]interface{}{
@@ -118,17 +184,25 @@ func outputTaskDetailJSON(task *models.Task, projectInfo *database.ProjectInfo)
 		},
 		"task": task,
 	}
+	if len(similar) > 0 {
+		output["similar_tasks"] = similar
+	}
+	if len(task.DependsOn) > 0 {
+		output["blocked"] = len(blockedBy) > 0
+		if len(blockedBy) > 0 {
+			output["blocked_by"] = blockedBy
+		}
+	}
 
 	data, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error formatting JSON output: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
 	}
 
 	fmt.Println(string(data))
 }
 
-func outputTaskDetailHuman(task *models.Task, projectInfo *database.ProjectInfo) {
+func outputTaskDetailHuman(task *models.Task, projectInfo *database.ProjectInfo, similar []models.SimilarTask, blockedBy []int) {
 	// Header
 	statusIcon := getStatusIcon(task.Status)
 	priorityColor := getPriorityIndicator(task.Priority)
@@ -140,17 +214,86 @@ func outputTaskDetailHuman(task *models.Task, projectInfo *database.ProjectInfo)
 		fmt.Printf("Description: %s\n", task.Description)
 	}
 
-	fmt.Printf("Status: %s\n", task.Status)
+	if task.IsClosed() {
+		fmt.Printf("Status: %s\n", task.Status)
+	} else {
+		fmt.Printf("Status: %s for %s\n", task.Status, formatDuration(task.TimeInStatus(time.Now())))
+	}
 	fmt.Printf("Priority: %s\n", task.Priority)
 
+	if len(task.TimeEntries) > 0 {
+		if running := task.RunningTimeEntry(); running != nil {
+			fmt.Printf("Time tracked: %s (running since %s)\n", formatDuration(task.TotalTime(time.Now())), running.Start.Local().Format("15:04"))
+		} else {
+			fmt.Printf("Time tracked: %s over %d session(s)\n", formatDuration(task.TotalTime(time.Now())), len(task.TimeEntries))
+		}
+	}
+
+	if len(task.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(task.Tags, ", "))
+	}
+
+	if task.StartDate != nil {
+		fmt.Printf("Starts: %s\n", task.StartDate.Local().Format("2006-01-02 15:04"))
+	}
+	if task.DueDate != nil {
+		fmt.Printf("Due: %s\n", task.DueDate.Local().Format("2006-01-02 15:04"))
+	}
+	if task.Recurrence != "" {
+		fmt.Printf("Repeats: %s\n", task.Recurrence)
+	}
+
+	if len(task.DependsOn) > 0 {
+		fmt.Printf("Depends on: %s\n", formatTaskRefs(task.DependsOn))
+	}
+	if len(blockedBy) > 0 {
+		fmt.Printf("⛔ Blocked by: %s\n", formatTaskRefs(blockedBy))
+	}
+
+	if task.CompletedAt != nil {
+		fmt.Printf("Completed: %s (%s)\n", task.CompletedAt.Local().Format("2006-01-02 15:04:05"), task.EffectiveResolution())
+	}
+
+	if len(task.Attachments) > 0 {
+		fmt.Println("Attachments:")
+		for _, attachment := range task.Attachments {
+			fmt.Printf("  - %s: %s\n", attachment.Name, attachment.URL)
+		}
+	}
+
+	if len(task.Checklist) > 0 {
+		progress := task.ChecklistProgress()
+		fmt.Printf("Checklist (%d/%d):\n", progress.Done, progress.Total)
+		for i, item := range task.Checklist {
+			mark := " "
+			if item.Done {
+				mark = "x"
+			}
+			fmt.Printf("  %d. [%s] %s\n", i+1, mark, item.Text)
+		}
+	}
+
+	if len(task.Comments) > 0 {
+		comments := append([]models.Comment(nil), task.Comments...)
+		sort.SliceStable(comments, func(i, j int) bool { return comments[i].CreatedAt.Before(comments[j].CreatedAt) })
+		fmt.Printf("Comments (%d):\n", len(comments))
+		for _, comment := range comments {
+			author := comment.Author
+			if author == "" {
+				author = "unknown"
+			}
+			fmt.Printf("  %s %s: %s\n", comment.CreatedAt.Local().Format("2006-01-02 15:04"), author, comment.Text)
+		}
+	}
+
 	// Timestamps
 	fmt.Printf("Created: %s (%s)\n",
-		task.CreatedAt.Format("2006-01-02 15:04:05"),
+		task.CreatedAt.Local().Format("2006-01-02 15:04:05"),
 		task.GetAge())
 
 	if !task.UpdatedAt.Equal(task.CreatedAt) {
 		fmt.Printf("Updated: %s (%s)\n",
-			task.UpdatedAt.Format("2006-01-02 15:04:05"),
+			task.UpdatedAt.Local().Format("2006-01-02 15:04:05"),
 			formatTimeAgo(task.UpdatedAt))
 	}
 
@@ -162,7 +305,7 @@ func outputTaskDetailHuman(task *models.Task, projectInfo *database.ProjectInfo)
 	if task.IsLocked() {
 		fmt.Printf("Locked by: %s\n", task.LockedBy)
 		fmt.Printf("Locked at: %s (%s)\n",
-			task.LockedAt.Format("2006-01-02 15:04:05"),
+			task.LockedAt.Local().Format("2006-01-02 15:04:05"),
 			formatTimeAgo(task.LockedAt))
 
 		if task.IsStale() {
@@ -170,6 +313,13 @@ func outputTaskDetailHuman(task *models.Task, projectInfo *database.ProjectInfo)
 		}
 	}
 
+	if len(similar) > 0 {
+		fmt.Println("Similar tasks:")
+		for _, match := range similar {
+			fmt.Printf("  - #%d [%s] %s (%.0f%% similar)\n", match.Task.ID, match.Task.Status, match.Task.Title, match.Score*100)
+		}
+	}
+
 	// Project info
 	fmt.Printf("\nProject: %s\n", projectInfo.Name)
 	if verbose {
@@ -264,5 +414,8 @@ func formatDuration(d time.Duration) string {
 }
 
 func init() {
+	displayTaskCmd.Flags().StringVar(&displayFormat, "format", "", "Render the task as a snippet (markdown, slack)")
+	displayTaskCmd.Flags().StringVar(&displayOutputFile, "output-file", "", "Write the --format snippet to this file")
+
 	RootCmd.AddCommand(displayTaskCmd)
 }
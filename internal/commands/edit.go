@@ -9,6 +9,7 @@ import (
 	"quicktodo/internal/notify"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -17,6 +18,14 @@ var (
 	editTitle       string
 	editDescription string
 	editPriority    string
+	editStatus      string
+	editDue         string
+	editStart       string
+	editDependsOn   string
+	editFromStdin   bool
+	editInEditor    bool
+
+	editDescriptionFile string
 )
 
 // editTaskCmd represents the edit-task command
@@ -24,16 +33,42 @@ var editTaskCmd = &cobra.Command{
 	Use:     "edit-task <id>",
 	Aliases: []string{"edit"},
 	Short:   "Edit an existing task",
-	Long: `Edit an existing task's title, description, or priority.
+	Long: `Edit an existing task's title, description, status, priority, start date,
+due date, or dependencies.
 
 You can specify which fields to update using the flags. If no flags are provided,
 the command will show the current task details.
 
+--status follows the same rules as set-task-status: a full WIP limit warns
+(or refuses with --strict), completing a task with open dependencies needs
+--force, and auto_assign_on_start applies when starting a task.
+
+--due and --start take a YYYY-MM-DD date or a date relative to today such as
++3d or +2w; "none" clears the date. The start date can't be after the due date.
+
+--depends-on replaces the task's dependencies with a comma-separated list of
+task IDs, or clears them with "none". Dependencies that would form a cycle are
+refused.
+
+With --stdin, a JSON object is read from standard input and applied as a patch:
+only the fields present are changed (title, description, status, priority,
+assigned_to, tags, start_date, due_date). Send "due_date": "" to clear the due
+date, and likewise for start_date.
+
+--edit (or --description -) opens $EDITOR on the task's title and description
+instead, and --description-file reads the new description from a file.
+
 Examples:
   quicktodo edit-task 1 --title "Updated task title"
   quicktodo edit 2 --description "New description"
   quicktodo edit-task 3 --priority high
-  quicktodo edit 4 --title "New title" --description "New description" --priority medium`,
+  quicktodo edit-task 3 --title "Fix login redirect" --status in_progress
+  quicktodo edit 4 --title "New title" --description "New description" --priority medium
+  quicktodo edit-task 6 --due +1w
+  quicktodo edit-task 7 --depends-on 3,5
+  quicktodo edit-task 8 --edit
+  quicktodo edit-task 8 --description-file notes.md
+  echo '{"status":"in_progress","tags":["backend"]}' | quicktodo edit-task 5 --stdin --json`,
 	Args: cobra.ExactArgs(1),
 	Run:  runEditTask,
 }
@@ -42,38 +77,104 @@ func runEditTask(cmd *cobra.Command, args []string) {
 	// Parse task ID
 	taskID, err := strconv.Atoi(args[0])
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: invalid task ID '%s'\n", args[0])
-		os.Exit(1)
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'", args[0])
+	}
+
+	// Validate the new status before taking any locks
+	var status models.Status
+	if editStatus != "" {
+		status = models.NormalizeStatus(editStatus)
+		if !models.IsValidStatus(string(status)) {
+			exitWithError(codeInvalidStatus, "Error: invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", editStatus)
+		}
+	}
+
+	// Parse the due date before taking any locks; "none" clears it
+	dueChanged := cmd.Flags().Changed("due")
+	var dueDate *time.Time
+	if dueChanged && editDue != "" && !strings.EqualFold(editDue, "none") {
+		due, err := parseDueDate(editDue)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		dueDate = &due
+	}
+
+	startChanged := cmd.Flags().Changed("start")
+	var startDate *time.Time
+	if startChanged && editStart != "" && !strings.EqualFold(editStart, "none") {
+		start, err := parseStartDate(editStart)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		startDate = &start
+	}
+
+	dependsChanged := cmd.Flags().Changed("depends-on")
+	var dependsOn []int
+	if dependsChanged && editDependsOn != "" && !strings.EqualFold(editDependsOn, "none") {
+		dependsOn, err = parseTaskIDList(editDependsOn)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: invalid --depends-on: %v", err)
+		}
+	}
+
+	// The description may also come from a file or the editor, which can
+	// leave it empty
+	editing := editInEditor || editDescription == "-"
+	if editing {
+		editDescription = ""
+	}
+	descriptionChanged := editDescription != ""
+	if editDescriptionFile != "" {
+		if descriptionChanged {
+			exitWithError(codeInvalidArgument, "Error: --description cannot be combined with --description-file")
+		}
+		if editDescription, err = readDescriptionFile(editDescriptionFile); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		descriptionChanged = true
+	}
+
+	// Read and validate the JSON patch before taking any locks
+	var patch *taskPatch
+	if editFromStdin {
+		if editTitle != "" || descriptionChanged || editing || editStatus != "" || editPriority != "" || dueChanged || startChanged || dependsChanged {
+			exitWithError(codeInvalidArgument, "Error: --stdin cannot be combined with --title, --description, --edit, --status, --priority, --due, --start or --depends-on")
+		}
+
+		patch, err = readTaskPatch(os.Stdin)
+		if err == nil {
+			err = patch.validate(false)
+		}
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
 	}
 
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
 	}
 
 	// Get current directory
 	currentDir, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
 	}
 
 	// Load project registry
 	registryPath := cfg.GetProjectsPath()
 	registry, err := database.LoadProjectRegistry(registryPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project registry: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
 	}
 
 	// Find project for current directory
 	projectInfo, exists := registry.GetProjectByPath(currentDir)
 	if !exists {
-		fmt.Fprintf(os.Stderr, "Error: current directory is not a registered project\n")
-		fmt.Fprintf(os.Stderr, "Run 'quicktodo initialize-project' first\n")
-		os.Exit(1)
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
 	}
 
 	// Update last accessed time
@@ -83,15 +184,38 @@ func runEditTask(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	// Create lock manager
-	lockManager := database.NewLockManager(cfg.DataDir+"/locks", cfg.LockTimeout)
+	// Edit the text before taking the lock, which would otherwise be held for
+	// as long as the editor is open; only the title and description are taken
+	// from the editor, so concurrent changes to other fields are kept
+	if editing {
+		currentDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectInfo.Name))
+		if err != nil {
+			exitWithError(codeStorageError, "Error loading project database: %v", err)
+		}
+		current, err := currentDB.GetTask(taskID)
+		if err != nil {
+			exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
+		}
 
-	// Acquire lock for project
-	lockInfo, err := lockManager.AcquireLock(projectInfo.Name)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error acquiring project lock: %v\n", err)
-		os.Exit(1)
+		title, description := editTitle, editDescription
+		if title == "" {
+			title = current.Title
+		}
+		if !descriptionChanged {
+			description = current.Description
+		}
+		editedTitle, editedDescription, err := editTaskText(title, description)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		if models.SanitizeTitle(editedTitle) == "" {
+			exitWithError(codeInvalidArgument, "Error: task title cannot be empty")
+		}
+		editTitle, editDescription, descriptionChanged = editedTitle, editedDescription, true
 	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
 	defer func() {
 		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
 			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
@@ -100,21 +224,20 @@ func runEditTask(cmd *cobra.Command, args []string) {
 
 	// Load project database
 	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
-	projectDB, err := loadProjectDatabase(dbPath)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project database: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
 	}
 
 	// Find task
 	task, err := projectDB.GetTask(taskID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: task #%d not found\n", taskID)
-		os.Exit(1)
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
 	}
 
 	// Check if any edit flags were provided
-	hasUpdates := editTitle != "" || editDescription != "" || editPriority != ""
+	hasUpdates := editTitle != "" || descriptionChanged || editStatus != "" || editPriority != "" || dueChanged || startChanged || dependsChanged ||
+		(patch != nil && !patch.isEmpty())
 	if !hasUpdates {
 		// No updates requested, just show current task details
 		if jsonOutput {
@@ -130,34 +253,86 @@ func runEditTask(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// A status change from a flag or the patch goes through the same checks
+	// as set-task-status
+	if patch != nil && patch.Status != nil {
+		status = models.NormalizeStatus(*patch.Status)
+	}
+	if status != "" {
+		enforceStatusChange(cfg, projectDB, task, status)
+	}
+
 	// Update task fields
+	before := task.Clone()
 	updated := false
 
 	if editTitle != "" {
-		task.Title = strings.TrimSpace(editTitle)
+		task.Title = models.SanitizeTitle(editTitle)
 		updated = true
 	}
 
-	if editDescription != "" {
+	if descriptionChanged {
 		task.Description = strings.TrimSpace(editDescription)
 		updated = true
 	}
 
+	if editStatus != "" {
+		if err := task.UpdateStatus(status); err != nil {
+			exitWithError(codeInvalidStatus, "Error updating task status: %v", err)
+		}
+		updated = true
+	}
+
 	if editPriority != "" {
-		priority := models.Priority(strings.ToLower(editPriority))
+		priority := models.NormalizePriority(editPriority)
 		if !models.IsValidPriority(string(priority)) {
-			fmt.Fprintf(os.Stderr, "Error: invalid priority '%s'. Valid priorities: low, medium, high\n", editPriority)
-			os.Exit(1)
+			exitWithError(codeInvalidPriority, "Error: invalid priority '%s'. Valid priorities: low, medium, high, critical", editPriority)
 		}
 		task.Priority = priority
 		updated = true
 	}
 
+	if dueChanged {
+		task.SetDueDate(dueDate)
+		updated = true
+	}
+
+	if startChanged {
+		task.SetStartDate(startDate)
+		updated = true
+	}
+
+	if dependsChanged {
+		if err := projectDB.ValidateDependencies(task.ID, dependsOn); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		task.SetDependsOn(dependsOn)
+		updated = true
+	}
+
+	if patch != nil {
+		if err := patch.apply(task); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		updated = true
+	}
+
 	if updated {
+		// Claim unassigned tasks when starting them, if configured
+		autoAssignOnStart(cfg, task, before.Status)
+
+		if err := validateTaskText(cfg, task.Title, task.Description); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		if err := task.ValidateSchedule(); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+
+		projectDB.RecordTaskChanges(before, task, currentActor())
+
 		// Save project database
-		if err := saveProjectDatabase(projectDB, dbPath); err != nil {
-			fmt.Fprintf(os.Stderr, "Error saving project database: %v\n", err)
-			os.Exit(1)
+		if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+			exitWithError(codeStorageError, "Error saving project database: %v", err)
 		}
 
 		// Save updated registry
@@ -170,7 +345,12 @@ func runEditTask(cmd *cobra.Command, args []string) {
 
 		// Notify web server of task update
 		if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
-			fmt.Fprintf(os.Stderr, "Warning: failed to notify web server: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+		}
+
+		// Run lifecycle hooks when the patch changed the status
+		if task.Status != before.Status {
+			runStatusChangeHooks(cfg, before.Status, task, projectInfo.Name)
 		}
 
 		// Output result
@@ -193,7 +373,16 @@ func runEditTask(cmd *cobra.Command, args []string) {
 func init() {
 	editTaskCmd.Flags().StringVarP(&editTitle, "title", "t", "", "New task title")
 	editTaskCmd.Flags().StringVarP(&editDescription, "description", "d", "", "New task description")
-	editTaskCmd.Flags().StringVarP(&editPriority, "priority", "p", "", "New task priority (low, medium, high)")
+	editTaskCmd.Flags().StringVarP(&editStatus, "status", "s", "", "New task status (pending, in_progress, done, cancelled)")
+	editTaskCmd.Flags().BoolVar(&wipStrict, "strict", false, "Refuse a status change when the target status is at its WIP limit")
+	editTaskCmd.Flags().BoolVar(&forceComplete, "force", false, "Allow completing the task even if tasks it depends on are still open")
+	editTaskCmd.Flags().StringVarP(&editPriority, "priority", "p", "", "New task priority (low, medium, high, critical)")
+	editTaskCmd.Flags().StringVar(&editStart, "start", "", "New start date (YYYY-MM-DD, +Nd/+Nw from today, or none to clear)")
+	editTaskCmd.Flags().StringVar(&editDue, "due", "", "New due date (YYYY-MM-DD, +Nd/+Nw from today, or none to clear)")
+	editTaskCmd.Flags().StringVar(&editDependsOn, "depends-on", "", "IDs of tasks that must be done first, comma-separated (none to clear)")
+	editTaskCmd.Flags().BoolVar(&editFromStdin, "stdin", false, "Read a JSON patch for the task from stdin")
+	editTaskCmd.Flags().BoolVar(&editInEditor, "edit", false, "Edit the title and description in $EDITOR")
+	editTaskCmd.Flags().StringVar(&editDescriptionFile, "description-file", "", "Read the new description from a file")
 
 	RootCmd.AddCommand(editTaskCmd)
 }
\ No newline at end of file
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editorTemplateHelp ends the file opened by create-task and edit-task --edit
+const editorTemplateHelp = `
+# Write the task title on the first line and the description below it.
+# Lines starting with '#' are ignored; an empty title aborts.
+`
+
+// editTaskText opens the user's editor on a file holding title and
+// description and returns them as edited. The title is the first line that
+// isn't blank, and the description everything after it.
+func editTaskText(title, description string) (string, string, error) {
+	editor, err := editorCommand()
+	if err != nil {
+		return "", "", err
+	}
+
+	file, err := os.CreateTemp("", "quicktodo-task-*.md")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	template := title + "\n\n"
+	if description != "" {
+		template += description + "\n"
+	}
+	template += editorTemplateHelp
+	if _, err := file.WriteString(template); err != nil {
+		file.Close()
+		return "", "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", "", fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+
+	var kept []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			kept = append(kept, line)
+		}
+	}
+	title, description = splitTaskText(strings.Join(kept, "\n"))
+	return title, description, nil
+}
+
+// editorCommand returns the editor to run with its arguments: $EDITOR, else
+// $VISUAL, else vi when it is installed
+func editorCommand() ([]string, error) {
+	for _, name := range []string{"EDITOR", "VISUAL"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields, nil
+		}
+	}
+	if path, err := exec.LookPath("vi"); err == nil {
+		return []string{path}, nil
+	}
+	return nil, fmt.Errorf("no editor found: set $EDITOR, or use --description or --description-file instead")
+}
+
+// splitTaskText splits text into a title, its first line that isn't blank,
+// and a description, the rest without surrounding blank lines and trailing
+// whitespace
+func splitTaskText(text string) (string, string) {
+	text = strings.TrimLeft(text, " \t\r\n")
+	title, description, _ := strings.Cut(text, "\n")
+	return strings.TrimSpace(title), trimDescription(description)
+}
+
+// readTaskText reads a title and description from r as splitTaskText splits
+// them, e.g. for create-task -
+func readTaskText(r io.Reader) (string, string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	title, description := splitTaskText(string(data))
+	return title, description, nil
+}
+
+// readDescriptionFile reads a task description from a file, for
+// --description-file
+func readDescriptionFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read description file: %w", err)
+	}
+	return trimDescription(string(data)), nil
+}
+
+// trimDescription drops leading blank lines and trailing whitespace, keeping
+// the indentation of the first line
+func trimDescription(description string) string {
+	description = strings.TrimRight(description, " \t\r\n")
+	for {
+		line, rest, found := strings.Cut(description, "\n")
+		if !found || strings.TrimSpace(line) != "" {
+			return description
+		}
+		description = rest
+	}
+}
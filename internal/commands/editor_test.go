@@ -0,0 +1,23 @@
+package commands
+
+import "testing"
+
+func TestSplitTaskText(t *testing.T) {
+	tests := []struct {
+		text        string
+		title       string
+		description string
+	}{
+		{"Fix login\n", "Fix login", ""},
+		{"\n\n  Fix login  \n\n\n  Steps:\n  1. Open the page\n\n", "Fix login", "  Steps:\n  1. Open the page"},
+		{"Fix login\nFirst line\n\nSecond paragraph \t\n", "Fix login", "First line\n\nSecond paragraph"},
+		{"  \n\n", "", ""},
+	}
+
+	for _, tt := range tests {
+		title, description := splitTaskText(tt.text)
+		if title != tt.title || description != tt.description {
+			t.Errorf("splitTaskText(%q) = %q, %q; want %q, %q", tt.text, title, description, tt.title, tt.description)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Error codes reported under "error.code" when a command fails with --json.
+// They are part of the JSON interface: agents branch on them, so existing
+// codes must not change meaning or be renamed.
+const (
+	codeProjectNotFound = "PROJECT_NOT_FOUND"
+	codeTaskNotFound    = "TASK_NOT_FOUND"
+	codeNotFound        = "NOT_FOUND"
+	codeAlreadyExists   = "ALREADY_EXISTS"
+	codeLockTimeout     = "LOCK_TIMEOUT"
+	codeInvalidArgument = "INVALID_ARGUMENT"
+	codeInvalidStatus   = "INVALID_STATUS"
+	codeInvalidPriority = "INVALID_PRIORITY"
+	codeInvalidTaskID   = "INVALID_TASK_ID"
+	codeInvalidState    = "INVALID_STATE"
+	codeTaskBlocked     = "TASK_BLOCKED"
+	codeWIPLimit        = "WIP_LIMIT_REACHED"
+	codeCancelled       = "CANCELLED"
+	codeConfigError     = "CONFIG_ERROR"
+	codeStorageError    = "STORAGE_ERROR"
+	codeInternalError   = "INTERNAL_ERROR"
+)
+
+// commandError is the "error" object of a failed command's JSON output
+type commandError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// exitWithError reports a failed command and exits with status 1. The
+// formatted text is printed to stderr as is; with --json it is printed to
+// stdout as a JSON error instead, see emitJSONError.
+func exitWithError(code, format string, args ...interface{}) {
+	text := fmt.Sprintf(format, args...)
+	if jsonOutput {
+		emitJSONError(code, strings.TrimPrefix(text, "Error: "))
+	} else {
+		fmt.Fprintln(os.Stderr, text)
+	}
+	os.Exit(1)
+}
+
+// emitJSONError prints {"success": false, "error": {"code": ..., "message":
+// ...}} to stdout. The first line of message is the error message; any
+// further lines, such as what to run next, become "error.hint".
+func emitJSONError(code, message string) {
+	message, hint, _ := strings.Cut(message, "\n")
+	output := map[string]interface{}{
+		"success": false,
+		"error":   commandError{Code: code, Message: message, Hint: hint},
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", message)
+		return
+	}
+
+	fmt.Println(string(data))
+}
+
+// ExitWithError reports an error returned by RootCmd.Execute, such as an
+// unknown flag or a missing argument, and exits with status 1. With --json it
+// is printed as a JSON error with the INVALID_ARGUMENT code.
+func ExitWithError(err error) {
+	// Flag parsing stops at the first bad flag, so --json may not be parsed yet
+	for _, arg := range os.Args[1:] {
+		if arg == "--" {
+			break
+		}
+		if arg == "--json" || arg == "--json=true" {
+			jsonOutput = true
+		}
+	}
+	exitWithError(codeInvalidArgument, "%v", err)
+}
@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/export"
+	"quicktodo/internal/models"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat     string
+	exportOutputFile string
+)
+
+// exportStatusOrder is the order of the status sections in an exported project
+var exportStatusOrder = []models.Status{models.StatusInProgress, models.StatusPending, models.StatusDone, models.StatusCancelled}
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the current project's tasks as a document",
+	Long: `Render every task in the current project as a document to paste into a
+README, issue or pull request, or to open in a spreadsheet.
+
+Supported formats:
+  markdown   A checklist grouped by status (in progress, pending, done), with
+             done tasks checked off and priority shown as an emoji, under a
+             header with the project name and task counts
+  csv        One row per task with the columns id, title, description,
+             status, priority, assigned_to, created_at and updated_at; load
+             it into another project with import --format csv
+
+The document is printed to stdout, or written to a file with -o.
+
+Examples:
+  quicktodo export --format markdown
+  quicktodo export -o TODO.md
+  quicktodo export --format csv -o tasks.csv`,
+	Args: cobra.NoArgs,
+	Run:  runExport,
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	format := strings.ToLower(exportFormat)
+	if format != export.FormatMarkdown && format != export.FormatCSV {
+		exitWithError(codeInvalidArgument, "Error: unsupported format '%s'. Supported formats: %s, %s", exportFormat, export.FormatMarkdown, export.FormatCSV)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Load project database
+	projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectInfo.Name))
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	var document string
+	switch format {
+	case export.FormatCSV:
+		tasks := projectDB.ListTasks(nil)
+		sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+		var b strings.Builder
+		if err := export.WriteTasksCSV(&b, tasks); err != nil {
+			exitWithError(codeInternalError, "Error: %v", err)
+		}
+		document = b.String()
+	default:
+		document = renderProjectMarkdown(projectInfo.Name, projectDB.GetSummary(), projectDB.ListTasks(nil))
+	}
+
+	if exportOutputFile == "" {
+		fmt.Print(document)
+		return
+	}
+
+	if err := os.WriteFile(exportOutputFile, []byte(document), 0644); err != nil {
+		exitWithError(codeStorageError, "Error writing output file: %v", err)
+	}
+	fmt.Printf("Exported %d task(s) from %s to %s\n", len(projectDB.Tasks), projectInfo.Name, exportOutputFile)
+}
+
+// renderProjectMarkdown renders tasks as a Markdown checklist with one section
+// per status, in ID order, under a header built from summary
+func renderProjectMarkdown(projectName string, summary *models.ProjectSummary, tasks []*models.Task) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", projectName)
+	fmt.Fprintf(&b, "%d tasks: %s %d in progress · %s %d pending · %s %d done",
+		summary.TaskCount,
+		getStatusIcon(models.StatusInProgress), summary.InProgressTasks,
+		getStatusIcon(models.StatusPending), summary.PendingTasks,
+		getStatusIcon(models.StatusDone), summary.CompletedTasks)
+	if summary.CancelledTasks > 0 {
+		fmt.Fprintf(&b, " · %s %d cancelled", getStatusIcon(models.StatusCancelled), summary.CancelledTasks)
+	}
+	b.WriteString("\n")
+
+	sorted := append([]*models.Task(nil), tasks...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	for _, status := range exportStatusOrder {
+		var section []*models.Task
+		for _, task := range sorted {
+			if task.Status == status {
+				section = append(section, task)
+			}
+		}
+		if len(section) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n## %s %s (%d)\n\n", getStatusIcon(status), statusHeading(status), len(section))
+		for _, task := range section {
+			mark, title := " ", task.Title
+			if task.IsClosed() {
+				mark = "x"
+			}
+			if task.IsCancelled() {
+				title = "~~" + title + "~~"
+			}
+			fmt.Fprintf(&b, "- [%s] %s#%d %s\n", mark, getPriorityIndicator(task.Priority), task.ID, title)
+		}
+	}
+
+	return b.String()
+}
+
+// statusHeading is the section title for a status in exported documents
+func statusHeading(status models.Status) string {
+	switch status {
+	case models.StatusInProgress:
+		return "In progress"
+	case models.StatusPending:
+		return "Pending"
+	case models.StatusDone:
+		return "Done"
+	case models.StatusCancelled:
+		return "Cancelled"
+	default:
+		return string(status)
+	}
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", export.FormatMarkdown, "Document format (markdown, csv)")
+	exportCmd.Flags().StringVarP(&exportOutputFile, "output", "o", "", "Write the document to this file instead of stdout")
+
+	RootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"quicktodo/internal/models"
+	"testing"
+)
+
+func TestRenderProjectMarkdown(t *testing.T) {
+	db := models.NewProjectDatabase(models.NewProject("webapp", "/path/to/webapp"))
+	for _, spec := range []struct {
+		title    string
+		priority models.Priority
+		status   models.Status
+	}{
+		{"Ship release", models.PriorityHigh, models.StatusDone},
+		{"Write docs", models.PriorityLow, models.StatusPending},
+		{"Fix login", models.PriorityMedium, models.StatusInProgress},
+		{"Add tests", models.PriorityHigh, models.StatusPending},
+	} {
+		task := models.NewTaskWithDetails(db.NextID, spec.title, "", spec.priority)
+		if err := task.UpdateStatus(spec.status); err != nil {
+			t.Fatalf("UpdateStatus failed: %v", err)
+		}
+		if err := db.AddTask(task); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+
+	expected := "# webapp\n" +
+		"\n" +
+		"4 tasks: 🏃 1 in progress · ⏳ 2 pending · ✅ 1 done\n" +
+		"\n" +
+		"## 🏃 In progress (1)\n" +
+		"\n" +
+		"- [ ] 🟡 #3 Fix login\n" +
+		"\n" +
+		"## ⏳ Pending (2)\n" +
+		"\n" +
+		"- [ ] 🟢 #2 Write docs\n" +
+		"- [ ] 🔴 #4 Add tests\n" +
+		"\n" +
+		"## ✅ Done (1)\n" +
+		"\n" +
+		"- [x] 🔴 #1 Ship release\n"
+
+	if got := renderProjectMarkdown("webapp", db.GetSummary(), db.ListTasks(nil)); got != expected {
+		t.Errorf("Unexpected markdown:\n%s\nwant:\n%s", got, expected)
+	}
+}
@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// focusCmd represents the focus command
+var focusCmd = &cobra.Command{
+	Use:   "focus [id]",
+	Short: "Set the task you are working on",
+	Long: `Remember the task you are currently working on in this project.
+
+Focus is stored per agent (--agent-id, or your user name when not given) and
+per project. Commands that act on a single task, such as display-task,
+task-history and mark-completed, use the focused task when no ID is given.
+
+Without an ID, focus shows the focused task, like 'focus show'.
+
+Examples:
+  quicktodo focus 3
+  quicktodo focus 3 --agent-id claude
+  quicktodo focus show --json
+  quicktodo mark-completed
+  quicktodo focus clear`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			runFocusShow(cmd, args)
+			return
+		}
+		runFocusSet(args[0])
+	},
+}
+
+// focusShowCmd represents the focus show command
+var focusShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the focused task",
+	Args:  cobra.NoArgs,
+	Run:   runFocusShow,
+}
+
+// focusClearCmd represents the focus clear command
+var focusClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Stop focusing on a task",
+	Args:  cobra.NoArgs,
+	Run:   runFocusClear,
+}
+
+// focusContext is the focus state of the calling agent in the current project
+type focusContext struct {
+	cfg         *config.Config
+	projectInfo *database.ProjectInfo
+	state       *database.FocusState
+	agent       string
+}
+
+// loadFocusContext finds the current project and loads the focus state,
+// exiting on failure
+func loadFocusContext() *focusContext {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	state, err := database.LoadFocusState(cfg.GetFocusPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading focus: %v", err)
+	}
+
+	return &focusContext{
+		cfg:         cfg,
+		projectInfo: projectInfo,
+		state:       state,
+		agent:       currentActor(),
+	}
+}
+
+// save writes the focus state, exiting on failure
+func (c *focusContext) save() {
+	if err := c.state.Save(c.cfg.GetFocusPath()); err != nil {
+		exitWithError(codeStorageError, "Error saving focus: %v", err)
+	}
+}
+
+// taskIDArg returns the task ID argument, or the caller's focused task in the
+// current project when no ID was given
+func taskIDArg(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+
+	ctx := loadFocusContext()
+	focus, exists := ctx.state.Get(ctx.agent, ctx.projectInfo.Name)
+	if !exists {
+		exitWithError(codeInvalidArgument, "Error: no task ID given and %s has no focused task in project %s\nPass a task ID or run 'quicktodo focus <id>' first", ctx.agent, ctx.projectInfo.Name)
+	}
+
+	return strconv.Itoa(focus.TaskID)
+}
+
+func runFocusSet(taskIDStr string) {
+	// Parse task ID
+	taskID, err := strconv.Atoi(taskIDStr)
+	if err != nil {
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'. Task ID must be a number.", taskIDStr)
+	}
+
+	ctx := loadFocusContext()
+
+	// Only focus on tasks that exist
+	projectDB, err := loadProjectDatabase(ctx.cfg, ctx.cfg.GetProjectDatabasePath(ctx.projectInfo.Name))
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	task, err := projectDB.GetTask(taskID)
+	if err != nil {
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
+	}
+
+	focus := ctx.state.Set(ctx.agent, ctx.projectInfo.Name, task.ID)
+	ctx.save()
+
+	if jsonOutput {
+		outputFocusJSON(ctx, focus, task)
+		return
+	}
+
+	fmt.Printf("Focused on task #%d: %s\n", task.ID, task.Title)
+}
+
+func runFocusShow(cmd *cobra.Command, args []string) {
+	ctx := loadFocusContext()
+
+	focus, exists := ctx.state.Get(ctx.agent, ctx.projectInfo.Name)
+	if !exists {
+		if jsonOutput {
+			outputFocusJSON(ctx, nil, nil)
+			return
+		}
+		fmt.Printf("No focused task for %s in project %s\n", ctx.agent, ctx.projectInfo.Name)
+		return
+	}
+
+	// The focused task may have been deleted since
+	var task *models.Task
+	projectDB, err := loadProjectDatabase(ctx.cfg, ctx.cfg.GetProjectDatabasePath(ctx.projectInfo.Name))
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+	if found, err := projectDB.GetTask(focus.TaskID); err == nil {
+		task = found
+	}
+
+	if jsonOutput {
+		outputFocusJSON(ctx, focus, task)
+		return
+	}
+
+	if task == nil {
+		fmt.Printf("Focused on task #%d, which no longer exists\n", focus.TaskID)
+		return
+	}
+	fmt.Printf("Focused on task #%d: %s (%s)\n", task.ID, task.Title, task.Status)
+	fmt.Printf("Since: %s (%s)\n", focus.SetAt.Local().Format("2006-01-02 15:04:05"), formatTimeAgo(focus.SetAt))
+}
+
+func runFocusClear(cmd *cobra.Command, args []string) {
+	ctx := loadFocusContext()
+
+	cleared := ctx.state.Clear(ctx.agent, ctx.projectInfo.Name)
+	if cleared {
+		ctx.save()
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success": true,
+			"agent":   ctx.agent,
+			"project": ctx.projectInfo.Name,
+			"cleared": cleared,
+		}
+		printFocusJSON(output)
+		return
+	}
+
+	if !cleared {
+		fmt.Printf("No focused task for %s in project %s\n", ctx.agent, ctx.projectInfo.Name)
+		return
+	}
+	fmt.Println("Focus cleared")
+}
+
+func outputFocusJSON(ctx *focusContext, focus *database.Focus, task *models.Task) {
+	output := map[string]interface{}{
+		"success": true,
+		"agent":   ctx.agent,
+		"project": ctx.projectInfo.Name,
+		"focused": focus != nil,
+	}
+	if focus != nil {
+		output["task_id"] = focus.TaskID
+		output["set_at"] = focus.SetAt
+	}
+	if task != nil {
+		output["task"] = task
+	}
+	printFocusJSON(output)
+}
+
+func printFocusJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	focusCmd.AddCommand(focusShowCmd)
+	focusCmd.AddCommand(focusClearCmd)
+
+	RootCmd.AddCommand(focusCmd)
+}
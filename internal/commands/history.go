@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	historySince  string
+	historyFields []string
+	historyLimit  int
+	historyOffset int
+)
+
+// taskHistoryCmd represents the task-history command
+var taskHistoryCmd = &cobra.Command{
+	Use:     "task-history [id]",
+	Aliases: []string{"history"},
+	Short:   "Show the change history of a task",
+	Long: `Show every recorded change to a task in chronological order, including
+the field that changed, its before/after values, and who made the change.
+
+The --since flag accepts an RFC3339 timestamp, a date (2006-01-02), or a
+duration such as 24h or 7d meaning "that long ago". Without an ID, the history
+of the focused task is shown.
+
+Use --field to show only changes to some fields (e.g. status transitions), and
+--limit/--offset to page through long histories. Paging applies after filtering.
+
+Examples:
+  quicktodo task-history 1
+  quicktodo history 3 --json
+  quicktodo task-history 2 --since 24h --json
+  quicktodo task-history 4 --field status
+  quicktodo task-history 4 --limit 20 --offset 40`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runTaskHistory,
+}
+
+func runTaskHistory(cmd *cobra.Command, args []string) {
+	// Parse task ID
+	taskIDStr := taskIDArg(args)
+	taskID, err := strconv.Atoi(taskIDStr)
+	if err != nil {
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'. Task ID must be a number.", taskIDStr)
+	}
+
+	var since *time.Time
+	if historySince != "" {
+		parsed, err := parseTimeFlag(historySince)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: invalid --since value: %v", err)
+		}
+		since = &parsed
+	}
+
+	if err := validateHistoryPaging(historyFields, historyOffset, historyLimit); err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo init' first")
+	}
+
+	// Load project database
+	projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectInfo.Name))
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	events := projectDB.GetTaskHistory(taskID, since)
+
+	// Deleted tasks keep their history, so only fail when nothing is known at all
+	if _, err := projectDB.GetTask(taskID); err != nil && len(events) == 0 {
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
+	}
+
+	events = models.FilterEventsByField(events, historyFields)
+	total := len(events)
+	events = models.PageEvents(events, historyOffset, historyLimit)
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":     true,
+			"task_id":     taskID,
+			"event_count": len(events),
+			"total":       total,
+			"offset":      historyOffset,
+			"events":      events,
+		}
+		if historyLimit > 0 {
+			output["limit"] = historyLimit
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("History for task #%d\n", taskID)
+	if len(events) == 0 {
+		fmt.Println("No recorded changes")
+		return
+	}
+
+	for _, event := range events {
+		fmt.Printf("%s  %-12s %s\n", event.Timestamp.Local().Format("2006-01-02 15:04:05"), event.Actor, describeEvent(event))
+	}
+
+	if len(events) < total {
+		fmt.Printf("Showing events %d-%d of %d\n", historyOffset+1, historyOffset+len(events), total)
+	}
+}
+
+// validateHistoryPaging checks history field names and paging values
+func validateHistoryPaging(fields []string, offset, limit int) error {
+	for _, field := range fields {
+		if !models.IsValidEventField(field) {
+			return fmt.Errorf("invalid history field '%s'. Valid fields: %s", field, strings.Join(models.EventFields(), ", "))
+		}
+	}
+	if offset < 0 {
+		return fmt.Errorf("offset cannot be negative")
+	}
+	if limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+	return nil
+}
+
+// describeEvent renders a history event as a short human-readable line
+func describeEvent(event *models.TaskEvent) string {
+	switch event.Field {
+	case models.EventFieldCreated:
+		return fmt.Sprintf("created %q", event.After)
+	case models.EventFieldDeleted:
+		return fmt.Sprintf("deleted %q", event.Before)
+	case models.EventFieldNote:
+		return fmt.Sprintf("note: %s", event.After)
+	default:
+		return fmt.Sprintf("%s: %q → %q", event.Field, event.Before, event.After)
+	}
+}
+
+// currentActor returns the identity recorded for changes made by this invocation
+func currentActor() string {
+	if agentID != "" {
+		return agentID
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return "cli"
+}
+
+// parseTimeFlag parses an RFC3339 timestamp, a 2006-01-02 date, or a relative
+// duration (e.g. 90m, 24h, 7d) interpreted as that long before now
+func parseTimeFlag(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t.UTC(), nil
+	}
+
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil && days >= 0 {
+			return time.Now().UTC().Add(-time.Duration(days) * 24 * time.Hour), nil
+		}
+	}
+
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return time.Now().UTC().Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("expected RFC3339 time, YYYY-MM-DD date, or duration like 24h/7d, got '%s'", value)
+}
+
+func init() {
+	taskHistoryCmd.Flags().StringVar(&historySince, "since", "", "Only show events after this time (RFC3339, YYYY-MM-DD, or duration like 24h)")
+	taskHistoryCmd.Flags().StringSliceVar(&historyFields, "field", nil, "Only show changes to these fields, e.g. status (repeatable or comma-separated)")
+	taskHistoryCmd.Flags().IntVar(&historyLimit, "limit", 0, "Maximum number of events to show (0 for no limit)")
+	taskHistoryCmd.Flags().IntVar(&historyOffset, "offset", 0, "Number of matching events to skip")
+
+	RootCmd.AddCommand(taskHistoryCmd)
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeFlag(t *testing.T) {
+	got, err := parseTimeFlag("2024-03-10T08:30:00-05:00")
+	if err != nil {
+		t.Fatalf("Expected RFC3339 to parse, got %v", err)
+	}
+	if !got.Equal(time.Date(2024, 3, 10, 13, 30, 0, 0, time.UTC)) || got.Location() != time.UTC {
+		t.Errorf("Expected 13:30 UTC, got %v", got)
+	}
+
+	got, err = parseTimeFlag("2024-03-10")
+	if err != nil {
+		t.Fatalf("Expected date to parse, got %v", err)
+	}
+	if !got.Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Expected local midnight, got %v", got)
+	}
+
+	got, err = parseTimeFlag("2d")
+	if err != nil {
+		t.Fatalf("Expected day duration to parse, got %v", err)
+	}
+	if diff := time.Since(got) - 48*time.Hour; diff < 0 || diff > time.Minute {
+		t.Errorf("Expected about 48h ago, got %v", got)
+	}
+
+	for _, invalid := range []string{"", "yesterday", "-5h", "2024-13-01"} {
+		if _, err := parseTimeFlag(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
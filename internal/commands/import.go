@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/hooks"
+	importer "quicktodo/internal/import"
+	"quicktodo/internal/models"
+	"quicktodo/internal/notify"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	importFormat         string
+	importFile           string
+	importUpdateExisting bool
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import tasks from an external export file",
+	Long: `Import tasks into the current project from a file exported by another tool.
+
+Supported formats:
+  github-json   A JSON array of issues from the GitHub REST API
+                (title, body, state, labels and assignees are mapped;
+                pull requests are skipped)
+  csv           A CSV file with a header row, as written by
+                export --format csv (id, title, description, status,
+                priority, assigned_to, created_at, updated_at)
+
+The file is given as an argument or with --file.
+
+Each task imported from GitHub remembers its source issue, so running the same
+import again skips issues that were already imported. Use --update-existing to
+refresh those tasks from the export instead.
+
+CSV rows always create new tasks: the id column is ignored and new IDs are
+assigned. Only the title column is required. Rows with an empty title or an
+invalid status, priority or timestamp are skipped and reported with the
+reason.
+
+Examples:
+  quicktodo import --format github-json --file issues.json
+  quicktodo import --format github-json --file issues.json --update-existing --json
+  quicktodo import --format csv tasks.csv`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runImport,
+}
+
+func runImport(cmd *cobra.Command, args []string) {
+	if importFormat != importer.FormatGitHubJSON && importFormat != importer.FormatCSV {
+		exitWithError(codeInvalidArgument, "Error: unsupported import format '%s'. Supported formats: %s, %s", importFormat, importer.FormatGitHubJSON, importer.FormatCSV)
+	}
+
+	path := importFile
+	if len(args) > 0 {
+		if importFile != "" {
+			exitWithError(codeInvalidArgument, "Error: give the import file either as an argument or with --file, not both")
+		}
+		path = args[0]
+	}
+	if path == "" {
+		exitWithError(codeInvalidArgument, "Error: no import file given")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		exitWithError(codeStorageError, "Error reading import file: %v", err)
+	}
+
+	var issues []importer.GitHubIssue
+	if importFormat == importer.FormatGitHubJSON {
+		issues, err = importer.ParseGitHubIssues(data)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registryPath := cfg.GetProjectsPath()
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	if importFormat == importer.FormatCSV {
+		runImportCSV(cfg, projectDB, dbPath, projectInfo, data)
+		return
+	}
+
+	result, err := importer.ImportGitHubIssues(projectDB, issues, importer.Options{
+		UpdateExisting: importUpdateExisting,
+		Actor:          currentActor(),
+	})
+	if err != nil {
+		exitWithError(codeStorageError, "Error importing issues: %v", err)
+	}
+
+	// Save project database
+	if len(result.Created) > 0 || len(result.Updated) > 0 {
+		if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+			exitWithError(codeStorageError, "Error saving project database: %v", err)
+		}
+	}
+
+	// Notify web server and run hooks for the imported tasks
+	for _, task := range result.Created {
+		if err := notify.NotifyTaskCreated(cfg, task, projectInfo.Name); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+		}
+		runTaskHooks(cfg, hooks.EventTaskCreated, task, projectInfo.Name)
+	}
+	for _, task := range result.Updated {
+		if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+		}
+	}
+
+	// Output result
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":       true,
+			"format":        importFormat,
+			"created_count": len(result.Created),
+			"updated_count": len(result.Updated),
+			"skipped_count": len(result.Skipped),
+			"created":       result.Created,
+			"updated":       result.Updated,
+			"skipped":       result.Skipped,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("Imported %d issue(s) into project %s: %d created, %d updated, %d skipped\n",
+		len(issues), projectInfo.Name, len(result.Created), len(result.Updated), len(result.Skipped))
+	if verbose {
+		for _, task := range result.Created {
+			fmt.Printf("  + #%d %s (%s)\n", task.ID, task.Title, task.ExternalID)
+		}
+		for _, task := range result.Updated {
+			fmt.Printf("  ~ #%d %s (%s)\n", task.ID, task.Title, task.ExternalID)
+		}
+	}
+}
+
+// runImportCSV creates a task for each valid row of a CSV file, saves the
+// database and reports the created and skipped rows. The project lock must be
+// held.
+func runImportCSV(cfg *config.Config, projectDB *models.ProjectDatabase, dbPath string, projectInfo *database.ProjectInfo, data []byte) {
+	result, err := importer.ImportCSV(projectDB, bytes.NewReader(data), importer.Options{Actor: currentActor()})
+	if err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+
+	// Save project database
+	if len(result.Created) > 0 {
+		if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+			exitWithError(codeStorageError, "Error saving project database: %v", err)
+		}
+	}
+
+	// Notify web server and run hooks for the imported tasks
+	for _, task := range result.Created {
+		if err := notify.NotifyTaskCreated(cfg, task, projectInfo.Name); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+		}
+		runTaskHooks(cfg, hooks.EventTaskCreated, task, projectInfo.Name)
+	}
+
+	// Output result
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":        true,
+			"format":         importFormat,
+			"imported_count": len(result.Created),
+			"skipped_count":  len(result.Skipped),
+			"created":        result.Created,
+			"skipped":        result.Skipped,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("Imported %d row(s) into project %s, skipped %d\n", len(result.Created), projectInfo.Name, len(result.Skipped))
+	for _, skipped := range result.Skipped {
+		fmt.Printf("  row %d skipped: %s\n", skipped.Row, skipped.Reason)
+	}
+	if verbose {
+		for _, task := range result.Created {
+			fmt.Printf("  + #%d %s\n", task.ID, task.Title)
+		}
+	}
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFormat, "format", importer.FormatGitHubJSON, "Format of the import file (github-json, csv)")
+	importCmd.Flags().StringVar(&importFile, "file", "", "Path to the file to import (or give it as an argument)")
+	importCmd.Flags().BoolVar(&importUpdateExisting, "update-existing", false, "Update tasks previously imported from the same source")
+
+	RootCmd.AddCommand(importCmd)
+}
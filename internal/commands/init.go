@@ -12,6 +12,12 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	initNoMarker    bool
+	initNoDoc       bool
+	initGitFriendly bool
+)
+
 // initProjectCmd represents the init command
 var initProjectCmd = &cobra.Command{
 	Use:   "init [project_name]",
@@ -21,14 +27,32 @@ var initProjectCmd = &cobra.Command{
 This command:
 - Creates a new project entry in the registry
 - Initializes the project database
+- Creates a .quicktodo marker directory naming the project
+- Generates a QUICKTODO.md with instructions for AI agents (unless one exists)
+
+Commands run anywhere below a .quicktodo marker find the project by walking up
+to the marker, so the project can be used from subdirectories and keeps its
+identity when the repository is cloned or moved. Commit the marker to share it.
+
+QUICKTODO.md is rendered from <data_dir>/QUICKTODO.md.tmpl when that file
+exists, so teams can add their own instructions. It is a Go template with
+{{.ProjectName}} and {{.DataDir}} available. Use --no-doc to skip it.
 
-If no project name is provided, it will use the current directory name.
+With --git-friendly the project database is saved with tasks sorted by ID and
+without the last_modified and version fields that change on every save, which
+keeps diffs small when the database is committed to git.
+
+If no project name is provided, the name in an existing .quicktodo marker is
+used, falling back to the current directory name.
 Use 'quicktodo context' to see AI usage instructions.
 
 Examples:
   quicktodo init myproject
   quicktodo init
-  quicktodo init "My Amazing Project"`,
+  quicktodo init "My Amazing Project"
+  quicktodo init myproject --no-marker
+  quicktodo init myproject --no-doc
+  quicktodo init myproject --git-friendly`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runInitProject,
 }
@@ -37,87 +61,102 @@ func runInitProject(cmd *cobra.Command, args []string) {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
 	}
 
 	// Ensure all directories exist
 	if err := cfg.EnsureAllDirectories(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating directories: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error creating directories: %v", err)
 	}
 
 	// Get current directory
 	currentDir, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
 	}
 
 	// Determine project name
 	var projectName string
 	if len(args) > 0 {
 		projectName = strings.TrimSpace(args[0])
+	} else if marker, err := database.ReadProjectMarker(currentDir); err == nil {
+		projectName = marker.Name
 	} else {
 		projectName = filepath.Base(currentDir)
 	}
 
 	if projectName == "" {
-		fmt.Fprintf(os.Stderr, "Error: project name cannot be empty\n")
-		os.Exit(1)
+		exitWithError(codeInvalidArgument, "Error: project name cannot be empty")
 	}
 
 	// Validate project name
 	if err := validateProjectName(projectName); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInvalidArgument, "Error: %v", err)
 	}
 
-	// Load project registry
+	// Load project registry, holding its lock until the project is registered
+	registryLock, registryLockInfo := acquireRegistryLock(cfg)
+	defer registryLock.ReleaseLock(registryLockInfo)
 	registryPath := cfg.GetProjectsPath()
 	registry, err := database.LoadProjectRegistry(registryPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project registry: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
 	}
 
 	// Check if project already exists
 	if _, exists := registry.GetProjectByName(projectName); exists {
-		fmt.Fprintf(os.Stderr, "Error: project '%s' already exists\n", projectName)
-		os.Exit(1)
+		exitWithError(codeAlreadyExists, "Error: project '%s' already exists", projectName)
 	}
 
 	// Check if current directory is already registered
 	if existingProject, exists := registry.GetProjectByPath(currentDir); exists {
-		fmt.Fprintf(os.Stderr, "Error: directory '%s' is already registered as project '%s'\n",
+		exitWithError(codeAlreadyExists, "Error: directory '%s' already belongs to project '%s'",
 			currentDir, existingProject.Name)
-		os.Exit(1)
 	}
 
 	// Register project
 	if err := registry.RegisterProject(projectName, currentDir); err != nil {
-		fmt.Fprintf(os.Stderr, "Error registering project: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error registering project: %v", err)
 	}
 
 	// Save updated registry
 	if err := registry.Save(registryPath); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving project registry: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error saving project registry: %v", err)
 	}
 
 	// Create project database
 	project := models.NewProject(projectName, currentDir)
 	projectDB := models.NewProjectDatabase(project)
+	projectDB.GitFriendly = initGitFriendly
 
 	// Save project database
 	dbPath := cfg.GetProjectDatabasePath(projectName)
-	if err := saveProjectDatabase(projectDB, dbPath); err != nil {
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
 		// Try to rollback registry change
 		registry.RemoveProject(projectName)
 		registry.Save(registryPath)
-		fmt.Fprintf(os.Stderr, "Error creating project database: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error creating project database: %v", err)
+	}
+
+	// Mark the project root so commands can find it from subdirectories
+	if !initNoMarker {
+		if err := database.WriteProjectMarker(currentDir, projectName); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to create %s marker: %v\n", database.MarkerDirName, err)
+		}
+	}
+
+	// Generate agent instructions for the project
+	if !initNoDoc {
+		docPath, written, err := writeProjectDoc(cfg, currentDir, projectName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to generate %s: %v\n", projectDocFileName, err)
+		} else if verbose {
+			if written {
+				fmt.Printf("Generated %s\n", docPath)
+			} else {
+				fmt.Printf("Kept existing %s\n", docPath)
+			}
+		}
 	}
 
 	// Output success message
@@ -149,33 +188,74 @@ func validateProjectName(name string) error {
 	return nil
 }
 
-func saveProjectDatabase(db *models.ProjectDatabase, filePath string) error {
-	// Create directory if it doesn't exist
-	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+// saveProjectDatabase saves a project database and records the tasks the
+// save changes in the project's operation log, for undo, labelled with the
+// running CLI command
+func saveProjectDatabase(cfg *config.Config, db *models.ProjectDatabase, filePath string) error {
+	return saveProjectDatabaseAs(cfg, db, filePath, operationCommand())
+}
+
+// saveProjectDatabaseAs is saveProjectDatabase with the operation labelled
+// command, for saves that don't come from the command line, such as the web
+// server's
+func saveProjectDatabaseAs(cfg *config.Config, db *models.ProjectDatabase, filePath, command string) error {
+	var op *database.Operation
+	if cfg.MaxUndoOperations > 0 {
+		previous, err := readPreviousDatabase(filePath)
+		if err != nil {
+			// A database that can't be read has nothing to undo to
+			if verbose {
+				fmt.Fprintf(os.Stderr, "Warning: not recording this change for undo: %v\n", err)
+			}
+		} else if op, err = database.NewOperation(command, currentActor(), previous, db); err != nil {
+			return fmt.Errorf("failed to record operation: %w", err)
+		}
 	}
 
-	// Convert to JSON
-	data, err := db.ToJSON()
-	if err != nil {
-		return fmt.Errorf("failed to marshal database: %w", err)
+	if err := writeProjectDatabase(cfg, db, filePath); err != nil {
+		return err
+	}
+
+	if op != nil {
+		projectName := strings.TrimSuffix(filepath.Base(filePath), ".json")
+		if err := database.AppendOperation(cfg.GetProjectOpsPath(projectName), op, cfg.MaxUndoOperations); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to record this change for undo: %v\n", err)
+		}
 	}
+	return nil
+}
 
-	// Write to temporary file first, then rename for atomicity
-	tempPath := filePath + ".tmp"
-	if err := os.WriteFile(tempPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temporary file: %w", err)
+// writeProjectDatabase saves a project database without recording an
+// operation, as undo does when reverting one
+func writeProjectDatabase(cfg *config.Config, db *models.ProjectDatabase, filePath string) error {
+	store, err := database.OpenStore(cfg.Backend, filePath)
+	if err != nil {
+		return err
 	}
 
-	if err := os.Rename(tempPath, filePath); err != nil {
-		os.Remove(tempPath) // Clean up temp file
-		return fmt.Errorf("failed to rename temporary file: %w", err)
+	// Keep a copy of the previous version for diff and recovery, every
+	// backup_every_n_writes writes
+	if cfg.CreateBackups {
+		projectName := strings.TrimSuffix(filepath.Base(filePath), ".json")
+		due, err := database.BackupDue(cfg.DataDir, projectName, filePath, cfg.BackupEveryNWrites)
+		if err != nil {
+			return fmt.Errorf("failed to check backups: %w", err)
+		}
+		if due {
+			if _, err := database.BackupProjectDatabase(cfg.DataDir, projectName, filePath, cfg.MaxBackups); err != nil {
+				return fmt.Errorf("failed to back up database: %w", err)
+			}
+		}
 	}
 
-	return nil
+	return store.Save(db)
 }
 
 
 func init() {
+	initProjectCmd.Flags().BoolVar(&initNoMarker, "no-marker", false, "Don't create a .quicktodo marker directory")
+	initProjectCmd.Flags().BoolVar(&initNoDoc, "no-doc", false, "Don't generate a QUICKTODO.md file")
+	initProjectCmd.Flags().BoolVar(&initGitFriendly, "git-friendly", false, "Save the project database in a diff-friendly form for committing to git")
+
 	RootCmd.AddCommand(initProjectCmd)
 }
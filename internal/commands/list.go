@@ -1,13 +1,18 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
 	"quicktodo/internal/config"
 	"quicktodo/internal/database"
+	"quicktodo/internal/export"
 	"quicktodo/internal/models"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -16,6 +21,32 @@ var (
 	statusFilter   string
 	priorityFilter string
 	assignedFilter string
+	activeOnly     bool
+	showAll        bool
+	savedFilter    string
+	changedSince   string
+	overdueOnly    bool
+	scheduledOnly  bool
+	completedSince string
+	flatJSON       bool
+	withArchived   bool
+	stuckFor       string
+	doneLast       bool
+	listFormat     string
+	listOutputFile string
+	truncateDesc   int
+	watchList      bool
+	listSort       string
+	listReverse    bool
+	listLimit      int
+	summaryOnly    bool
+)
+
+// watchPollInterval is how often list-tasks --watch checks the database file,
+// and watchDebounce how long the file must stay unchanged before a redraw
+var (
+	watchPollInterval = 200 * time.Millisecond
+	watchDebounce     = 300 * time.Millisecond
 )
 
 // listTasksCmd represents the list-tasks command
@@ -33,39 +64,116 @@ Examples:
   quicktodo show-tasks --status pending
   quicktodo list-tasks --priority high --json
   quicktodo list-tasks --assigned-to ai-agent-1
-  quicktodo list-tasks --status in_progress --priority high`,
+  quicktodo list-tasks --status in_progress --priority high
+  quicktodo list-tasks --active
+  quicktodo list-tasks --all
+  quicktodo list-tasks --filter mywork
+  quicktodo list-tasks --changed-since 2024-05-01T12:00:00Z --json
+  quicktodo list-tasks --overdue
+  quicktodo list-tasks --scheduled
+  quicktodo list-tasks --completed-since 24h --json
+  quicktodo list-tasks --status pending --flat-json
+  quicktodo list-tasks --include-archived --all
+  quicktodo list-tasks --stuck 3d
+  quicktodo list-tasks --all --done-last
+  quicktodo list-tasks --format html --output-file tasks.html
+
+Done tasks are hidden when --active is given or hide_done_by_default is set in
+the config; --all or --status done shows them again. --completed-since always
+lists done tasks, since that is what it asks for.
+
+--scheduled hides tasks whose start date (create-task --start) is still in the
+future, leaving only what can be worked on now.
+
+--stuck lists open tasks whose status hasn't changed for at least the given
+duration (e.g. 72h or 3d), such as work that has sat in progress for days.
+
+--done-last lists done tasks after all the others, in every output format,
+so finished work doesn't crowd the top; done_last_by_default in the config
+turns it on unless --done-last=false is given.
+
+--truncate-description shortens each description to the given number of
+characters, ending it with an ellipsis, so long descriptions don't swamp the
+list; truncate_description in the config sets a default, and 0 shows them in
+full. Only the human output is shortened: JSON, HTML and display-task always
+have the full text.
+
+--include-archived merges in the tasks moved out by the archive command. They
+are done, so they are subject to the same hiding as other done tasks.
+
+--flat-json prints just the array of tasks, without the envelope that --json
+wraps it in, matching GET /api/projects/{name}/tasks from the web server.
+
+--format html renders the tasks as a self-contained HTML table, with its own
+styles, for embedding in a wiki, README or static dashboard. Rows carry
+status-<status> and priority-<priority> classes for restyling. --output-file
+writes the table to a file instead of stdout.
+
+--watch keeps the list on screen and redraws it whenever the project database
+changes, e.g. when another terminal or an agent updates a task, until Ctrl+C.
+Bursts of changes produce a single redraw. It works with the human output only.
+
+--sort orders the tasks, in every output format, by id (the default), title,
+status, priority, created_at, updated_at, due_date or position (board order).
+Each field sorts in its natural order: title A-Z, status pending first,
+priority critical first, oldest or earliest first, and tasks without a due
+date last. --reverse turns the order around.
+
+--limit shows only the first N tasks after sorting; the output says how many
+matched in all. --summary prints only the status and priority counts of the
+tasks that would be listed (after --limit), and with --json the project
+summary of those tasks instead of the tasks themselves.`,
 	Run: runListTasks,
 }
 
 func runListTasks(cmd *cobra.Command, args []string) {
+	format := strings.ToLower(listFormat)
+	if format != "text" && format != export.FormatHTML {
+		exitWithError(codeInvalidArgument, "Error: unsupported format '%s'. Supported formats: text, %s", listFormat, export.FormatHTML)
+	}
+	if format == export.FormatHTML && (jsonOutput || flatJSON) {
+		exitWithError(codeInvalidArgument, "Error: --format html cannot be combined with --json or --flat-json")
+	}
+	if listOutputFile != "" && format != export.FormatHTML {
+		exitWithError(codeInvalidArgument, "Error: --output-file requires --format html")
+	}
+	if watchList && (format != "text" || jsonOutput || flatJSON) {
+		exitWithError(codeInvalidArgument, "Error: --watch cannot be combined with --json, --flat-json or --format html")
+	}
+	if summaryOnly && (format != "text" || flatJSON) {
+		exitWithError(codeInvalidArgument, "Error: --summary cannot be combined with --flat-json or --format html")
+	}
+	if listLimit < 0 {
+		exitWithError(codeInvalidArgument, "Error: --limit cannot be negative")
+	}
+	listSort = strings.ToLower(listSort)
+	if !models.IsValidSortField(listSort) {
+		exitWithError(codeInvalidArgument, "Error: invalid sort field '%s'. Valid fields: %s", listSort, strings.Join(models.SortFields(), ", "))
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
 	}
 
 	// Get current directory
 	currentDir, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
 	}
 
 	// Load project registry
 	registryPath := cfg.GetProjectsPath()
 	registry, err := database.LoadProjectRegistry(registryPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project registry: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
 	}
 
 	// Find project for current directory
 	projectInfo, exists := registry.GetProjectByPath(currentDir)
 	if !exists {
-		fmt.Fprintf(os.Stderr, "Error: current directory is not a registered project\n")
-		fmt.Fprintf(os.Stderr, "Run 'quicktodo initialize-project' first\n")
-		os.Exit(1)
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
 	}
 
 	// Update last accessed time
@@ -75,50 +183,225 @@ func runListTasks(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	// Load project database
+	// Apply saved filter (command-line flags take precedence)
+	if savedFilter != "" {
+		saved, ok := cfg.SavedFilters[savedFilter]
+		if !ok {
+			exitWithError(codeNotFound, "Error: saved filter '%s' not found\nRun 'quicktodo saved-filter list' to see saved filters", savedFilter)
+		}
+		merged := saved.Merge(config.SavedFilter{
+			Status:     statusFilter,
+			Priority:   priorityFilter,
+			AssignedTo: assignedFilter,
+		})
+		statusFilter, priorityFilter, assignedFilter = merged.Status, merged.Priority, merged.AssignedTo
+	}
+	if !cmd.Flags().Changed("done-last") {
+		doneLast = cfg.DoneLastByDefault
+	}
+	if !cmd.Flags().Changed("truncate-description") {
+		truncateDesc = cfg.TruncateDescription
+	}
+	if truncateDesc < 0 {
+		exitWithError(codeInvalidArgument, "Error: --truncate-description cannot be negative")
+	}
+
+	// Save updated registry (for last accessed time)
+	if err := registry.Save(registryPath); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save registry: %v\n", err)
+	}
+
 	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
-	projectDB, err := loadProjectDatabase(dbPath)
+	if watchList {
+		watchTaskList(cfg, projectInfo, dbPath)
+		return
+	}
+
+	tasks, projectDB, err := loadListedTasks(cfg, projectInfo, dbPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project database: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error: %v", err)
+	}
+	tasks, total := limitListedTasks(tasks)
+
+	// Output results
+	if format == export.FormatHTML {
+		outputTasksHTML(tasks, projectInfo)
+	} else if flatJSON {
+		outputTasksFlatJSON(tasks)
+	} else if jsonOutput && summaryOnly {
+		outputTaskSummaryJSON(tasks, total, projectDB, projectInfo)
+	} else if jsonOutput {
+		outputTasksJSON(tasks, total, projectInfo, projectDB.LastModified)
+	} else {
+		outputTasksHuman(tasks, total, projectInfo)
+	}
+}
+
+// limitListedTasks applies --limit to the listed tasks, returning the tasks
+// to show and how many there were
+func limitListedTasks(tasks []*models.Task) ([]*models.Task, int) {
+	total := len(tasks)
+	if listLimit > 0 && total > listLimit {
+		tasks = tasks[:listLimit]
+	}
+	return tasks, total
+}
+
+// loadListedTasks loads the project database and returns its tasks narrowed
+// by the list-tasks filters, in listing order. Time-based filters such as
+// --overdue are measured from the time of the call. Invalid flags exit.
+func loadListedTasks(cfg *config.Config, projectInfo *database.ProjectInfo, dbPath string) ([]*models.Task, *models.ProjectDatabase, error) {
+	// Load project database
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load project database: %w", err)
 	}
 
 	// Create filter
 	filter := createTaskFilter()
+	filter.HideDone = shouldHideDone(cfg.HideDoneByDefault, activeOnly, showAll)
+	if changedSince != "" {
+		since, err := parseTimeFlag(changedSince)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: invalid --changed-since value: %v", err)
+		}
+		filter.ChangedSince = &since
+	}
+	if overdueOnly {
+		now := time.Now().UTC()
+		filter.OverdueAt = &now
+	}
+	if scheduledOnly {
+		now := time.Now().UTC()
+		filter.StartedAt = &now
+	}
+	if stuckFor != "" {
+		cutoff, err := parseTimeFlag(stuckFor)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: invalid --stuck value: %v", err)
+		}
+		filter.StuckSince = &cutoff
+	}
+	if completedSince != "" {
+		since, err := parseTimeFlag(completedSince)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: invalid --completed-since value: %v", err)
+		}
+		filter.CompletedSince = &since
+	}
 
 	// Get filtered tasks
 	tasks := projectDB.ListTasks(filter)
+	if withArchived {
+		archived, err := loadArchivedTasks(cfg, projectInfo.Name, filter)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load archive: %w", err)
+		}
+		tasks = append(tasks, archived...)
+	}
+	sorter := &models.TaskSorter{Field: listSort, Desc: listReverse, DoneLast: doneLast}
+	sorter.Sort(tasks)
 
-	// Save updated registry (for last accessed time)
-	if err := registry.Save(registryPath); err != nil && verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to save registry: %v\n", err)
+	return tasks, projectDB, nil
+}
+
+// watchTaskList renders the task list, then redraws it whenever the database
+// file changes until interrupted. Errors while reloading are shown in place of
+// the list, since the next change may well fix them.
+func watchTaskList(cfg *config.Config, projectInfo *database.ProjectInfo, dbPath string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	render := func() {
+		if stdoutIsTerminal() {
+			// Clear the screen and move the cursor home
+			fmt.Print("\033[H\033[2J")
+		}
+		tasks, _, err := loadListedTasks(cfg, projectInfo, dbPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		} else {
+			tasks, total := limitListedTasks(tasks)
+			outputTasksHuman(tasks, total, projectInfo)
+		}
+		fmt.Printf("\nWatching for changes since %s; press Ctrl+C to stop\n", time.Now().Format("15:04:05"))
 	}
 
-	// Output results
-	if jsonOutput {
-		outputTasksJSON(tasks, projectInfo)
-	} else {
-		outputTasksHuman(tasks, projectInfo)
+	render()
+	for range watchFile(ctx, dbPath, watchPollInterval, watchDebounce) {
+		render()
 	}
 }
 
+// watchFile polls path every interval and sends on the returned channel once
+// its modification time or size has changed and then stayed unchanged for
+// debounce, so a burst of writes is reported once. A missing file counts as a
+// state of its own. The channel is closed when ctx is done.
+func watchFile(ctx context.Context, path string, interval, debounce time.Duration) <-chan struct{} {
+	changes := make(chan struct{})
+
+	type fileState struct {
+		modTime time.Time
+		size    int64
+		exists  bool
+	}
+	stat := func() fileState {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fileState{}
+		}
+		return fileState{modTime: info.ModTime(), size: info.Size(), exists: true}
+	}
+
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		last := stat()
+		var changedAt time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if current := stat(); current != last {
+					last = current
+					changedAt = now
+					continue
+				}
+				if changedAt.IsZero() || now.Sub(changedAt) < debounce {
+					continue
+				}
+				changedAt = time.Time{}
+				select {
+				case changes <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return changes
+}
+
 func createTaskFilter() *models.TaskFilter {
 	filter := &models.TaskFilter{}
 
 	if statusFilter != "" {
-		status := models.Status(strings.ToLower(statusFilter))
+		status := models.NormalizeStatus(statusFilter)
 		if !models.IsValidStatus(string(status)) {
-			fmt.Fprintf(os.Stderr, "Error: invalid status '%s'. Valid statuses: pending, in_progress, done\n", statusFilter)
-			os.Exit(1)
+			exitWithError(codeInvalidStatus, "Error: invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", statusFilter)
 		}
 		filter.Status = &status
 	}
 
 	if priorityFilter != "" {
-		priority := models.Priority(strings.ToLower(priorityFilter))
+		priority := models.NormalizePriority(priorityFilter)
 		if !models.IsValidPriority(string(priority)) {
-			fmt.Fprintf(os.Stderr, "Error: invalid priority '%s'. Valid priorities: low, medium, high\n", priorityFilter)
-			os.Exit(1)
+			exitWithError(codeInvalidPriority, "Error: invalid priority '%s'. Valid priorities: low, medium, high, critical", priorityFilter)
 		}
 		filter.Priority = &priority
 	}
@@ -130,65 +413,184 @@ func createTaskFilter() *models.TaskFilter {
 	return filter
 }
 
-func outputTasksJSON(tasks []*models.Task, projectInfo *database.ProjectInfo) {
+// shouldHideDone decides whether done tasks are excluded from the listing.
+// --all always wins; otherwise --active or the config default hides them.
+// An explicit --status filter is honored by TaskFilter itself.
+func shouldHideDone(hideByDefault, active, all bool) bool {
+	if all {
+		return false
+	}
+	return active || hideByDefault
+}
+
+// outputTasksJSON prints the listed tasks; total is how many matched before
+// --limit
+func outputTasksJSON(tasks []*models.Task, total int, projectInfo *database.ProjectInfo, lastModified time.Time) {
+	if tasks == nil {
+		tasks = []*models.Task{}
+	}
+
+	output := map[string]interface{}{
+		"success": true,
+		"project": map[string]interface{}{
+			"name": projectInfo.Name,
+			"path": projectInfo.Path,
+		},
+		"task_count":    len(tasks),
+		"total_count":   total,
+		"truncated":     len(tasks) < total,
+		"tasks":         tasks,
+		"last_modified": lastModified,
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+// outputTaskSummaryJSON prints the project summary of the listed tasks for
+// --summary --json
+func outputTaskSummaryJSON(tasks []*models.Task, total int, projectDB *models.ProjectDatabase, projectInfo *database.ProjectInfo) {
 	output := map[string]interface{}{
 		"success": true,
 		"project": map[string]interface{}{
 			"name": projectInfo.Name,
 			"path": projectInfo.Path,
 		},
-		"task_count": len(tasks),
-		"tasks":      tasks,
+		"task_count":    len(tasks),
+		"total_count":   total,
+		"truncated":     len(tasks) < total,
+		"summary":       models.SummarizeTasks(projectDB.Project, tasks, time.Now().UTC()),
+		"last_modified": projectDB.LastModified,
 	}
 
 	data, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error formatting JSON output: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+// outputTasksFlatJSON prints the bare task array, as served by the web API
+func outputTasksFlatJSON(tasks []*models.Task) {
+	if tasks == nil {
+		tasks = []*models.Task{}
+	}
+
+	data, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
 	}
 
 	fmt.Println(string(data))
 }
 
-func outputTasksHuman(tasks []*models.Task, projectInfo *database.ProjectInfo) {
+// outputTasksHTML prints the tasks as an HTML table, or writes it to
+// --output-file
+func outputTasksHTML(tasks []*models.Task, projectInfo *database.ProjectInfo) {
+	var b strings.Builder
+	if err := export.WriteTasksHTML(&b, tasks, projectInfo.Name); err != nil {
+		exitWithError(codeInternalError, "Error: %v", err)
+	}
+
+	if listOutputFile == "" {
+		fmt.Print(b.String())
+		return
+	}
+
+	if err := os.WriteFile(listOutputFile, []byte(b.String()), 0644); err != nil {
+		exitWithError(codeStorageError, "Error writing output file: %v", err)
+	}
+	fmt.Printf("Wrote %d task(s) from %s to %s\n", len(tasks), projectInfo.Name, listOutputFile)
+}
+
+// outputTasksHuman lists the tasks, or only their counts with --summary;
+// total is how many matched before --limit
+func outputTasksHuman(tasks []*models.Task, total int, projectInfo *database.ProjectInfo) {
 	// Project header
 	fmt.Printf("Project: %s (%s)\n", projectInfo.Name, projectInfo.Path)
 
 	if len(tasks) == 0 {
 		fmt.Println("No tasks found")
-		if statusFilter != "" || priorityFilter != "" || assignedFilter != "" {
+		if statusFilter != "" || priorityFilter != "" || assignedFilter != "" || activeOnly || changedSince != "" ||
+			overdueOnly || scheduledOnly || completedSince != "" || stuckFor != "" {
 			fmt.Println("Try removing filters to see all tasks")
 		}
 		return
 	}
 
-	fmt.Printf("Found %d task(s):\n\n", len(tasks))
-
-	// Sort tasks by ID
-	sorter := &models.TaskSorter{Field: "id", Desc: false}
-	sorter.Sort(tasks)
+	truncated := len(tasks) < total
+	if truncated {
+		fmt.Printf("Showing the first %d of %d task(s) (--limit %d)", len(tasks), total, listLimit)
+	} else {
+		fmt.Printf("Found %d task(s)", len(tasks))
+	}
+	if summaryOnly {
+		fmt.Print("\n\n")
+		showTaskSummary(tasks)
+		return
+	}
+	fmt.Print(":\n\n")
 
 	// Display tasks
 	for _, task := range tasks {
+		if truncateDesc > 0 {
+			shown := *task
+			shown.Description = truncateText(task.Description, truncateDesc)
+			task = &shown
+		}
 		displayTask(task)
 		fmt.Println()
 	}
 
+	if truncated {
+		fmt.Printf("%d more task(s) not shown; raise --limit to see them\n", total-len(tasks))
+	}
+
 	// Show summary if verbose
 	if verbose {
 		showTaskSummary(tasks)
 	}
 }
 
+// truncateText shortens text to at most limit characters, marking the cut
+// with an ellipsis
+func truncateText(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return strings.TrimRight(string(runes[:limit]), " \t\n") + "…"
+}
+
 func displayTask(task *models.Task) {
+	displayTaskHighlighted(task, "", nil)
+}
+
+// displayTaskHighlighted prints a task like list-tasks, with an optional label
+// before the title and a function marking up the title and description
+func displayTaskHighlighted(task *models.Task, label string, highlight func(string) string) {
+	if highlight == nil {
+		highlight = func(text string) string { return text }
+	}
+
 	// Status indicator
 	statusIcon := getStatusIcon(task.Status)
 	priorityColor := getPriorityIndicator(task.Priority)
 
-	fmt.Printf("%s #%-3d %s%s\n", statusIcon, task.ID, priorityColor, task.Title)
+	overdue := ""
+	if task.IsOverdue(time.Now()) {
+		overdue = fmt.Sprintf(" ⚠️  OVERDUE (due %s)", task.DueDate.Local().Format("2006-01-02"))
+	}
+
+	fmt.Printf("%s #%-3d %s%s%s%s\n", statusIcon, task.ID, priorityColor, label, highlight(task.Title), overdue)
 
 	if task.Description != "" {
-		fmt.Printf("     %s\n", task.Description)
+		fmt.Printf("     %s\n", highlight(task.Description))
 	}
 
 	// Show metadata in verbose mode or if assigned
@@ -218,6 +620,8 @@ func getStatusIcon(status models.Status) string {
 		return "🏃"
 	case models.StatusDone:
 		return "✅"
+	case models.StatusCancelled:
+		return "🚫"
 	default:
 		return "❓"
 	}
@@ -225,6 +629,8 @@ func getStatusIcon(status models.Status) string {
 
 func getPriorityIndicator(priority models.Priority) string {
 	switch priority {
+	case models.PriorityCritical:
+		return "🚨 "
 	case models.PriorityHigh:
 		return "🔴 "
 	case models.PriorityMedium:
@@ -246,21 +652,43 @@ func showTaskSummary(tasks []*models.Task) {
 	}
 
 	fmt.Println("Summary:")
-	fmt.Printf("  Status: %d pending, %d in progress, %d done\n",
+	fmt.Printf("  Status: %d pending, %d in progress, %d done, %d cancelled\n",
 		statusCounts[models.StatusPending],
 		statusCounts[models.StatusInProgress],
-		statusCounts[models.StatusDone])
+		statusCounts[models.StatusDone],
+		statusCounts[models.StatusCancelled])
 
-	fmt.Printf("  Priority: %d high, %d medium, %d low\n",
+	fmt.Printf("  Priority: %d critical, %d high, %d medium, %d low\n",
+		priorityCounts[models.PriorityCritical],
 		priorityCounts[models.PriorityHigh],
 		priorityCounts[models.PriorityMedium],
 		priorityCounts[models.PriorityLow])
 }
 
 func init() {
-	listTasksCmd.Flags().StringVarP(&statusFilter, "status", "s", "", "Filter by status (pending, in_progress, done)")
-	listTasksCmd.Flags().StringVarP(&priorityFilter, "priority", "p", "", "Filter by priority (low, medium, high)")
+	listTasksCmd.Flags().StringVarP(&statusFilter, "status", "s", "", "Filter by status (pending, in_progress, done, cancelled)")
+	listTasksCmd.Flags().StringVarP(&priorityFilter, "priority", "p", "", "Filter by priority (low, medium, high, critical)")
 	listTasksCmd.Flags().StringVarP(&assignedFilter, "assigned-to", "a", "", "Filter by assignee")
+	listTasksCmd.Flags().BoolVar(&activeOnly, "active", false, "Hide done tasks")
+	listTasksCmd.Flags().BoolVar(&showAll, "all", false, "Show done tasks even when hidden by default")
+	listTasksCmd.MarkFlagsMutuallyExclusive("active", "all")
+	listTasksCmd.Flags().StringVar(&savedFilter, "filter", "", "Apply a saved filter by name")
+	listTasksCmd.Flags().StringVar(&changedSince, "changed-since", "", "Only show tasks updated after this time (RFC3339, YYYY-MM-DD, or duration like 24h)")
+	listTasksCmd.Flags().BoolVar(&scheduledOnly, "scheduled", false, "Hide tasks whose start date is still in the future")
+	listTasksCmd.Flags().BoolVar(&overdueOnly, "overdue", false, "Only show unfinished tasks whose due date has passed")
+	listTasksCmd.Flags().BoolVar(&flatJSON, "flat-json", false, "Output only the JSON array of tasks, as the web API does")
+	listTasksCmd.Flags().StringVar(&listFormat, "format", "text", "Output format (text, html)")
+	listTasksCmd.Flags().IntVar(&truncateDesc, "truncate-description", 0, "Shorten descriptions to this many characters in human output, 0 for full text (default: config truncate_description)")
+	listTasksCmd.Flags().StringVar(&listOutputFile, "output-file", "", "Write the --format html table to this file instead of stdout")
+	listTasksCmd.Flags().StringVar(&stuckFor, "stuck", "", "Only show open tasks whose status hasn't changed for this long (duration like 72h or 3d)")
+	listTasksCmd.Flags().BoolVar(&doneLast, "done-last", false, "List done tasks after all others (default: config done_last_by_default)")
+	listTasksCmd.Flags().BoolVar(&withArchived, "include-archived", false, "Also show tasks moved out by the archive command")
+	listTasksCmd.Flags().StringVar(&completedSince, "completed-since", "", "Only show tasks completed since this time (RFC3339, YYYY-MM-DD, or duration like 24h)")
+	listTasksCmd.Flags().BoolVar(&watchList, "watch", false, "Redraw the list whenever the project changes, until Ctrl+C")
+	listTasksCmd.Flags().StringVar(&listSort, "sort", "id", "Sort by id, title, status, priority, created_at, updated_at, due_date or position")
+	listTasksCmd.Flags().BoolVar(&listReverse, "reverse", false, "Reverse the sort order")
+	listTasksCmd.Flags().IntVar(&listLimit, "limit", 0, "Show at most this many tasks, after sorting (0 for all)")
+	listTasksCmd.Flags().BoolVar(&summaryOnly, "summary", false, "Print only the status and priority counts of the listed tasks")
 
 	RootCmd.AddCommand(listTasksCmd)
 }
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShouldHideDone(t *testing.T) {
+	tests := []struct {
+		name          string
+		hideByDefault bool
+		active        bool
+		all           bool
+		expected      bool
+	}{
+		{"default shows done", false, false, false, false},
+		{"config hides done", true, false, false, true},
+		{"active flag hides done", false, true, false, true},
+		{"all overrides config", true, false, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldHideDone(tt.hideByDefault, tt.active, tt.all); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		text     string
+		limit    int
+		expected string
+	}{
+		{"short", 10, "short"},
+		{"exactly ten", 11, "exactly ten"},
+		{"a much longer description", 6, "a much…"},
+		{"trailing space here", 9, "trailing…"},
+		{"héllo wörld", 7, "héllo w…"},
+	}
+
+	for _, tt := range tests {
+		if got := truncateText(tt.text, tt.limit); got != tt.expected {
+			t.Errorf("truncateText(%q, %d): expected %q, got %q", tt.text, tt.limit, tt.expected, got)
+		}
+	}
+}
+
+func TestWatchFileDebounces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.json")
+	if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := watchFile(ctx, path, 5*time.Millisecond, 50*time.Millisecond)
+
+	// A burst of writes is reported once, after it settles
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", i+1)), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a change after the writes settled")
+	}
+	select {
+	case <-changes:
+		t.Fatal("Expected the burst of writes to be reported once")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case _, open := <-changes:
+		if open {
+			t.Error("Expected no change after cancelling")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the channel to close when the context is done")
+	}
+}
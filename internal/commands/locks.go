@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	locksRelease string
+	locksClean   bool
+)
+
+// locksCmd represents the locks command
+var locksCmd = &cobra.Command{
+	Use:   "locks",
+	Short: "Show or release project locks",
+	Long: `Show the project locks currently held by running quicktodo processes, or
+release one with --release.
+
+Each lock is listed with its holder's PID, age and the command it was running
+from which directory. Locks held longer than stale_timeout are marked stale.
+
+Commands that change a project wait for its lock (see --wait). If a process
+crashed or hung while holding a lock, release it here so other commands can
+proceed. --clean removes every stale lock and every lock whose process has
+exited.
+
+Examples:
+  quicktodo locks
+  quicktodo locks --clean
+  quicktodo locks --release myproject`,
+	Args: cobra.NoArgs,
+	Run:  runLocks,
+}
+
+func runLocks(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	lockManager := database.NewLockManager(cfg.DataDir+"/locks", cfg.LockTimeout)
+	staleAge := time.Duration(cfg.StaleTimeout) * time.Minute
+
+	if locksClean && locksRelease != "" {
+		exitWithError(codeInvalidArgument, "Error: --clean and --release cannot be combined")
+	}
+
+	if locksClean {
+		cleaned, err := lockManager.CleanupStaleLocks(staleAge)
+		if err != nil {
+			exitWithError(codeStorageError, "Error cleaning locks: %v", err)
+		}
+
+		projects := make([]string, len(cleaned))
+		for i, name := range cleaned {
+			projects[i] = strings.TrimSuffix(name, ".lock")
+		}
+		sort.Strings(projects)
+
+		if jsonOutput {
+			printLocksJSON(map[string]interface{}{
+				"success": true,
+				"removed": projects,
+			})
+			return
+		}
+
+		if len(projects) == 0 {
+			fmt.Println("No stale locks")
+			return
+		}
+		fmt.Printf("Removed %d stale lock(s): %s\n", len(projects), strings.Join(projects, ", "))
+		return
+	}
+
+	if locksRelease != "" {
+		holder, err := lockManager.RemoveLock(locksRelease)
+		if err != nil {
+			exitWithError(codeStorageError, "Error releasing lock: %v", err)
+		}
+
+		if jsonOutput {
+			output := map[string]interface{}{
+				"success":  true,
+				"project":  locksRelease,
+				"released": holder != nil,
+			}
+			if holder != nil {
+				output["holder"] = lockHolderJSON(holder)
+			}
+			printLocksJSON(output)
+			return
+		}
+
+		if holder == nil {
+			fmt.Printf("Project %s is not locked\n", locksRelease)
+			return
+		}
+		fmt.Printf("Released lock on project %s (%s)\n", locksRelease, describeLockHolder(holder))
+		return
+	}
+
+	locks, err := lockManager.GetActiveLocks()
+	if err != nil {
+		exitWithError(codeStorageError, "Error reading locks: %v", err)
+	}
+
+	projects := make([]string, 0, len(locks))
+	for projectName := range locks {
+		projects = append(projects, projectName)
+	}
+	sort.Strings(projects)
+
+	if jsonOutput {
+		lockList := make([]map[string]interface{}, 0, len(projects))
+		for _, projectName := range projects {
+			entry := lockHolderJSON(locks[projectName])
+			entry["project"] = projectName
+			entry["stale"] = time.Since(locks[projectName].CreatedAt) > staleAge
+			lockList = append(lockList, entry)
+		}
+		printLocksJSON(map[string]interface{}{
+			"success": true,
+			"count":   len(lockList),
+			"locks":   lockList,
+		})
+		return
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("No active locks")
+		return
+	}
+
+	fmt.Printf("Active locks (%d):\n", len(projects))
+	for _, projectName := range projects {
+		line := fmt.Sprintf("  %-20s %s", projectName, describeLockHolder(locks[projectName]))
+		if time.Since(locks[projectName].CreatedAt) > staleAge {
+			line += " [stale]"
+		}
+		fmt.Println(line)
+	}
+}
+
+// acquireProjectLock takes the project lock, waiting up to --wait when given
+// or the configured lock timeout otherwise. Ctrl+C stops the wait. On failure
+// it reports who holds the lock and how to release it, then exits.
+func acquireProjectLock(cfg *config.Config, projectName string) (*database.LockManager, *database.LockInfo) {
+	lockManager := database.NewLockManager(cfg.DataDir+"/locks", cfg.LockTimeout)
+
+	wait := time.Duration(cfg.LockTimeout) * time.Second
+	if lockWait > 0 {
+		wait = lockWait
+	}
+
+	// Ctrl+C aborts the wait; once the lock is held it stops the command as usual
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	lockInfo, err := lockManager.AcquireLockContext(ctx, projectName)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			exitWithError(codeCancelled, "Interrupted while waiting for the lock of project %s", projectName)
+		}
+		var held *database.LockHeldError
+		if errors.As(err, &held) {
+			exitWithError(codeLockTimeout, "Error acquiring project lock: %v\nLock holder: %s\nRetry with a longer --wait, or if that process is stuck run 'quicktodo locks --release %s'",
+				err, describeLockHolder(held.Holder), projectName)
+		}
+		exitWithError(codeStorageError, "Error acquiring project lock: %v", err)
+	}
+
+	return lockManager, lockInfo
+}
+
+// acquireRegistryLock takes the registry file lock for commands that load,
+// change and save the project registry, waiting up to the configured lock
+// timeout, and exits when it can't
+func acquireRegistryLock(cfg *config.Config) (*database.LockManager, *database.LockInfo) {
+	lockManager, lockInfo, err := database.LockRegistry(cfg.GetProjectsPath(), time.Duration(cfg.LockTimeout)*time.Second)
+	if err != nil {
+		exitWithError(codeLockTimeout, "Error acquiring the project registry lock: %v", err)
+	}
+	return lockManager, lockInfo
+}
+
+// describeLockHolder summarizes a lock holder's PID, host, lock age and
+// command
+func describeLockHolder(holder *database.LockInfo) string {
+	if holder.CreatedAt.IsZero() {
+		return "unreadable lock file"
+	}
+
+	description := fmt.Sprintf("PID %d", holder.ProcessID)
+	if holder.Hostname != "" {
+		description += " on " + holder.Hostname
+	}
+	description += fmt.Sprintf(", held for %s", time.Since(holder.CreatedAt).Round(time.Second))
+	if command := holder.Describe(); command != "" {
+		description += ": " + command
+	}
+	return description
+}
+
+func lockHolderJSON(holder *database.LockInfo) map[string]interface{} {
+	entry := map[string]interface{}{
+		"pid":      holder.ProcessID,
+		"hostname": holder.Hostname,
+		"command":  holder.Command,
+		"work_dir": holder.WorkDir,
+	}
+	if !holder.CreatedAt.IsZero() {
+		entry["created_at"] = holder.CreatedAt.UTC()
+		entry["age_seconds"] = int(time.Since(holder.CreatedAt).Seconds())
+	}
+	return entry
+}
+
+func printLocksJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	locksCmd.Flags().StringVar(&locksRelease, "release", "", "Forcibly release the lock held on the named project")
+	locksCmd.Flags().BoolVar(&locksClean, "clean", false, "Remove stale locks and locks whose process has exited")
+
+	RootCmd.AddCommand(locksCmd)
+}
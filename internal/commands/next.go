@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// nextCmd represents the next command
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the task to work on next",
+	Long: `Pick the single best task to work on next in the current project: the
+highest-priority pending task, oldest first among equals. Tasks waiting on
+unfinished dependencies, before their start date, or assigned to someone else
+are skipped.
+
+With --agent-id, the agent's own tasks are considered along with unassigned
+ones; without it only unassigned tasks are. Use set-task-status or start to
+pick the task up.
+
+With --json, task is null when nothing is actionable.
+
+Examples:
+  quicktodo next
+  quicktodo next --agent-id claude --json`,
+	Args: cobra.NoArgs,
+	Run:  runNext,
+}
+
+func runNext(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Load project database
+	projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectInfo.Name))
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	task := projectDB.NextActionable(agentID, time.Now().UTC())
+
+	// Output result
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":    true,
+			"project":    projectInfo.Name,
+			"actionable": task != nil,
+			"task":       task,
+		}
+		if task == nil {
+			output["message"] = "nothing actionable"
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	if task == nil {
+		fmt.Println("Nothing actionable: no pending task is unblocked and free to pick up")
+		return
+	}
+
+	fmt.Println("👉 Next task:")
+	displayTask(task)
+}
+
+func init() {
+	RootCmd.AddCommand(nextCmd)
+}
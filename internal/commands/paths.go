@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+
+	"github.com/spf13/cobra"
+)
+
+// pathInfo is a resolved location and whether anything exists there yet
+type pathInfo struct {
+	Path   string `json:"path"`
+	Exists bool   `json:"exists"`
+}
+
+// projectPaths are the locations belonging to the current directory's project
+type projectPaths struct {
+	Name      string   `json:"name"`
+	Directory string   `json:"directory"`
+	Database  pathInfo `json:"database"`
+	Lock      pathInfo `json:"lock"`
+	Backups   pathInfo `json:"backups"`
+	Archive   pathInfo `json:"archive"`
+	Ops       pathInfo `json:"ops"`
+}
+
+// resolvedPaths is everything the paths command reports
+type resolvedPaths struct {
+	Config        pathInfo      `json:"config"`
+	DataDir       pathInfo      `json:"data_dir"`
+	DataDirSource string        `json:"data_dir_source"`
+	Registry      pathInfo      `json:"registry"`
+	ProjectsDir   pathInfo      `json:"projects_dir"`
+	LocksDir      pathInfo      `json:"locks_dir"`
+	Project       *projectPaths `json:"project"`
+}
+
+// pathsCmd represents the paths command
+var pathsCmd = &cobra.Command{
+	Use:     "paths",
+	Aliases: []string{"env"},
+	Short:   "Show where QuickTodo keeps its configuration and data",
+	Long: `Show the resolved locations of the configuration file, data directory,
+project registry and lock directory, and whether each exists.
+
+The configuration file is read from ~/.config/quicktodo/config.json, or from
+the file QUICKTODO_CONFIG names; its data_dir setting decides where everything
+else lives, unless QUICKTODO_DATA_DIR overrides it. When run inside a
+registered project the project's database, lock file, backup directory and
+archive file are shown as well.
+
+Examples:
+  quicktodo paths
+  quicktodo env --json`,
+	Args: cobra.NoArgs,
+	Run:  runPaths,
+}
+
+func runPaths(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	paths := resolvedPaths{
+		Config:        statPath(config.GetConfigPath()),
+		DataDir:       statPath(cfg.DataDir),
+		DataDirSource: "default",
+		Registry:      statPath(cfg.GetProjectsPath()),
+		ProjectsDir:   statPath(cfg.GetProjectsDir()),
+		LocksDir:      statPath(cfg.GetLocksDir()),
+	}
+	if os.Getenv(config.EnvDataDir) != "" {
+		paths.DataDirSource = "env"
+	} else if cfg.DataDir != config.DefaultConfig().DataDir {
+		paths.DataDirSource = "config"
+	}
+
+	// Registry problems are left to the commands that need it; the paths
+	// are still worth reporting
+	if registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath()); err == nil {
+		if projectInfo, exists := registry.GetProjectByPath(currentDir); exists {
+			paths.Project = &projectPaths{
+				Name:      projectInfo.Name,
+				Directory: projectInfo.Path,
+				Database:  statPath(cfg.GetProjectDatabasePath(projectInfo.Name)),
+				Lock:      statPath(cfg.GetProjectLockPath(projectInfo.Name)),
+				Backups:   statPath(database.BackupDir(cfg.DataDir, projectInfo.Name)),
+				Archive:   statPath(cfg.GetProjectArchivePath(projectInfo.Name)),
+				Ops:       statPath(cfg.GetProjectOpsPath(projectInfo.Name)),
+			}
+		}
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success": true,
+			"paths":   paths,
+		}
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	printPath("Config file", paths.Config)
+	source := "default"
+	switch paths.DataDirSource {
+	case "config":
+		source = "data_dir in config file"
+	case "env":
+		source = config.EnvDataDir
+	}
+	fmt.Printf("%-14s %s%s (%s)\n", "Data dir:", paths.DataDir.Path, missingSuffix(paths.DataDir), source)
+	printPath("Registry", paths.Registry)
+	printPath("Projects dir", paths.ProjectsDir)
+	printPath("Locks dir", paths.LocksDir)
+
+	fmt.Println()
+	if paths.Project == nil {
+		fmt.Println("Current directory is not a registered project")
+		return
+	}
+	fmt.Printf("Project %s (%s):\n", paths.Project.Name, paths.Project.Directory)
+	printPath("  Database", paths.Project.Database)
+	printPath("  Lock file", paths.Project.Lock)
+	printPath("  Backups", paths.Project.Backups)
+	printPath("  Archive", paths.Project.Archive)
+	printPath("  Ops log", paths.Project.Ops)
+}
+
+// statPath resolves whether a path exists
+func statPath(path string) pathInfo {
+	_, err := os.Stat(path)
+	return pathInfo{Path: path, Exists: err == nil}
+}
+
+func printPath(label string, info pathInfo) {
+	fmt.Printf("%-14s %s%s\n", label+":", info.Path, missingSuffix(info))
+}
+
+func missingSuffix(info pathInfo) string {
+	if info.Exists {
+		return ""
+	}
+	return " (does not exist)"
+}
+
+func init() {
+	RootCmd.AddCommand(pathsCmd)
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"quicktodo/internal/config"
+	"text/template"
+)
+
+// projectDocFileName is the agent instructions file generated by init
+const projectDocFileName = "QUICKTODO.md"
+
+// projectDocTemplateName is the optional user template in the data dir that
+// replaces the built-in QUICKTODO.md content
+const projectDocTemplateName = "QUICKTODO.md.tmpl"
+
+//go:embed templates/QUICKTODO.md.tmpl
+var defaultProjectDocTemplate string
+
+// projectDocData is the data available to QUICKTODO.md templates
+type projectDocData struct {
+	ProjectName string
+	DataDir     string
+}
+
+// renderProjectDoc renders the project doc from <data_dir>/QUICKTODO.md.tmpl
+// when present, falling back to the built-in template
+func renderProjectDoc(cfg *config.Config, projectName string) ([]byte, error) {
+	name := "default"
+	text := defaultProjectDocTemplate
+
+	templatePath := filepath.Join(cfg.DataDir, projectDocTemplateName)
+	if data, err := os.ReadFile(templatePath); err == nil {
+		name, text = templatePath, string(data)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", templatePath, err)
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, projectDocData{ProjectName: projectName, DataDir: cfg.DataDir}); err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeProjectDoc generates QUICKTODO.md in projectDir. An existing file is
+// left untouched, since it may have been edited by hand; the returned bool
+// reports whether a file was written.
+func writeProjectDoc(cfg *config.Config, projectDir, projectName string) (string, bool, error) {
+	docPath := filepath.Join(projectDir, projectDocFileName)
+	if _, err := os.Stat(docPath); err == nil {
+		return docPath, false, nil
+	}
+
+	content, err := renderProjectDoc(cfg, projectName)
+	if err != nil {
+		return docPath, false, err
+	}
+
+	if err := os.WriteFile(docPath, content, 0644); err != nil {
+		return docPath, false, fmt.Errorf("failed to write %s: %w", docPath, err)
+	}
+
+	return docPath, true, nil
+}
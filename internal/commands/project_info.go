@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	addDefaultTags    []string
+	removeDefaultTags []string
+)
+
+// projectInfoCmd represents the project-info command
+var projectInfoCmd = &cobra.Command{
+	Use:   "project-info",
+	Short: "Show the current project and manage its default tags",
+	Long: `Show the current project's name, directory, description, number of tasks and
+default tags.
+
+Default tags are added to every task created in the project, by create-task
+and through the web interface, so a repository where everything is a
+"backend" task doesn't need each task tagged by hand. Add them with
+--add-default-tag and remove them with --remove-default-tag; both can be
+repeated. create-task --no-default-tags skips them for one task.
+
+Examples:
+  quicktodo project-info
+  quicktodo project-info --add-default-tag backend
+  quicktodo project-info --remove-default-tag backend --json`,
+	Args: cobra.NoArgs,
+	Run:  runProjectInfo,
+}
+
+func runProjectInfo(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	add := normalizeTags(addDefaultTags)
+	remove := normalizeTags(removeDefaultTags)
+
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	if len(add) == 0 && len(remove) == 0 {
+		projectDB, err := loadProjectDatabase(cfg, dbPath)
+		if err != nil {
+			exitWithError(codeStorageError, "Error loading project database: %v", err)
+		}
+		outputProjectInfo(projectDB)
+		return
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	changed := false
+	for _, tag := range remove {
+		if projectDB.Project.RemoveDefaultTag(tag) {
+			changed = true
+		} else if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: '%s' is not a default tag\n", tag)
+		}
+	}
+	for _, tag := range add {
+		if projectDB.Project.AddDefaultTag(tag) {
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+			exitWithError(codeStorageError, "Error saving project database: %v", err)
+		}
+	}
+
+	outputProjectInfo(projectDB)
+}
+
+func outputProjectInfo(projectDB *models.ProjectDatabase) {
+	project := projectDB.Project
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":      true,
+			"project":      project,
+			"task_count":   len(projectDB.Tasks),
+			"default_tags": append([]string{}, project.DefaultTags...),
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("Project: %s\n", project.Name)
+	fmt.Printf("Path: %s\n", project.Path)
+	if project.Description != "" {
+		fmt.Printf("Description: %s\n", project.Description)
+	}
+	fmt.Printf("Created: %s\n", project.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Tasks: %d\n", len(projectDB.Tasks))
+	if len(project.DefaultTags) > 0 {
+		fmt.Printf("Default tags: %s\n", strings.Join(project.DefaultTags, ", "))
+	} else {
+		fmt.Println("Default tags: none")
+	}
+}
+
+func init() {
+	projectInfoCmd.Flags().StringArrayVar(&addDefaultTags, "add-default-tag", nil, "Add a tag to every new task in the project (repeatable)")
+	projectInfoCmd.Flags().StringArrayVar(&removeDefaultTags, "remove-default-tag", nil, "Stop adding a tag to new tasks (repeatable)")
+
+	RootCmd.AddCommand(projectInfoCmd)
+}
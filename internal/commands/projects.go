@@ -0,0 +1,241 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	pruneDryRun     bool
+	pruneDeleteData bool
+)
+
+// prunedProject reports one project removed, or to be removed, by prune-empty
+type prunedProject struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	DataDeleted bool   `json:"data_deleted"`
+}
+
+// projectsCmd groups the project maintenance subcommands
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Manage registered projects",
+	Long: `Maintenance commands for the projects in the registry.
+
+Examples:
+  quicktodo projects prune-empty --dry-run
+  quicktodo projects prune-empty --delete-data`,
+}
+
+var projectsPruneEmptyCmd = &cobra.Command{
+	Use:   "prune-empty",
+	Short: "Unregister projects that have no tasks",
+	Long: `Remove registered projects whose database holds no tasks, such as throwaway
+projects left over from experiments. Projects with archived tasks are kept, as
+are projects whose database can't be read.
+
+The project databases are left on disk unless --delete-data is given, which
+also deletes their archive and backups. The project directories themselves are
+never touched.
+
+You are asked to confirm before anything is removed; --assume-yes skips the
+question. --dry-run lists the projects that would be removed.
+
+Examples:
+  quicktodo projects prune-empty --dry-run
+  quicktodo projects prune-empty
+  quicktodo projects prune-empty --delete-data --assume-yes --json`,
+	Args: cobra.NoArgs,
+	Run:  runProjectsPruneEmpty,
+}
+
+func runProjectsPruneEmpty(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Load project registry
+	registryPath := cfg.GetProjectsPath()
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	var names []string
+	for name := range registry.ListProjects() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var candidates []string
+	for _, name := range names {
+		empty, err := projectIsEmpty(cfg, name)
+		if err != nil {
+			if verbose {
+				fmt.Fprintf(os.Stderr, "Warning: skipping project %s: %v\n", name, err)
+			}
+			continue
+		}
+		if empty {
+			candidates = append(candidates, name)
+		}
+	}
+
+	pruned := []prunedProject{}
+	if pruneDryRun || len(candidates) == 0 {
+		for _, name := range candidates {
+			info, _ := registry.GetProjectByName(name)
+			pruned = append(pruned, prunedProject{Name: name, Path: info.Path})
+		}
+		outputPruneResult(pruned)
+		return
+	}
+
+	question := fmt.Sprintf("Unregister %d empty project(s)?", len(candidates))
+	if pruneDeleteData {
+		question = fmt.Sprintf("Unregister %d empty project(s) and delete their data?", len(candidates))
+	}
+	if !confirm(question) {
+		exitWithError(codeCancelled, "No projects removed")
+	}
+
+	for _, name := range candidates {
+		if result, ok := pruneEmptyProject(cfg, registry, name); ok {
+			pruned = append(pruned, result)
+		}
+	}
+
+	if len(pruned) > 0 {
+		if err := registry.Save(registryPath); err != nil {
+			exitWithError(codeStorageError, "Error saving project registry: %v", err)
+		}
+	}
+
+	outputPruneResult(pruned)
+}
+
+// projectIsEmpty reports whether a project has no tasks, archived or not
+func projectIsEmpty(cfg *config.Config, projectName string) (bool, error) {
+	projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectName))
+	if err != nil {
+		return false, err
+	}
+	if len(projectDB.Tasks) > 0 {
+		return false, nil
+	}
+
+	archive, err := database.LoadTaskArchive(cfg.GetProjectArchivePath(projectName), projectName)
+	if err != nil {
+		return false, err
+	}
+	return len(archive.Tasks) == 0, nil
+}
+
+// pruneEmptyProject unregisters a project, and with --delete-data deletes its
+// files, under the project lock. It checks again that the project is empty in
+// case a task was added since it was listed.
+func pruneEmptyProject(cfg *config.Config, registry *database.ProjectRegistry, projectName string) (prunedProject, bool) {
+	lockManager, lockInfo := acquireProjectLock(cfg, projectName)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	empty, err := projectIsEmpty(cfg, projectName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: skipping project %s: %v\n", projectName, err)
+		return prunedProject{}, false
+	}
+	if !empty {
+		fmt.Fprintf(os.Stderr, "Warning: project %s is no longer empty, keeping it\n", projectName)
+		return prunedProject{}, false
+	}
+
+	info, _ := registry.GetProjectByName(projectName)
+	result := prunedProject{Name: projectName, Path: info.Path}
+	if err := registry.RemoveProject(projectName); err != nil {
+		exitWithError(codeStorageError, "Error removing project %s: %v", projectName, err)
+	}
+
+	if pruneDeleteData {
+		if err := deleteProjectData(cfg, projectName); err != nil {
+			exitWithError(codeStorageError, "Error: %v", err)
+		}
+		result.DataDeleted = true
+	}
+
+	return result, true
+}
+
+// deleteProjectData deletes a project's database, archive, backups and
+// operation log. The project directory itself is never touched.
+func deleteProjectData(cfg *config.Config, projectName string) error {
+	for _, path := range []string{
+		cfg.GetProjectDatabasePath(projectName),
+		cfg.GetProjectArchivePath(projectName),
+		cfg.GetProjectOpsPath(projectName),
+		database.BackupDir(cfg.DataDir, projectName),
+	} {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func outputPruneResult(pruned []prunedProject) {
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":  true,
+			"dry_run":  pruneDryRun,
+			"projects": pruned,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(pruned) == 0 {
+		fmt.Println("No empty projects found")
+		return
+	}
+
+	for _, project := range pruned {
+		switch {
+		case pruneDryRun:
+			fmt.Printf("Would remove: %s (%s)\n", project.Name, project.Path)
+		case project.DataDeleted:
+			fmt.Printf("🗑️  Removed %s (%s) and deleted its data\n", project.Name, project.Path)
+		default:
+			fmt.Printf("🗑️  Removed %s (%s)\n", project.Name, project.Path)
+		}
+	}
+	if pruneDryRun {
+		fmt.Printf("%d empty project(s) would be removed\n", len(pruned))
+		return
+	}
+	fmt.Printf("Removed %d empty project(s)\n", len(pruned))
+}
+
+func init() {
+	projectsPruneEmptyCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "List the empty projects without removing them")
+	projectsPruneEmptyCmd.Flags().BoolVar(&pruneDeleteData, "delete-data", false, "Also delete the database, archive and backups of removed projects")
+
+	projectsCmd.AddCommand(projectsPruneEmptyCmd)
+
+	RootCmd.AddCommand(projectsCmd)
+}
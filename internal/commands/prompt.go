@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// assumeYesEnv is the environment variable equivalent of --assume-yes
+const assumeYesEnv = "QUICKTODO_ASSUME_YES"
+
+// nonInteractive reports whether prompts should be answered yes without
+// reading input, because of --assume-yes or QUICKTODO_ASSUME_YES
+func nonInteractive() bool {
+	return assumeYes || envEnabled(os.Getenv(assumeYesEnv))
+}
+
+// envEnabled reports whether an environment variable value switches a
+// setting on: 1, true or yes in any case
+func envEnabled(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		return enabled
+	}
+	return value == "yes" || value == "y"
+}
+
+// confirm asks a yes/no question on stderr and reads the answer from stdin;
+// anything but y or yes is a no. In non-interactive mode the question is
+// answered yes without reading stdin.
+func confirm(question string) bool {
+	if nonInteractive() {
+		fmt.Fprintf(os.Stderr, "%s [y/N]: y (assumed)\n", question)
+		return true
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
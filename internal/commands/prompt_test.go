@@ -0,0 +1,34 @@
+package commands
+
+import "testing"
+
+func TestEnvEnabled(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"1", true},
+		{"true", true},
+		{"TRUE", true},
+		{"yes", true},
+		{" y ", true},
+		{"0", false},
+		{"false", false},
+		{"no", false},
+		{"maybe", false},
+	}
+
+	for _, tt := range tests {
+		if got := envEnabled(tt.value); got != tt.expected {
+			t.Errorf("envEnabled(%q): expected %v, got %v", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestConfirmAssumeYes(t *testing.T) {
+	t.Setenv(assumeYesEnv, "1")
+	if !confirm("Proceed?") {
+		t.Error("Expected confirm to answer yes without reading input")
+	}
+}
@@ -0,0 +1,286 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var removeProjectPurge bool
+
+// registeredProject is one line of list-projects. LastAccessed is when the
+// project was last used: the later of its registry entry's last access and
+// its database's last save.
+type registeredProject struct {
+	Name            string    `json:"name"`
+	Path            string    `json:"path"`
+	TaskCount       int       `json:"task_count"`
+	LastAccessed    time.Time `json:"last_accessed"`
+	LastAccessedAge string    `json:"last_accessed_age"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// listProjectsCmd represents the list-projects command
+var listProjectsCmd = &cobra.Command{
+	Use:   "list-projects",
+	Short: "List registered projects",
+	Long: `List every registered project with its path, task count and when it was last
+used, most recently used first. Projects whose database can't be read are
+listed with the error instead of a task count.
+
+Examples:
+  quicktodo list-projects
+  quicktodo list-projects --json`,
+	Args: cobra.NoArgs,
+	Run:  runListProjects,
+}
+
+// removeProjectCmd represents the remove-project command
+var removeProjectCmd = &cobra.Command{
+	Use:   "remove-project <name>",
+	Short: "Unregister a project",
+	Long: `Remove a project from the registry. Its database is kept on disk unless
+--purge is given, which also deletes its archive and backups after asking for
+confirmation (--assume-yes skips the question). The project directory itself
+is never touched.
+
+A project that is locked by another command can't be removed; see 'locks'.
+
+Examples:
+  quicktodo remove-project old-experiment
+  quicktodo remove-project old-experiment --purge --assume-yes`,
+	Args: cobra.ExactArgs(1),
+	Run:  runRemoveProject,
+}
+
+// cleanupProjectsCmd represents the cleanup-projects command
+var cleanupProjectsCmd = &cobra.Command{
+	Use:   "cleanup-projects",
+	Short: "Unregister projects whose directory no longer exists",
+	Long: `Remove the registrations of projects whose directory has been deleted or
+moved. Their databases are kept on disk; use 'projects prune-empty' to remove
+projects without tasks instead.
+
+Examples:
+  quicktodo cleanup-projects
+  quicktodo cleanup-projects --json`,
+	Args: cobra.NoArgs,
+	Run:  runCleanupProjects,
+}
+
+func runListProjects(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	projects := []*registeredProject{}
+	for _, info := range registry.ListProjects() {
+		projects = append(projects, describeRegisteredProject(cfg, info))
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if !projects[i].LastAccessed.Equal(projects[j].LastAccessed) {
+			return projects[i].LastAccessed.After(projects[j].LastAccessed)
+		}
+		return projects[i].Name < projects[j].Name
+	})
+
+	if jsonOutput {
+		outputRegistryJSON(map[string]interface{}{
+			"success":       true,
+			"project_count": len(projects),
+			"projects":      projects,
+		})
+		return
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("No registered projects")
+		fmt.Println("Run 'quicktodo initialize-project' in a project directory to register one")
+		return
+	}
+
+	fmt.Printf("Registered projects (%d):\n", len(projects))
+	for _, project := range projects {
+		tasks := fmt.Sprintf("%d task(s)", project.TaskCount)
+		if project.Error != "" {
+			tasks = "unreadable"
+		}
+		fmt.Printf("  %-20s %-12s %-16s %s\n", project.Name, tasks, project.LastAccessedAge, project.Path)
+		if project.Error != "" && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: project %s: %s\n", project.Name, project.Error)
+		}
+	}
+}
+
+// describeRegisteredProject loads a project's database for its task count
+// and last use
+func describeRegisteredProject(cfg *config.Config, info *database.ProjectInfo) *registeredProject {
+	project := &models.Project{Name: info.Name, Path: info.Path, LastAccessed: info.LastAccessed}
+	result := &registeredProject{Name: info.Name, Path: info.Path}
+
+	projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(info.Name))
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.TaskCount = len(projectDB.Tasks)
+		if projectDB.LastModified.After(project.LastAccessed) {
+			project.LastAccessed = projectDB.LastModified
+		}
+	}
+
+	result.LastAccessed = project.LastAccessed
+	result.LastAccessedAge = project.GetLastAccessedAge()
+	return result
+}
+
+func runRemoveProject(cmd *cobra.Command, args []string) {
+	projectName := args[0]
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Load project registry
+	registryPath := cfg.GetProjectsPath()
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	info, exists := registry.GetProjectByName(projectName)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: project '%s' is not registered\nRun 'quicktodo list-projects' to see registered projects", projectName)
+	}
+
+	// Don't wait for the lock: a project in use is not one to remove
+	lockManager := database.NewLockManager(cfg.DataDir+"/locks", cfg.LockTimeout)
+	lockInfo, err := lockManager.AcquireLockWithin(projectName, 0)
+	if err != nil {
+		var held *database.LockHeldError
+		if errors.As(err, &held) {
+			exitWithError(codeLockTimeout, "Error: project %s is locked and can't be removed\nLock holder: %s", projectName, describeLockHolder(held.Holder))
+		}
+		exitWithError(codeLockTimeout, "Error acquiring project lock: %v", err)
+	}
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	if removeProjectPurge && !confirm(fmt.Sprintf("Unregister %s and delete its tasks, archive and backups?", projectName)) {
+		exitWithError(codeCancelled, "Project not removed")
+	}
+
+	if err := registry.RemoveProject(projectName); err != nil {
+		exitWithError(codeStorageError, "Error removing project: %v", err)
+	}
+	if err := registry.Save(registryPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project registry: %v", err)
+	}
+
+	if removeProjectPurge {
+		if err := deleteProjectData(cfg, projectName); err != nil {
+			exitWithError(codeStorageError, "Error: %v", err)
+		}
+	}
+
+	if jsonOutput {
+		outputRegistryJSON(map[string]interface{}{
+			"success": true,
+			"project": prunedProject{Name: projectName, Path: info.Path, DataDeleted: removeProjectPurge},
+		})
+		return
+	}
+
+	if removeProjectPurge {
+		fmt.Printf("🗑️  Removed %s (%s) and deleted its data\n", projectName, info.Path)
+		return
+	}
+	fmt.Printf("🗑️  Removed %s (%s)\n", projectName, info.Path)
+	fmt.Printf("Its database is kept at %s\n", cfg.GetProjectDatabasePath(projectName))
+}
+
+func runCleanupProjects(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Load project registry
+	registryLock, registryLockInfo := acquireRegistryLock(cfg)
+	defer registryLock.ReleaseLock(registryLockInfo)
+	registryPath := cfg.GetProjectsPath()
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	removed, err := registry.Cleanup()
+	if err != nil {
+		exitWithError(codeStorageError, "Error cleaning up projects: %v", err)
+	}
+	sort.Strings(removed)
+
+	if len(removed) > 0 {
+		if err := registry.Save(registryPath); err != nil {
+			exitWithError(codeStorageError, "Error saving project registry: %v", err)
+		}
+	}
+
+	if jsonOutput {
+		if removed == nil {
+			removed = []string{}
+		}
+		outputRegistryJSON(map[string]interface{}{
+			"success": true,
+			"removed": removed,
+		})
+		return
+	}
+
+	if len(removed) == 0 {
+		fmt.Println("Every registered project directory exists; nothing to clean up")
+		return
+	}
+	for _, name := range removed {
+		fmt.Printf("🗑️  Removed %s\n", name)
+	}
+	fmt.Printf("Removed %d project(s) whose directory no longer exists\n", len(removed))
+}
+
+func outputRegistryJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	removeProjectCmd.Flags().BoolVar(&removeProjectPurge, "purge", false, "Also delete the project's database, archive and backups")
+
+	RootCmd.AddCommand(listProjectsCmd)
+	RootCmd.AddCommand(removeProjectCmd)
+	RootCmd.AddCommand(cleanupProjectsCmd)
+}
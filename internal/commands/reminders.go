@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/hooks"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	remindersWithin time.Duration
+	remindersDryRun bool
+)
+
+// reminderResult reports one task that is due for a reminder
+type reminderResult struct {
+	Project string    `json:"project"`
+	TaskID  int       `json:"task_id"`
+	Title   string    `json:"title"`
+	DueDate time.Time `json:"due_date"`
+	Overdue bool      `json:"overdue"`
+	Sent    bool      `json:"sent"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// remindersCmd represents the reminders command
+var remindersCmd = &cobra.Command{
+	Use:   "reminders",
+	Short: "Send reminders for tasks that are due soon or overdue",
+	Long: `Scan every registered project for open tasks that are overdue or due within
+--within, and run the task_due hook once for each of them. Meant to be run
+periodically, e.g. from cron.
+
+The hook is configured like the other lifecycle hooks, under "task_due" in the
+config's "hooks" map, and gets the task JSON on stdin; use curl in it to call a
+webhook. A task is reminded only once per due date: the time of the reminder is
+saved as reminded_at, and changing the due date clears it. Failed hooks are
+retried on the next run.
+
+With --dry-run, the tasks that would be reminded are listed and nothing is run
+or saved.
+
+Examples:
+  quicktodo reminders
+  quicktodo reminders --within 72h
+  quicktodo reminders --dry-run --json`,
+	Args: cobra.NoArgs,
+	Run:  runReminders,
+}
+
+func runReminders(cmd *cobra.Command, args []string) {
+	if remindersWithin < 0 {
+		exitWithError(codeInvalidArgument, "Error: --within cannot be negative")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	runner := hooks.NewRunner(cfg.Hooks)
+	if !remindersDryRun {
+		if noHooks {
+			exitWithError(codeInvalidArgument, "Error: reminders are sent through hooks, which --no-hooks disables")
+		}
+		if !runner.HasHook(hooks.EventTaskDue) {
+			exitWithError(codeConfigError, "Error: no %s hook is configured\nAdd a command for \"%s\" to the \"hooks\" map in %s", hooks.EventTaskDue, hooks.EventTaskDue, config.GetConfigPath())
+		}
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	var names []string
+	for name := range registry.ListProjects() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now().UTC()
+	results := []*reminderResult{}
+	for _, name := range names {
+		results = append(results, sendProjectReminders(cfg, runner, name, now)...)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":   failed == 0,
+			"dry_run":   remindersDryRun,
+			"within":    remindersWithin.String(),
+			"reminders": results,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		outputRemindersHuman(results, now)
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// sendProjectReminders runs the task_due hook for the tasks of one project
+// that need a reminder and saves when they were reminded. Projects whose
+// database can't be loaded are skipped, as in assignments.
+func sendProjectReminders(cfg *config.Config, runner *hooks.Runner, projectName string, now time.Time) []*reminderResult {
+	var lockManager *database.LockManager
+	var lockInfo *database.LockInfo
+	if !remindersDryRun {
+		lockManager, lockInfo = acquireProjectLock(cfg, projectName)
+		defer func() {
+			if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+				fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+			}
+		}()
+	}
+
+	dbPath := cfg.GetProjectDatabasePath(projectName)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: skipping project %s: %v\n", projectName, err)
+		}
+		return nil
+	}
+
+	var results []*reminderResult
+	sent := 0
+	for _, task := range projectDB.GetTasksNeedingReminder(now, remindersWithin) {
+		result := &reminderResult{
+			Project: projectName,
+			TaskID:  task.ID,
+			Title:   task.Title,
+			DueDate: *task.DueDate,
+			Overdue: task.IsOverdue(now),
+		}
+		results = append(results, result)
+		if remindersDryRun {
+			continue
+		}
+
+		if err := runner.Run(hooks.EventTaskDue, task, projectName); err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		task.MarkReminded(now)
+		result.Sent = true
+		sent++
+	}
+
+	if sent > 0 {
+		if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+			// The hooks already ran; say so, as they will run again next time
+			exitWithError(codeStorageError, "Error saving project database for %s: %v\n%d reminder(s) were sent but not recorded and will be sent again", projectName, err, sent)
+		}
+	}
+
+	return results
+}
+
+func outputRemindersHuman(results []*reminderResult, now time.Time) {
+	if len(results) == 0 {
+		fmt.Printf("No tasks due within %s need a reminder\n", formatDuration(remindersWithin))
+		return
+	}
+
+	sent := 0
+	for _, result := range results {
+		when := "due in " + formatDuration(result.DueDate.Sub(now))
+		if result.Overdue {
+			when = "overdue by " + formatDuration(now.Sub(result.DueDate))
+		}
+
+		switch {
+		case remindersDryRun:
+			fmt.Printf("Would remind: %s #%d %s (%s)\n", result.Project, result.TaskID, result.Title, when)
+		case result.Sent:
+			fmt.Printf("⏰ Reminded: %s #%d %s (%s)\n", result.Project, result.TaskID, result.Title, when)
+			sent++
+		default:
+			fmt.Fprintf(os.Stderr, "Error: reminder for %s #%d failed: %s\n", result.Project, result.TaskID, result.Error)
+		}
+	}
+
+	if remindersDryRun {
+		fmt.Printf("%d reminder(s) would be sent\n", len(results))
+		return
+	}
+	fmt.Printf("Sent %d of %d reminder(s)\n", sent, len(results))
+}
+
+func init() {
+	remindersCmd.Flags().DurationVar(&remindersWithin, "within", 24*time.Hour, "Remind about tasks due within this long from now, e.g. 72h")
+	remindersCmd.Flags().BoolVar(&remindersDryRun, "dry-run", false, "List the reminders that would be sent without running hooks or saving")
+
+	RootCmd.AddCommand(remindersCmd)
+}
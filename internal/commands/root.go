@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
@@ -8,6 +10,10 @@ var (
 	verbose    bool
 	agentID    string
 	jsonOutput bool
+	noHooks    bool
+	noSync     bool
+	lockWait   time.Duration
+	assumeYes  bool
 )
 
 // RootCmd represents the base command when called without any subcommands
@@ -17,7 +23,15 @@ var RootCmd = &cobra.Command{
 	Long: `QuickTodo is a lightweight, fast, and AI-friendly CLI tool for managing tasks across multiple projects.
 
 It provides file-based storage with concurrent access protection and comprehensive JSON output
-for seamless integration with AI agents and development workflows.`,
+for seamless integration with AI agents and development workflows.
+
+For CI and agent runs, pass --assume-yes or set QUICKTODO_ASSUME_YES=1 so that
+commands never wait for input: every confirmation prompt is answered yes.
+
+To keep a run isolated from ~/.config/quicktodo, set QUICKTODO_CONFIG to an
+alternate config file and QUICKTODO_DATA_DIR to an alternate data directory.
+QUICKTODO_DEFAULT_PRIORITY and QUICKTODO_LOCK_TIMEOUT (seconds) override those
+settings as well. Overrides are never written back to the config file.`,
 	Version: "1.0.0",
 }
 
@@ -26,6 +40,10 @@ func init() {
 	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
 	RootCmd.PersistentFlags().StringVar(&agentID, "agent-id", "", "Agent identifier for AI coordination")
 	RootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	RootCmd.PersistentFlags().BoolVar(&noHooks, "no-hooks", false, "Skip configured task lifecycle hooks")
+	RootCmd.PersistentFlags().BoolVar(&noSync, "no-sync", false, "Skip updating the AI TODO list even when sync is enabled")
+	RootCmd.PersistentFlags().BoolVarP(&assumeYes, "assume-yes", "y", false, "Answer yes to every prompt instead of waiting for input (or set QUICKTODO_ASSUME_YES=1); recommended for CI and agents")
+	RootCmd.PersistentFlags().DurationVar(&lockWait, "wait", 0, "How long to wait for a busy project lock, e.g. 2m (default: config lock_timeout)")
 	
 	// Disable completion command
 	RootCmd.CompletionOptions.DisableDefaultCmd = true
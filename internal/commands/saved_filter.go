@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"quicktodo/internal/config"
+	"quicktodo/internal/models"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	savedFilterStatus   string
+	savedFilterPriority string
+	savedFilterAssigned string
+)
+
+// savedFilterCmd groups the saved-filter subcommands
+var savedFilterCmd = &cobra.Command{
+	Use:   "saved-filter",
+	Short: "Manage named list-tasks filters",
+	Long: `Save frequently used list-tasks filters under a name and apply them
+with 'list-tasks --filter <name>'. Flags given on the command line override
+the values stored in the saved filter.
+
+Examples:
+  quicktodo saved-filter save mywork --status in_progress --assigned-to me
+  quicktodo saved-filter list
+  quicktodo saved-filter delete mywork
+  quicktodo list-tasks --filter mywork --priority high`,
+}
+
+var savedFilterSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save a named filter",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSavedFilterSave,
+}
+
+var savedFilterListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved filters",
+	Args:  cobra.NoArgs,
+	Run:   runSavedFilterList,
+}
+
+var savedFilterDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a saved filter",
+	Args:  cobra.ExactArgs(1),
+	Run:   runSavedFilterDelete,
+}
+
+func runSavedFilterSave(cmd *cobra.Command, args []string) {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		exitWithError(codeInvalidArgument, "Error: filter name cannot be empty")
+	}
+
+	filter := config.SavedFilter{
+		Status:     string(models.NormalizeStatus(savedFilterStatus)),
+		Priority:   string(models.NormalizePriority(savedFilterPriority)),
+		AssignedTo: savedFilterAssigned,
+	}
+	if err := validateSavedFilter(filter); err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	if cfg.SavedFilters == nil {
+		cfg.SavedFilters = make(map[string]config.SavedFilter)
+	}
+	cfg.SavedFilters[name] = filter
+
+	if err := cfg.Save(); err != nil {
+		exitWithError(codeConfigError, "Error saving configuration: %v", err)
+	}
+
+	if jsonOutput {
+		outputSavedFilterJSON(map[string]interface{}{
+			"success": true,
+			"name":    name,
+			"filter":  filter,
+		})
+		return
+	}
+
+	fmt.Printf("Saved filter '%s': %s\n", name, describeSavedFilter(filter))
+}
+
+func runSavedFilterList(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	names := make([]string, 0, len(cfg.SavedFilters))
+	for name := range cfg.SavedFilters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if jsonOutput {
+		filters := cfg.SavedFilters
+		if filters == nil {
+			filters = map[string]config.SavedFilter{}
+		}
+		outputSavedFilterJSON(map[string]interface{}{
+			"success":      true,
+			"filter_count": len(names),
+			"filters":      filters,
+		})
+		return
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No saved filters")
+		return
+	}
+
+	for _, name := range names {
+		fmt.Printf("%-20s %s\n", name, describeSavedFilter(cfg.SavedFilters[name]))
+	}
+}
+
+func runSavedFilterDelete(cmd *cobra.Command, args []string) {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	if _, ok := cfg.SavedFilters[name]; !ok {
+		exitWithError(codeNotFound, "Error: saved filter '%s' not found", name)
+	}
+	delete(cfg.SavedFilters, name)
+
+	if err := cfg.Save(); err != nil {
+		exitWithError(codeConfigError, "Error saving configuration: %v", err)
+	}
+
+	if jsonOutput {
+		outputSavedFilterJSON(map[string]interface{}{
+			"success": true,
+			"name":    name,
+			"deleted": true,
+		})
+		return
+	}
+
+	fmt.Printf("Deleted saved filter '%s'\n", name)
+}
+
+// validateSavedFilter checks that a filter has criteria and valid values
+func validateSavedFilter(filter config.SavedFilter) error {
+	if filter.IsEmpty() {
+		return fmt.Errorf("a saved filter needs at least one of --status, --priority or --assigned-to")
+	}
+	if filter.Status != "" && !models.IsValidStatus(filter.Status) {
+		return fmt.Errorf("invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", filter.Status)
+	}
+	if filter.Priority != "" && !models.IsValidPriority(filter.Priority) {
+		return fmt.Errorf("invalid priority '%s'. Valid priorities: low, medium, high, critical", filter.Priority)
+	}
+	return nil
+}
+
+// describeSavedFilter renders a filter as the equivalent list-tasks flags
+func describeSavedFilter(filter config.SavedFilter) string {
+	var parts []string
+	if filter.Status != "" {
+		parts = append(parts, "--status "+filter.Status)
+	}
+	if filter.Priority != "" {
+		parts = append(parts, "--priority "+filter.Priority)
+	}
+	if filter.AssignedTo != "" {
+		parts = append(parts, "--assigned-to "+filter.AssignedTo)
+	}
+	return strings.Join(parts, " ")
+}
+
+func outputSavedFilterJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	savedFilterSaveCmd.Flags().StringVar(&savedFilterStatus, "status", "", "Filter by status (pending, in_progress, done, cancelled)")
+	savedFilterSaveCmd.Flags().StringVar(&savedFilterPriority, "priority", "", "Filter by priority (low, medium, high, critical)")
+	savedFilterSaveCmd.Flags().StringVar(&savedFilterAssigned, "assigned-to", "", "Filter by assigned user/agent")
+
+	savedFilterCmd.AddCommand(savedFilterSaveCmd)
+	savedFilterCmd.AddCommand(savedFilterListCmd)
+	savedFilterCmd.AddCommand(savedFilterDeleteCmd)
+
+	RootCmd.AddCommand(savedFilterCmd)
+}
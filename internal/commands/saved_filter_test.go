@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"quicktodo/internal/config"
+	"testing"
+)
+
+func TestValidateSavedFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  config.SavedFilter
+		wantErr bool
+	}{
+		{"status and assignee", config.SavedFilter{Status: "in_progress", AssignedTo: "me"}, false},
+		{"priority only", config.SavedFilter{Priority: "high"}, false},
+		{"empty filter", config.SavedFilter{}, true},
+		{"invalid status", config.SavedFilter{Status: "blocked"}, true},
+		{"invalid priority", config.SavedFilter{Priority: "urgent"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSavedFilter(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDescribeSavedFilter(t *testing.T) {
+	got := describeSavedFilter(config.SavedFilter{Status: "in_progress", AssignedTo: "me"})
+	if got != "--status in_progress --assigned-to me" {
+		t.Errorf("Unexpected description: %q", got)
+	}
+}
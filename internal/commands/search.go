@@ -0,0 +1,240 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchAllProjects bool
+	searchMaxResults  int
+)
+
+// defaultSearchMaxResults keeps search output small enough for agent context windows
+const defaultSearchMaxResults = 100
+
+// searchTasksCmd represents the search-tasks command
+var searchTasksCmd = &cobra.Command{
+	Use:     "search-tasks <query>",
+	Aliases: []string{"search", "find"},
+	Short:   "Search tasks by title, tag, or description",
+	Long: `Search tasks in the current project, or in every registered project with
+--all-projects. Matching is case-insensitive; title matches rank above tag
+matches, which rank above description matches.
+
+Results are capped by --max-results (default 100) after ranking, so the best
+matches are kept. Use --max-results 0 to return everything. --status and
+--priority narrow the matches the same way they filter list-tasks.
+
+Matches are printed like list-tasks, with the matched text highlighted when
+writing to a terminal.
+
+Examples:
+  quicktodo search-tasks login
+  quicktodo find login --status pending --priority high
+  quicktodo search "auth" --all-projects
+  quicktodo search bug --all-projects --max-results 20 --json`,
+	Args: cobra.ExactArgs(1),
+	Run:  runSearchTasks,
+}
+
+// searchReport is the ranked, possibly truncated outcome of a search
+type searchReport struct {
+	Query        string                `json:"query"`
+	TotalMatches int                   `json:"total_matches"`
+	Truncated    bool                  `json:"truncated"`
+	Results      []models.SearchResult `json:"results"`
+}
+
+func runSearchTasks(cmd *cobra.Command, args []string) {
+	query := strings.TrimSpace(args[0])
+	if query == "" {
+		exitWithError(codeInvalidArgument, "Error: search query cannot be empty")
+	}
+
+	if searchMaxResults < 0 {
+		exitWithError(codeInvalidArgument, "Error: --max-results cannot be negative")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	var projects []*database.ProjectInfo
+	if searchAllProjects {
+		for _, projectInfo := range registry.ListProjects() {
+			projects = append(projects, projectInfo)
+		}
+		sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	} else {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			exitWithError(codeInternalError, "Error getting current directory: %v", err)
+		}
+
+		projectInfo, exists := registry.GetProjectByPath(currentDir)
+		if !exists {
+			exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first, or use --all-projects")
+		}
+		projects = append(projects, projectInfo)
+	}
+
+	filter := createTaskFilter()
+	report := searchProjects(cfg, projects, query, filter, searchMaxResults)
+
+	if jsonOutput {
+		tasks := make([]*models.Task, len(report.Results))
+		for i, result := range report.Results {
+			tasks[i] = result.Task
+		}
+
+		output := map[string]interface{}{
+			"success":       true,
+			"query":         report.Query,
+			"match_count":   len(report.Results),
+			"result_count":  len(report.Results),
+			"total_matches": report.TotalMatches,
+			"truncated":     report.Truncated,
+			"tasks":         tasks,
+			"results":       report.Results,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(report.Results) == 0 {
+		fmt.Printf("No tasks matching '%s'\n", query)
+		return
+	}
+
+	var highlight func(string) string
+	if stdoutIsTerminal() {
+		highlight = func(text string) string { return highlightMatches(text, query) }
+	}
+
+	fmt.Printf("Found %d task(s) matching '%s':\n\n", report.TotalMatches, query)
+	for _, result := range report.Results {
+		label := ""
+		if searchAllProjects {
+			label = "[" + result.Project + "] "
+		}
+		displayTaskHighlighted(result.Task, label, highlight)
+		fmt.Println()
+	}
+
+	if report.Truncated {
+		fmt.Printf("\nShowing the top %d of %d matches. Use --max-results to see more.\n", len(report.Results), report.TotalMatches)
+	}
+}
+
+// searchProjects searches the given projects for tasks matching filter (nil
+// for all tasks), ranks all matches together, and applies the result cap.
+// Projects whose database can't be loaded are skipped.
+func searchProjects(cfg *config.Config, projects []*database.ProjectInfo, query string, filter *models.TaskFilter, maxResults int) *searchReport {
+	results := make([]models.SearchResult, 0)
+
+	for _, projectInfo := range projects {
+		projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectInfo.Name))
+		if err != nil {
+			if verbose {
+				fmt.Fprintf(os.Stderr, "Warning: skipping project %s: %v\n", projectInfo.Name, err)
+			}
+			continue
+		}
+
+		for _, task := range projectDB.Tasks {
+			if filter != nil && !filter.Matches(task) {
+				continue
+			}
+			if score := models.ScoreTask(task, query); score > 0 {
+				results = append(results, models.SearchResult{
+					Project: projectInfo.Name,
+					Score:   score,
+					Task:    task.Clone(),
+				})
+			}
+		}
+	}
+
+	models.RankSearchResults(results)
+	truncated, wasTruncated := models.TruncateSearchResults(results, maxResults)
+
+	return &searchReport{
+		Query:        query,
+		TotalMatches: len(results),
+		Truncated:    wasTruncated,
+		Results:      truncated,
+	}
+}
+
+// highlightEscape and resetEscape mark matched text in terminal output
+const (
+	highlightEscape = "\033[1;33m"
+	resetEscape     = "\033[0m"
+)
+
+// highlightMatches wraps each case-insensitive occurrence of query in text
+// with terminal highlighting
+func highlightMatches(text, query string) string {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return text
+	}
+
+	lowerText, lowerQuery := strings.ToLower(text), strings.ToLower(query)
+	if len(lowerText) != len(text) {
+		// Lowercasing changed byte offsets; leave the text alone rather
+		// than risk splitting a character
+		return text
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(lowerText, lowerQuery)
+		if i < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		end := i + len(lowerQuery)
+		b.WriteString(text[:i])
+		b.WriteString(highlightEscape + text[i:end] + resetEscape)
+		text, lowerText = text[end:], lowerText[end:]
+	}
+}
+
+// stdoutIsTerminal reports whether standard output is an interactive terminal
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func init() {
+	searchTasksCmd.Flags().StringVarP(&statusFilter, "status", "s", "", "Only match tasks with this status (pending, in_progress, done, cancelled)")
+	searchTasksCmd.Flags().StringVarP(&priorityFilter, "priority", "p", "", "Only match tasks with this priority (low, medium, high, critical)")
+	searchTasksCmd.Flags().BoolVar(&searchAllProjects, "all-projects", false, "Search every registered project")
+	searchTasksCmd.Flags().IntVar(&searchMaxResults, "max-results", defaultSearchMaxResults, "Maximum number of results to return (0 for no limit)")
+
+	RootCmd.AddCommand(searchTasksCmd)
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"testing"
+)
+
+func TestSearchProjectsTruncatesAfterRanking(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	dbPath := cfg.GetProjectDatabasePath(projectName)
+
+	db, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		t.Fatalf("Failed to load project database: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		task := models.NewTaskWithDetails(db.NextID, fmt.Sprintf("Task %d", i), "mentions deploy", models.PriorityMedium)
+		db.AddTask(task)
+	}
+	db.AddTask(models.NewTask(db.NextID, "Deploy pipeline"))
+	if err := saveProjectDatabase(cfg, db, dbPath); err != nil {
+		t.Fatalf("Failed to save project database: %v", err)
+	}
+
+	projectInfo, _ := registry.GetProjectByName(projectName)
+	projects := []*database.ProjectInfo{projectInfo}
+
+	report := searchProjects(cfg, projects, "deploy", nil, 3)
+	if !report.Truncated {
+		t.Error("Expected truncation to be reported")
+	}
+	if report.TotalMatches != 6 || len(report.Results) != 3 {
+		t.Errorf("Expected 3 of 6 matches, got %d of %d", len(report.Results), report.TotalMatches)
+	}
+	if report.Results[0].Task.Title != "Deploy pipeline" {
+		t.Errorf("Expected the title match to survive truncation, got '%s'", report.Results[0].Task.Title)
+	}
+
+	report = searchProjects(cfg, projects, "deploy", nil, 10)
+	if report.Truncated || len(report.Results) != 6 {
+		t.Errorf("Expected all 6 results without truncation, got %d (truncated=%v)", len(report.Results), report.Truncated)
+	}
+}
+
+func TestSearchProjectsFilter(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	dbPath := cfg.GetProjectDatabasePath(projectName)
+
+	db, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		t.Fatalf("Failed to load project database: %v", err)
+	}
+	db.AddTask(models.NewTaskWithDetails(db.NextID, "Deploy staging", "", models.PriorityHigh))
+	db.AddTask(models.NewTaskWithDetails(db.NextID, "Deploy production", "", models.PriorityLow))
+	finished := models.NewTaskWithDetails(db.NextID, "Deploy docs", "", models.PriorityHigh)
+	finished.UpdateStatus(models.StatusDone)
+	db.AddTask(finished)
+	if err := saveProjectDatabase(cfg, db, dbPath); err != nil {
+		t.Fatalf("Failed to save project database: %v", err)
+	}
+
+	projectInfo, _ := registry.GetProjectByName(projectName)
+	projects := []*database.ProjectInfo{projectInfo}
+
+	high := models.PriorityHigh
+	report := searchProjects(cfg, projects, "deploy", &models.TaskFilter{Priority: &high}, 0)
+	if report.TotalMatches != 2 {
+		t.Errorf("Expected 2 high priority matches, got %d", report.TotalMatches)
+	}
+
+	pending := models.StatusPending
+	report = searchProjects(cfg, projects, "deploy", &models.TaskFilter{Status: &pending, Priority: &high}, 0)
+	if report.TotalMatches != 1 || report.Results[0].Task.Title != "Deploy staging" {
+		t.Errorf("Expected only the pending high priority match, got %+v", report.Results)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	tests := []struct {
+		text  string
+		query string
+		want  string
+	}{
+		{"Fix login bug", "login", "Fix " + highlightEscape + "login" + resetEscape + " bug"},
+		{"Login and LOGIN", "login", highlightEscape + "Login" + resetEscape + " and " + highlightEscape + "LOGIN" + resetEscape},
+		{"No match here", "deploy", "No match here"},
+		{"Anything", "  ", "Anything"},
+	}
+	for _, tt := range tests {
+		if got := highlightMatches(tt.text, tt.query); got != tt.want {
+			t.Errorf("highlightMatches(%q, %q) = %q, want %q", tt.text, tt.query, got, tt.want)
+		}
+	}
+}
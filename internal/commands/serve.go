@@ -2,16 +2,21 @@ package commands
 
 import (
 	"context"
+	"crypto/subtle"
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"os/signal"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,6 +28,7 @@ import (
 
 	"quicktodo/internal/config"
 	"quicktodo/internal/database"
+	"quicktodo/internal/hooks"
 	"quicktodo/internal/models"
 )
 
@@ -30,15 +36,20 @@ import (
 var staticFiles embed.FS
 
 var (
-	port       int
+	port        int
+	serveHost   string
 	openBrowser bool
+	viewToken   string
+	writeToken  string
+	extraDirs   []string
+	staticDir   string
+	allowOrigin []string
 )
 
-// WebSocket upgrader
+// WebSocket upgrader. runServe replaces CheckOrigin with one that also
+// accepts the --allow-origin origins.
 var upgrader = websocket.Upgrader{
-	CheckOrigin: func(r *http.Request) bool {
-		return true // Allow connections from any origin for local development
-	},
+	CheckOrigin: originAllowlist(nil).checkOrigin,
 }
 
 // Hub maintains the set of active clients and broadcasts messages to them
@@ -48,6 +59,12 @@ type Hub struct {
 	register   chan *Client
 	unregister chan *Client
 	mu         sync.RWMutex
+
+	// done is closed by stop to shut the hub down; run closes stopped once
+	// every client has been sent a close frame
+	done     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
 }
 
 // Client represents a websocket client connection
@@ -67,19 +84,75 @@ type WSMessage struct {
 // Global hub instance
 var hub *Hub
 
+// broadcastBuffer is how many broadcasts can wait for the hub to deliver them
+const broadcastBuffer = 256
+
+// resyncMessage replaces the queued messages of a client that has fallen
+// behind, telling it to reload rather than miss updates
+var resyncMessage, _ = json.Marshal(WSMessage{Type: "resync_required"})
+
+// webActor is recorded as the actor for changes made through the web interface
+const webActor = "web"
+
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start a web server with a kanban board interface",
 	Long: `Start a web server that provides a kanban board interface for managing tasks.
 	
 The server provides a REST API and a web interface for viewing and managing tasks
-across all your projects.`,
-	RunE: runServe,
+across all your projects.
+
+Use --extra-data-dir (repeatable) to also serve the projects of other data
+directories, e.g. one per team. Their projects appear as "<dir>:<project>",
+where <dir> is the data directory's base name, and changes are written back to
+the data directory that owns the project.
+
+The listen address defaults to serve_host and serve_port from the config (port
+8080 on all interfaces when unset); --host and --port override them.
+
+--static-dir serves the web interface from a directory on disk instead of the
+copy built into the binary, so front-end changes show up on reload without a
+rebuild. Point it at internal/commands/static in a checkout.
+
+POST /api/projects/{name}/tasks/{id}/comments adds a comment to a task, e.g.
+{"text":"Waiting on review","author":"alice"}; the author defaults to web.
+
+GET /api/agents/{id}/tasks lists the tasks assigned to an agent across every
+served project, grouped by project. It takes an optional status filter and
+offset and limit for paging; X-Total-Count has the number of matching tasks.
+
+PUT /api/projects/{name}/tasks/reorder saves the order of the board's
+columns, e.g. {"pending":[3,1]}; the board calls it when a card is dropped.
+
+GET /api/openapi.json describes the REST API as an OpenAPI 3 document.
+
+Browsers may only use the API and the WebSocket from pages on localhost,
+127.0.0.1 or the address given with --host, so other websites can't read or
+change tasks. --allow-origin (repeatable) allows another origin, e.g.
+--allow-origin https://dash.example.com.
+
+POST /api/maintenance/cleanup unregisters projects whose directories no longer
+exist and removes stale locks, so a remote server can be tidied without a
+shell. It requires the write token when tokens are enabled.
+
+POST /api/command runs any CLI command remotely and returns the JSON it prints
+with --json, e.g. {"command":"create-task","args":["Fix login"],
+"flags":{"priority":"high"},"project":"web"}. The command runs in the
+project's directory, or the server's when no project is given. Only the
+command's own flags are accepted, not global flags or flags naming files. It
+is only available when the server has a write token.`,
+	Run:  runServe,
 }
 
 func init() {
-	serveCmd.Flags().IntVarP(&port, "port", "p", 8080, "Port to run the server on")
+	serveCmd.Flags().IntVarP(&port, "port", "p", config.DefaultServePort, "Port to run the server on (default: config serve_port, then 8080)")
+	serveCmd.Flags().StringVar(&serveHost, "host", "", "Host or IP address to listen on (default: config serve_host, then all interfaces)")
 	serveCmd.Flags().BoolVar(&openBrowser, "open", false, "Open browser automatically")
+	serveCmd.Flags().StringVar(&viewToken, "view-token", "", "Token granting read-only (GET) access to the API")
+	serveCmd.Flags().StringVar(&writeToken, "write-token", "", "Token granting full read/write access to the API")
+	serveCmd.Flags().StringArrayVar(&extraDirs, "extra-data-dir", nil, "Additional data directory whose projects are also served (repeatable)")
+	serveCmd.Flags().StringVar(&staticDir, "static-dir", "", "Serve the web interface from this directory instead of the embedded files (for front-end development)")
+	serveCmd.Flags().StringArrayVar(&allowOrigin, "allow-origin", nil, "Additional origin allowed to use the API and WebSocket from a browser, e.g. https://dash.example.com (repeatable)")
 	RootCmd.AddCommand(serveCmd)
 }
 
@@ -87,9 +160,11 @@ func init() {
 func newHub() *Hub {
 	return &Hub{
 		clients:    make(map[*Client]bool),
-		broadcast:  make(chan []byte),
+		broadcast:  make(chan []byte, broadcastBuffer),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
 	}
 }
 
@@ -115,19 +190,68 @@ func (h *Hub) run() {
 		case message := <-h.broadcast:
 			h.mu.RLock()
 			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					delete(h.clients, client)
-					close(client.send)
-				}
+				client.deliver(message)
 			}
 			h.mu.RUnlock()
+
+		case <-h.done:
+			h.closeClients()
+			close(h.stopped)
+			return
 		}
 	}
 }
 
-// broadcastUpdate sends an update to all connected clients
+// stop tells every client the server is going away, closing their
+// connections, and waits for run to return. It may be called more than once.
+func (h *Hub) stop() {
+	h.stopOnce.Do(func() { close(h.done) })
+	<-h.stopped
+}
+
+// closeClients sends each client a going-away close frame and closes its
+// queue, which makes its writePump close the connection
+func (h *Hub) closeClients() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	closeFrame := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+	for client := range h.clients {
+		if client.conn != nil {
+			client.conn.WriteControl(websocket.CloseMessage, closeFrame, time.Now().Add(time.Second))
+		}
+		delete(h.clients, client)
+		close(client.send)
+	}
+}
+
+// deliver queues a message for the client. When the client's queue is full
+// its backlog is discarded and replaced with a resync_required message, so a
+// slow client reloads the board instead of silently missing updates.
+func (c *Client) deliver(message []byte) {
+	select {
+	case c.send <- message:
+		return
+	default:
+	}
+
+drain:
+	for {
+		select {
+		case <-c.send:
+		default:
+			break drain
+		}
+	}
+	select {
+	case c.send <- resyncMessage:
+	default:
+	}
+}
+
+// broadcastUpdate sends an update to all connected clients. It waits for room
+// in the broadcast queue rather than dropping the update; the hub never
+// blocks on clients, so the wait is short.
 func (h *Hub) broadcastUpdate(msgType string, data interface{}, project string) {
 	message := WSMessage{
 		Type:    msgType,
@@ -143,15 +267,17 @@ func (h *Hub) broadcastUpdate(msgType string, data interface{}, project string)
 	
 	select {
 	case h.broadcast <- jsonData:
-	default:
-		log.Printf("Broadcast channel full, dropping message")
+	case <-h.done:
 	}
 }
 
 // Client WebSocket handlers
 func (c *Client) readPump() {
 	defer func() {
-		c.hub.unregister <- c
+		select {
+		case c.hub.unregister <- c:
+		case <-c.hub.done:
+		}
 		c.conn.Close()
 	}()
 	
@@ -203,24 +329,31 @@ func (c *Client) writePump() {
 	}
 }
 
-func runServe(cmd *cobra.Command, args []string) error {
+func runServe(cmd *cobra.Command, args []string) {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Resolve the listen address (flags override config)
+	host, port := resolveServeAddress(cmd, cfg)
+	if port < 1 || port > 65535 {
+		exitWithError(codeInvalidArgument, "Error: invalid port %d: must be between 1 and 65535", port)
 	}
+	baseURL := serveURL(host, port)
 
 	// Load project registry
 	registryPath := cfg.GetProjectsPath()
 	registry, err := database.LoadProjectRegistry(registryPath)
 	if err != nil {
-		return fmt.Errorf("failed to load project registry: %w", err)
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
 	}
 
 	// Check if current directory is a registered project
 	currentDir, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
 	}
 
 	currentProject, isCurrentProject := registry.GetProjectByPath(currentDir)
@@ -241,10 +374,10 @@ func runServe(cmd *cobra.Command, args []string) error {
 			}
 		}
 		
-		fmt.Printf("\nStarting web server anyway... You can manage all projects at http://localhost:%d\n", port)
+		fmt.Printf("\nStarting web server anyway... You can manage all projects at %s\n", baseURL)
 	} else {
 		fmt.Printf("📁 Detected project: %s\n", currentProject.Name)
-		fmt.Printf("🌐 Starting web server at http://localhost:%d\n", port)
+		fmt.Printf("🌐 Starting web server at %s\n", baseURL)
 		
 		// Update last accessed time for the current project
 		if err := registry.UpdateLastAccessed(currentProject.Name); err != nil && verbose {
@@ -252,6 +385,39 @@ func runServe(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Load extra data directories
+	catalog := newServeCatalog(cfg, registry)
+	for _, dir := range extraDirs {
+		source, err := catalog.addDataDir(dir)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+		fmt.Printf("📚 Serving %d project(s) from %s as %s%s*\n", len(source.registry.ListProjects()), source.cfg.DataDir, source.name, sourceSeparator)
+	}
+
+	// Resolve access tokens (flags override config)
+	if !cmd.Flags().Changed("view-token") {
+		viewToken = cfg.ServeViewToken
+	}
+	if !cmd.Flags().Changed("write-token") {
+		writeToken = cfg.ServeWriteToken
+	}
+	tokens := accessTokens{view: viewToken, write: writeToken}
+	if tokens.enabled() {
+		fmt.Println("🔒 API access requires a view or write token")
+	}
+
+	// Remote commands are run by this same executable
+	executable, err := os.Executable()
+	if err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to locate executable, /api/command is disabled: %v\n", err)
+	}
+
+	// Restrict browser access to localhost, the listen address and the
+	// allowed origins
+	origins := originAllowlist(append([]string{baseURL}, allowOrigin...))
+	upgrader.CheckOrigin = origins.checkOrigin
+
 	// Initialize WebSocket hub
 	hub = newHub()
 	go hub.run()
@@ -259,24 +425,30 @@ func runServe(cmd *cobra.Command, args []string) error {
 	mux := http.NewServeMux()
 
 	// WebSocket route
-	mux.HandleFunc("/ws", handleWebSocket)
+	mux.HandleFunc("/ws", authMiddleware(tokens, handleWebSocket))
 
 	// API routes
-	mux.HandleFunc("/api/projects", corsMiddleware(handleProjects(registry)))
-	mux.HandleFunc("/api/projects/", corsMiddleware(handleProjectTasks(cfg, registry)))
-	mux.HandleFunc("/api/current-project", corsMiddleware(handleCurrentProject(currentProject, isCurrentProject)))
-	mux.HandleFunc("/api/notify", corsMiddleware(handleNotification))
-
-	// Static files - serve from embedded files with proper path stripping
-	staticSubFS, err := fs.Sub(staticFiles, "static")
+	mux.HandleFunc("/api/projects", corsMiddleware(origins, authMiddleware(tokens, handleProjects(catalog))))
+	mux.HandleFunc("/api/projects/", corsMiddleware(origins, authMiddleware(tokens, handleProjectTasks(catalog))))
+	mux.HandleFunc("/api/agents/", corsMiddleware(origins, authMiddleware(tokens, handleAgentTasks(catalog))))
+	mux.HandleFunc("/api/current-project", corsMiddleware(origins, authMiddleware(tokens, handleCurrentProject(currentProject, isCurrentProject))))
+	mux.HandleFunc("/api/notify", corsMiddleware(origins, authMiddleware(tokens, handleNotification)))
+	mux.HandleFunc("/api/openapi.json", corsMiddleware(origins, authMiddleware(tokens, handleOpenAPI)))
+	mux.HandleFunc("/api/maintenance/cleanup", corsMiddleware(origins, authMiddleware(tokens, handleMaintenanceCleanup(catalog))))
+	mux.HandleFunc("/api/command", corsMiddleware(origins, authMiddleware(tokens, handleCommand(catalog, tokens, executable))))
+
+	// Static files - embedded, or from disk with --static-dir
+	staticFS, err := staticFileSystem(staticDir)
 	if err != nil {
-		return fmt.Errorf("failed to create sub filesystem: %w", err)
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
+	if staticDir != "" {
+		fmt.Printf("🛠️  Serving static files from %s\n", staticDir)
 	}
-	staticHandler := http.FileServer(http.FS(staticSubFS))
-	mux.Handle("/", staticHandler)
+	mux.Handle("/", http.FileServer(http.FS(staticFS)))
 
 	srv := &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
+		Addr:    net.JoinHostPort(host, strconv.Itoa(port)),
 		Handler: mux,
 	}
 
@@ -287,39 +459,114 @@ func runServe(cmd *cobra.Command, args []string) error {
 		signal.Notify(sigint, os.Interrupt, syscall.SIGTERM)
 		<-sigint
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		if err := srv.Shutdown(ctx); err != nil {
+		if err := shutdownServer(srv, hub, 5*time.Second); err != nil {
 			log.Printf("Server shutdown error: %v", err)
 		}
 		close(done)
 	}()
 
-	fmt.Printf("Starting server on http://localhost:%d\n", port)
+	fmt.Printf("Starting server on %s\n", baseURL)
 	fmt.Println("Press Ctrl+C to stop")
 
 	if openBrowser {
 		go func() {
 			time.Sleep(1 * time.Second)
-			openURL(fmt.Sprintf("http://localhost:%d", port))
+			openURL(baseURL)
 		}()
 	}
 
 	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-		return fmt.Errorf("server error: %w", err)
+		exitWithError(codeInternalError, "Error running server: %v", err)
 	}
 
 	<-done
 	fmt.Println("\nServer stopped")
-	return nil
 }
 
-func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
+// shutdownServer stops the hub, which closes the WebSocket connections that
+// srv.Shutdown doesn't track, then waits up to timeout for the requests in
+// flight to finish
+func shutdownServer(srv *http.Server, hub *Hub, timeout time.Duration) error {
+	hub.stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
+
+// staticFileSystem returns the files of the web interface: the embedded copy
+// when dir is empty, otherwise the directory on disk. The directory is opened
+// as an os.Root so requests can't reach files outside it, whether through ".."
+// or symlinks.
+func staticFileSystem(dir string) (fs.FS, error) {
+	if dir == "" {
+		staticSubFS, err := fs.Sub(staticFiles, "static")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sub filesystem: %w", err)
+		}
+		return staticSubFS, nil
+	}
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open static directory: %w", err)
+	}
+	return root.FS(), nil
+}
+
+// resolveServeAddress returns the host and port to listen on: the --host and
+// --port flags when given, otherwise serve_host and serve_port from the config
+func resolveServeAddress(cmd *cobra.Command, cfg *config.Config) (string, int) {
+	host, _ := cmd.Flags().GetString("host")
+	if !cmd.Flags().Changed("host") && cfg.ServeHost != "" {
+		host = cfg.ServeHost
+	}
+
+	port, _ := cmd.Flags().GetInt("port")
+	if !cmd.Flags().Changed("port") && cfg.ServePort != 0 {
+		port = cfg.ServePort
+	}
+
+	return host, port
+}
+
+// serveURL is the address to open in a browser for a listen address
+func serveURL(host string, port int) string {
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// apiOperation labels a save made by an API request in the operation log,
+// e.g. "api: PUT /api/projects/demo/tasks/3", as operationCommand does for
+// the CLI
+func apiOperation(r *http.Request) string {
+	return "api: " + r.Method + " " + r.URL.Path
+}
+
+// corsMiddleware lets browsers on the allowed origins call the API. Other
+// origins get no CORS headers, so browsers refuse to send them the response,
+// and their requests that could change data are refused outright: a simple
+// cross-site POST reaches the server without a preflight, so hiding the
+// response alone would not stop it.
+func corsMiddleware(origins originAllowlist, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); origin != "" {
+			if !origins.allows(origin) {
+				switch r.Method {
+				case http.MethodGet, http.MethodHead, http.MethodOptions:
+				default:
+					http.Error(w, "Origin not allowed", http.StatusForbidden)
+					return
+				}
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			}
+		}
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -330,7 +577,97 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-func handleProjects(registry *database.ProjectRegistry) http.HandlerFunc {
+// originAllowlist holds the server's listen address and the origins given
+// with --allow-origin. Pages on localhost and 127.0.0.1 are always allowed.
+// The request's Host header is never trusted: after a DNS rebinding attack a
+// hostile page's Origin and Host match each other.
+type originAllowlist []string
+
+// allows reports whether a browser page on origin may use the server
+func (o originAllowlist) allows(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	for _, allowed := range o {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkOrigin is the WebSocket upgrader's origin check. Clients that send no
+// Origin, such as scripts, aren't browsers and are let through.
+func (o originAllowlist) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	return origin == "" || o.allows(origin)
+}
+
+// accessTokens holds the optional view (read-only) and write (full access) tokens
+type accessTokens struct {
+	view  string
+	write string
+}
+
+// enabled reports whether any token is configured
+func (t accessTokens) enabled() bool {
+	return t.view != "" || t.write != ""
+}
+
+// authMiddleware enforces the token tiers: safe methods accept either token,
+// mutating methods require the write token. With no tokens configured the
+// server stays open, preserving the local development behavior.
+func authMiddleware(tokens accessTokens, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !tokens.enabled() {
+			next(w, r)
+			return
+		}
+
+		presented := requestToken(r)
+		isWrite := tokenMatches(presented, tokens.write)
+		isView := tokenMatches(presented, tokens.view)
+
+		if !isWrite && !isView {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next(w, r)
+		default:
+			if !isWrite {
+				http.Error(w, "Forbidden: write token required", http.StatusForbidden)
+				return
+			}
+			next(w, r)
+		}
+	}
+}
+
+// tokenMatches compares a presented token with a configured one in constant
+// time, so response timing doesn't reveal how much of a guess was right. An
+// unset token matches nothing.
+func tokenMatches(presented, token string) bool {
+	return presented != "" && token != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
+
+// requestToken extracts a bearer token from the Authorization header or the
+// token query parameter (used by shared board links and WebSocket connections)
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return r.URL.Query().Get("token")
+}
+
+func handleProjects(catalog *serveCatalog) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -338,12 +675,14 @@ func handleProjects(registry *database.ProjectRegistry) http.HandlerFunc {
 		}
 
 		projects := make([]map[string]interface{}, 0)
-		for name, projectInfo := range registry.ListProjects() {
+		for _, project := range catalog.projects() {
 			projects = append(projects, map[string]interface{}{
-				"name": name,
-				"path": projectInfo.Path,
-				"created_at": projectInfo.CreatedAt,
-				"last_accessed": projectInfo.LastAccessed,
+				"name": project.name,
+				"path": project.info.Path,
+				"created_at": project.info.CreatedAt,
+				"last_accessed": project.info.LastAccessed,
+				"source": project.source.name,
+				"data_dir": project.source.cfg.DataDir,
 			})
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -351,7 +690,7 @@ func handleProjects(registry *database.ProjectRegistry) http.HandlerFunc {
 	}
 }
 
-func handleProjectTasks(cfg *config.Config, registry *database.ProjectRegistry) http.HandlerFunc {
+func handleProjectTasks(catalog *serveCatalog) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/projects/"), "/")
 		if len(parts) < 1 || parts[0] == "" {
@@ -359,20 +698,85 @@ func handleProjectTasks(cfg *config.Config, registry *database.ProjectRegistry)
 			return
 		}
 
-		projectName := parts[0]
-		_, exists := registry.GetProjectByName(projectName)
+		project, exists := catalog.resolve(parts[0])
 		if !exists {
 			http.Error(w, "Project not found", http.StatusNotFound)
 			return
 		}
 
-		dbPath := cfg.GetProjectDatabasePath(projectName)
-		db, err := loadProjectDatabase(dbPath)
+		// Writes hold the project lock from load to save, like the CLI, so
+		// concurrent requests can't overwrite each other or reuse an ID
+		if r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodDelete {
+			unlock, err := lockServedProject(project)
+			if err != nil {
+				status := http.StatusInternalServerError
+				var held *database.LockHeldError
+				if errors.As(err, &held) {
+					status = http.StatusConflict
+				}
+				http.Error(w, fmt.Sprintf("Failed to lock project: %v", err), status)
+				return
+			}
+			defer unlock()
+		}
+
+		db, err := loadProjectDatabase(project.cfg, project.dbPath)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Failed to load project: %v", err), http.StatusInternalServerError)
 			return
 		}
 
+		// Handle work-in-progress limit state
+		if len(parts) == 2 && parts[1] == "wip" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(db.WIPStates(project.cfg.WIPLimits))
+			return
+		}
+
+		// Handle lightweight metadata polled to detect in-flight writes
+		if len(parts) == 2 && parts[1] == "meta" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			handleGetProjectMeta(w, r, db, project)
+			return
+		}
+
+		// Handle task comments
+		if len(parts) == 4 && parts[1] == "tasks" && parts[3] == "comments" {
+			if r.Method != http.MethodPost {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			handleAddComment(w, r, db, parts[2], project)
+			return
+		}
+
+		// Handle task history
+		if len(parts) == 4 && parts[1] == "tasks" && parts[3] == "history" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			handleGetTaskHistory(w, r, db, parts[2])
+			return
+		}
+
+		// Handle saving the order of board columns
+		if len(parts) == 3 && parts[1] == "tasks" && parts[2] == "reorder" {
+			if r.Method != http.MethodPut {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			handleReorderTasks(w, r, db, project)
+			return
+		}
+
 		// Handle specific task operations
 		if len(parts) >= 3 && parts[1] == "tasks" {
 			taskID := parts[2]
@@ -380,9 +784,9 @@ func handleProjectTasks(cfg *config.Config, registry *database.ProjectRegistry)
 			case http.MethodGet:
 				handleGetTask(w, r, db, taskID)
 			case http.MethodPut:
-				handleUpdateTask(w, r, db, taskID, projectName, cfg, dbPath)
+				handleUpdateTask(w, r, db, taskID, project)
 			case http.MethodDelete:
-				handleDeleteTask(w, r, db, taskID, projectName, cfg, dbPath)
+				handleDeleteTask(w, r, db, taskID, project)
 			default:
 				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			}
@@ -395,7 +799,7 @@ func handleProjectTasks(cfg *config.Config, registry *database.ProjectRegistry)
 			case http.MethodGet:
 				handleGetTasks(w, r, db)
 			case http.MethodPost:
-				handleCreateTask(w, r, db, projectName, cfg, dbPath)
+				handleCreateTask(w, r, db, project)
 			default:
 				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			}
@@ -406,12 +810,141 @@ func handleProjectTasks(cfg *config.Config, registry *database.ProjectRegistry)
 	}
 }
 
+// serveWriteLocks holds a mutex per project database, so the server's own
+// write requests queue in memory rather than polling the lock file
+var serveWriteLocks sync.Map
+
+// lockServedProject acquires the project lock for a write request and
+// returns the function that releases it. Unlike acquireProjectLock it reports
+// failure to the caller instead of exiting.
+func lockServedProject(project *servedProject) (func(), error) {
+	value, _ := serveWriteLocks.LoadOrStore(project.dbPath, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+
+	lockManager := database.NewLockManager(project.cfg.DataDir+"/locks", project.cfg.LockTimeout)
+	lockInfo, err := lockManager.AcquireLock(project.localName)
+	if err != nil {
+		mu.Unlock()
+		return nil, err
+	}
+
+	return func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+		mu.Unlock()
+	}, nil
+}
+
+// handleGetTasks lists a project's tasks, narrowed by optional query
+// parameters that can be combined freely; a task must match all of them:
+//
+//	status=pending|in_progress|done|cancelled (synonyms as in the CLI, e.g. todo)
+//	priority=low|medium|high|critical   (synonyms as in the CLI, e.g. hi)
+//	assigned_to=<name>                  (exact match)
+//	changed_since=<RFC3339 time, date or duration such as 24h>
+//	q=<text>                            (in the title or description, ignoring case)
+//
+// Invalid values are rejected with 400 and a JSON {"error": ...} body.
 func handleGetTasks(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase) {
-	tasks := db.ListTasks(nil) // Get all tasks with no filter
+	query := r.URL.Query()
+	filter := &models.TaskFilter{}
+	if value := query.Get("changed_since"); value != "" {
+		since, err := parseTimeFlag(value)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid changed_since: %v", err))
+			return
+		}
+		filter.ChangedSince = &since
+	}
+	if value := query.Get("status"); value != "" {
+		status := models.NormalizeStatus(value)
+		if !models.IsValidStatus(string(status)) {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", value))
+			return
+		}
+		filter.Status = &status
+	}
+	if value := query.Get("priority"); value != "" {
+		priority := models.NormalizePriority(value)
+		if !models.IsValidPriority(string(priority)) {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid priority '%s'. Valid priorities: low, medium, high, critical", value))
+			return
+		}
+		filter.Priority = &priority
+	}
+	if value := query.Get("assigned_to"); value != "" {
+		filter.AssignedTo = &value
+	}
+
+	tasks := db.ListTasks(filter)
+	if text := query.Get("q"); text != "" {
+		// Keep board order rather than the search's ID order
+		found := make(map[int]bool)
+		for _, task := range db.SearchTasks(text) {
+			found[task.ID] = true
+		}
+		var matches []*models.Task
+		for _, task := range tasks {
+			if found[task.ID] {
+				matches = append(matches, task)
+			}
+		}
+		tasks = matches
+	}
+	if tasks == nil {
+		tasks = []*models.Task{}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Last-Modified", db.LastModified.UTC().Format(http.TimeFormat))
 	json.NewEncoder(w).Encode(tasks)
 }
 
+// handleReorderTasks saves the order of one or more board columns. The body
+// maps a status to the task IDs of its column from top to bottom, e.g.
+// {"pending": [3, 1]}; tasks left out keep their order below the listed ones.
+func handleReorderTasks(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, project *servedProject) {
+	var order map[string][]int
+	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	for value, ids := range order {
+		status := models.NormalizeStatus(value)
+		if !models.IsValidStatus(string(status)) {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", value))
+			return
+		}
+		if err := db.ReorderColumn(status, ids); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if err := saveProjectDatabaseAs(project.cfg, db, project.dbPath, apiOperation(r)); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to save project: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Broadcast the new order to WebSocket clients
+	if hub != nil {
+		hub.broadcastUpdate("tasks_reordered", order, project.name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(db.ListTasks(nil))
+}
+
+// writeJSONError reports an API error as {"error": message}
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
 func handleGetTask(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, taskID string) {
 	id, err := strconv.Atoi(taskID)
 	if err != nil {
@@ -428,11 +961,95 @@ func handleGetTask(w http.ResponseWriter, r *http.Request, db *models.ProjectDat
 	json.NewEncoder(w).Encode(task)
 }
 
-func handleCreateTask(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, projectName string, cfg *config.Config, dbPath string) {
+// handleGetProjectMeta reports the database version and whether a CLI write
+// currently holds the project lock, so clients can show a busy indicator
+// without fetching every task
+func handleGetProjectMeta(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, project *servedProject) {
+	lockManager := database.NewLockManager(project.cfg.DataDir+"/locks", project.cfg.LockTimeout)
+	locks, err := lockManager.GetActiveLocks()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to read locks: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	meta := map[string]interface{}{
+		"project":       project.name,
+		"version":       db.Version,
+		"last_modified": db.LastModified,
+		"locked":        false,
+	}
+	if lock, held := locks[project.localName]; held {
+		meta["locked"] = true
+		meta["locked_since"] = lock.CreatedAt
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(meta)
+}
+
+// handleGetTaskHistory returns a task's change events in chronological order.
+// An optional ?since= query parameter restricts the events to those after it.
+func handleGetTaskHistory(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, taskID string) {
+	id, err := strconv.Atoi(taskID)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+
+	var since *time.Time
+	if value := query.Get("since"); value != "" {
+		parsed, err := parseTimeFlag(value)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid since parameter: %v", err), http.StatusBadRequest)
+			return
+		}
+		since = &parsed
+	}
+
+	// Fields may be repeated or comma-separated, like --field
+	var fields []string
+	for _, value := range query["field"] {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	offset, limit := 0, 0
+	if value := query.Get("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid offset parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+	}
+	if err := validateHistoryPaging(fields, offset, limit); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	events := models.FilterEventsByField(db.GetTaskHistory(id, since), fields)
+
+	// The body stays a plain array; the unpaged count is sent as a header
+	w.Header().Set("X-Total-Count", strconv.Itoa(len(events)))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(models.PageEvents(events, offset, limit))
+}
+
+func handleCreateTask(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, project *servedProject) {
 	var input struct {
 		Title       string `json:"title"`
 		Description string `json:"description"`
 		Priority    string `json:"priority"`
+		Status      string `json:"status,omitempty"`
 		AssignedTo  string `json:"assigned_to,omitempty"`
 	}
 
@@ -441,8 +1058,15 @@ func handleCreateTask(w http.ResponseWriter, r *http.Request, db *models.Project
 		return
 	}
 
+	// Validate initial status
+	status := models.NormalizeStatus(input.Status)
+	if input.Status != "" && !models.IsValidStatus(string(status)) {
+		http.Error(w, fmt.Sprintf("Invalid status '%s'", input.Status), http.StatusBadRequest)
+		return
+	}
+
 	// Validate priority
-	priority := models.Priority(strings.ToLower(input.Priority))
+	priority := models.NormalizePriority(input.Priority)
 	if input.Priority != "" && !models.IsValidPriority(string(priority)) {
 		priority = models.PriorityMedium // Default
 	}
@@ -450,33 +1074,46 @@ func handleCreateTask(w http.ResponseWriter, r *http.Request, db *models.Project
 		priority = models.PriorityMedium
 	}
 
+	title := models.SanitizeTitle(input.Title)
+	if err := validateTaskText(project.cfg, title, input.Description); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Create task
-	task := models.NewTaskWithDetails(db.NextID, input.Title, input.Description, priority)
+	task := models.NewTaskWithDetails(db.NextID, title, input.Description, priority)
+	if input.Status != "" {
+		task.UpdateStatus(status)
+	}
 	if input.AssignedTo != "" {
 		task.AssignTo(input.AssignedTo)
 	}
+	db.ApplyDefaultTags(task)
 
 	if err := db.AddTask(task); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to add task: %v", err), http.StatusInternalServerError)
 		return
 	}
+	db.RecordTaskCreated(task, webActor)
 
-	if err := saveProjectDatabase(db, dbPath); err != nil {
+	if err := saveProjectDatabaseAs(project.cfg, db, project.dbPath, apiOperation(r)); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to save task: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	// Broadcast task creation to WebSocket clients
 	if hub != nil {
-		hub.broadcastUpdate("task_created", task, projectName)
+		hub.broadcastUpdate("task_created", task, project.name)
 	}
 
+	runTaskHooks(project.cfg, hooks.EventTaskCreated, task, project.localName)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(task)
 }
 
-func handleUpdateTask(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, taskID string, projectName string, cfg *config.Config, dbPath string) {
+func handleUpdateTask(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, taskID string, project *servedProject) {
 	id, err := strconv.Atoi(taskID)
 	if err != nil {
 		http.Error(w, "Invalid task ID", http.StatusBadRequest)
@@ -495,49 +1132,109 @@ func handleUpdateTask(w http.ResponseWriter, r *http.Request, db *models.Project
 		return
 	}
 
+	before := task.Clone()
+
 	// Apply updates
 	if title, ok := updates["title"].(string); ok {
-		task.UpdateTitle(title)
+		task.UpdateTitle(models.SanitizeTitle(title))
 	}
 	if description, ok := updates["description"].(string); ok {
 		task.UpdateDescription(description)
 	}
 	if status, ok := updates["status"].(string); ok {
-		if models.IsValidStatus(status) {
-			task.UpdateStatus(models.Status(status))
+		if normalized := models.NormalizeStatus(status); models.IsValidStatus(string(normalized)) {
+			task.UpdateStatus(normalized)
 		}
 	}
 	if priority, ok := updates["priority"].(string); ok {
-		if models.IsValidPriority(priority) {
-			task.UpdatePriority(models.Priority(priority))
+		if normalized := models.NormalizePriority(priority); models.IsValidPriority(string(normalized)) {
+			task.UpdatePriority(normalized)
 		}
 	}
 	if assignedTo, ok := updates["assigned_to"].(string); ok {
 		task.AssignTo(assignedTo)
 	}
 
-	task.UpdatedAt = time.Now()
+	if err := validateTaskText(project.cfg, task.Title, task.Description); err != nil {
+		// Roll back the in-memory changes so the cached database stays valid
+		*task = *before
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	task.UpdatedAt = time.Now().UTC()
 
 	if err := db.UpdateTask(task); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to update task: %v", err), http.StatusInternalServerError)
 		return
 	}
+	db.RecordTaskChanges(before, task, webActor)
 
-	if err := saveProjectDatabase(db, dbPath); err != nil {
+	if err := saveProjectDatabaseAs(project.cfg, db, project.dbPath, apiOperation(r)); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to save project: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	// Broadcast task update to WebSocket clients
 	if hub != nil {
-		hub.broadcastUpdate("task_updated", task, projectName)
+		hub.broadcastUpdate("task_updated", task, project.name)
 	}
 
+	runStatusChangeHooks(project.cfg, before.Status, task, project.localName)
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(task)
 }
 
-func handleDeleteTask(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, taskID string, projectName string, cfg *config.Config, dbPath string) {
+// handleAddComment appends a comment to a task. The author defaults to the
+// web actor when the request doesn't name one.
+func handleAddComment(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, taskID string, project *servedProject) {
+	id, err := strconv.Atoi(taskID)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	task, err := db.GetTask(id)
+	if err != nil {
+		http.Error(w, "Task not found", http.StatusNotFound)
+		return
+	}
+
+	var input struct {
+		Author string `json:"author"`
+		Text   string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if input.Author == "" {
+		input.Author = webActor
+	}
+
+	comment, err := task.AddComment(input.Author, input.Text)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := saveProjectDatabaseAs(project.cfg, db, project.dbPath, apiOperation(r)); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to save project: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Broadcast task update to WebSocket clients
+	if hub != nil {
+		hub.broadcastUpdate("task_updated", task, project.name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(comment)
+}
+
+func handleDeleteTask(w http.ResponseWriter, r *http.Request, db *models.ProjectDatabase, taskID string, project *servedProject) {
 	id, err := strconv.Atoi(taskID)
 	if err != nil {
 		http.Error(w, "Invalid task ID", http.StatusBadRequest)
@@ -555,21 +1252,22 @@ func handleDeleteTask(w http.ResponseWriter, r *http.Request, db *models.Project
 		http.Error(w, fmt.Sprintf("Failed to delete task: %v", err), http.StatusInternalServerError)
 		return
 	}
+	db.RecordTaskDeleted(task, webActor)
 
-	if err := saveProjectDatabase(db, dbPath); err != nil {
+	if err := saveProjectDatabaseAs(project.cfg, db, project.dbPath, apiOperation(r)); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to save project: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	// Sync to TODO list if enabled
-	syncToTodoList(task, projectName, "delete", cfg)
+	syncToTodoList(task, project.localName, "delete", project.cfg)
 
 	// Broadcast task deletion to WebSocket clients
 	if hub != nil {
 		hub.broadcastUpdate("task_deleted", map[string]interface{}{
 			"id": task.ID,
 			"title": task.Title,
-		}, projectName)
+		}, project.name)
 	}
 
 	w.WriteHeader(http.StatusNoContent)
@@ -589,7 +1287,12 @@ func handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		send: make(chan []byte, 256),
 	}
 	
-	client.hub.register <- client
+	select {
+	case client.hub.register <- client:
+	case <-client.hub.done:
+		conn.Close()
+		return
+	}
 	
 	// Start goroutines for reading and writing
 	go client.writePump()
@@ -623,6 +1326,56 @@ func handleNotification(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// handleMaintenanceCleanup removes registered projects whose directories no
+// longer exist and stale or orphaned locks in every served data dir, and
+// reports what was removed. Being a POST, it requires the write token when
+// tokens are enabled.
+func handleMaintenanceCleanup(catalog *serveCatalog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		removedProjects := []string{}
+		removedLocks := []string{}
+		for _, source := range catalog.sources {
+			removed, err := catalog.cleanupRegistry(source)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Failed to clean up registry: %v", err), http.StatusInternalServerError)
+				return
+			}
+			for _, name := range removed {
+				removedProjects = append(removedProjects, source.qualify(name))
+			}
+
+			lockManager := database.NewLockManager(source.cfg.DataDir+"/locks", source.cfg.LockTimeout)
+			cleaned, err := lockManager.CleanupStaleLocks(database.StaleLockAge)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Failed to clean up locks: %v", err), http.StatusInternalServerError)
+				return
+			}
+			for _, file := range cleaned {
+				removedLocks = append(removedLocks, source.qualify(strings.TrimSuffix(file, ".lock")))
+			}
+		}
+		sort.Strings(removedProjects)
+		sort.Strings(removedLocks)
+
+		result := map[string]interface{}{
+			"removed_projects": removedProjects,
+			"removed_locks":    removedLocks,
+		}
+
+		if hub != nil {
+			hub.broadcastUpdate("projects_changed", result, "")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
 // handleCurrentProject returns information about the current project (if any)
 func handleCurrentProject(currentProject *database.ProjectInfo, isCurrentProject bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"quicktodo/internal/models"
+	"strconv"
+	"strings"
+)
+
+// agentProjectTasks is one project's share of an agent's task queue
+type agentProjectTasks struct {
+	Project string         `json:"project"`
+	Tasks   []*models.Task `json:"tasks"`
+}
+
+// agentTasksPage is the body of GET /api/agents/{id}/tasks
+type agentTasksPage struct {
+	Agent    string               `json:"agent"`
+	Total    int                  `json:"total"`
+	Offset   int                  `json:"offset"`
+	Limit    int                  `json:"limit"`
+	Projects []*agentProjectTasks `json:"projects"`
+}
+
+// handleAgentTasks serves an agent's task queue: the tasks assigned to it in
+// every served project, optionally only those with one status. Tasks are
+// paged in catalog order, then board order within each project, and the page
+// is grouped by project. Projects whose database can't be loaded are skipped,
+// as in assignments.
+func handleAgentTasks(catalog *serveCatalog) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/agents/"), "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] != "tasks" {
+			http.Error(w, "Invalid endpoint", http.StatusBadRequest)
+			return
+		}
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		agent := parts[0]
+
+		query := r.URL.Query()
+		filter := &models.TaskFilter{AssignedTo: &agent}
+		if value := query.Get("status"); value != "" {
+			status := models.NormalizeStatus(value)
+			if !models.IsValidStatus(string(status)) {
+				writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("Invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", value))
+				return
+			}
+			filter.Status = &status
+		}
+
+		offset, limit := 0, 0
+		var err error
+		if value := query.Get("offset"); value != "" {
+			if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+				writeJSONError(w, http.StatusBadRequest, "Invalid offset parameter")
+				return
+			}
+		}
+		if value := query.Get("limit"); value != "" {
+			if limit, err = strconv.Atoi(value); err != nil || limit < 0 {
+				writeJSONError(w, http.StatusBadRequest, "Invalid limit parameter")
+				return
+			}
+		}
+
+		page := &agentTasksPage{Agent: agent, Offset: offset, Limit: limit, Projects: []*agentProjectTasks{}}
+		skipped := 0
+		for _, project := range catalog.projects() {
+			db, err := loadProjectDatabase(project.source.cfg, project.source.cfg.GetProjectDatabasePath(project.info.Name))
+			if err != nil {
+				continue
+			}
+
+			var tasks []*models.Task
+			for _, task := range db.ListTasks(filter) {
+				page.Total++
+				if skipped < offset {
+					skipped++
+					continue
+				}
+				if limit > 0 && page.taskCount()+len(tasks) >= limit {
+					continue
+				}
+				tasks = append(tasks, task)
+			}
+			if len(tasks) > 0 {
+				page.Projects = append(page.Projects, &agentProjectTasks{Project: project.name, Tasks: tasks})
+			}
+		}
+
+		w.Header().Set("X-Total-Count", strconv.Itoa(page.Total))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}
+}
+
+// taskCount counts the tasks on the page so far
+func (p *agentTasksPage) taskCount() int {
+	count := 0
+	for _, project := range p.Projects {
+		count += len(project.Tasks)
+	}
+	return count
+}
@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commandTimeout bounds how long a command run through /api/command may take
+const commandTimeout = 30 * time.Second
+
+// remoteBlockedCommands run until they are stopped, so they can't be run
+// through /api/command
+var remoteBlockedCommands = map[string]bool{
+	"serve": true,
+}
+
+// remoteFileFlags name files or directories to read or write, which would
+// let a remote client reach any path the server's user can, so they can't be
+// given through /api/command
+var remoteFileFlags = map[string]bool{
+	"assignee-file":    true,
+	"description-file": true,
+	"dir":              true,
+	"extra-data-dir":   true,
+	"file":             true,
+	"output":           true,
+	"output-file":      true,
+	"static-dir":       true,
+}
+
+// commandFlagName matches the long flag names accepted by /api/command
+var commandFlagName = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
+
+// commandRequest is the body of POST /api/command
+type commandRequest struct {
+	Command string                 `json:"command"`
+	Args    []string               `json:"args"`
+	Flags   map[string]interface{} `json:"flags"`
+	Project string                 `json:"project"`
+}
+
+// handleCommand runs a CLI command on behalf of a remote client and returns
+// the JSON the command prints with --json. The command is run by the server's
+// own executable in the project's directory, so it behaves exactly as if it
+// had been typed there, locking included. Without a project it runs in the
+// server's working directory.
+//
+// As it can run any command, the endpoint is only available when the server
+// has a write token, and like every POST it requires that token.
+func handleCommand(catalog *serveCatalog, tokens accessTokens, executable string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if tokens.write == "" {
+			http.Error(w, "Forbidden: /api/command requires the server to be started with a write token", http.StatusForbidden)
+			return
+		}
+		if executable == "" {
+			http.Error(w, "Commands are unavailable: the server executable could not be located", http.StatusInternalServerError)
+			return
+		}
+
+		var req commandRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+
+		argv, err := commandArgv(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		dir := ""
+		if req.Project != "" {
+			project, exists := catalog.resolve(req.Project)
+			if !exists {
+				http.Error(w, "Project not found", http.StatusNotFound)
+				return
+			}
+			// The command loads its own configuration, which only knows the
+			// primary data dir
+			primary := catalog.sources[0]
+			if project.cfg != primary.cfg {
+				http.Error(w, "Commands can only be run against projects in the primary data dir", http.StatusBadRequest)
+				return
+			}
+			if stat, err := os.Stat(project.info.Path); err != nil || !stat.IsDir() {
+				http.Error(w, fmt.Sprintf("Project directory %s does not exist", project.info.Path), http.StatusConflict)
+				return
+			}
+			dir = project.info.Path
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), commandTimeout)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, executable, argv...)
+		cmd.Dir = dir
+		// There is nobody to answer a prompt
+		cmd.Env = append(os.Environ(), assumeYesEnv+"=1")
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		exitCode := 0
+		if err := cmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			switch {
+			case ctx.Err() == context.DeadlineExceeded:
+				writeCommandResult(w, http.StatusGatewayTimeout, map[string]interface{}{
+					"success": false,
+					"error":   fmt.Sprintf("command timed out after %s", commandTimeout),
+				})
+				return
+			case errors.As(err, &exitErr):
+				exitCode = exitErr.ExitCode()
+			default:
+				http.Error(w, fmt.Sprintf("Failed to run command: %v", err), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		status := http.StatusOK
+		if exitCode != 0 {
+			status = http.StatusUnprocessableEntity
+		}
+
+		// Commands with JSON output are passed through unchanged; anything
+		// else is wrapped so clients always get JSON back
+		if output := bytes.TrimSpace(stdout.Bytes()); len(output) > 0 && json.Valid(output) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write(append(output, '\n'))
+			return
+		}
+
+		result := map[string]interface{}{
+			"success":   exitCode == 0,
+			"output":    stdout.String(),
+			"exit_code": exitCode,
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			result["error"] = strings.TrimPrefix(message, "Error: ")
+		}
+		writeCommandResult(w, status, result)
+	}
+}
+
+// commandArgv turns a command request into command-line arguments: the
+// command path, its flags in name order, --json, then the positional
+// arguments after "--" so they are never read as flags. Only the command's
+// own flags are accepted; global flags and flags naming files are refused.
+func commandArgv(req commandRequest) ([]string, error) {
+	path := strings.Fields(req.Command)
+	if len(path) == 0 {
+		return nil, fmt.Errorf("command is required")
+	}
+
+	found, rest, err := RootCmd.Find(path)
+	if err != nil || found == RootCmd || len(rest) > 0 {
+		return nil, fmt.Errorf("unknown command %q", req.Command)
+	}
+	if remoteBlockedCommands[found.Name()] {
+		return nil, fmt.Errorf("command %q can't be run remotely", req.Command)
+	}
+
+	argv := append([]string{}, path...)
+
+	flags := found.LocalNonPersistentFlags()
+	names := make([]string, 0, len(req.Flags))
+	for name := range req.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values, err := commandFlagValues(name, req.Flags[name])
+		if err != nil {
+			return nil, err
+		}
+		if flags.Lookup(name) == nil {
+			return nil, fmt.Errorf("unknown flag --%s for %q", name, req.Command)
+		}
+		if remoteFileFlags[name] {
+			return nil, fmt.Errorf("flag --%s can't be used remotely", name)
+		}
+		for _, value := range values {
+			argv = append(argv, "--"+name+"="+value)
+		}
+	}
+
+	argv = append(argv, "--json")
+	if len(req.Args) > 0 {
+		argv = append(argv, "--")
+		argv = append(argv, req.Args...)
+	}
+	return argv, nil
+}
+
+// commandFlagValues renders a JSON flag value; arrays repeat the flag
+func commandFlagValues(name string, value interface{}) ([]string, error) {
+	if !commandFlagName.MatchString(name) {
+		return nil, fmt.Errorf("invalid flag name %q", name)
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return []string{strconv.FormatBool(v)}, nil
+	case string:
+		return []string{v}, nil
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			if _, isList := item.([]interface{}); isList {
+				return nil, fmt.Errorf("invalid value for flag %q: nested lists are not supported", name)
+			}
+			itemValues, err := commandFlagValues(name, item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, itemValues...)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("invalid value for flag %q: expected a string, number, boolean or list", name)
+	}
+}
+
+func writeCommandResult(w http.ResponseWriter, status int, result map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
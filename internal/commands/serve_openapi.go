@@ -0,0 +1,449 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"quicktodo/internal/models"
+)
+
+// openAPIEnums lists the allowed values of the string types used in schemas
+var openAPIEnums = map[reflect.Type][]string{
+	reflect.TypeOf(models.Status("")):     statusStrings(),
+	reflect.TypeOf(models.Priority("")):   priorityStrings(),
+	reflect.TypeOf(models.Resolution("")): resolutionStrings(),
+}
+
+func statusStrings() []string {
+	var values []string
+	for _, status := range models.ValidStatuses() {
+		values = append(values, string(status))
+	}
+	return values
+}
+
+func priorityStrings() []string {
+	var values []string
+	for _, priority := range models.ValidPriorities() {
+		values = append(values, string(priority))
+	}
+	return values
+}
+
+func resolutionStrings() []string {
+	var values []string
+	for _, resolution := range models.ValidResolutions() {
+		values = append(values, string(resolution))
+	}
+	return values
+}
+
+// openAPISchemas builds component schemas from Go types by reflection.
+// Structs become named components referenced with $ref.
+type openAPISchemas struct {
+	components map[string]interface{}
+}
+
+// schema returns the OpenAPI schema for t, registering struct components
+func (s *openAPISchemas) schema(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		schema := s.schema(t.Elem())
+		schema["nullable"] = true
+		return schema
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	if values, ok := openAPIEnums[t]; ok {
+		return map[string]interface{}{"type": "string", "enum": values}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.Struct:
+		if _, exists := s.components[t.Name()]; !exists {
+			s.components[t.Name()] = nil // placeholder guards against recursion
+			s.components[t.Name()] = s.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema describes a struct's JSON fields as an object schema
+func (s *openAPISchemas) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = s.schema(field.Type)
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// ref returns a $ref to a component schema
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// jsonResponse describes a JSON response with the given schema
+func jsonResponse(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// arrayOf wraps a schema as an array schema
+func arrayOf(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "array", "items": schema}
+}
+
+// parameter describes a path or query parameter
+func parameter(name, in, description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"in":          in,
+		"description": description,
+		"required":    in == "path",
+		"schema":      schema,
+	}
+}
+
+// buildOpenAPISpec describes the server's REST API as an OpenAPI 3 document.
+// Task, history and WIP schemas are derived from the model structs.
+func buildOpenAPISpec() map[string]interface{} {
+	schemas := &openAPISchemas{components: map[string]interface{}{}}
+	schemas.schema(reflect.TypeOf(models.Task{}))
+	schemas.schema(reflect.TypeOf(models.TaskEvent{}))
+	schemas.schema(reflect.TypeOf(models.WIPState{}))
+
+	// checklist_progress and total_time_seconds are computed when tasks are
+	// encoded, not struct fields
+	taskSchema := schemas.components["Task"].(map[string]interface{})
+	taskSchema["properties"].(map[string]interface{})["checklist_progress"] = schemas.schema(reflect.TypeOf(models.ChecklistProgress{}))
+	taskSchema["properties"].(map[string]interface{})["total_time_seconds"] = map[string]interface{}{"type": "integer"}
+
+	schemas.components["Project"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name":          map[string]interface{}{"type": "string"},
+			"path":          map[string]interface{}{"type": "string"},
+			"created_at":    map[string]interface{}{"type": "string", "format": "date-time"},
+			"last_accessed": map[string]interface{}{"type": "string", "format": "date-time"},
+			"source":        map[string]interface{}{"type": "string", "description": "Data source name; empty for the primary data dir"},
+			"data_dir":      map[string]interface{}{"type": "string"},
+		},
+	}
+	schemas.components["TaskInput"] = map[string]interface{}{
+		"type":     "object",
+		"required": []string{"title"},
+		"properties": map[string]interface{}{
+			"title":       map[string]interface{}{"type": "string"},
+			"description": map[string]interface{}{"type": "string"},
+			"priority":    map[string]interface{}{"type": "string", "enum": priorityStrings()},
+			"status":      map[string]interface{}{"type": "string", "enum": statusStrings()},
+			"assigned_to": map[string]interface{}{"type": "string"},
+		},
+	}
+	schemas.components["TaskUpdate"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"title":       map[string]interface{}{"type": "string"},
+			"description": map[string]interface{}{"type": "string"},
+			"priority":    map[string]interface{}{"type": "string", "enum": priorityStrings()},
+			"status":      map[string]interface{}{"type": "string", "enum": statusStrings()},
+			"assigned_to": map[string]interface{}{"type": "string"},
+		},
+	}
+	schemas.components["AgentTasks"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"agent":  map[string]interface{}{"type": "string"},
+			"total":  map[string]interface{}{"type": "integer", "description": "Matching tasks across all projects, before paging"},
+			"offset": map[string]interface{}{"type": "integer"},
+			"limit":  map[string]interface{}{"type": "integer"},
+			"projects": arrayOf(map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"project": map[string]interface{}{"type": "string"},
+					"tasks":   arrayOf(ref("Task")),
+				},
+			}),
+		},
+	}
+	schemas.components["CommentInput"] = map[string]interface{}{
+		"type":     "object",
+		"required": []string{"text"},
+		"properties": map[string]interface{}{
+			"text":   map[string]interface{}{"type": "string"},
+			"author": map[string]interface{}{"type": "string", "description": "Defaults to web"},
+		},
+	}
+	schemas.components["TaskOrder"] = map[string]interface{}{
+		"type":                 "object",
+		"description":          "Statuses mapped to the task IDs of their board column, top first; tasks left out keep their order below the listed ones",
+		"additionalProperties": arrayOf(map[string]interface{}{"type": "integer"}),
+	}
+	schemas.components["ProjectMeta"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"project":       map[string]interface{}{"type": "string"},
+			"version":       map[string]interface{}{"type": "integer"},
+			"last_modified": map[string]interface{}{"type": "string", "format": "date-time"},
+			"locked":        map[string]interface{}{"type": "boolean"},
+			"locked_since":  map[string]interface{}{"type": "string", "format": "date-time"},
+		},
+	}
+	schemas.components["CleanupResult"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"removed_projects": arrayOf(map[string]interface{}{"type": "string"}),
+			"removed_locks":    arrayOf(map[string]interface{}{"type": "string"}),
+		},
+	}
+
+	schemas.components["CommandRequest"] = map[string]interface{}{
+		"type":     "object",
+		"required": []string{"command"},
+		"properties": map[string]interface{}{
+			"command": map[string]interface{}{"type": "string", "description": "CLI command, e.g. create-task or \"saved-filter save\""},
+			"args":    arrayOf(map[string]interface{}{"type": "string"}),
+			"flags":   map[string]interface{}{"type": "object", "description": "Long flag names mapped to a string, number, boolean or list (repeated flag)"},
+			"project": map[string]interface{}{"type": "string", "description": "Project to run in; defaults to the server's working directory"},
+		},
+	}
+
+	projectParam := parameter("project", "path", "Project name; projects from extra data dirs are named <source>:<project>", map[string]interface{}{"type": "string"})
+	taskIDParam := parameter("id", "path", "Task ID", map[string]interface{}{"type": "integer"})
+	notFound := map[string]interface{}{"description": "Project or task not found"}
+	badRequest := map[string]interface{}{"description": "Invalid input"}
+
+	paths := map[string]interface{}{
+		"/api/projects": map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary":   "List projects",
+				"responses": map[string]interface{}{"200": jsonResponse("Registered projects", arrayOf(ref("Project")))},
+			},
+		},
+		"/api/current-project": map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary": "Get the project the server was started in, if any",
+				"responses": map[string]interface{}{"200": jsonResponse("Current project", map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"has_current_project": map[string]interface{}{"type": "boolean"},
+						"current_project":     map[string]interface{}{"type": "object", "nullable": true},
+					},
+				})},
+			},
+		},
+		"/api/projects/{project}/tasks": map[string]interface{}{
+			"parameters": []interface{}{projectParam},
+			"get": map[string]interface{}{
+				"summary": "List a project's tasks",
+				"parameters": []interface{}{
+					parameter("changed_since", "query", "Only tasks updated after this time (RFC3339 or a duration such as 24h)", map[string]interface{}{"type": "string"}),
+					parameter("status", "query", "Only tasks with this status", map[string]interface{}{"type": "string", "enum": statusStrings()}),
+					parameter("priority", "query", "Only tasks with this priority", map[string]interface{}{"type": "string", "enum": priorityStrings()}),
+					parameter("assigned_to", "query", "Only tasks assigned to this name", map[string]interface{}{"type": "string"}),
+					parameter("q", "query", "Only tasks whose title or description contains this text, ignoring case", map[string]interface{}{"type": "string"}),
+				},
+				"responses": map[string]interface{}{"200": jsonResponse("Tasks", arrayOf(ref("Task"))), "400": badRequest, "404": notFound},
+			},
+			"post": map[string]interface{}{
+				"summary": "Create a task",
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": ref("TaskInput")}},
+				},
+				"responses": map[string]interface{}{"201": jsonResponse("Created task", ref("Task")), "400": badRequest, "404": notFound},
+			},
+		},
+		"/api/agents/{agent}/tasks": map[string]interface{}{
+			"parameters": []interface{}{parameter("agent", "path", "Assignee name, e.g. an agent ID", map[string]interface{}{"type": "string"})},
+			"get": map[string]interface{}{
+				"summary": "List an agent's tasks across all projects",
+				"parameters": []interface{}{
+					parameter("status", "query", "Only tasks with this status", map[string]interface{}{"type": "string", "enum": statusStrings()}),
+					parameter("offset", "query", "Tasks to skip", map[string]interface{}{"type": "integer", "minimum": 0}),
+					parameter("limit", "query", "Maximum tasks to return", map[string]interface{}{"type": "integer", "minimum": 0}),
+				},
+				"responses": map[string]interface{}{"200": jsonResponse("Tasks grouped by project", ref("AgentTasks")), "400": badRequest},
+			},
+		},
+		"/api/projects/{project}/tasks/reorder": map[string]interface{}{
+			"parameters": []interface{}{projectParam},
+			"put": map[string]interface{}{
+				"summary": "Save the order of board columns",
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": ref("TaskOrder")}},
+				},
+				"responses": map[string]interface{}{"200": jsonResponse("Tasks in board order", arrayOf(ref("Task"))), "400": badRequest, "404": notFound},
+			},
+		},
+		"/api/projects/{project}/tasks/{id}": map[string]interface{}{
+			"parameters": []interface{}{projectParam, taskIDParam},
+			"get": map[string]interface{}{
+				"summary":   "Get a task",
+				"responses": map[string]interface{}{"200": jsonResponse("Task", ref("Task")), "404": notFound},
+			},
+			"put": map[string]interface{}{
+				"summary": "Update a task",
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": ref("TaskUpdate")}},
+				},
+				"responses": map[string]interface{}{"200": jsonResponse("Updated task", ref("Task")), "400": badRequest, "404": notFound},
+			},
+			"delete": map[string]interface{}{
+				"summary":   "Delete a task",
+				"responses": map[string]interface{}{"204": map[string]interface{}{"description": "Task deleted"}, "404": notFound},
+			},
+		},
+		"/api/projects/{project}/tasks/{id}/comments": map[string]interface{}{
+			"parameters": []interface{}{projectParam, taskIDParam},
+			"post": map[string]interface{}{
+				"summary": "Add a comment to a task",
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": ref("CommentInput")}},
+				},
+				"responses": map[string]interface{}{"201": jsonResponse("Added comment", ref("Comment")), "400": badRequest, "404": notFound},
+			},
+		},
+		"/api/projects/{project}/tasks/{id}/history": map[string]interface{}{
+			"parameters": []interface{}{projectParam, taskIDParam},
+			"get": map[string]interface{}{
+				"summary": "Get a task's change history",
+				"parameters": []interface{}{
+					parameter("since", "query", "Only events after this time", map[string]interface{}{"type": "string"}),
+					parameter("field", "query", "Only events for these fields (repeatable or comma-separated)", arrayOf(map[string]interface{}{"type": "string", "enum": models.EventFields()})),
+					parameter("offset", "query", "Events to skip", map[string]interface{}{"type": "integer", "minimum": 0}),
+					parameter("limit", "query", "Maximum events to return", map[string]interface{}{"type": "integer", "minimum": 0}),
+				},
+				"responses": map[string]interface{}{
+					"200": func() map[string]interface{} {
+						response := jsonResponse("Events in chronological order", arrayOf(ref("TaskEvent")))
+						response["headers"] = map[string]interface{}{
+							"X-Total-Count": map[string]interface{}{
+								"description": "Number of matching events before paging",
+								"schema":      map[string]interface{}{"type": "integer"},
+							},
+						}
+						return response
+					}(),
+					"400": badRequest,
+					"404": notFound,
+				},
+			},
+		},
+		"/api/projects/{project}/wip": map[string]interface{}{
+			"parameters": []interface{}{projectParam},
+			"get": map[string]interface{}{
+				"summary":   "Get work-in-progress limit states",
+				"responses": map[string]interface{}{"200": jsonResponse("Limited statuses", arrayOf(ref("WIPState"))), "404": notFound},
+			},
+		},
+		"/api/projects/{project}/meta": map[string]interface{}{
+			"parameters": []interface{}{projectParam},
+			"get": map[string]interface{}{
+				"summary":   "Get the database version and whether a write is in progress",
+				"responses": map[string]interface{}{"200": jsonResponse("Project metadata", ref("ProjectMeta")), "404": notFound},
+			},
+		},
+		"/api/maintenance/cleanup": map[string]interface{}{
+			"post": map[string]interface{}{
+				"summary":   "Remove dead projects and stale locks",
+				"responses": map[string]interface{}{"200": jsonResponse("Removed entries", ref("CleanupResult"))},
+			},
+		},
+		"/api/command": map[string]interface{}{
+			"post": map[string]interface{}{
+				"summary": "Run a CLI command and return its JSON output (requires a write token)",
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": ref("CommandRequest")}},
+				},
+				"responses": map[string]interface{}{
+					"200": jsonResponse("The command's JSON output", map[string]interface{}{"type": "object"}),
+					"400": badRequest,
+					"403": map[string]interface{}{"description": "The server has no write token"},
+					"404": map[string]interface{}{"description": "Project not found"},
+					"422": jsonResponse("The command failed", map[string]interface{}{"type": "object"}),
+				},
+			},
+		},
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "QuickTodo API",
+			"version": buildInfo.Version,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				"bearerToken": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+		// Tokens are only enforced when the server is started with them
+		"security": []interface{}{
+			map[string]interface{}{},
+			map[string]interface{}{"bearerToken": []string{}},
+		},
+	}
+}
+
+// handleOpenAPI serves the OpenAPI document for the REST API
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(buildOpenAPISpec())
+}
@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+)
+
+// sourceSeparator joins a data source name and a project name in the API
+const sourceSeparator = ":"
+
+// dataSource is one data directory served by the web server. The primary data
+// dir has an empty name and its projects keep their plain names; projects from
+// extra data dirs are exposed as "<source>:<project>" so names stay unique.
+type dataSource struct {
+	name     string
+	cfg      *config.Config
+	registry *database.ProjectRegistry
+}
+
+// qualify returns the API name for one of this source's projects
+func (s *dataSource) qualify(projectName string) string {
+	if s.name == "" {
+		return projectName
+	}
+	return s.name + sourceSeparator + projectName
+}
+
+// servedProject is a project resolved to the data dir that owns it
+type servedProject struct {
+	name      string // name used by the API and WebSocket updates
+	localName string // name in the owning data dir's registry
+	cfg       *config.Config
+	dbPath    string
+	info      *database.ProjectInfo
+}
+
+// serveCatalog resolves API project names across the served data dirs. mu
+// guards the sources' registries, which maintenance cleanup replaces while
+// handlers read them.
+type serveCatalog struct {
+	mu      sync.RWMutex
+	sources []*dataSource
+}
+
+// newServeCatalog creates a catalog serving the primary data dir
+func newServeCatalog(cfg *config.Config, registry *database.ProjectRegistry) *serveCatalog {
+	return &serveCatalog{
+		sources: []*dataSource{{cfg: cfg, registry: registry}},
+	}
+}
+
+// addDataDir loads the registry of another data dir and serves its projects
+// under a source name derived from the directory name
+func (c *serveCatalog) addDataDir(dataDir string) (*dataSource, error) {
+	absDir, err := filepath.Abs(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve data dir %s: %w", dataDir, err)
+	}
+	if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("data dir %s does not exist", dataDir)
+	}
+
+	// Extra data dirs share the primary settings but keep their own storage
+	sourceCfg := *c.sources[0].cfg
+	sourceCfg.DataDir = absDir
+
+	registry, err := database.LoadProjectRegistry(sourceCfg.GetProjectsPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project registry in %s: %w", dataDir, err)
+	}
+
+	source := &dataSource{
+		name:     c.uniqueSourceName(filepath.Base(absDir)),
+		cfg:      &sourceCfg,
+		registry: registry,
+	}
+	c.sources = append(c.sources, source)
+	return source, nil
+}
+
+// uniqueSourceName makes base safe to use as a name prefix and distinct from
+// the sources already in the catalog
+func (c *serveCatalog) uniqueSourceName(base string) string {
+	base = strings.ReplaceAll(strings.TrimPrefix(base, "."), sourceSeparator, "-")
+	if base == "" {
+		base = "data"
+	}
+
+	name := base
+	for i := 2; c.source(name) != nil; i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	return name
+}
+
+func (c *serveCatalog) source(name string) *dataSource {
+	for _, source := range c.sources {
+		if source.name == name {
+			return source
+		}
+	}
+	return nil
+}
+
+// resolve finds the data dir and registry entry behind an API project name
+func (c *serveCatalog) resolve(name string) (*servedProject, bool) {
+	source, localName := c.sources[0], name
+	if prefix, rest, ok := strings.Cut(name, sourceSeparator); ok {
+		if extra := c.source(prefix); extra != nil && prefix != "" {
+			source, localName = extra, rest
+		}
+	}
+
+	c.mu.RLock()
+	info, exists := source.registry.GetProjectByName(localName)
+	c.mu.RUnlock()
+	if !exists {
+		return nil, false
+	}
+
+	return &servedProject{
+		name:      name,
+		localName: localName,
+		cfg:       source.cfg,
+		dbPath:    source.cfg.GetProjectDatabasePath(localName),
+		info:      info,
+	}, true
+}
+
+// cleanupRegistry removes the source's projects whose directories no longer
+// exist. It reloads the registry from disk under the registry file lock, so
+// projects registered by 'quicktodo init' while serving are kept, and swaps
+// the result into the catalog.
+func (c *serveCatalog) cleanupRegistry(source *dataSource) ([]string, error) {
+	registryPath := source.cfg.GetProjectsPath()
+	lockManager, lockInfo, err := database.LockRegistry(registryPath, time.Duration(source.cfg.LockTimeout)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	defer lockManager.ReleaseLock(lockInfo)
+
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project registry: %w", err)
+	}
+	removed, err := registry.Cleanup()
+	if err != nil {
+		return nil, err
+	}
+	if len(removed) > 0 {
+		if err := registry.Save(registryPath); err != nil {
+			return nil, fmt.Errorf("failed to save project registry: %w", err)
+		}
+	}
+
+	c.mu.Lock()
+	source.registry = registry
+	c.mu.Unlock()
+
+	return removed, nil
+}
+
+// catalogProject is a project listed by the API along with its data source
+type catalogProject struct {
+	name   string
+	source *dataSource
+	info   *database.ProjectInfo
+}
+
+// projects lists every served project, grouped by source in catalog order
+func (c *serveCatalog) projects() []catalogProject {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var projects []catalogProject
+	for _, source := range c.sources {
+		var sourceProjects []catalogProject
+		for name, info := range source.registry.ListProjects() {
+			sourceProjects = append(sourceProjects, catalogProject{
+				name:   source.qualify(name),
+				source: source,
+				info:   info,
+			})
+		}
+		sort.Slice(sourceProjects, func(i, j int) bool { return sourceProjects[i].name < sourceProjects[j].name })
+		projects = append(projects, sourceProjects...)
+	}
+	return projects
+}
@@ -0,0 +1,1438 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// newTestProject creates a config rooted in a temp dir with one registered
+// project and returns the config, registry, and project name
+func newTestProject(t *testing.T) (*config.Config, *database.ProjectRegistry, string) {
+	t.Helper()
+
+	cfg := config.DefaultConfig()
+	cfg.DataDir = t.TempDir()
+	if err := cfg.EnsureAllDirectories(); err != nil {
+		t.Fatalf("Failed to create directories: %v", err)
+	}
+
+	projectName := "test-project"
+	registry := database.NewProjectRegistry()
+	if err := registry.RegisterProject(projectName, t.TempDir()); err != nil {
+		t.Fatalf("Failed to register project: %v", err)
+	}
+	if err := registry.Save(cfg.GetProjectsPath()); err != nil {
+		t.Fatalf("Failed to save registry: %v", err)
+	}
+
+	projectInfo, _ := registry.GetProjectByName(projectName)
+	db := models.NewProjectDatabase(models.NewProject(projectName, projectInfo.Path))
+	if err := saveProjectDatabase(cfg, db, cfg.GetProjectDatabasePath(projectName)); err != nil {
+		t.Fatalf("Failed to save project database: %v", err)
+	}
+
+	return cfg, registry, projectName
+}
+
+func TestAuthMiddlewareTokenTiers(t *testing.T) {
+	tokens := accessTokens{view: "view-secret", write: "write-secret"}
+	handler := authMiddleware(tokens, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		token    string
+		expected int
+	}{
+		{"GET with view token", http.MethodGet, "view-secret", http.StatusOK},
+		{"GET with write token", http.MethodGet, "write-secret", http.StatusOK},
+		{"POST with view token", http.MethodPost, "view-secret", http.StatusForbidden},
+		{"PUT with view token", http.MethodPut, "view-secret", http.StatusForbidden},
+		{"DELETE with view token", http.MethodDelete, "view-secret", http.StatusForbidden},
+		{"POST with write token", http.MethodPost, "write-secret", http.StatusOK},
+		{"GET without token", http.MethodGet, "", http.StatusUnauthorized},
+		{"POST with wrong token", http.MethodPost, "nope", http.StatusUnauthorized},
+		{"POST with truncated write token", http.MethodPost, "write-secre", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/api/projects", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+
+			handler(rec, req)
+
+			if rec.Code != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareQueryToken(t *testing.T) {
+	tokens := accessTokens{view: "view-secret", write: "write-secret"}
+	handler := authMiddleware(tokens, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ws?token=view-secret", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d for query token, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestAuthMiddlewareDisabledWithoutTokens(t *testing.T) {
+	handler := authMiddleware(accessTokens{}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/projects/demo/tasks", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected open access without tokens, got %d", rec.Code)
+	}
+}
+
+func TestHandleTaskHistoryEndpoint(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+
+	// Create a task, then change its status through the API
+	create := httptest.NewRequest(http.MethodPost, "/api/projects/"+projectName+"/tasks",
+		strings.NewReader(`{"title":"History task"}`))
+	handler(httptest.NewRecorder(), create)
+
+	update := httptest.NewRequest(http.MethodPut, "/api/projects/"+projectName+"/tasks/1",
+		strings.NewReader(`{"status":"in_progress"}`))
+	handler(httptest.NewRecorder(), update)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/projects/"+projectName+"/tasks/1/history", nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var events []models.TaskEvent
+	if err := json.Unmarshal(rec.Body.Bytes(), &events); err != nil {
+		t.Fatalf("Failed to parse history: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	if events[0].Field != models.EventFieldCreated || events[1].Field != "status" {
+		t.Errorf("Unexpected event order: %s, %s", events[0].Field, events[1].Field)
+	}
+
+	if events[1].Before != "pending" || events[1].After != "in_progress" {
+		t.Errorf("Unexpected status change: %s -> %s", events[1].Before, events[1].After)
+	}
+}
+
+func TestHandleGetTasksChangedSince(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+	tasksURL := "/api/projects/" + projectName + "/tasks"
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(`{"title":"Old task"}`)))
+	time.Sleep(10 * time.Millisecond)
+	since := time.Now().UTC()
+	time.Sleep(10 * time.Millisecond)
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(`{"title":"New task"}`)))
+
+	req := httptest.NewRequest(http.MethodGet, tasksURL+"?changed_since="+since.Format(time.RFC3339Nano), nil)
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Last-Modified") == "" {
+		t.Error("Expected Last-Modified header on task list")
+	}
+
+	var tasks []models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &tasks); err != nil {
+		t.Fatalf("Failed to parse tasks: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "New task" {
+		t.Errorf("Expected only the task changed since %s, got %+v", since, tasks)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, tasksURL+"?changed_since=yesterday-ish", nil)
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid changed_since, got %d", rec.Code)
+	}
+}
+
+func TestHandleGetTasksFilters(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+	tasksURL := "/api/projects/" + projectName + "/tasks"
+
+	for _, body := range []string{
+		`{"title":"Fix login bug","priority":"high","assigned_to":"alice"}`,
+		`{"title":"Write docs","description":"login flow","priority":"low","assigned_to":"bob"}`,
+		`{"title":"Refactor login","priority":"high","status":"done","assigned_to":"alice"}`,
+		`{"title":"Release","priority":"high","status":"in_progress"}`,
+	} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(body)))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Failed to create task %s: %d %s", body, rec.Code, rec.Body.String())
+		}
+	}
+
+	for query, expected := range map[string]string{
+		"":                                   "[1 2 3 4]",
+		"?status=pending":                    "[1 2]",
+		"?status=todo":                       "[1 2]",
+		"?priority=high":                     "[1 3 4]",
+		"?assigned_to=alice":                 "[1 3]",
+		"?priority=high&status=in_progress":  "[4]",
+		"?q=LOGIN":                           "[1 2 3]",
+		"?q=login&assigned_to=alice":         "[1 3]",
+		"?q=login&status=done&priority=high": "[3]",
+		"?q=nothing-matches":                 "[]",
+	} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, tasksURL+query, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("%q: expected 200, got %d: %s", query, rec.Code, rec.Body.String())
+			continue
+		}
+		var tasks []*models.Task
+		if err := json.Unmarshal(rec.Body.Bytes(), &tasks); err != nil {
+			t.Fatalf("%q: failed to parse tasks: %v", query, err)
+		}
+		if got := fmt.Sprint(serveTaskIDs(tasks)); got != expected {
+			t.Errorf("%q: expected tasks %s, got %s", query, expected, got)
+		}
+	}
+
+	for _, query := range []string{"?status=sideways", "?priority=urgent", "?q=login&status=bogus"} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, tasksURL+query, nil))
+		var body struct {
+			Error string `json:"error"`
+		}
+		if rec.Code != http.StatusBadRequest || json.Unmarshal(rec.Body.Bytes(), &body) != nil || body.Error == "" {
+			t.Errorf("%q: expected 400 with a JSON error, got %d: %s", query, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func serveTaskIDs(tasks []*models.Task) []int {
+	ids := make([]int, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func TestHandleCreateTaskEnforcesLimits(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	cfg.MaxTitleLength = 20
+	cfg.MaxDescriptionLength = 50
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+	tasksURL := "/api/projects/" + projectName + "/tasks"
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"oversized title", `{"title":"` + strings.Repeat("x", 21) + `"}`},
+		{"oversized description", `{"title":"ok","description":"` + strings.Repeat("y", 51) + `"}`},
+		{"control characters only", `{"title":"\u0000\u0007\n\t"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(tt.body)))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("Expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if strings.TrimSpace(rec.Body.String()) == "" {
+				t.Error("Expected an error message in the response body")
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(`{"title":"Clean\u0000 me\u001b\n"}`)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var task models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &task); err != nil {
+		t.Fatalf("Failed to parse task: %v", err)
+	}
+	if task.Title != "Clean me" {
+		t.Errorf("Expected control characters stripped from title, got %q", task.Title)
+	}
+}
+
+func TestHandleCreateTaskInitialStatus(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+	tasksURL := "/api/projects/" + projectName + "/tasks"
+
+	for _, status := range models.ValidStatuses() {
+		t.Run(string(status), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			body := `{"title":"Backfilled","status":"` + string(status) + `"}`
+			handler(rec, httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(body)))
+			if rec.Code != http.StatusCreated {
+				t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+			}
+
+			var task models.Task
+			if err := json.Unmarshal(rec.Body.Bytes(), &task); err != nil {
+				t.Fatalf("Failed to parse task: %v", err)
+			}
+			if task.Status != status {
+				t.Errorf("Expected status %s, got %s", status, task.Status)
+			}
+			if (task.CompletedAt != nil) != (status == models.StatusDone) {
+				t.Errorf("Expected CompletedAt set only for done tasks, got %v", task.CompletedAt)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(`{"title":"Bad","status":"sideways"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid status, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleCreateTaskConcurrent(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+	tasksURL := "/api/projects/" + projectName + "/tasks"
+
+	const creates = 20
+	var wg sync.WaitGroup
+	statuses := make([]int, creates)
+	for i := 0; i < creates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			body := fmt.Sprintf(`{"title":"Task %d"}`, i)
+			handler(rec, httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(body)))
+			statuses[i] = rec.Code
+		}(i)
+	}
+	// Edits racing the creates must not drop any of them either
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, tasksURL+"/1", strings.NewReader(`{"priority":"high"}`)))
+		}()
+	}
+	wg.Wait()
+
+	for i, status := range statuses {
+		if status != http.StatusCreated {
+			t.Errorf("Create %d: expected status %d, got %d", i, http.StatusCreated, status)
+		}
+	}
+
+	db, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectName))
+	if err != nil {
+		t.Fatalf("Failed to load project database: %v", err)
+	}
+	if len(db.Tasks) != creates || db.NextID != creates+1 {
+		t.Fatalf("Expected %d tasks and NextID %d, got %d tasks and NextID %d", creates, creates+1, len(db.Tasks), db.NextID)
+	}
+	seen := make(map[int]bool)
+	for _, task := range db.Tasks {
+		if seen[task.ID] {
+			t.Errorf("Task ID %d was assigned twice", task.ID)
+		}
+		seen[task.ID] = true
+	}
+
+	locks, err := database.NewLockManager(cfg.DataDir+"/locks", cfg.LockTimeout).GetActiveLocks()
+	if err != nil || len(locks) != 0 {
+		t.Errorf("Expected every lock to be released, got %v (%v)", locks, err)
+	}
+}
+
+func TestHandleUpdateTaskEnforcesLimits(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	cfg.MaxTitleLength = 20
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/projects/"+projectName+"/tasks",
+		strings.NewReader(`{"title":"Original"}`)))
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPut, "/api/projects/"+projectName+"/tasks/1",
+		strings.NewReader(`{"title":"`+strings.Repeat("z", 21)+`"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/projects/"+projectName+"/tasks/1", nil))
+	var task models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &task); err != nil {
+		t.Fatalf("Failed to parse task: %v", err)
+	}
+	if task.Title != "Original" {
+		t.Errorf("Expected rejected update to leave title unchanged, got %q", task.Title)
+	}
+}
+
+func TestHandlersLabelOperations(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+	tasksURL := "/api/projects/" + projectName + "/tasks"
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(`{"title":"Original"}`)))
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, tasksURL+"/1", strings.NewReader(`{"title":"Renamed"}`)))
+
+	ops, err := database.LoadOperations(cfg.GetProjectOpsPath(projectName))
+	if err != nil {
+		t.Fatalf("LoadOperations failed: %v", err)
+	}
+	if len(ops) != 2 || ops[0].Command != "api: POST "+tasksURL || ops[1].Command != "api: PUT "+tasksURL+"/1" {
+		var commands []string
+		for _, op := range ops {
+			commands = append(commands, op.Command)
+		}
+		t.Errorf("Expected the API requests as operation labels, got %q", commands)
+	}
+}
+
+func TestHandleReorderTasks(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/projects/"+projectName+"/tasks",
+			strings.NewReader(`{"title":"`+title+`"}`)))
+	}
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPut, "/api/projects/"+projectName+"/tasks/reorder",
+		strings.NewReader(`{"pending":[3,1]}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// The order is saved, so a fresh listing comes back in board order
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/projects/"+projectName+"/tasks", nil))
+	var tasks []*models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &tasks); err != nil {
+		t.Fatalf("Failed to parse tasks: %v", err)
+	}
+	var ids []int
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	if fmt.Sprint(ids) != "[3 1 2]" {
+		t.Errorf("Expected tasks in board order [3 1 2], got %v", ids)
+	}
+
+	for _, body := range []string{`{"done":[1]}`, `{"pending":[1,1]}`, `{"pending":[99]}`, `{"someday":[1]}`, `not json`} {
+		rec = httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodPut, "/api/projects/"+projectName+"/tasks/reorder", strings.NewReader(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d: %s", body, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestHandleCreateTaskDefaultTags(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+
+	dbPath := cfg.GetProjectDatabasePath(projectName)
+	db, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	db.Project.AddDefaultTag("backend")
+	if err := saveProjectDatabase(cfg, db, dbPath); err != nil {
+		t.Fatalf("Failed to save project: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/api/projects/"+projectName+"/tasks", strings.NewReader(`{"title":"From the board"}`)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var task models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &task); err != nil {
+		t.Fatalf("Failed to parse task: %v", err)
+	}
+	if len(task.Tags) != 1 || task.Tags[0] != "backend" {
+		t.Errorf("Expected the web-created task to inherit the default tag, got %v", task.Tags)
+	}
+}
+
+func TestHandleAddComment(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/projects/"+projectName+"/tasks",
+		strings.NewReader(`{"title":"Discussed"}`)))
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/api/projects/"+projectName+"/tasks/1/comments",
+		strings.NewReader(`{"text":"Looks good"}`)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var comment models.Comment
+	if err := json.Unmarshal(rec.Body.Bytes(), &comment); err != nil {
+		t.Fatalf("Failed to parse comment: %v", err)
+	}
+	if comment.Author != webActor || comment.Text != "Looks good" {
+		t.Errorf("Unexpected comment: %+v", comment)
+	}
+
+	for path, want := range map[string]int{
+		"/tasks/1/comments":  http.StatusBadRequest,
+		"/tasks/99/comments": http.StatusNotFound,
+	} {
+		rec = httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodPost, "/api/projects/"+projectName+path, strings.NewReader(`{"text":" "}`)))
+		if rec.Code != want {
+			t.Errorf("Expected %d for %s, got %d: %s", want, path, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/projects/"+projectName+"/tasks/1", nil))
+	var task models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &task); err != nil {
+		t.Fatalf("Failed to parse task: %v", err)
+	}
+	if len(task.Comments) != 1 || task.Comments[0].Text != "Looks good" {
+		t.Errorf("Expected the comment to be saved, got %+v", task.Comments)
+	}
+}
+
+func TestHandleAgentTasks(t *testing.T) {
+	cfg, registry, firstProject := newTestProject(t)
+
+	secondProject := "second-project"
+	if err := registry.RegisterProject(secondProject, t.TempDir()); err != nil {
+		t.Fatalf("Failed to register project: %v", err)
+	}
+	secondInfo, _ := registry.GetProjectByName(secondProject)
+	if err := saveProjectDatabase(cfg, models.NewProjectDatabase(models.NewProject(secondProject, secondInfo.Path)), cfg.GetProjectDatabasePath(secondProject)); err != nil {
+		t.Fatalf("Failed to save project database: %v", err)
+	}
+
+	catalog := newServeCatalog(cfg, registry)
+	tasksHandler := handleProjectTasks(catalog)
+	for _, input := range []struct{ project, body string }{
+		{firstProject, `{"title":"Alpha one","assigned_to":"alpha"}`},
+		{firstProject, `{"title":"Beta one","assigned_to":"beta"}`},
+		{firstProject, `{"title":"Alpha done","assigned_to":"alpha","status":"done"}`},
+		{secondProject, `{"title":"Alpha two","assigned_to":"alpha","status":"in_progress"}`},
+		{secondProject, `{"title":"Beta two","assigned_to":"beta"}`},
+		{secondProject, `{"title":"Unassigned"}`},
+	} {
+		rec := httptest.NewRecorder()
+		tasksHandler(rec, httptest.NewRequest(http.MethodPost, "/api/projects/"+input.project+"/tasks", strings.NewReader(input.body)))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("Failed to create task: %d %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	handler := handleAgentTasks(catalog)
+	get := func(url string) (agentTasksPage, map[string][]string) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200 for %s, got %d: %s", url, rec.Code, rec.Body.String())
+		}
+		var page agentTasksPage
+		if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+			t.Fatalf("Failed to parse page: %v", err)
+		}
+		titles := make(map[string][]string)
+		for _, group := range page.Projects {
+			for _, task := range group.Tasks {
+				titles[group.Project] = append(titles[group.Project], task.Title)
+			}
+		}
+		return page, titles
+	}
+
+	page, titles := get("/api/agents/alpha/tasks")
+	if page.Total != 3 || fmt.Sprint(titles[firstProject]) != "[Alpha one Alpha done]" || fmt.Sprint(titles[secondProject]) != "[Alpha two]" {
+		t.Errorf("Expected alpha's three tasks grouped by project, got %d: %v", page.Total, titles)
+	}
+
+	page, titles = get("/api/agents/beta/tasks?status=pending")
+	if page.Total != 2 || fmt.Sprint(titles[firstProject]) != "[Beta one]" || fmt.Sprint(titles[secondProject]) != "[Beta two]" {
+		t.Errorf("Expected beta's two pending tasks, got %d: %v", page.Total, titles)
+	}
+
+	// Projects are paged in name order, so second-project's task comes first
+	page, titles = get("/api/agents/alpha/tasks?offset=1&limit=1")
+	if page.Total != 3 || len(page.Projects) != 1 || fmt.Sprint(titles[firstProject]) != "[Alpha one]" {
+		t.Errorf("Expected the second of alpha's tasks only, got %d: %v", page.Total, titles)
+	}
+
+	if page, _ := get("/api/agents/nobody/tasks"); page.Total != 0 || len(page.Projects) != 0 {
+		t.Errorf("Expected no tasks for an unknown agent, got %+v", page)
+	}
+
+	for _, url := range []string{"/api/agents/alpha/tasks?status=someday", "/api/agents/alpha/tasks?limit=-1", "/api/agents/alpha"} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", url, rec.Code)
+		}
+	}
+}
+
+func TestServeExtraDataDir(t *testing.T) {
+	cfg, registry, primaryProject := newTestProject(t)
+
+	// A second data dir, e.g. another team's, with a project of the same name
+	teamDir := filepath.Join(t.TempDir(), "team-b")
+	teamCfg := *cfg
+	teamCfg.DataDir = teamDir
+	if err := teamCfg.EnsureAllDirectories(); err != nil {
+		t.Fatalf("Failed to create directories: %v", err)
+	}
+	teamRegistry := database.NewProjectRegistry()
+	if err := teamRegistry.RegisterProject(primaryProject, t.TempDir()); err != nil {
+		t.Fatalf("Failed to register project: %v", err)
+	}
+	if err := teamRegistry.Save(teamCfg.GetProjectsPath()); err != nil {
+		t.Fatalf("Failed to save registry: %v", err)
+	}
+	teamInfo, _ := teamRegistry.GetProjectByName(primaryProject)
+	teamDB := models.NewProjectDatabase(models.NewProject(primaryProject, teamInfo.Path))
+	if err := saveProjectDatabase(&teamCfg, teamDB, teamCfg.GetProjectDatabasePath(primaryProject)); err != nil {
+		t.Fatalf("Failed to save project database: %v", err)
+	}
+
+	catalog := newServeCatalog(cfg, registry)
+	if _, err := catalog.addDataDir(teamDir); err != nil {
+		t.Fatalf("addDataDir failed: %v", err)
+	}
+	teamProject := "team-b:" + primaryProject
+
+	// Both projects are listed, tagged with their source
+	rec := httptest.NewRecorder()
+	handleProjects(catalog)(rec, httptest.NewRequest(http.MethodGet, "/api/projects", nil))
+	var projects []map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &projects); err != nil {
+		t.Fatalf("Failed to parse projects: %v", err)
+	}
+	sources := make(map[string]interface{})
+	for _, project := range projects {
+		sources[project["name"].(string)] = project["source"]
+	}
+	if len(sources) != 2 || sources[primaryProject] != "" || sources[teamProject] != "team-b" {
+		t.Fatalf("Expected projects from both data dirs, got %v", projects)
+	}
+
+	// A task created through the namespaced name lands in the team data dir only
+	handler := handleProjectTasks(catalog)
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/api/projects/"+teamProject+"/tasks",
+		strings.NewReader(`{"title":"Team task"}`)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	teamDB, err := loadProjectDatabase(&teamCfg, teamCfg.GetProjectDatabasePath(primaryProject))
+	if err != nil {
+		t.Fatalf("Failed to load team database: %v", err)
+	}
+	if len(teamDB.Tasks) != 1 || teamDB.Tasks[0].Title != "Team task" {
+		t.Errorf("Expected the task in the team data dir, got %v", teamDB.Tasks)
+	}
+
+	primaryDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(primaryProject))
+	if err != nil {
+		t.Fatalf("Failed to load primary database: %v", err)
+	}
+	if len(primaryDB.Tasks) != 0 {
+		t.Errorf("Expected the primary data dir to be untouched, got %v", primaryDB.Tasks)
+	}
+
+	// Edits are routed the same way
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPut, "/api/projects/"+teamProject+"/tasks/1",
+		strings.NewReader(`{"status":"done"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	teamDB, _ = loadProjectDatabase(&teamCfg, teamCfg.GetProjectDatabasePath(primaryProject))
+	if teamDB.Tasks[0].Status != models.StatusDone {
+		t.Errorf("Expected the edit to land in the team data dir, got %s", teamDB.Tasks[0].Status)
+	}
+
+	rec = httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/projects/unknown:"+primaryProject+"/tasks", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown source, got %d", rec.Code)
+	}
+}
+
+func TestHandleGetWIPStates(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	cfg.WIPLimits = map[string]int{"in_progress": 1}
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+
+	for _, body := range []string{`{"title":"One"}`, `{"title":"Two"}`} {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/projects/"+projectName+"/tasks", strings.NewReader(body)))
+	}
+	for _, id := range []string{"1", "2"} {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/api/projects/"+projectName+"/tasks/"+id,
+			strings.NewReader(`{"status":"in_progress"}`)))
+	}
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/projects/"+projectName+"/wip", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var states []models.WIPState
+	if err := json.Unmarshal(rec.Body.Bytes(), &states); err != nil {
+		t.Fatalf("Failed to parse WIP states: %v", err)
+	}
+	if len(states) != 1 || states[0].Status != models.StatusInProgress || states[0].Count != 2 || !states[0].OverLimit {
+		t.Errorf("Expected in_progress over its limit, got %+v", states)
+	}
+}
+
+func TestHandleTaskHistoryFieldAndPaging(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+	taskURL := "/api/projects/" + projectName + "/tasks/1"
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/projects/"+projectName+"/tasks",
+		strings.NewReader(`{"title":"History task"}`)))
+	for _, body := range []string{
+		`{"status":"in_progress"}`,
+		`{"title":"Renamed"}`,
+		`{"status":"done"}`,
+		`{"status":"pending"}`,
+	} {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, taskURL, strings.NewReader(body)))
+	}
+
+	get := func(query string) ([]models.TaskEvent, *httptest.ResponseRecorder) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, taskURL+"/history"+query, nil))
+		var events []models.TaskEvent
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &events); err != nil {
+				t.Fatalf("Failed to parse history: %v", err)
+			}
+		}
+		return events, rec
+	}
+
+	events, rec := get("?field=status")
+	if len(events) != 3 || rec.Header().Get("X-Total-Count") != "3" {
+		t.Fatalf("Expected 3 status events, got %d (total %s)", len(events), rec.Header().Get("X-Total-Count"))
+	}
+	for _, event := range events {
+		if event.Field != "status" {
+			t.Errorf("Expected only status events, got %s", event.Field)
+		}
+	}
+
+	events, rec = get("?field=status&offset=1&limit=1")
+	if len(events) != 1 || events[0].After != "done" || rec.Header().Get("X-Total-Count") != "3" {
+		t.Errorf("Expected the second status event of 3, got %+v (total %s)", events, rec.Header().Get("X-Total-Count"))
+	}
+
+	events, _ = get("?limit=2")
+	if len(events) != 2 || events[0].Field != models.EventFieldCreated {
+		t.Errorf("Expected the first 2 events, got %+v", events)
+	}
+
+	for _, query := range []string{"?field=stauts", "?limit=-1", "?offset=abc"} {
+		if _, rec := get(query); rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestHandleGetProjectMeta(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := handleProjectTasks(newServeCatalog(cfg, registry))
+	metaURL := "/api/projects/" + projectName + "/meta"
+
+	getMeta := func() map[string]interface{} {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, metaURL, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var meta map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &meta); err != nil {
+			t.Fatalf("Failed to parse meta: %v", err)
+		}
+		return meta
+	}
+
+	meta := getMeta()
+	if meta["locked"] != false {
+		t.Errorf("Expected no lock held, got %v", meta)
+	}
+	if _, ok := meta["version"]; !ok {
+		t.Error("Expected version in meta")
+	}
+	if _, ok := meta["last_modified"]; !ok {
+		t.Error("Expected last_modified in meta")
+	}
+
+	// A lock held by this (running) process counts as a write in progress
+	lockManager := database.NewLockManager(cfg.DataDir+"/locks", cfg.LockTimeout)
+	lockInfo, err := lockManager.AcquireLock(projectName)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	meta = getMeta()
+	if meta["locked"] != true || meta["locked_since"] == nil {
+		t.Errorf("Expected the active lock reported, got %v", meta)
+	}
+
+	if err := lockManager.ReleaseLock(lockInfo); err != nil {
+		t.Fatalf("Failed to release lock: %v", err)
+	}
+	if meta = getMeta(); meta["locked"] != false {
+		t.Errorf("Expected the lock cleared after release, got %v", meta)
+	}
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/api/projects/missing/meta", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown project, got %d", rec.Code)
+	}
+}
+
+func TestHandleMaintenanceCleanup(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+
+	// A project whose directory has been deleted
+	goneDir := t.TempDir()
+	if err := registry.RegisterProject("gone", goneDir); err != nil {
+		t.Fatalf("Failed to register project: %v", err)
+	}
+	if err := os.RemoveAll(goneDir); err != nil {
+		t.Fatalf("Failed to remove project directory: %v", err)
+	}
+	if err := registry.Save(cfg.GetProjectsPath()); err != nil {
+		t.Fatalf("Failed to save registry: %v", err)
+	}
+
+	// A lock abandoned long ago, and a live one that must survive
+	lockDir := cfg.DataDir + "/locks"
+	stale := fmt.Sprintf("%d\n%s\n", os.Getpid(), time.Now().Add(-time.Hour).Format(time.RFC3339))
+	if err := os.WriteFile(lockDir+"/gone.lock", []byte(stale), 0644); err != nil {
+		t.Fatalf("Failed to write lock: %v", err)
+	}
+	lockManager := database.NewLockManager(lockDir, cfg.LockTimeout)
+	lockInfo, err := lockManager.AcquireLock(projectName)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer lockManager.ReleaseLock(lockInfo)
+
+	tokens := accessTokens{view: "view-secret", write: "write-secret"}
+	catalog := newServeCatalog(cfg, registry)
+	handler := authMiddleware(tokens, handleMaintenanceCleanup(catalog))
+
+	// A project registered by 'quicktodo init' after the server started
+	onDisk, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		t.Fatalf("Failed to load registry: %v", err)
+	}
+	if err := onDisk.RegisterProject("late", t.TempDir()); err != nil {
+		t.Fatalf("Failed to register project: %v", err)
+	}
+	if err := onDisk.Save(cfg.GetProjectsPath()); err != nil {
+		t.Fatalf("Failed to save registry: %v", err)
+	}
+
+	// Cleanup changes state, so the view token is not enough
+	req := httptest.NewRequest(http.MethodPost, "/api/maintenance/cleanup", nil)
+	req.Header.Set("Authorization", "Bearer view-secret")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected 403 with the view token, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/maintenance/cleanup", nil)
+	req.Header.Set("Authorization", "Bearer write-secret")
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var result struct {
+		RemovedProjects []string `json:"removed_projects"`
+		RemovedLocks    []string `json:"removed_locks"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(result.RemovedProjects) != 1 || result.RemovedProjects[0] != "gone" {
+		t.Errorf("Expected project 'gone' removed, got %v", result.RemovedProjects)
+	}
+	if len(result.RemovedLocks) != 1 || result.RemovedLocks[0] != "gone" {
+		t.Errorf("Expected the stale lock removed, got %v", result.RemovedLocks)
+	}
+
+	// The cleaned registry is saved and the live project and lock remain
+	saved, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		t.Fatalf("Failed to load registry: %v", err)
+	}
+	if _, exists := saved.GetProjectByName("gone"); exists {
+		t.Error("Expected the dead project removed from the saved registry")
+	}
+	if _, exists := saved.GetProjectByName(projectName); !exists {
+		t.Error("Expected the live project to remain registered")
+	}
+	if _, exists := saved.GetProjectByName("late"); !exists {
+		t.Error("Expected the project registered while serving to remain registered")
+	}
+	if _, exists := catalog.resolve("late"); !exists {
+		t.Error("Expected the reloaded registry to be served")
+	}
+	if _, exists := catalog.resolve("gone"); exists {
+		t.Error("Expected the removed project to no longer be served")
+	}
+	if _, err := os.Stat(lockInfo.FilePath); err != nil {
+		t.Errorf("Expected the live lock to remain: %v", err)
+	}
+}
+
+func TestHandleOpenAPI(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handleOpenAPI(rec, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+
+	var spec struct {
+		OpenAPI    string                            `json:"openapi"`
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Expected valid JSON: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Errorf("Expected an OpenAPI 3 document, got version %q", spec.OpenAPI)
+	}
+
+	for path, methods := range map[string][]string{
+		"/api/projects":                               {"get"},
+		"/api/projects/{project}/tasks":               {"get", "post"},
+		"/api/projects/{project}/tasks/{id}":          {"get", "put", "delete"},
+		"/api/projects/{project}/tasks/{id}/history":  {"get"},
+		"/api/projects/{project}/wip":                 {"get"},
+		"/api/projects/{project}/meta":                {"get"},
+		"/api/projects/{project}/tasks/reorder":       {"put"},
+		"/api/projects/{project}/tasks/{id}/comments": {"post"},
+		"/api/agents/{agent}/tasks":                   {"get"},
+	} {
+		for _, method := range methods {
+			if _, ok := spec.Paths[path][method]; !ok {
+				t.Errorf("Expected %s %s in the spec", method, path)
+			}
+		}
+	}
+
+	// The task schema is derived from the model, including newer fields
+	task, ok := spec.Components.Schemas["Task"]
+	if !ok {
+		t.Fatal("Expected a Task schema")
+	}
+	for _, field := range []string{"id", "title", "status", "due_date", "attachments", "checklist", "checklist_progress"} {
+		if _, ok := task.Properties[field]; !ok {
+			t.Errorf("Expected Task schema property %q", field)
+		}
+	}
+
+	// Every $ref must resolve to a component
+	for _, match := range regexp.MustCompile(`"#/components/schemas/([^"]+)"`).FindAllStringSubmatch(rec.Body.String(), -1) {
+		if _, ok := spec.Components.Schemas[match[1]]; !ok {
+			t.Errorf("Unresolved schema reference %s", match[1])
+		}
+	}
+}
+
+func TestResolveServeAddress(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().IntP("port", "p", config.DefaultServePort, "")
+		cmd.Flags().String("host", "", "")
+		return cmd
+	}
+	cfg := config.DefaultConfig()
+
+	// Nothing configured: flag defaults
+	if host, port := resolveServeAddress(newCmd(), cfg); host != "" || port != config.DefaultServePort {
+		t.Errorf("Expected :%d, got %s:%d", config.DefaultServePort, host, port)
+	}
+
+	// Config values are used when no flag is passed
+	cfg.ServeHost = "127.0.0.1"
+	cfg.ServePort = 9191
+	if host, port := resolveServeAddress(newCmd(), cfg); host != "127.0.0.1" || port != 9191 {
+		t.Errorf("Expected config address 127.0.0.1:9191, got %s:%d", host, port)
+	}
+
+	// Flags override the config, even when set to the flag default
+	cmd := newCmd()
+	cmd.Flags().Set("port", "8080")
+	cmd.Flags().Set("host", "0.0.0.0")
+	if host, port := resolveServeAddress(cmd, cfg); host != "0.0.0.0" || port != 8080 {
+		t.Errorf("Expected flag address 0.0.0.0:8080, got %s:%d", host, port)
+	}
+
+	if got := serveURL("0.0.0.0", 8080); got != "http://localhost:8080" {
+		t.Errorf("Expected wildcard host to open localhost, got %s", got)
+	}
+	if got := serveURL("127.0.0.1", 9191); got != "http://127.0.0.1:9191" {
+		t.Errorf("Expected configured host in URL, got %s", got)
+	}
+}
+
+func TestStaticFileSystemFromDisk(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "static")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatalf("Failed to create static dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<p>edited on disk</p>"), 0644); err != nil {
+		t.Fatalf("Failed to write index.html: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(parent, "secret.txt"), []byte("do not serve"), 0644); err != nil {
+		t.Fatalf("Failed to write secret: %v", err)
+	}
+	if err := os.Symlink(filepath.Join(parent, "secret.txt"), filepath.Join(dir, "link.txt")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	get := func(staticDir, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		staticFS, err := staticFileSystem(staticDir)
+		if err != nil {
+			t.Fatalf("staticFileSystem(%q) failed: %v", staticDir, err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = path
+		w := httptest.NewRecorder()
+		http.FileServer(http.FS(staticFS)).ServeHTTP(w, req)
+		return w
+	}
+
+	// The file on disk replaces the embedded one
+	if w := get(dir, "/"); w.Code != http.StatusOK || w.Body.String() != "<p>edited on disk</p>" {
+		t.Errorf("Expected the on-disk index.html, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := get("", "/"); w.Code != http.StatusOK || strings.Contains(w.Body.String(), "edited on disk") {
+		t.Errorf("Expected the embedded index.html without --static-dir, got %d", w.Code)
+	}
+
+	// Nothing outside the directory is reachable
+	for _, path := range []string{"/../secret.txt", "/link.txt"} {
+		if w := get(dir, path); strings.Contains(w.Body.String(), "do not serve") {
+			t.Errorf("Expected %s to stay inside the static dir, got %d: %s", path, w.Code, w.Body.String())
+		}
+	}
+
+	if _, err := staticFileSystem(filepath.Join(parent, "missing")); err == nil {
+		t.Error("Expected an error for a missing static dir")
+	}
+}
+
+func TestHandleCommand(t *testing.T) {
+	// The command runs in a separate process that reads the config from HOME
+	t.Setenv("HOME", t.TempDir())
+	cfg, registry, projectName := newTestProject(t)
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	executable := filepath.Join(t.TempDir(), "quicktodo")
+	if output, err := exec.Command("go", "build", "-o", executable, "quicktodo").CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build binary: %v, output: %s", err, output)
+	}
+
+	tokens := accessTokens{write: "write-secret"}
+	handler := authMiddleware(tokens, handleCommand(newServeCatalog(cfg, registry), tokens, executable))
+	run := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/command", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer write-secret")
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	rec := run(`{"command":"create-task","args":["--looks like a flag"],"flags":{"priority":"high","description":"From afar"},"project":"` + projectName + `"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from create-task, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var created struct {
+		Success bool         `json:"success"`
+		Task    *models.Task `json:"task"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Expected the CLI's JSON output: %v, body: %s", err, rec.Body.String())
+	}
+	if !created.Success || created.Task == nil || created.Task.Title != "--looks like a flag" || created.Task.Priority != models.PriorityHigh || created.Task.Description != "From afar" {
+		t.Errorf("Unexpected created task: %s", rec.Body.String())
+	}
+
+	rec = run(`{"command":"set-task-status","args":["1","done"],"project":"` + projectName + `"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from set-task-status, got %d: %s", rec.Code, rec.Body.String())
+	}
+	db, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectName))
+	if err != nil {
+		t.Fatalf("Failed to load project database: %v", err)
+	}
+	if task, err := db.GetTask(1); err != nil || task.Status != models.StatusDone {
+		t.Errorf("Expected task 1 to be done on disk, got %+v (%v)", task, err)
+	}
+
+	// Failures report the CLI's error with a non-2xx status
+	rec = run(`{"command":"display-task","args":["99"],"project":"` + projectName + `"}`)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "not found") {
+		t.Errorf("Expected 422 with the error for a missing task, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	for body, code := range map[string]int{
+		`{"command":"no-such-command"}`:                          http.StatusBadRequest,
+		`{"command":"serve"}`:                                    http.StatusBadRequest,
+		`{"command":"list-tasks","flags":{"--bad":true}}`:        http.StatusBadRequest,
+		`{"command":"list-tasks","flags":{"data-dir":"/tmp/x"}}`: http.StatusBadRequest,
+		`{"command":"list-tasks","project":"missing-project"}`:   http.StatusNotFound,
+	} {
+		if rec := run(body); rec.Code != code {
+			t.Errorf("Expected %d for %s, got %d: %s", code, body, rec.Code, rec.Body.String())
+		}
+	}
+
+	// A view token isn't enough, and without a write token the endpoint is off
+	req := httptest.NewRequest(http.MethodPost, "/api/command", strings.NewReader(`{"command":"list-tasks"}`))
+	req.Header.Set("Authorization", "Bearer view-secret")
+	rec = httptest.NewRecorder()
+	authMiddleware(accessTokens{view: "view-secret", write: "write-secret"}, handleCommand(newServeCatalog(cfg, registry), tokens, executable))(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 with a view token, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	handleCommand(newServeCatalog(cfg, registry), accessTokens{}, executable)(rec, httptest.NewRequest(http.MethodPost, "/api/command", strings.NewReader(`{"command":"list-tasks"}`)))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 without a write token, got %d", rec.Code)
+	}
+}
+
+func TestCommandArgv(t *testing.T) {
+	argv, err := commandArgv(commandRequest{
+		Command: "saved-filter save",
+		Args:    []string{"mine"},
+		Flags:   map[string]interface{}{"status": []interface{}{"pending", "in_progress"}, "priority": "high"},
+	})
+	if err != nil {
+		t.Fatalf("commandArgv failed: %v", err)
+	}
+	expected := "saved-filter save --priority=high --status=pending --status=in_progress --json -- mine"
+	if got := strings.Join(argv, " "); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	argv, err = commandArgv(commandRequest{Command: "list-tasks", Flags: map[string]interface{}{"limit": float64(5), "all": true}})
+	if err != nil {
+		t.Fatalf("commandArgv failed: %v", err)
+	}
+	if got := strings.Join(argv, " "); got != "list-tasks --all=true --limit=5 --json" {
+		t.Errorf("Expected numbers and booleans as flag values, got %q", got)
+	}
+
+	if _, err := commandArgv(commandRequest{Command: "list-tasks", Flags: map[string]interface{}{"status": nil}}); err == nil {
+		t.Error("Expected an error for a null flag value")
+	}
+
+	// Global flags and flags naming files would reach past the project
+	for _, flag := range []string{"data-dir", "json", "agent-id", "output-file", "no-such-flag"} {
+		if _, err := commandArgv(commandRequest{Command: "list-tasks", Flags: map[string]interface{}{flag: "/tmp/x"}}); err == nil {
+			t.Errorf("Expected --%s to be refused", flag)
+		}
+	}
+}
+
+func TestOriginAllowlist(t *testing.T) {
+	origins := originAllowlist{"http://board.lan:8080", "https://dash.example.com/"}
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"http://localhost:3000", true},
+		{"http://127.0.0.1:8080", true},
+		{"http://board.lan:8080", true}, // the listen address
+		{"http://board.lan:9090", false},
+		{"https://dash.example.com", true},
+		{"https://DASH.example.com", true},
+		{"https://dash.example.com:8443", false},
+		{"https://evil.example.com", false},
+		{"http://localhost.evil.com", false},
+		{"null", false},
+	}
+	for _, tt := range tests {
+		if got := origins.allows(tt.origin); got != tt.want {
+			t.Errorf("allows(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	if !originAllowlist(nil).checkOrigin(req) {
+		t.Error("Expected a request without an Origin to be accepted")
+	}
+	req.Header.Set("Origin", "https://dash.example.com")
+	if originAllowlist(nil).checkOrigin(req) {
+		t.Error("Expected an unlisted origin to be rejected by default")
+	}
+
+	// A rebound hostname sends an Origin that matches the Host it reached
+	req = httptest.NewRequest(http.MethodGet, "http://attacker.example:8080/ws", nil)
+	req.Header.Set("Origin", "http://attacker.example:8080")
+	if originAllowlist(nil).checkOrigin(req) {
+		t.Error("Expected an origin matching only the Host header to be rejected")
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	handler := corsMiddleware(originAllowlist{"https://dash.example.com"}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+	req.Header.Set("Origin", "https://dash.example.com")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://dash.example.com" {
+		t.Errorf("Expected the allowed origin to be echoed, got %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no CORS header for another origin, got %q", got)
+	}
+}
+
+func TestCORSMiddlewareRejectsCrossSiteWrites(t *testing.T) {
+	cfg, registry, projectName := newTestProject(t)
+	handler := corsMiddleware(nil, handleProjectTasks(newServeCatalog(cfg, registry)))
+	tasksURL := "/api/projects/" + projectName + "/tasks"
+
+	// A form POST from another site needs no preflight
+	req := httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader("title=Planted"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Origin", "https://evil.example")
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected 403 for a POST from another origin, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// After DNS rebinding the attacker's Origin and Host agree
+	req = httptest.NewRequest(http.MethodPost, "http://attacker.example:8080"+tasksURL, strings.NewReader(`{"title":"Planted"}`))
+	req.Header.Set("Origin", "http://attacker.example:8080")
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("Expected 403 for a POST from a rebound hostname, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	db, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectName))
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	if len(db.Tasks) != 0 {
+		t.Errorf("Expected no task created, got %+v", db.Tasks)
+	}
+
+	// Reads and same-site writes still go through
+	req = httptest.NewRequest(http.MethodGet, tasksURL, nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Expected the GET served without CORS headers, got %d, %v", rec.Code, rec.Header())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, tasksURL, strings.NewReader(`{"title":"Local"}`))
+	req.Header.Set("Origin", "http://localhost:8080")
+	rec = httptest.NewRecorder()
+	handler(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Errorf("Expected 201 for a POST from localhost, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+// TestHubFloodResync floods the hub with updates and checks that every client,
+// fast or slow, ends up with all of them, either delivered or through a resync
+func TestHubFloodResync(t *testing.T) {
+	const updates = 1000
+
+	h := newHub()
+	go h.run()
+
+	var issued atomic.Int64
+	type floodClient struct {
+		*Client
+		mu      sync.Mutex
+		seen    map[int]bool
+		resyncs int
+	}
+	consume := func(c *floodClient) {
+		for message := range c.send {
+			var msg struct {
+				Type string `json:"type"`
+				Data struct {
+					N int `json:"n"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(message, &msg); err != nil {
+				t.Errorf("Invalid message: %v", err)
+				continue
+			}
+			c.mu.Lock()
+			switch msg.Type {
+			case "task_updated":
+				c.seen[msg.Data.N] = true
+			case "resync_required":
+				// A resync reloads everything sent so far
+				for n := 1; n <= int(issued.Load()); n++ {
+					c.seen[n] = true
+				}
+				c.resyncs++
+			}
+			c.mu.Unlock()
+		}
+	}
+
+	fast := &floodClient{Client: &Client{hub: h, send: make(chan []byte, 256)}, seen: map[int]bool{}}
+	slow := &floodClient{Client: &Client{hub: h, send: make(chan []byte, 4)}, seen: map[int]bool{}}
+	h.register <- fast.Client
+	h.register <- slow.Client
+	go consume(fast)
+
+	for n := 1; n <= updates; n++ {
+		issued.Add(1)
+		h.broadcastUpdate("task_updated", map[string]int{"n": n}, "test-project")
+	}
+	// The slow client only starts reading once the flood is over
+	go consume(slow)
+
+	seen := func(c *floodClient) int {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.seen)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for seen(fast) != updates || seen(slow) != updates {
+		if time.Now().After(deadline) {
+			t.Fatalf("Clients missed updates: fast saw %d, slow saw %d of %d", seen(fast), seen(slow), updates)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	slow.mu.Lock()
+	defer slow.mu.Unlock()
+	if slow.resyncs == 0 {
+		t.Error("Expected the slow client to be told to resync")
+	}
+}
+
+// TestHubStop checks that stopping the hub closes client queues and that
+// broadcasts and disconnects after it don't block
+func TestHubStop(t *testing.T) {
+	h := newHub()
+	go h.run()
+
+	client := &Client{hub: h, send: make(chan []byte, 4)}
+	h.register <- client
+
+	stopped := make(chan struct{})
+	go func() {
+		h.stop()
+		h.stop()
+		h.broadcastUpdate("task_updated", nil, "test-project")
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected stop and a later broadcast to return")
+	}
+	if _, ok := <-client.send; ok {
+		t.Error("Expected the client's queue to be closed")
+	}
+}
@@ -0,0 +1,300 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// stateManifestFile lists the exported projects in a state directory
+const stateManifestFile = "registry.json"
+
+// stateProjectsDir holds one database file per project in a state directory
+const stateProjectsDir = "projects"
+
+var stateDir string
+
+// stateManifest is the registry as written by export-state. It leaves out
+// last-accessed times so that using a project doesn't change the export.
+type stateManifest struct {
+	Projects []stateProject `json:"projects"`
+}
+
+// stateProject is one project entry in a state manifest
+type stateProject struct {
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// exportStateCmd represents the export-state command
+var exportStateCmd = &cobra.Command{
+	Use:   "export-state --dir <path>",
+	Short: "Write all projects to plain files for version control",
+	Long: `Write the project registry and every project database to a directory of plain,
+stably formatted JSON files that can be committed and reviewed as diffs.
+
+The directory gets a registry.json listing the projects and a projects/
+directory with one <name>.json per project, saved in the git-friendly form
+(tasks sorted by ID, volatile metadata left out). Project files in the
+directory for projects that are no longer registered are removed, so deleted
+projects show up as deletions.
+
+Load the files back with import-state.
+
+Examples:
+  quicktodo export-state --dir ./quicktodo-state
+  quicktodo export-state --dir ./quicktodo-state --json`,
+	Args: cobra.NoArgs,
+	Run:  runExportState,
+}
+
+// importStateCmd represents the import-state command
+var importStateCmd = &cobra.Command{
+	Use:   "import-state --dir <path>",
+	Short: "Load projects written by export-state",
+	Long: `Load the projects in a directory written by export-state into the data
+directory.
+
+Projects that aren't registered yet are registered at the path recorded in the
+export; projects that are keep their local path. Each project database is
+replaced by the exported one, keeping a backup of the previous version when
+create_backups is enabled (see 'quicktodo diff').
+
+Examples:
+  quicktodo import-state --dir ./quicktodo-state
+  quicktodo import-state --dir ./quicktodo-state --json`,
+	Args: cobra.NoArgs,
+	Run:  runImportState,
+}
+
+func runExportState(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	projectsDir := filepath.Join(stateDir, stateProjectsDir)
+	if err := os.MkdirAll(projectsDir, 0755); err != nil {
+		exitWithError(codeStorageError, "Error creating state directory: %v", err)
+	}
+
+	manifest := stateManifest{Projects: []stateProject{}}
+	exported := make(map[string]bool)
+	for name, info := range registry.ListProjects() {
+		projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(name))
+		if err != nil {
+			exitWithError(codeStorageError, "Error loading project database for %s: %v", name, err)
+		}
+
+		projectDB.GitFriendly = true
+		data, err := projectDB.ToJSON()
+		if err != nil {
+			exitWithError(codeStorageError, "Error formatting project %s: %v", name, err)
+		}
+		if err := writeStateFile(filepath.Join(projectsDir, name+".json"), data); err != nil {
+			exitWithError(codeStorageError, "Error writing project %s: %v", name, err)
+		}
+
+		manifest.Projects = append(manifest.Projects, stateProject{
+			Name:      name,
+			Path:      info.Path,
+			CreatedAt: info.CreatedAt.UTC(),
+		})
+		exported[name+".json"] = true
+	}
+	sort.Slice(manifest.Projects, func(i, j int) bool { return manifest.Projects[i].Name < manifest.Projects[j].Name })
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		exitWithError(codeStorageError, "Error formatting registry: %v", err)
+	}
+	if err := writeStateFile(filepath.Join(stateDir, stateManifestFile), append(data, '\n')); err != nil {
+		exitWithError(codeStorageError, "Error writing registry: %v", err)
+	}
+
+	// Drop files of projects that are no longer registered
+	removed := []string{}
+	entries, err := os.ReadDir(projectsDir)
+	if err != nil {
+		exitWithError(codeStorageError, "Error reading state directory: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") || exported[entry.Name()] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(projectsDir, entry.Name())); err != nil {
+			exitWithError(codeStorageError, "Error removing %s: %v", entry.Name(), err)
+		}
+		removed = append(removed, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":  true,
+			"dir":      stateDir,
+			"projects": manifest.Projects,
+			"removed":  removed,
+		}
+		printStateJSON(output)
+		return
+	}
+
+	fmt.Printf("Exported %d project(s) to %s\n", len(manifest.Projects), stateDir)
+	for _, name := range removed {
+		fmt.Printf("Removed %s, which is no longer registered\n", name)
+	}
+}
+
+func runImportState(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(stateDir, stateManifestFile))
+	if err != nil {
+		exitWithError(codeStorageError, "Error reading state registry: %v", err)
+	}
+	var manifest stateManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		exitWithError(codeStorageError, "Error parsing state registry: %v", err)
+	}
+
+	// Load project registry
+	registryPath := cfg.GetProjectsPath()
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Read and validate every project before changing anything
+	databases := make(map[string]*models.ProjectDatabase)
+	for _, project := range manifest.Projects {
+		if err := validateProjectName(project.Name); err != nil {
+			exitWithError(codeStorageError, "Error: invalid project name '%s' in state registry: %v", project.Name, err)
+		}
+		projectDB, err := loadProjectDatabase(cfg, filepath.Join(stateDir, stateProjectsDir, project.Name+".json"))
+		if err != nil {
+			exitWithError(codeStorageError, "Error loading exported project %s: %v", project.Name, err)
+		}
+		databases[project.Name] = projectDB
+	}
+
+	var registered, imported []string
+	for _, project := range manifest.Projects {
+		if _, exists := registry.GetProjectByName(project.Name); !exists {
+			if err := registry.RegisterProject(project.Name, project.Path); err != nil {
+				exitWithError(codeStorageError, "Error registering project %s: %v", project.Name, err)
+			}
+			if info, ok := registry.GetProjectByName(project.Name); ok && !project.CreatedAt.IsZero() {
+				info.CreatedAt = project.CreatedAt
+			}
+			registered = append(registered, project.Name)
+		}
+
+		importProjectState(cfg, project.Name, databases[project.Name])
+		imported = append(imported, project.Name)
+	}
+
+	if err := registry.Save(registryPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project registry: %v", err)
+	}
+
+	if jsonOutput {
+		if registered == nil {
+			registered = []string{}
+		}
+		if imported == nil {
+			imported = []string{}
+		}
+		output := map[string]interface{}{
+			"success":    true,
+			"dir":        stateDir,
+			"imported":   imported,
+			"registered": registered,
+		}
+		printStateJSON(output)
+		return
+	}
+
+	fmt.Printf("Imported %d project(s) from %s\n", len(imported), stateDir)
+	for _, name := range registered {
+		fmt.Printf("Registered new project %s\n", name)
+	}
+}
+
+// importProjectState replaces a project's database with an exported one under
+// the project lock, keeping the local git-friendly setting
+func importProjectState(cfg *config.Config, projectName string, projectDB *models.ProjectDatabase) {
+	lockManager, lockInfo := acquireProjectLock(cfg, projectName)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	dbPath := cfg.GetProjectDatabasePath(projectName)
+	projectDB.GitFriendly = false
+	if existing, err := loadProjectDatabase(cfg, dbPath); err == nil {
+		projectDB.GitFriendly = existing.GitFriendly
+	}
+
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database for %s: %v", projectName, err)
+	}
+}
+
+// writeStateFile writes data atomically, leaving the file untouched when its
+// content is already the same so unchanged projects keep their timestamps
+func writeStateFile(path string, data []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && string(existing) == string(data) {
+		return nil
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up temp file
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+	return nil
+}
+
+func printStateJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	exportStateCmd.Flags().StringVar(&stateDir, "dir", "", "Directory to write the state files to")
+	exportStateCmd.MarkFlagRequired("dir")
+	importStateCmd.Flags().StringVar(&stateDir, "dir", "", "Directory written by export-state")
+	importStateCmd.MarkFlagRequired("dir")
+
+	RootCmd.AddCommand(exportStateCmd)
+	RootCmd.AddCommand(importStateCmd)
+}
@@ -1,10 +1,17 @@
 // Global state
 let currentProject = '';
 let tasks = [];
+let wipLimits = {};
 let ws = null;
 let reconnectAttempts = 0;
 const maxReconnectAttempts = 5;
 
+// Project metadata polling, used to show when a CLI write holds the project lock
+const metaPollInterval = 2000;
+
+// Access token from a shared board link (?token=...), forwarded on API and WebSocket requests
+const accessToken = new URLSearchParams(window.location.search).get('token');
+
 // DOM Elements
 const projectSelect = document.getElementById('project-select');
 const kanbanBoard = document.getElementById('kanban-board');
@@ -22,24 +29,33 @@ const settingsModal = document.getElementById('settings-modal');
 const settingsForm = document.getElementById('settings-form');
 const settingsClose = document.getElementById('settings-close');
 const settingsCancelBtn = document.getElementById('settings-cancel-btn');
+const syncIndicator = document.getElementById('sync-indicator');
 
 // Initialize
 document.addEventListener('DOMContentLoaded', () => {
     loadProjects();
     setupEventListeners();
     connectWebSocket();
+    setInterval(pollProjectMeta, metaPollInterval);
 });
 
 // WebSocket Connection
 function connectWebSocket() {
     const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-    const wsUrl = `${protocol}//${window.location.host}/ws`;
+    let wsUrl = `${protocol}//${window.location.host}/ws`;
+    if (accessToken) {
+        wsUrl += `?token=${encodeURIComponent(accessToken)}`;
+    }
     
     try {
         ws = new WebSocket(wsUrl);
         
         ws.onopen = () => {
             console.log('WebSocket connected');
+            // Updates sent while disconnected were missed
+            if (reconnectAttempts > 0) {
+                loadTasks();
+            }
             reconnectAttempts = 0;
         };
         
@@ -89,6 +105,13 @@ function handleWebSocketMessage(message) {
         case 'task_deleted':
             handleTaskDeleted(data);
             break;
+        case 'tasks_reordered':
+        case 'resync_required':
+            loadTasks();
+            break;
+        case 'projects_changed':
+            loadProjects();
+            break;
         default:
             console.log('Unknown WebSocket message type:', type);
     }
@@ -204,6 +227,9 @@ function setupEventListeners() {
 
 // API Functions
 async function fetchAPI(url, options = {}) {
+    if (accessToken) {
+        options.headers = { ...(options.headers || {}), 'Authorization': `Bearer ${accessToken}` };
+    }
     try {
         const response = await fetch(url, options);
         if (!response.ok) {
@@ -256,6 +282,7 @@ async function loadTasks() {
     
     try {
         tasks = await fetchAPI(`/api/projects/${currentProject}/tasks`);
+        await loadWIPLimits();
         renderTasks();
         kanbanBoard.style.display = 'flex';
     } catch (err) {
@@ -265,6 +292,39 @@ async function loadTasks() {
     }
 }
 
+// Poll the project's lock state and version; failures are silent since this
+// only drives the busy indicator
+async function pollProjectMeta() {
+    if (!currentProject) {
+        syncIndicator.style.display = 'none';
+        return;
+    }
+
+    try {
+        const options = accessToken ? { headers: { 'Authorization': `Bearer ${accessToken}` } } : {};
+        const response = await fetch(`/api/projects/${currentProject}/meta`, options);
+        if (!response.ok) return;
+        const meta = await response.json();
+
+        syncIndicator.style.display = meta.locked ? 'inline' : 'none';
+    } catch (err) {
+        syncIndicator.style.display = 'none';
+    }
+}
+
+// Work-in-progress limits per status; counts are recomputed on each render
+async function loadWIPLimits() {
+    try {
+        const states = await fetchAPI(`/api/projects/${currentProject}/wip`);
+        wipLimits = {};
+        states.forEach(state => {
+            wipLimits[state.status] = state.limit;
+        });
+    } catch (err) {
+        wipLimits = {};
+    }
+}
+
 async function updateTask(taskId, updates) {
     try {
         const updated = await fetchAPI(`/api/projects/${currentProject}/tasks/${taskId}`, {
@@ -311,6 +371,20 @@ async function deleteTask(taskId) {
     }
 }
 
+// Save the order of a column; the server answers with every task in board order
+async function reorderTasks(status, order) {
+    try {
+        tasks = await fetchAPI(`/api/projects/${currentProject}/tasks/reorder`, {
+            method: 'PUT',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({ [status]: order })
+        });
+    } catch (err) {
+        showError('Failed to save task order');
+        throw err;
+    }
+}
+
 // UI Functions
 function showLoading(show) {
     loading.style.display = show ? 'block' : 'none';
@@ -345,7 +419,8 @@ function renderTasks() {
     const columns = {
         pending: document.getElementById('pending-tasks'),
         in_progress: document.getElementById('in-progress-tasks'),
-        done: document.getElementById('done-tasks')
+        done: document.getElementById('done-tasks'),
+        cancelled: document.getElementById('cancelled-tasks')
     };
     
     // Clear all columns
@@ -355,7 +430,8 @@ function renderTasks() {
     const grouped = {
         pending: [],
         in_progress: [],
-        done: []
+        done: [],
+        cancelled: []
     };
     
     tasks.forEach(task => {
@@ -368,7 +444,10 @@ function renderTasks() {
     Object.entries(grouped).forEach(([status, statusTasks]) => {
         const column = columns[status];
         const countElement = column.parentElement.querySelector('.task-count');
-        countElement.textContent = statusTasks.length;
+        const limit = wipLimits[status];
+        countElement.textContent = limit ? `${statusTasks.length} / ${limit}` : statusTasks.length;
+        column.parentElement.classList.toggle('at-limit', !!limit && statusTasks.length === limit);
+        column.parentElement.classList.toggle('over-limit', !!limit && statusTasks.length > limit);
         
         statusTasks.forEach(task => {
             const card = createTaskCard(task);
@@ -404,7 +483,11 @@ function createTaskCard(task) {
         <div class="task-meta">
             <span class="priority ${task.priority}">${task.priority}</span>
             ${task.assigned_to ? `<span>@${escapeHtml(task.assigned_to)}</span>` : ''}
+            ${task.checklist_progress ? `<span class="checklist-progress${task.checklist_progress.done === task.checklist_progress.total ? ' complete' : ''}" title="Checklist items done">☑ ${task.checklist_progress.done}/${task.checklist_progress.total}</span>` : ''}
         </div>
+        ${(task.attachments || []).length ? `<div class="task-attachments">
+            ${task.attachments.map(a => `<a href="${escapeHtml(a.url).replace(/"/g, '&quot;')}" target="_blank" rel="noopener noreferrer">🔗 ${escapeHtml(a.name)}</a>`).join('')}
+        </div>` : ''}
         <div class="task-dates">
             <div class="date-info">
                 <small>Created: ${createdDate} ${createdTime}</small>
@@ -424,7 +507,7 @@ function createTaskCard(task) {
     
     // Click to open detail (but not on copy button)
     card.addEventListener('click', (e) => {
-        if (!e.target.classList.contains('copy-btn')) {
+        if (!e.target.classList.contains('copy-btn') && !e.target.closest('.task-attachments')) {
             openTaskModal(task);
         }
     });
@@ -485,17 +568,36 @@ async function handleDrop(e) {
     
     const newStatus = dropZone.parentElement.dataset.status;
     const taskId = draggedElement.dataset.taskId;
+    const task = tasks.find(t => String(t.id) === taskId);
+    
+    // Put the card where it was dropped, then save the column's order
+    dropZone.insertBefore(draggedElement, cardAfterPointer(dropZone, e.clientY));
+    const order = [...dropZone.querySelectorAll('.task-card')].map(card => Number(card.dataset.taskId));
     
     try {
-        await updateTask(taskId, { status: newStatus });
+        if (!task || task.status !== newStatus) {
+            await updateTask(taskId, { status: newStatus });
+        }
+        await reorderTasks(newStatus, order);
         renderTasks();
     } catch (err) {
-        console.error('Failed to update task status:', err);
+        console.error('Failed to move task:', err);
+        loadTasks();
     }
     
     return false;
 }
 
+// The first card in a column whose middle is below the pointer, or null when
+// the pointer is below every card
+function cardAfterPointer(zone, y) {
+    const cards = [...zone.querySelectorAll('.task-card:not(.dragging)')];
+    return cards.find(card => {
+        const box = card.getBoundingClientRect();
+        return y < box.top + box.height / 2;
+    }) || null;
+}
+
 // Modal Functions
 function openTaskModal(task = null) {
     const isNew = !task;
@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var statsAllProjects bool
+
+// statsTotals aggregates the summaries of several projects
+type statsTotals struct {
+	Projects            int           `json:"projects"`
+	TaskCount           int           `json:"task_count"`
+	PendingTasks        int           `json:"pending_tasks"`
+	InProgressTasks     int           `json:"in_progress_tasks"`
+	CompletedTasks      int           `json:"completed_tasks"`
+	CompletionPercent   float64       `json:"completion_percent"`
+	AverageOpenAgeHours float64       `json:"average_open_age_hours"`
+	OldestOpenTask      *statsTaskRef `json:"oldest_open_task"`
+	LastUpdatedTask     *statsTaskRef `json:"last_updated_task"`
+}
+
+// statsTaskRef points at a task in one of several projects
+type statsTaskRef struct {
+	Project string       `json:"project"`
+	Task    *models.Task `json:"task"`
+}
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show statistics for the current project",
+	Long: `Show statistics for the current project: the number of tasks by status and
+priority, the share that is done, the average age of open tasks, the oldest
+open task and the most recently updated task.
+
+With --all, every registered project is summarized in one table with totals.
+
+Examples:
+  quicktodo stats
+  quicktodo stats --all
+  quicktodo stats --json`,
+	Args: cobra.NoArgs,
+	Run:  runStats,
+}
+
+func runStats(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	now := time.Now().UTC()
+
+	if statsAllProjects {
+		runStatsAll(cfg, registry, now)
+		return
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first, or use --all")
+	}
+
+	// Load project database
+	projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectInfo.Name))
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	summary := projectDB.GetSummaryAt(now)
+
+	if jsonOutput {
+		printStatsJSON(map[string]interface{}{
+			"success": true,
+			"summary": summary,
+		})
+		return
+	}
+
+	outputStatsHuman(projectInfo.Name, summary, now)
+}
+
+// runStatsAll summarizes every registered project
+func runStatsAll(cfg *config.Config, registry *database.ProjectRegistry, now time.Time) {
+	var names []string
+	for name := range registry.ListProjects() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Projects whose database can't be loaded are skipped, as in assignments
+	summaries := make(map[string]*models.ProjectSummary)
+	var loaded []string
+	for _, name := range names {
+		projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(name))
+		if err != nil {
+			if verbose {
+				fmt.Fprintf(os.Stderr, "Warning: skipping project %s: %v\n", name, err)
+			}
+			continue
+		}
+		summaries[name] = projectDB.GetSummaryAt(now)
+		loaded = append(loaded, name)
+	}
+
+	totals := aggregateStats(loaded, summaries)
+
+	if jsonOutput {
+		printStatsJSON(map[string]interface{}{
+			"success":  true,
+			"projects": summaries,
+			"totals":   totals,
+		})
+		return
+	}
+
+	if len(loaded) == 0 {
+		fmt.Println("No projects registered")
+		return
+	}
+
+	fmt.Printf("%-20s %6s %8s %12s %6s %7s  %s\n", "NAME", "TOTAL", "PENDING", "IN-PROGRESS", "DONE", "%DONE", "AVG OPEN AGE")
+	for _, name := range loaded {
+		s := summaries[name]
+		fmt.Printf("%-20s %6d %8d %12d %6d %6.0f%%  %s\n", name, s.TaskCount, s.PendingTasks, s.InProgressTasks, s.CompletedTasks, s.CompletionPercent, formatOpenAge(s.AverageOpenAgeHours, s.PendingTasks+s.InProgressTasks))
+	}
+	fmt.Printf("%-20s %6d %8d %12d %6d %6.0f%%  %s\n", "TOTAL", totals.TaskCount, totals.PendingTasks, totals.InProgressTasks, totals.CompletedTasks, totals.CompletionPercent, formatOpenAge(totals.AverageOpenAgeHours, totals.PendingTasks+totals.InProgressTasks))
+
+	if totals.OldestOpenTask != nil {
+		ref := totals.OldestOpenTask
+		fmt.Printf("\nOldest open task:    %s #%d %s (%s old)\n", ref.Project, ref.Task.ID, ref.Task.Title, formatDuration(now.Sub(ref.Task.CreatedAt)))
+	}
+	if totals.LastUpdatedTask != nil {
+		ref := totals.LastUpdatedTask
+		fmt.Printf("Last updated task:   %s #%d %s (%s)\n", ref.Project, ref.Task.ID, ref.Task.Title, formatTimeAgo(ref.Task.UpdatedAt))
+	}
+}
+
+// aggregateStats adds up project summaries, weighting the average open age by
+// each project's number of open tasks
+func aggregateStats(names []string, summaries map[string]*models.ProjectSummary) *statsTotals {
+	totals := &statsTotals{Projects: len(names)}
+	var openHours float64
+	for _, name := range names {
+		s := summaries[name]
+		totals.TaskCount += s.TaskCount
+		totals.PendingTasks += s.PendingTasks
+		totals.InProgressTasks += s.InProgressTasks
+		totals.CompletedTasks += s.CompletedTasks
+		openHours += s.AverageOpenAgeHours * float64(s.PendingTasks+s.InProgressTasks)
+
+		if t := s.OldestOpenTask; t != nil && (totals.OldestOpenTask == nil || t.CreatedAt.Before(totals.OldestOpenTask.Task.CreatedAt)) {
+			totals.OldestOpenTask = &statsTaskRef{Project: name, Task: t}
+		}
+		if t := s.LastUpdatedTask; t != nil && (totals.LastUpdatedTask == nil || t.UpdatedAt.After(totals.LastUpdatedTask.Task.UpdatedAt)) {
+			totals.LastUpdatedTask = &statsTaskRef{Project: name, Task: t}
+		}
+	}
+
+	if totals.TaskCount > 0 {
+		totals.CompletionPercent = float64(totals.CompletedTasks) * 100 / float64(totals.TaskCount)
+	}
+	if open := totals.PendingTasks + totals.InProgressTasks; open > 0 {
+		totals.AverageOpenAgeHours = openHours / float64(open)
+	}
+	return totals
+}
+
+func outputStatsHuman(projectName string, summary *models.ProjectSummary, now time.Time) {
+	fmt.Printf("Statistics for %s:\n\n", projectName)
+	fmt.Printf("  Total tasks:        %d\n", summary.TaskCount)
+	fmt.Printf("  Completion:         %.0f%% (%d of %d done)\n", summary.CompletionPercent, summary.CompletedTasks, summary.TaskCount-summary.CancelledTasks)
+
+	fmt.Println("\n  By status:")
+	for _, status := range models.ValidStatuses() {
+		fmt.Printf("    %s %-12s %d\n", getStatusIcon(status), status, summary.StatusCounts[status])
+	}
+
+	fmt.Println("\n  By priority:")
+	for _, priority := range []models.Priority{models.PriorityCritical, models.PriorityHigh, models.PriorityMedium, models.PriorityLow} {
+		fmt.Printf("    %-14s %d\n", priority, summary.PriorityCounts[priority])
+	}
+
+	open := summary.PendingTasks + summary.InProgressTasks
+	fmt.Println()
+	fmt.Printf("  Average open age:   %s\n", formatOpenAge(summary.AverageOpenAgeHours, open))
+	if task := summary.OldestOpenTask; task != nil {
+		fmt.Printf("  Oldest open task:   #%d %s (%s old)\n", task.ID, task.Title, formatDuration(now.Sub(task.CreatedAt)))
+	}
+	if task := summary.LastUpdatedTask; task != nil {
+		fmt.Printf("  Last updated task:  #%d %s (%s)\n", task.ID, task.Title, formatTimeAgo(task.UpdatedAt))
+	}
+}
+
+// formatOpenAge renders an average age in hours, or a dash when nothing is open
+func formatOpenAge(hours float64, open int) string {
+	if open == 0 {
+		return "-"
+	}
+	return formatDuration(time.Duration(hours * float64(time.Hour)))
+}
+
+func printStatsJSON(output map[string]interface{}) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+	}
+
+	fmt.Println(string(data))
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&statsAllProjects, "all", false, "Summarize every registered project")
+
+	RootCmd.AddCommand(statsCmd)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"quicktodo/internal/config"
 	"quicktodo/internal/database"
+	"quicktodo/internal/hooks"
 	"quicktodo/internal/models"
 	"quicktodo/internal/notify"
 	"strconv"
@@ -14,123 +15,209 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	completionNote       string
+	completionResolution string
+	wipStrict            bool
+	forceComplete        bool
+	cancelNote           string
+	reopenNote           string
+	reopening            bool
+)
+
 // setTaskStatusCmd represents the set-task-status command
 var setTaskStatusCmd = &cobra.Command{
-	Use:   "set-task-status <id> <status>",
+	Use:   "set-task-status <id>... <status>",
 	Short: "Update task status",
-	Long: `Update the status of a task by ID.
+	Long: `Update the status of one or more tasks by ID.
+
+Valid statuses: pending, in_progress, done, cancelled
+Case is ignored and common synonyms are accepted: todo for pending, wip or
+in-progress for in_progress, complete or completed for done, and canceled for
+cancelled.
+
+If wip_limits in the config caps the target status and it is already full, a
+warning is printed; with --strict the change is refused instead.
+
+With auto_assign_on_start enabled in the config, moving an unassigned task into
+in_progress assigns it to --agent-id, or to $USER when no agent ID is given.
 
-Valid statuses: pending, in_progress, done
+Several IDs may be given before the status to change them all in one step,
+under a single lock and save. Tasks that can't be changed (unknown or invalid
+IDs, or a full WIP limit with --strict) are reported and the rest are still
+updated; the command then exits with an error. With --json a single object is
+printed with a results entry per ID.
+
+A task can't be moved to done while tasks it depends on are still open,
+unless --force is given.
 
 Examples:
   quicktodo set-task-status 1 in_progress
+  quicktodo set-task-status 2 in_progress --strict
   quicktodo set-task-status 5 done
-  quicktodo set-task-status 3 pending`,
-	Args: cobra.ExactArgs(2),
+  quicktodo set-task-status 3 pending
+  quicktodo set-task-status 4 wip
+  quicktodo set-task-status 1 3 7 done --json`,
+	Args: cobra.MinimumNArgs(2),
 	Run:  runSetTaskStatus,
 }
 
 // markCompletedCmd represents the mark-completed command
 var markCompletedCmd = &cobra.Command{
-	Use:     "mark-completed <id>",
+	Use:     "mark-completed [id]",
 	Aliases: []string{"mark-done"},
 	Short:   "Mark task as completed",
 	Long: `Mark a task as done/completed.
 
+A closing note can be recorded in the task history in the same step, and a
+resolution (done, wontfix, duplicate) is stored on the task for reporting.
+The resolution defaults to done. Without an ID, the focused task is completed.
+
+Tasks whose dependencies (see create-task --depends-on) aren't all done are
+refused; pass --force to complete them anyway.
+
 Examples:
   quicktodo mark-completed 1
-  quicktodo mark-done 5`,
-	Args: cobra.ExactArgs(1),
+  quicktodo mark-done 5
+  quicktodo mark-completed 3 --note "shipped in v1.2"
+  quicktodo mark-completed 4 --resolution duplicate --note "same as #2"
+  quicktodo mark-completed 6 --force`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		runSetTaskStatusWithValue(args[0], "done")
+		resolution := strings.ToLower(completionResolution)
+		if !models.IsValidResolution(resolution) {
+			exitWithError(codeInvalidArgument, "Error: invalid resolution '%s'. Valid resolutions: done, wontfix, duplicate", completionResolution)
+		}
+		runSetTaskStatusWithValue(taskIDArg(args), "done", strings.TrimSpace(completionNote), models.Resolution(resolution))
 	},
 }
 
 // markInProgressCmd represents the mark-in-progress command
 var markInProgressCmd = &cobra.Command{
-	Use:   "mark-in-progress <id>",
+	Use:   "mark-in-progress [id]",
 	Short: "Mark task as in progress",
 	Long: `Mark a task as in progress.
 
+Warns when in_progress is at its configured WIP limit, or refuses the change
+with --strict. Without an ID, the focused task is used.
+
 Examples:
   quicktodo mark-in-progress 1
-  quicktodo mark-in-progress 5`,
-	Args: cobra.ExactArgs(1),
+  quicktodo mark-in-progress 5 --strict`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		runSetTaskStatusWithValue(args[0], "in_progress")
+		runSetTaskStatusWithValue(taskIDArg(args), "in_progress", "", "")
 	},
 }
 
 // markPendingCmd represents the mark-pending command
 var markPendingCmd = &cobra.Command{
-	Use:   "mark-pending <id>",
+	Use:   "mark-pending [id]",
 	Short: "Mark task as pending",
-	Long: `Mark a task as pending.
+	Long: `Mark a task as pending. Without an ID, the focused task is used.
 
 Examples:
   quicktodo mark-pending 1
   quicktodo mark-pending 5`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSetTaskStatusWithValue(taskIDArg(args), "pending", "", "")
+	},
+}
+
+// cancelTaskCmd represents the cancel command
+var cancelTaskCmd = &cobra.Command{
+	Use:   "cancel [id]",
+	Short: "Mark task as cancelled",
+	Long: `Mark a task as cancelled: abandoned rather than completed.
+
+Cancelled tasks count as closed. They are hidden by list-tasks --hide-done,
+no longer block the tasks that depend on them, are left out of the completion
+percentage and are archived like done tasks. A recurring task that is cancelled
+doesn't schedule its next occurrence. Without an ID, the focused task is used.
+
+Examples:
+  quicktodo cancel 4
+  quicktodo cancel 7 --note "superseded by #9"`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSetTaskStatusWithValue(taskIDArg(args), "cancelled", strings.TrimSpace(cancelNote), "")
+	},
+}
+
+// reopenTaskCmd represents the reopen command
+var reopenTaskCmd = &cobra.Command{
+	Use:   "reopen [id]",
+	Short: "Move a done or cancelled task back to pending",
+	Long: `Reopen a done or cancelled task by moving it back to pending. Its completion
+time and resolution are cleared. Tasks that are still open are refused. Without
+an ID, the focused task is used.
+
+Examples:
+  quicktodo reopen 5
+  quicktodo reopen 5 --note "regressed in v1.3"`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		runSetTaskStatusWithValue(args[0], "pending")
+		reopening = true
+		runSetTaskStatusWithValue(taskIDArg(args), "pending", strings.TrimSpace(reopenNote), "")
 	},
 }
 
 func runSetTaskStatus(cmd *cobra.Command, args []string) {
-	taskIDStr := args[0]
-	newStatus := strings.ToLower(args[1])
-	
-	runSetTaskStatusWithValue(taskIDStr, newStatus)
+	// All but the last argument are task IDs
+	taskIDStrs := args[:len(args)-1]
+	newStatus := strings.ToLower(args[len(args)-1])
+
+	if len(taskIDStrs) > 1 {
+		runBulkSetTaskStatus(taskIDStrs, newStatus)
+		return
+	}
+
+	runSetTaskStatusWithValue(taskIDStrs[0], newStatus, "", "")
 }
 
-func runSetTaskStatusWithValue(taskIDStr, newStatus string) {
+// runSetTaskStatusWithValue changes a task's status. A non-empty note is added
+// to its history, and when completing a task resolution is stored on it.
+func runSetTaskStatusWithValue(taskIDStr, newStatus, note string, resolution models.Resolution) {
 	// Parse task ID
 	taskID, err := strconv.Atoi(taskIDStr)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: invalid task ID '%s'. Task ID must be a number.\n", taskIDStr)
-		os.Exit(1)
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'. Task ID must be a number.", taskIDStr)
 	}
 
 	if taskID <= 0 {
-		fmt.Fprintf(os.Stderr, "Error: task ID must be positive\n")
-		os.Exit(1)
+		exitWithError(codeInvalidTaskID, "Error: task ID must be positive")
 	}
 
 	// Validate status
-	status := models.Status(newStatus)
+	status := models.NormalizeStatus(newStatus)
 	if !models.IsValidStatus(string(status)) {
-		fmt.Fprintf(os.Stderr, "Error: invalid status '%s'. Valid statuses: pending, in_progress, done\n", newStatus)
-		os.Exit(1)
+		exitWithError(codeInvalidStatus, "Error: invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", newStatus)
 	}
 
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
 	}
 
 	// Get current directory
 	currentDir, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
 	}
 
 	// Load project registry
 	registryPath := cfg.GetProjectsPath()
 	registry, err := database.LoadProjectRegistry(registryPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project registry: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
 	}
 
 	// Find project for current directory
 	projectInfo, exists := registry.GetProjectByPath(currentDir)
 	if !exists {
-		fmt.Fprintf(os.Stderr, "Error: current directory is not a registered project\n")
-		fmt.Fprintf(os.Stderr, "Run 'quicktodo init' first\n")
-		os.Exit(1)
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo init' first")
 	}
 
 	// Update last accessed time
@@ -140,15 +227,8 @@ func runSetTaskStatusWithValue(taskIDStr, newStatus string) {
 		}
 	}
 
-	// Create lock manager
-	lockManager := database.NewLockManager(cfg.DataDir+"/locks", cfg.LockTimeout)
-
 	// Acquire lock for project
-	lockInfo, err := lockManager.AcquireLock(projectInfo.Name)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error acquiring project lock: %v\n", err)
-		os.Exit(1)
-	}
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
 	defer func() {
 		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
 			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
@@ -157,38 +237,60 @@ func runSetTaskStatusWithValue(taskIDStr, newStatus string) {
 
 	// Load project database
 	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
-	projectDB, err := loadProjectDatabase(dbPath)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading project database: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
 	}
 
 	// Find task
 	task, err := projectDB.GetTask(taskID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: task #%d not found\n", taskID)
-		os.Exit(1)
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
+	}
+
+	// Only closed tasks can be reopened
+	if reopening && !task.IsClosed() {
+		exitWithError(codeInvalidState, "Error: task #%d is not done or cancelled (status: %s)", taskID, task.Status)
 	}
 
 	// Store old status for output
 	oldStatus := task.Status
+	before := task.Clone()
+
+	// Check dependencies and the WIP limit of the target status
+	wipWarning := enforceStatusChange(cfg, projectDB, task, status)
 
 	// Update task status
-	if err := task.UpdateStatus(status); err != nil {
-		fmt.Fprintf(os.Stderr, "Error updating task status: %v\n", err)
-		os.Exit(1)
+	if status == models.StatusDone && resolution != "" {
+		err = task.Complete(resolution)
+	} else {
+		err = task.UpdateStatus(status)
+	}
+	if err != nil {
+		exitWithError(codeInvalidStatus, "Error updating task status: %v", err)
 	}
 
+	// Claim unassigned tasks when starting them, if configured
+	autoAssigned := autoAssignOnStart(cfg, task, oldStatus)
+
 	// Update task in database
 	if err := projectDB.UpdateTask(task); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving task: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeStorageError, "Error saving task: %v", err)
+	}
+	projectDB.RecordTaskChanges(before, task, currentActor())
+	if note != "" {
+		projectDB.RecordTaskNote(task, note, currentActor())
+	}
+
+	// Schedule the next instance of a recurring task
+	nextTask, err := addNextOccurrence(projectDB, task, oldStatus)
+	if err != nil {
+		exitWithError(codeStorageError, "Error creating next occurrence: %v", err)
 	}
 
 	// Save project database
-	if err := saveProjectDatabase(projectDB, dbPath); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving project database: %v\n", err)
-		os.Exit(1)
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
 	}
 
 	// Save updated registry
@@ -201,18 +303,97 @@ func runSetTaskStatusWithValue(taskIDStr, newStatus string) {
 
 	// Notify web server of task update
 	if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
-		fmt.Fprintf(os.Stderr, "Warning: failed to notify web server: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+	}
+
+	// Run lifecycle hooks
+	runStatusChangeHooks(cfg, oldStatus, task, projectInfo.Name)
+	if nextTask != nil {
+		announceNextOccurrence(cfg, nextTask, projectInfo.Name)
 	}
 
 	// Output result
 	if jsonOutput {
-		outputStatusChangeJSON(task, string(oldStatus), projectInfo)
+		outputStatusChangeJSON(task, string(oldStatus), note, wipWarning, autoAssigned, nextTask, projectInfo)
 	} else {
-		outputStatusChangeHuman(task, string(oldStatus), projectInfo)
+		outputStatusChangeHuman(task, string(oldStatus), note, autoAssigned, nextTask, projectInfo)
+	}
+}
+
+// addNextOccurrence adds the next instance of a recurring task that has just
+// been completed to the database and returns it; other tasks get nil
+func addNextOccurrence(projectDB *models.ProjectDatabase, task *models.Task, oldStatus models.Status) (*models.Task, error) {
+	if task.Recurrence == "" || task.Status != models.StatusDone || oldStatus == models.StatusDone {
+		return nil, nil
+	}
+
+	next, err := projectDB.AddNextOccurrence(task)
+	if err != nil {
+		return nil, err
+	}
+	projectDB.RecordTaskCreated(next, currentActor())
+	return next, nil
+}
+
+// announceNextOccurrence syncs, notifies and runs the hooks for a task
+// created by completing a recurring task, once it has been saved
+func announceNextOccurrence(cfg *config.Config, next *models.Task, projectName string) {
+	// Sync to TODO list if enabled
+	syncToTodoList(next, projectName, "create", cfg)
+
+	// Notify web server of task creation
+	if err := notify.NotifyTaskCreated(cfg, next, projectName); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+	}
+
+	// Run lifecycle hooks
+	runTaskHooks(cfg, hooks.EventTaskCreated, next, projectName)
+}
+
+// enforceStatusChange applies the rules for moving task to status: a task
+// can't be completed while its dependencies are open (unless --force), and a
+// full WIP limit is a warning, or an error with --strict. Refusals exit; the
+// returned string is the WIP warning, if any, which has already been printed.
+func enforceStatusChange(cfg *config.Config, projectDB *models.ProjectDatabase, task *models.Task, status models.Status) string {
+	if task.Status == status {
+		return ""
+	}
+
+	// Refuse to complete a task that is still blocked
+	if status == models.StatusDone && !forceComplete {
+		if unmet := projectDB.UnmetDependencies(task); len(unmet) > 0 {
+			exitWithError(codeTaskBlocked, "Error: task #%d is blocked by unfinished task(s) %s\nComplete them first, or retry with --force", task.ID, formatTaskRefs(unmet))
+		}
+	}
+
+	// Enforce the work-in-progress limit of the target status
+	var wipWarning string
+	if limit, limited := cfg.WIPLimit(string(status)); limited {
+		if count, reached := projectDB.WIPLimitReached(task.ID, status, limit); reached {
+			wipWarning = fmt.Sprintf("%s is at its WIP limit (%d/%d)", status, count, limit)
+			if wipStrict {
+				exitWithError(codeWIPLimit, "Error: %s\nFinish or move a task out of %s first, or retry without --strict", wipWarning, status)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", wipWarning)
+		}
+	}
+
+	return wipWarning
+}
+
+// autoAssignOnStart assigns an unassigned task that has just moved into
+// in_progress to the current actor when auto_assign_on_start is enabled, and
+// returns the new assignee
+func autoAssignOnStart(cfg *config.Config, task *models.Task, oldStatus models.Status) string {
+	if !cfg.AutoAssignOnStart || task.Status != models.StatusInProgress || oldStatus == task.Status || task.AssignedTo != "" {
+		return ""
 	}
+	assignee := currentActor()
+	task.AssignTo(assignee)
+	return assignee
 }
 
-func outputStatusChangeJSON(task *models.Task, oldStatus string, projectInfo *database.ProjectInfo) {
+func outputStatusChangeJSON(task *models.Task, oldStatus, note, wipWarning, autoAssigned string, nextTask *models.Task, projectInfo *database.ProjectInfo) {
 	output := map[string]interface{}{
 		"success": true,
 		"project": map[string]interface{}{
@@ -224,32 +405,69 @@ func outputStatusChangeJSON(task *models.Task, oldStatus string, projectInfo *da
 		"new_status":  task.Status,
 		"changed_at":  task.UpdatedAt,
 	}
+	if note != "" {
+		output["note"] = note
+	}
+	if wipWarning != "" {
+		output["wip_warning"] = wipWarning
+	}
+	if autoAssigned != "" {
+		output["auto_assigned"] = autoAssigned
+	}
+	if nextTask != nil {
+		output["next_task"] = nextTask
+	}
 
 	data, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error formatting JSON output: %v\n", err)
-		os.Exit(1)
+		exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
 	}
 
 	fmt.Println(string(data))
 }
 
-func outputStatusChangeHuman(task *models.Task, oldStatus string, projectInfo *database.ProjectInfo) {
+func outputStatusChangeHuman(task *models.Task, oldStatus, note, autoAssigned string, nextTask *models.Task, projectInfo *database.ProjectInfo) {
 	statusIcon := getStatusIcon(task.Status)
 	
 	fmt.Printf("%s Task #%d status changed: %s → %s\n", 
 		statusIcon, task.ID, oldStatus, task.Status)
 	fmt.Printf("Title: %s\n", task.Title)
+	if task.Resolution != "" && task.Resolution != models.ResolutionDone {
+		fmt.Printf("Resolution: %s\n", task.Resolution)
+	}
+	if note != "" {
+		fmt.Printf("Note: %s\n", note)
+	}
+	if autoAssigned != "" {
+		fmt.Printf("Assigned to: %s (auto-assigned on start)\n", autoAssigned)
+	}
+	if nextTask != nil {
+		fmt.Printf("🔁 Next occurrence: #%d due %s\n", nextTask.ID, nextTask.DueDate.Local().Format("2006-01-02"))
+	}
 	
 	if verbose {
 		fmt.Printf("Project: %s\n", projectInfo.Name)
-		fmt.Printf("Updated: %s\n", task.UpdatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("Updated: %s\n", task.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
 	}
 }
 
 func init() {
+	markCompletedCmd.Flags().StringVar(&completionNote, "note", "", "Closing note recorded in the task history")
+	markCompletedCmd.Flags().StringVar(&completionResolution, "resolution", string(models.ResolutionDone), "Resolution (done, wontfix, duplicate)")
+
+	setTaskStatusCmd.Flags().BoolVar(&wipStrict, "strict", false, "Refuse the change when the target status is at its WIP limit")
+	markInProgressCmd.Flags().BoolVar(&wipStrict, "strict", false, "Refuse the change when in_progress is at its WIP limit")
+	markCompletedCmd.Flags().BoolVar(&forceComplete, "force", false, "Complete the task even if tasks it depends on are still open")
+	setTaskStatusCmd.Flags().BoolVar(&forceComplete, "force", false, "Allow moving tasks to done even if tasks they depend on are still open")
+
 	RootCmd.AddCommand(setTaskStatusCmd)
 	RootCmd.AddCommand(markCompletedCmd)
 	RootCmd.AddCommand(markInProgressCmd)
 	RootCmd.AddCommand(markPendingCmd)
+
+	cancelTaskCmd.Flags().StringVar(&cancelNote, "note", "", "Reason recorded in the task history")
+	reopenTaskCmd.Flags().StringVar(&reopenNote, "note", "", "Reason recorded in the task history")
+
+	RootCmd.AddCommand(cancelTaskCmd)
+	RootCmd.AddCommand(reopenTaskCmd)
 }
\ No newline at end of file
@@ -0,0 +1,247 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"quicktodo/internal/notify"
+	"strconv"
+)
+
+// statusChangeResult reports the outcome of one task in a bulk status change
+type statusChangeResult struct {
+	Input        string        `json:"input"`
+	ID           int           `json:"id,omitempty"`
+	Success      bool          `json:"success"`
+	Error        string        `json:"error,omitempty"`
+	OldStatus    models.Status `json:"old_status,omitempty"`
+	NewStatus    models.Status `json:"new_status,omitempty"`
+	WIPWarning   string        `json:"wip_warning,omitempty"`
+	AutoAssigned string        `json:"auto_assigned,omitempty"`
+	Task         *models.Task  `json:"task,omitempty"`
+	NextTask     *models.Task  `json:"next_task,omitempty"`
+}
+
+// runBulkSetTaskStatus moves several tasks to the same status under one lock
+// and one save. Tasks that can't be changed are reported without stopping the
+// others; the command exits non-zero if any failed.
+func runBulkSetTaskStatus(taskIDStrs []string, newStatus string) {
+	// Validate status
+	status := models.NormalizeStatus(newStatus)
+	if !models.IsValidStatus(string(status)) {
+		exitWithError(codeInvalidStatus, "Error: invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", newStatus)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registryPath := cfg.GetProjectsPath()
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo init' first")
+	}
+
+	// Update last accessed time
+	if err := registry.UpdateLastAccessed(projectInfo.Name); err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to update last accessed time: %v\n", err)
+		}
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	results := make([]*statusChangeResult, 0, len(taskIDStrs))
+	var changed []*models.Task
+	var oldStatuses []models.Status
+	seen := make(map[int]bool)
+	for _, taskIDStr := range taskIDStrs {
+		result := applyBulkStatusChange(cfg, projectDB, taskIDStr, status, seen)
+		results = append(results, result)
+		if result.Success {
+			changed = append(changed, result.Task)
+			oldStatuses = append(oldStatuses, result.OldStatus)
+		}
+	}
+
+	if len(changed) > 0 {
+		// Save project database
+		if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+			exitWithError(codeStorageError, "Error saving project database: %v", err)
+		}
+
+		// Save updated registry
+		if err := registry.Save(registryPath); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save registry: %v\n", err)
+		}
+
+		for i, task := range changed {
+			// Sync to TODO list if enabled
+			syncToTodoList(task, projectInfo.Name, "status", cfg)
+
+			// Notify web server of task update
+			if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
+				fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+			}
+
+			// Run lifecycle hooks
+			runStatusChangeHooks(cfg, oldStatuses[i], task, projectInfo.Name)
+		}
+		for _, result := range results {
+			if result.NextTask != nil {
+				announceNextOccurrence(cfg, result.NextTask, projectInfo.Name)
+			}
+		}
+	}
+
+	failed := len(results) - len(changed)
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success": failed == 0,
+			"project": map[string]interface{}{
+				"name": projectInfo.Name,
+				"path": projectInfo.Path,
+			},
+			"new_status": status,
+			"updated":    len(changed),
+			"failed":     failed,
+			"results":    results,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		for _, result := range results {
+			if !result.Success {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", result.Error)
+				continue
+			}
+			if result.WIPWarning != "" {
+				fmt.Fprintf(os.Stderr, "Warning: #%d: %s\n", result.ID, result.WIPWarning)
+			}
+			fmt.Printf("%s Task #%d status changed: %s → %s\n", getStatusIcon(result.NewStatus), result.ID, result.OldStatus, result.NewStatus)
+			if result.AutoAssigned != "" {
+				fmt.Printf("   Assigned to: %s (auto-assigned on start)\n", result.AutoAssigned)
+			}
+			if result.NextTask != nil {
+				fmt.Printf("   🔁 Next occurrence: #%d due %s\n", result.NextTask.ID, result.NextTask.DueDate.Local().Format("2006-01-02"))
+			}
+		}
+		fmt.Printf("Updated %d of %d task(s)\n", len(changed), len(results))
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// applyBulkStatusChange moves one task of a bulk change to status in memory,
+// applying the same WIP limit and auto-assign rules as a single change
+func applyBulkStatusChange(cfg *config.Config, projectDB *models.ProjectDatabase, taskIDStr string, status models.Status, seen map[int]bool) *statusChangeResult {
+	result := &statusChangeResult{Input: taskIDStr}
+
+	taskID, err := strconv.Atoi(taskIDStr)
+	if err != nil || taskID <= 0 {
+		result.Error = fmt.Sprintf("invalid task ID '%s'. Task ID must be a positive number.", taskIDStr)
+		return result
+	}
+	result.ID = taskID
+
+	if seen[taskID] {
+		result.Error = fmt.Sprintf("task #%d is listed more than once", taskID)
+		return result
+	}
+	seen[taskID] = true
+
+	task, err := projectDB.GetTask(taskID)
+	if err != nil {
+		result.Error = fmt.Sprintf("task #%d not found", taskID)
+		return result
+	}
+
+	oldStatus := task.Status
+	before := task.Clone()
+
+	// Refuse to complete a task that is still blocked; dependencies completed
+	// earlier in the same batch count as done
+	if status == models.StatusDone && oldStatus != status && !forceComplete {
+		if unmet := projectDB.UnmetDependencies(task); len(unmet) > 0 {
+			result.Error = fmt.Sprintf("task #%d is blocked by unfinished task(s) %s", taskID, formatTaskRefs(unmet))
+			return result
+		}
+	}
+
+	// Enforce the work-in-progress limit, counting tasks already moved
+	if limit, limited := cfg.WIPLimit(string(status)); limited && oldStatus != status {
+		if count, reached := projectDB.WIPLimitReached(task.ID, status, limit); reached {
+			result.WIPWarning = fmt.Sprintf("%s is at its WIP limit (%d/%d)", status, count, limit)
+			if wipStrict {
+				result.Error = fmt.Sprintf("task #%d not changed: %s", taskID, result.WIPWarning)
+				result.WIPWarning = ""
+				return result
+			}
+		}
+	}
+
+	if err := task.UpdateStatus(status); err != nil {
+		result.Error = fmt.Sprintf("task #%d: %v", taskID, err)
+		return result
+	}
+
+	// Claim unassigned tasks when starting them, if configured
+	result.AutoAssigned = autoAssignOnStart(cfg, task, oldStatus)
+
+	if err := projectDB.UpdateTask(task); err != nil {
+		*task = *before
+		result.Error = fmt.Sprintf("task #%d: %v", taskID, err)
+		return result
+	}
+	projectDB.RecordTaskChanges(before, task, currentActor())
+
+	// Schedule the next instance of a recurring task
+	next, err := addNextOccurrence(projectDB, task, oldStatus)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: task #%d: failed to create next occurrence: %v\n", taskID, err)
+	}
+	result.NextTask = next
+
+	result.Success = true
+	result.OldStatus = oldStatus
+	result.NewStatus = task.Status
+	result.Task = task
+	return result
+}
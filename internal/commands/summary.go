@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/export"
+	"quicktodo/internal/models"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	summaryMarkdown   bool
+	summarySince      string
+	summaryOutputFile string
+)
+
+// summaryCmd represents the summary command
+var summaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Summarize recent work for a standup",
+	Long: `Summarize the current project for an async standup: the tasks completed since
+yesterday, the tasks in progress, and the open tasks that are blocked by
+unfinished dependencies or past their due date. A blocked or overdue task is
+only listed in that section, even when it is in progress.
+
+--since changes the start of the completed section from the start of yesterday
+to an RFC3339 time, a YYYY-MM-DD date or a duration before now like 72h or 3d.
+
+--markdown renders the report as Markdown for pasting into Slack or an issue,
+and --output-file writes that Markdown to a file instead of printing it. Use
+'stats' for numbers rather than tasks.
+
+Examples:
+  quicktodo summary
+  quicktodo summary --markdown
+  quicktodo summary --markdown --since 3d --output-file standup.md
+  quicktodo summary --json`,
+	Args: cobra.NoArgs,
+	Run:  runSummary,
+}
+
+func runSummary(cmd *cobra.Command, args []string) {
+	if summaryOutputFile != "" && !summaryMarkdown {
+		exitWithError(codeInvalidArgument, "Error: --output-file requires --markdown")
+	}
+
+	now := time.Now().UTC()
+	since, sinceLabel := startOfYesterday(now), "yesterday"
+	if summarySince != "" {
+		parsed, err := parseTimeFlag(summarySince)
+		if err != nil {
+			exitWithError(codeInvalidArgument, "Error: invalid --since: %v", err)
+		}
+		since, sinceLabel = parsed, parsed.Local().Format("2006-01-02 15:04")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Load project database
+	projectDB, err := loadProjectDatabase(cfg, cfg.GetProjectDatabasePath(projectInfo.Name))
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	report := projectDB.StandupReport(since, now)
+
+	switch {
+	case jsonOutput:
+		output := map[string]interface{}{
+			"success": true,
+			"project": projectInfo.Name,
+			"summary": report,
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+		fmt.Println(string(data))
+	case summaryMarkdown:
+		outputSummaryMarkdown(report, projectInfo.Name, sinceLabel)
+	default:
+		outputSummaryHuman(report, projectInfo.Name, sinceLabel)
+	}
+}
+
+// startOfYesterday is local midnight at the start of the day before now
+func startOfYesterday(now time.Time) time.Time {
+	local := now.Local()
+	return time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, time.Local).UTC()
+}
+
+// outputSummaryMarkdown prints the report as Markdown, or writes it to
+// --output-file
+func outputSummaryMarkdown(report *models.StandupReport, projectName, sinceLabel string) {
+	markdown := export.SummaryMarkdown(report, projectName, sinceLabel)
+
+	if summaryOutputFile == "" {
+		fmt.Print(markdown)
+		return
+	}
+
+	if err := os.WriteFile(summaryOutputFile, []byte(markdown), 0644); err != nil {
+		exitWithError(codeStorageError, "Error writing output file: %v", err)
+	}
+	fmt.Printf("Wrote the %s summary to %s\n", projectName, summaryOutputFile)
+}
+
+func outputSummaryHuman(report *models.StandupReport, projectName, sinceLabel string) {
+	fmt.Printf("Summary for %s:\n", projectName)
+
+	fmt.Printf("\n✅ Completed since %s (%d):\n", sinceLabel, len(report.Completed))
+	for _, task := range report.Completed {
+		fmt.Printf("  #%d %s\n", task.ID, task.Title)
+	}
+
+	fmt.Printf("\n🔄 In progress (%d):\n", len(report.InProgress))
+	for _, task := range report.InProgress {
+		fmt.Printf("  #%d %s\n", task.ID, task.Title)
+	}
+
+	fmt.Printf("\n⚠️  Blocked/overdue (%d):\n", len(report.Blocked))
+	for _, item := range report.Blocked {
+		fmt.Printf("  #%d %s (%s)\n", item.Task.ID, item.Task.Title, strings.Join(export.SummaryReasons(item), "; "))
+	}
+}
+
+func init() {
+	summaryCmd.Flags().BoolVar(&summaryMarkdown, "markdown", false, "Render the summary as Markdown")
+	summaryCmd.Flags().StringVar(&summarySince, "since", "", "List tasks completed since this time (RFC3339, YYYY-MM-DD, or duration like 72h/3d; default: start of yesterday)")
+	summaryCmd.Flags().StringVar(&summaryOutputFile, "output-file", "", "Write the --markdown summary to this file instead of stdout")
+
+	RootCmd.AddCommand(summaryCmd)
+}
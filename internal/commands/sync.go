@@ -8,7 +8,9 @@ import (
 	"quicktodo/internal/config"
 	"quicktodo/internal/database"
 	"quicktodo/internal/models"
+	"quicktodo/internal/notify"
 	"quicktodo/internal/sync"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -18,6 +20,8 @@ var (
 	disableSync bool
 	fullSync    bool
 	showStatus  bool
+	pullSync    bool
+	syncFormat  string
 )
 
 var syncCmd = &cobra.Command{
@@ -31,8 +35,17 @@ This command allows you to:
 - Check synchronization status
 - View synchronized TODO items
 
-When enabled, changes to QuickTodo tasks will automatically update the AI's TODO list.`,
-	RunE: runSync,
+When enabled, changes to QuickTodo tasks will automatically update the AI's TODO list.
+
+The TODO file is written in the sync_format of the sync config: claude (the
+default, a JSON object keyed by item), markdown (a checklist) or generic (a
+JSON array). --format prints the TODO items in a format; combined with
+--enable it also makes that format the one the file is written in.
+
+--pull goes the other way: it reads the TODO list and applies status changes
+made there to the matching tasks of the current project. Items whose task has
+been deleted are reported and skipped.`,
+	Run:  runSync,
 }
 
 func init() {
@@ -40,45 +53,63 @@ func init() {
 	syncCmd.Flags().BoolVar(&disableSync, "disable", false, "Disable TODO synchronization")
 	syncCmd.Flags().BoolVar(&fullSync, "full-sync", false, "Perform full synchronization of current project")
 	syncCmd.Flags().BoolVar(&showStatus, "status", false, "Show synchronization status")
+	syncCmd.Flags().BoolVar(&pullSync, "pull", false, "Apply status changes from the TODO list to the current project")
+	syncCmd.Flags().StringVar(&syncFormat, "format", "", "Print the TODO items as claude, markdown or generic; with --enable, write the TODO file in this format")
 	
 	// Make flags mutually exclusive
-	syncCmd.MarkFlagsMutuallyExclusive("enable", "disable", "full-sync", "status")
+	syncCmd.MarkFlagsMutuallyExclusive("enable", "disable", "full-sync", "status", "pull")
 	
 	RootCmd.AddCommand(syncCmd)
 }
 
-func runSync(cmd *cobra.Command, args []string) error {
+func runSync(cmd *cobra.Command, args []string) {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
 	}
 
 	// Initialize sync manager
 	syncConfigPath := filepath.Join(cfg.DataDir, "sync_config.json")
 	syncManager, err := sync.NewTodoSyncManager(syncConfigPath)
 	if err != nil {
-		return fmt.Errorf("failed to initialize sync manager: %w", err)
+		exitWithError(codeStorageError, "Error initializing sync manager: %v", err)
 	}
 
 	switch {
 	case enableSync:
-		return handleEnableSync(syncManager)
+		handleEnableSync(syncManager, syncFormat)
+	case syncFormat != "" && (disableSync || fullSync || pullSync || showStatus):
+		exitWithError(codeInvalidArgument, "Error: --format can only be combined with --enable")
+	case syncFormat != "":
+		handlePrintFormat(syncManager, syncFormat)
 	case disableSync:
-		return handleDisableSync(syncManager)
+		handleDisableSync(syncManager)
 	case showStatus:
-		return handleShowStatus(syncManager, cfg)
+		handleShowStatus(syncManager, cfg)
 	case fullSync:
-		return handleFullSync(syncManager, cfg)
+		handleFullSync(syncManager, cfg)
+	case pullSync:
+		handlePullSync(syncManager, cfg)
 	default:
 		// Default behavior: show status
-		return handleShowStatus(syncManager, cfg)
+		handleShowStatus(syncManager, cfg)
 	}
 }
 
-func handleEnableSync(syncManager *sync.TodoSyncManager) error {
+func handleEnableSync(syncManager *sync.TodoSyncManager, format string) {
+	if format != "" {
+		if _, err := sync.FormatterFor(format); err != nil {
+			exitWithError(codeInvalidArgument, "Error: %v", err)
+		}
+	}
 	if err := syncManager.Enable(); err != nil {
-		return fmt.Errorf("failed to enable sync: %w", err)
+		exitWithError(codeStorageError, "Error enabling sync: %v", err)
+	}
+	if format != "" {
+		if err := syncManager.SetFormat(format); err != nil {
+			exitWithError(codeStorageError, "Error setting sync format: %v", err)
+		}
 	}
 
 	if jsonOutput {
@@ -86,21 +117,45 @@ func handleEnableSync(syncManager *sync.TodoSyncManager) error {
 			"success": true,
 			"message": "TODO synchronization enabled",
 			"status":  "enabled",
+			"format":  syncManager.Format(),
 		}
 		data, _ := json.MarshalIndent(output, "", "  ")
 		fmt.Println(string(data))
 	} else {
 		fmt.Println("✅ TODO synchronization enabled")
+		fmt.Printf("TODO file format: %s\n", syncManager.Format())
 		fmt.Println("QuickTodo database changes will now automatically sync to AI TODO lists")
 		fmt.Println("Use 'quicktodo sync --full-sync' to synchronize existing tasks")
 	}
+}
+
+// handlePrintFormat prints the TODO items in the given format
+func handlePrintFormat(syncManager *sync.TodoSyncManager, format string) {
+	data, err := syncManager.RenderTodoItems(format)
+	if err != nil {
+		exitWithError(codeInvalidArgument, "Error: %v", err)
+	}
 
-	return nil
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success": true,
+			"format":  format,
+			"content": string(data),
+		}
+		data, _ := json.MarshalIndent(output, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Print(string(data))
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		fmt.Println()
+	}
 }
 
-func handleDisableSync(syncManager *sync.TodoSyncManager) error {
+func handleDisableSync(syncManager *sync.TodoSyncManager) {
 	if err := syncManager.Disable(); err != nil {
-		return fmt.Errorf("failed to disable sync: %w", err)
+		exitWithError(codeStorageError, "Error disabling sync: %v", err)
 	}
 
 	if jsonOutput {
@@ -115,22 +170,25 @@ func handleDisableSync(syncManager *sync.TodoSyncManager) error {
 		fmt.Println("❌ TODO synchronization disabled")
 		fmt.Println("QuickTodo database changes will no longer sync to AI TODO lists")
 	}
-
-	return nil
 }
 
-func handleShowStatus(syncManager *sync.TodoSyncManager, cfg *config.Config) error {
+func handleShowStatus(syncManager *sync.TodoSyncManager, cfg *config.Config) {
 	todoItems := syncManager.GetTodoItems()
 	
 	if jsonOutput {
 		data, err := syncManager.GetTodoItemsAsJSON()
 		if err != nil {
-			return fmt.Errorf("failed to get TODO items as JSON: %w", err)
+			exitWithError(codeInternalError, "Error formatting TODO items as JSON: %v", err)
 		}
 		fmt.Println(string(data))
 	} else {
 		fmt.Println("TODO Synchronization Status")
 		fmt.Println("==========================")
+		if syncManager.IsEnabled() {
+			fmt.Println("Sync: enabled")
+		} else {
+			fmt.Println("Sync: disabled")
+		}
 		
 		if len(todoItems) == 0 {
 			fmt.Println("No synchronized TODO items")
@@ -145,8 +203,11 @@ func handleShowStatus(syncManager *sync.TodoSyncManager, cfg *config.Config) err
 				projectGroups[item.ProjectName] = append(projectGroups[item.ProjectName], item)
 			}
 			
+			breakdown := syncManager.GetProjectBreakdown()
 			for projectName, items := range projectGroups {
-				fmt.Printf("Project: %s (%d items)\n", projectName, len(items))
+				counts := breakdown[projectName]
+				fmt.Printf("Project: %s (%d items: %d pending, %d in progress, %d completed)\n",
+					projectName, len(items), counts.Pending, counts.InProgress, counts.Completed)
 				for _, item := range items {
 					statusIcon := getTodoStatusIcon(item.Status)
 					priorityIcon := getPriorityIcon(item.Priority)
@@ -160,36 +221,35 @@ func handleShowStatus(syncManager *sync.TodoSyncManager, cfg *config.Config) err
 		fmt.Println("  quicktodo sync --enable     Enable automatic synchronization")
 		fmt.Println("  quicktodo sync --disable    Disable automatic synchronization")
 		fmt.Println("  quicktodo sync --full-sync  Sync all tasks from current project")
+		fmt.Println("  quicktodo sync --pull       Apply TODO list status changes to current project")
 	}
-
-	return nil
 }
 
-func handleFullSync(syncManager *sync.TodoSyncManager, cfg *config.Config) error {
+func handleFullSync(syncManager *sync.TodoSyncManager, cfg *config.Config) {
 	// Get current directory
 	currentDir, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
 	}
 
 	// Load project registry
 	registryPath := cfg.GetProjectsPath()
 	registry, err := database.LoadProjectRegistry(registryPath)
 	if err != nil {
-		return fmt.Errorf("failed to load project registry: %w", err)
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
 	}
 
 	// Find project for current directory
 	projectInfo, exists := registry.GetProjectByPath(currentDir)
 	if !exists {
-		return fmt.Errorf("current directory is not a registered project")
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
 	}
 
 	// Load project database
 	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
-	projectDB, err := loadProjectDatabase(dbPath)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
 	if err != nil {
-		return fmt.Errorf("failed to load project database: %w", err)
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
 	}
 
 	// Get all tasks
@@ -197,7 +257,7 @@ func handleFullSync(syncManager *sync.TodoSyncManager, cfg *config.Config) error
 
 	// Perform full sync
 	if err := syncManager.SyncFromQuickTodo(tasks, projectInfo.Name); err != nil {
-		return fmt.Errorf("failed to perform full sync: %w", err)
+		exitWithError(codeStorageError, "Error performing full sync: %v", err)
 	}
 
 	if jsonOutput {
@@ -223,8 +283,118 @@ func handleFullSync(syncManager *sync.TodoSyncManager, cfg *config.Config) error
 			}
 		}
 	}
+}
+
+func handlePullSync(syncManager *sync.TodoSyncManager, cfg *config.Config) {
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
 
-	return nil
+	// Load project database
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	result, err := syncManager.ImportFromTodoFile(projectInfo.Name, projectDB.ListTasks(nil))
+	if err != nil {
+		exitWithError(codeStorageError, "Error reading TODO list: %v", err)
+	}
+
+	// Apply the status changes
+	var updated, nextTasks []*models.Task
+	oldStatuses := make(map[int]models.Status)
+	for _, change := range result.Changes {
+		task, err := projectDB.GetTask(change.TaskID)
+		if err != nil {
+			continue
+		}
+
+		before := task.Clone()
+		if err := task.UpdateStatus(change.NewStatus); err != nil {
+			exitWithError(codeInvalidStatus, "Error updating task #%d: %v", task.ID, err)
+		}
+		projectDB.RecordTaskChanges(before, task, currentActor())
+
+		// Schedule the next instance of a recurring task
+		next, err := addNextOccurrence(projectDB, task, before.Status)
+		if err != nil {
+			exitWithError(codeStorageError, "Error creating next occurrence of task #%d: %v", task.ID, err)
+		}
+		if next != nil {
+			nextTasks = append(nextTasks, next)
+		}
+
+		oldStatuses[task.ID] = before.Status
+		updated = append(updated, task)
+	}
+
+	if len(updated) > 0 {
+		// Save project database
+		if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+			exitWithError(codeStorageError, "Error saving project database: %v", err)
+		}
+
+		for _, task := range updated {
+			// Notify web server of task update
+			if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
+				fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+			}
+
+			// Run lifecycle hooks
+			runStatusChangeHooks(cfg, oldStatuses[task.ID], task, projectInfo.Name)
+		}
+		for _, next := range nextTasks {
+			announceNextOccurrence(cfg, next, projectInfo.Name)
+		}
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success": true,
+			"project": projectInfo.Name,
+			"changes": result.Changes,
+			"missing": result.Missing,
+		}
+		data, _ := json.MarshalIndent(output, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(result.Changes) == 0 {
+		fmt.Printf("✅ Project '%s' is up to date with the TODO list\n", projectInfo.Name)
+	} else {
+		fmt.Printf("✅ Pulled %d status change(s) into project '%s':\n", len(result.Changes), projectInfo.Name)
+		for _, change := range result.Changes {
+			fmt.Printf("  #%d %s → %s\n", change.TaskID, change.OldStatus, change.NewStatus)
+		}
+	}
+	if len(result.Missing) > 0 {
+		fmt.Printf("Skipped %d TODO item(s) without a matching task: %s\n", len(result.Missing), strings.Join(result.Missing, ", "))
+	}
 }
 
 func getTodoStatusIcon(status string) string {
@@ -242,6 +412,8 @@ func getTodoStatusIcon(status string) string {
 
 func getPriorityIcon(priority string) string {
 	switch priority {
+	case "critical":
+		return "🚨"
 	case "high":
 		return "🔴"
 	case "medium":
@@ -261,6 +433,8 @@ func getTaskStatusIcon(status models.Status) string {
 		return "🏃"
 	case models.StatusDone:
 		return "✅"
+	case models.StatusCancelled:
+		return "🚫"
 	default:
 		return "❓"
 	}
@@ -268,6 +442,8 @@ func getTaskStatusIcon(status models.Status) string {
 
 func getTaskPriorityIcon(priority models.Priority) string {
 	switch priority {
+	case models.PriorityCritical:
+		return "🚨"
 	case models.PriorityHigh:
 		return "🔴"
 	case models.PriorityMedium:
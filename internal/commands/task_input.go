@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"quicktodo/internal/config"
+	"quicktodo/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// taskPatch is a task JSON payload read with --stdin. It mirrors the web API
+// body; pointer fields distinguish omitted fields from empty values so that
+// edit-task only changes what was sent.
+type taskPatch struct {
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	Status      *string   `json:"status"`
+	Priority    *string   `json:"priority"`
+	AssignedTo  *string   `json:"assigned_to"`
+	Tags        *[]string `json:"tags"`
+	StartDate   *string   `json:"start_date"` // empty string clears the start date
+	DueDate     *string   `json:"due_date"`   // empty string clears the due date
+	Recurrence  *string   `json:"recurrence"` // empty string or "none" stops the task repeating
+}
+
+// readTaskPatch decodes a single JSON object, rejecting unknown fields
+func readTaskPatch(r io.Reader) (*taskPatch, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var patch taskPatch
+	if err := decoder.Decode(&patch); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("no JSON received on stdin")
+		}
+		return nil, fmt.Errorf("invalid task JSON: %w", err)
+	}
+
+	return &patch, nil
+}
+
+// validate checks field values; a title is required when creating a task
+func (p *taskPatch) validate(creating bool) error {
+	if creating && (p.Title == nil || models.SanitizeTitle(*p.Title) == "") {
+		return fmt.Errorf("task JSON must include a non-empty title")
+	}
+	if !creating && p.Title != nil && models.SanitizeTitle(*p.Title) == "" {
+		return fmt.Errorf("task title cannot be empty")
+	}
+	if p.Status != nil && !models.IsValidStatus(string(models.NormalizeStatus(*p.Status))) {
+		return fmt.Errorf("invalid status '%s'. Valid statuses: pending, in_progress, done, cancelled", *p.Status)
+	}
+	if p.Priority != nil && !models.IsValidPriority(string(models.NormalizePriority(*p.Priority))) {
+		return fmt.Errorf("invalid priority '%s'. Valid priorities: low, medium, high, critical", *p.Priority)
+	}
+	if p.StartDate != nil && *p.StartDate != "" {
+		if _, err := parseStartDate(*p.StartDate); err != nil {
+			return err
+		}
+	}
+	if p.DueDate != nil && *p.DueDate != "" {
+		if _, err := parseDueDate(*p.DueDate); err != nil {
+			return err
+		}
+	}
+	if p.Recurrence != nil && !models.IsValidRecurrence(models.NormalizeRecurrence(*p.Recurrence)) {
+		return fmt.Errorf("invalid recurrence '%s'. Valid recurrences: daily, weekly, monthly", *p.Recurrence)
+	}
+	return nil
+}
+
+// isEmpty reports whether the patch changes nothing
+func (p *taskPatch) isEmpty() bool {
+	return p.Title == nil && p.Description == nil && p.Status == nil && p.Priority == nil &&
+		p.AssignedTo == nil && p.Tags == nil && p.StartDate == nil && p.DueDate == nil && p.Recurrence == nil
+}
+
+// apply copies the fields present in the patch onto the task. The patch must
+// have been validated first.
+func (p *taskPatch) apply(task *models.Task) error {
+	if p.Title != nil {
+		if err := task.UpdateTitle(models.SanitizeTitle(*p.Title)); err != nil {
+			return err
+		}
+	}
+	if p.Description != nil {
+		task.UpdateDescription(strings.TrimSpace(*p.Description))
+	}
+	if p.Status != nil {
+		if err := task.UpdateStatus(models.NormalizeStatus(*p.Status)); err != nil {
+			return err
+		}
+	}
+	if p.Priority != nil {
+		if err := task.UpdatePriority(models.NormalizePriority(*p.Priority)); err != nil {
+			return err
+		}
+	}
+	if p.AssignedTo != nil {
+		task.AssignTo(strings.TrimSpace(*p.AssignedTo))
+	}
+	if p.Tags != nil {
+		task.SetTags(normalizeTags(*p.Tags))
+	}
+	if p.StartDate != nil {
+		if *p.StartDate == "" {
+			task.SetStartDate(nil)
+		} else {
+			start, err := parseStartDate(*p.StartDate)
+			if err != nil {
+				return err
+			}
+			task.SetStartDate(&start)
+		}
+	}
+	if p.DueDate != nil {
+		if *p.DueDate == "" {
+			task.SetDueDate(nil)
+		} else {
+			due, err := parseDueDate(*p.DueDate)
+			if err != nil {
+				return err
+			}
+			task.SetDueDate(&due)
+		}
+	}
+	if p.Recurrence != nil {
+		if err := task.SetRecurrence(models.NormalizeRecurrence(*p.Recurrence)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTaskText enforces the configured title and description length limits
+func validateTaskText(cfg *config.Config, title, description string) error {
+	if title == "" {
+		return fmt.Errorf("task title cannot be empty")
+	}
+	if n := utf8.RuneCountInString(title); n > cfg.MaxTitleLength {
+		return fmt.Errorf("task title is %d characters, exceeding the limit of %d", n, cfg.MaxTitleLength)
+	}
+	if n := utf8.RuneCountInString(description); n > cfg.MaxDescriptionLength {
+		return fmt.Errorf("task description is %d characters, exceeding the limit of %d", n, cfg.MaxDescriptionLength)
+	}
+	return nil
+}
+
+// normalizeTags trims tags and drops empty and duplicate entries
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// relativeDueDate matches due dates given as days or weeks from today, like +3d
+var relativeDueDate = regexp.MustCompile(`^\+(\d+)([dw])$`)
+
+// parseDueDate parses a due date in any form accepted by parseTaskDate
+func parseDueDate(value string) (time.Time, error) {
+	return parseTaskDate("due date", value)
+}
+
+// parseStartDate parses a start date in any form accepted by parseTaskDate
+func parseStartDate(value string) (time.Time, error) {
+	return parseTaskDate("start date", value)
+}
+
+// parseTaskDate parses an RFC3339 timestamp, a YYYY-MM-DD date (local
+// midnight), or a date relative to today such as +3d or +2w (also local
+// midnight). kind names the date in errors.
+func parseTaskDate(kind, value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t.UTC(), nil
+	}
+
+	if match := relativeDueDate.FindStringSubmatch(strings.ToLower(value)); match != nil {
+		days, err := strconv.Atoi(match[1])
+		if err == nil {
+			if match[2] == "w" {
+				days *= 7
+			}
+			now := time.Now()
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+			return today.AddDate(0, 0, days).UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid %s '%s': expected RFC3339 time, YYYY-MM-DD date, or +Nd/+Nw", kind, value)
+}
+
+// parseTaskIDList parses a comma-separated list of task IDs, such as the value
+// of --depends-on. "#" prefixes are accepted; an empty list is an error.
+func parseTaskIDList(value string) ([]int, error) {
+	var ids []int
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimPrefix(strings.TrimSpace(field), "#")
+		if field == "" {
+			continue
+		}
+		id, err := strconv.Atoi(field)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid task ID '%s'. Task IDs must be positive numbers", field)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no task IDs given")
+	}
+	return ids, nil
+}
+
+// formatTaskRefs renders task IDs as "#3, #5"
+func formatTaskRefs(ids []int) string {
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		refs[i] = "#" + strconv.Itoa(id)
+	}
+	return strings.Join(refs, ", ")
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"quicktodo/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadTaskPatchCreate(t *testing.T) {
+	input := `{"title":" Ship v2 ","description":"Release notes","priority":"HIGH","tags":["release"," ","release","ops"],"due_date":"2025-01-31T17:00:00Z","assigned_to":"agent-1"}`
+
+	patch, err := readTaskPatch(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readTaskPatch failed: %v", err)
+	}
+	if err := patch.validate(true); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+
+	task := models.NewTask(1, "placeholder")
+	if err := patch.apply(task); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	if task.Title != "Ship v2" || task.Description != "Release notes" {
+		t.Errorf("Unexpected title/description: %q / %q", task.Title, task.Description)
+	}
+	if task.Priority != models.PriorityHigh {
+		t.Errorf("Expected priority high, got %s", task.Priority)
+	}
+	if task.AssignedTo != "agent-1" {
+		t.Errorf("Expected assignee agent-1, got %s", task.AssignedTo)
+	}
+	if strings.Join(task.Tags, ",") != "release,ops" {
+		t.Errorf("Expected normalized tags [release ops], got %v", task.Tags)
+	}
+	if task.DueDate == nil || task.DueDate.Format("2006-01-02T15:04") != "2025-01-31T17:00" {
+		t.Errorf("Unexpected due date: %v", task.DueDate)
+	}
+}
+
+func TestReadTaskPatchPartialUpdate(t *testing.T) {
+	task := models.NewTaskWithDetails(1, "Original", "Keep me", models.PriorityLow)
+	task.Tags = []string{"old"}
+
+	patch, err := readTaskPatch(strings.NewReader(`{"status":"in_progress","tags":[]}`))
+	if err != nil {
+		t.Fatalf("readTaskPatch failed: %v", err)
+	}
+	if err := patch.validate(false); err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if err := patch.apply(task); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	if task.Title != "Original" || task.Description != "Keep me" || task.Priority != models.PriorityLow {
+		t.Errorf("Expected omitted fields to be untouched, got %+v", task)
+	}
+	if task.Status != models.StatusInProgress {
+		t.Errorf("Expected status in_progress, got %s", task.Status)
+	}
+	if len(task.Tags) != 0 {
+		t.Errorf("Expected tags cleared, got %v", task.Tags)
+	}
+}
+
+func TestReadTaskPatchRejectsInvalidPayloads(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		creating bool
+	}{
+		{"empty input", ``, true},
+		{"malformed JSON", `{"title":`, true},
+		{"unknown field", `{"title":"x","colour":"red"}`, true},
+		{"missing title on create", `{"priority":"high"}`, true},
+		{"blank title on edit", `{"title":"  "}`, false},
+		{"invalid priority", `{"title":"x","priority":"urgent"}`, true},
+		{"invalid status", `{"status":"blocked"}`, false},
+		{"invalid due date", `{"title":"x","due_date":"next tuesday"}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := readTaskPatch(strings.NewReader(tt.input))
+			if err == nil {
+				err = patch.validate(tt.creating)
+			}
+			if err == nil {
+				t.Errorf("Expected error for input %q", tt.input)
+			}
+		})
+	}
+}
+
+func TestParseDueDateRelative(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	tests := map[string]time.Time{
+		"+3d": today.AddDate(0, 0, 3),
+		"+0d": today,
+		"+2W": today.AddDate(0, 0, 14),
+	}
+	for value, want := range tests {
+		got, err := parseDueDate(value)
+		if err != nil {
+			t.Errorf("parseDueDate(%q) failed: %v", value, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("parseDueDate(%q) = %s, want %s", value, got, want)
+		}
+	}
+
+	for _, value := range []string{"+d", "3d", "+3m", "-3d", "+99999999999999999999d"} {
+		if _, err := parseDueDate(value); err == nil {
+			t.Errorf("Expected parseDueDate(%q) to fail", value)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"quicktodo/internal/notify"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// startCmd represents the start command
+var startCmd = &cobra.Command{
+	Use:   "start <id>",
+	Short: "Start tracking time on a task",
+	Long: `Start the timer of a task, recording how long it actually takes. The task
+moves to in_progress if it isn't already, with the same WIP limit warning and
+auto_assign_on_start behavior as set-task-status.
+
+A task has one timer: starting a task that is already running is an error.
+display-task shows the time accumulated over every start and stop.
+
+Examples:
+  quicktodo start 3
+  quicktodo start 3 --agent-id claude --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTimerChange(args[0], true)
+	},
+}
+
+// stopCmd represents the stop command
+var stopCmd = &cobra.Command{
+	Use:   "stop <id>",
+	Short: "Stop tracking time on a task",
+	Long: `Stop the running timer of a task and report the time spent on it. The task
+keeps its status; use set-task-status to complete it. Stopping a task that
+isn't running is an error.
+
+Examples:
+  quicktodo stop 3
+  quicktodo stop 3 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runTimerChange(args[0], false)
+	},
+}
+
+// runTimerChange starts or stops the timer of a task under the project lock
+// and reports the affected time entry and the task's total time
+func runTimerChange(taskIDStr string, start bool) {
+	// Parse task ID
+	taskID, err := strconv.Atoi(taskIDStr)
+	if err != nil {
+		exitWithError(codeInvalidTaskID, "Error: invalid task ID '%s'", taskIDStr)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registry, err := database.LoadProjectRegistry(cfg.GetProjectsPath())
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo initialize-project' first")
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	// Load project database
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	// Find task
+	task, err := projectDB.GetTask(taskID)
+	if err != nil {
+		exitWithError(codeTaskNotFound, "Error: task #%d not found", taskID)
+	}
+
+	oldStatus := task.Status
+	before := task.Clone()
+	now := time.Now()
+
+	var entry *models.TimeEntry
+	var wipWarning string
+	if start {
+		if entry, err = task.StartTimer(now); err == nil && task.Status != models.StatusInProgress {
+			// Started tasks are in progress
+			wipWarning = enforceStatusChange(cfg, projectDB, task, models.StatusInProgress)
+			err = task.UpdateStatus(models.StatusInProgress)
+			autoAssignOnStart(cfg, task, oldStatus)
+		}
+	} else {
+		entry, err = task.StopTimer(now)
+	}
+	if err != nil {
+		exitWithError(codeInvalidState, "Error: %v", err)
+	}
+	projectDB.RecordTaskChanges(before, task, currentActor())
+
+	// Save project database
+	if err := saveProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
+	}
+
+	// Sync to TODO list if enabled
+	if task.Status != oldStatus {
+		syncToTodoList(task, projectInfo.Name, "status", cfg)
+	}
+
+	// Notify web server of task update
+	if err := notify.NotifyTaskUpdated(cfg, task, projectInfo.Name); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+	}
+
+	// Run lifecycle hooks
+	runStatusChangeHooks(cfg, oldStatus, task, projectInfo.Name)
+
+	total := task.TotalTime(now)
+
+	// Output result
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":            true,
+			"entry":              entry,
+			"total_time_seconds": int64(total.Seconds()),
+			"task":               task,
+		}
+		if wipWarning != "" {
+			output["wip_warning"] = wipWarning
+		}
+
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+
+		fmt.Println(string(data))
+		return
+	}
+
+	if start {
+		fmt.Printf("⏱️  Started task #%d: %s (%s tracked so far)\n", task.ID, task.Title, formatDuration(total))
+		return
+	}
+	fmt.Printf("⏹️  Stopped task #%d: %s after %s (%s in total)\n", task.ID, task.Title, formatDuration(entry.Duration(now)), formatDuration(total))
+}
+
+func init() {
+	RootCmd.AddCommand(startCmd)
+	RootCmd.AddCommand(stopCmd)
+}
@@ -0,0 +1,279 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"quicktodo/internal/config"
+	"quicktodo/internal/database"
+	"quicktodo/internal/models"
+	"quicktodo/internal/notify"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var undoForce bool
+
+// undoCmd represents the undo command
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the last change to the project's tasks",
+	Long: `Revert the most recent change to the current project's tasks: tasks it
+created are removed, tasks it deleted or archived are restored, and tasks it
+changed get their previous status, priority, title and every other field back.
+Running undo again reverts the change before that.
+
+Every command that changes tasks, including edits made through the web UI, is
+recorded in the project's operation log; max_undo_operations in the config
+file sets how many are kept (default 50). Undo itself is not recorded.
+
+If a task has changed again since, e.g. in another terminal, undo refuses to
+overwrite it; --force reverts it anyway.
+
+Examples:
+  quicktodo undo
+  quicktodo undo --json
+  quicktodo undo --force`,
+	Args: cobra.NoArgs,
+	Run:  runUndo,
+}
+
+// revertedChange is one task put back by undo. Change is what the undone
+// operation did to the task, and Task is the task as restored, or nil when
+// undo removed it.
+type revertedChange struct {
+	TaskID int          `json:"task_id"`
+	Title  string       `json:"title"`
+	Change string       `json:"change"`
+	Task   *models.Task `json:"task,omitempty"`
+}
+
+func runUndo(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		exitWithError(codeConfigError, "Error loading configuration: %v", err)
+	}
+
+	// Get current directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		exitWithError(codeInternalError, "Error getting current directory: %v", err)
+	}
+
+	// Load project registry
+	registryPath := cfg.GetProjectsPath()
+	registry, err := database.LoadProjectRegistry(registryPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project registry: %v", err)
+	}
+
+	// Find project for current directory
+	projectInfo, exists := registry.GetProjectByPath(currentDir)
+	if !exists {
+		exitWithError(codeProjectNotFound, "Error: current directory is not a registered project\nRun 'quicktodo init' first")
+	}
+
+	// Acquire lock for project
+	lockManager, lockInfo := acquireProjectLock(cfg, projectInfo.Name)
+	defer func() {
+		if err := lockManager.ReleaseLock(lockInfo); err != nil && verbose {
+			fmt.Fprintf(os.Stderr, "Warning: failed to release lock: %v\n", err)
+		}
+	}()
+
+	opsPath := cfg.GetProjectOpsPath(projectInfo.Name)
+	ops, err := database.LoadOperations(opsPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading operation log: %v", err)
+	}
+	if len(ops) == 0 {
+		exitWithError(codeNotFound, "Error: nothing to undo in project %s", projectInfo.Name)
+	}
+	op := ops[len(ops)-1]
+
+	// Load project database
+	dbPath := cfg.GetProjectDatabasePath(projectInfo.Name)
+	projectDB, err := loadProjectDatabase(cfg, dbPath)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading project database: %v", err)
+	}
+
+	if !undoForce {
+		if conflict := undoConflict(projectDB, op); conflict != "" {
+			exitWithError(codeInvalidState, "Error: can't undo '%s': %s\nRun 'quicktodo undo --force' to revert it anyway", op.Command, conflict)
+		}
+	}
+
+	reverted, err := revertOperation(projectDB, op, currentActor())
+	if err != nil {
+		exitWithError(codeStorageError, "Error reverting operation: %v", err)
+	}
+
+	// Tasks restored from the archive must not stay archived as well
+	archivePath := cfg.GetProjectArchivePath(projectInfo.Name)
+	archive, err := database.LoadTaskArchive(archivePath, projectInfo.Name)
+	if err != nil {
+		exitWithError(codeStorageError, "Error loading archive: %v", err)
+	}
+	unarchived := false
+	for _, change := range reverted {
+		if change.Task != nil && archive.Remove(change.TaskID) {
+			unarchived = true
+		}
+	}
+
+	// Save without recording an operation, then drop the undone one
+	if err := writeProjectDatabase(cfg, projectDB, dbPath); err != nil {
+		exitWithError(codeStorageError, "Error saving project database: %v", err)
+	}
+	if unarchived {
+		if err := archive.Save(archivePath); err != nil {
+			exitWithError(codeStorageError, "Error saving archive: %v", err)
+		}
+	}
+	if _, err := database.PopOperation(opsPath); err != nil {
+		exitWithError(codeStorageError, "Error updating operation log: %v", err)
+	}
+
+	// Save updated registry
+	if err := registry.UpdateLastAccessed(projectInfo.Name); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update last accessed time: %v\n", err)
+	}
+	if err := registry.Save(registryPath); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save registry: %v\n", err)
+	}
+
+	for _, change := range reverted {
+		announceRevertedChange(cfg, change, projectInfo.Name)
+	}
+
+	if jsonOutput {
+		output := map[string]interface{}{
+			"success":   true,
+			"project":   projectInfo.Name,
+			"operation": op.Command,
+			"actor":     op.Actor,
+			"timestamp": op.Timestamp,
+			"reverted":  reverted,
+			"remaining": len(ops) - 1,
+		}
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("↩️  Undid '%s' from %s\n", op.Command, op.Timestamp.Local().Format("2006-01-02 15:04"))
+	for _, change := range reverted {
+		switch change.Change {
+		case "created":
+			fmt.Printf("  Removed #%d: %s\n", change.TaskID, change.Title)
+		case "deleted":
+			fmt.Printf("  Restored #%d: %s\n", change.TaskID, change.Title)
+		default:
+			fmt.Printf("  Reverted #%d: %s (%s)\n", change.TaskID, change.Title, change.Task.Status)
+		}
+	}
+	if remaining := len(ops) - 1; remaining > 0 {
+		fmt.Printf("%d earlier change(s) can be undone\n", remaining)
+	}
+}
+
+// undoConflict describes the first task that no longer is as op left it, or
+// returns "" when op can be reverted cleanly
+func undoConflict(db *models.ProjectDatabase, op *database.Operation) string {
+	for _, change := range op.Changes {
+		current, err := db.GetTask(change.TaskID)
+		switch {
+		case change.After == nil && err == nil:
+			return fmt.Sprintf("task #%d exists again", change.TaskID)
+		case change.After != nil && err != nil:
+			return fmt.Sprintf("task #%d has since been deleted", change.TaskID)
+		case change.After != nil && !database.SameTask(current, change.After):
+			return fmt.Sprintf("task #%d has changed since", change.TaskID)
+		}
+	}
+	return ""
+}
+
+// revertOperation applies the inverse of op to db, recording each reverted
+// task in its history
+func revertOperation(db *models.ProjectDatabase, op *database.Operation, actor string) ([]*revertedChange, error) {
+	reverted := []*revertedChange{}
+	for _, change := range op.Changes {
+		current, err := db.GetTask(change.TaskID)
+		exists := err == nil
+
+		result := &revertedChange{TaskID: change.TaskID, Change: change.Kind(), Task: change.Before}
+		switch {
+		case change.Before == nil:
+			result.Title = change.After.Title
+			if !exists {
+				break
+			}
+			if err := db.DeleteTask(change.TaskID); err != nil {
+				return nil, err
+			}
+			db.RecordTaskDeleted(current, actor)
+		case exists:
+			result.Title = change.Before.Title
+			if err := db.UpdateTask(change.Before); err != nil {
+				return nil, err
+			}
+			db.RecordTaskChanges(current, change.Before, actor)
+		default:
+			result.Title = change.Before.Title
+			if err := db.RestoreTask(change.Before); err != nil {
+				return nil, err
+			}
+			db.RecordTaskCreated(change.Before, actor)
+		}
+		reverted = append(reverted, result)
+	}
+	return reverted, nil
+}
+
+// announceRevertedChange syncs and notifies a task undo put back, as the
+// command that changed it did
+func announceRevertedChange(cfg *config.Config, change *revertedChange, projectName string) {
+	var err error
+	switch {
+	case change.Task == nil:
+		syncToTodoList(&models.Task{ID: change.TaskID}, projectName, "delete", cfg)
+		err = notify.NotifyTaskDeleted(cfg, change.TaskID, change.Title, projectName)
+	case change.Change == "deleted":
+		syncToTodoList(change.Task, projectName, "create", cfg)
+		err = notify.NotifyTaskCreated(cfg, change.Task, projectName)
+	default:
+		syncToTodoList(change.Task, projectName, "edit", cfg)
+		err = notify.NotifyTaskUpdated(cfg, change.Task, projectName)
+	}
+	if err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Warning: failed to send notifications: %v\n", err)
+	}
+}
+
+// operationCommand describes the running command for the operation log,
+// e.g. "set-task-status 3 done"
+func operationCommand() string {
+	return strings.Join(os.Args[1:], " ")
+}
+
+// readPreviousDatabase reads the project database as last saved, before a
+// save replaces it; it returns nil when there is none yet
+func readPreviousDatabase(filePath string) (*models.ProjectDatabase, error) {
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return database.ReadProjectDatabase(filePath)
+}
+
+func init() {
+	undoCmd.Flags().BoolVar(&undoForce, "force", false, "Revert the change even if the tasks it touched have changed since")
+
+	RootCmd.AddCommand(undoCmd)
+}
@@ -1,22 +1,68 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
 )
 
+// BuildInfo holds the build-time metadata injected via ldflags in main
+type BuildInfo struct {
+	Version   string `json:"version"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	Commit    string `json:"commit"`
+}
+
+// buildInfo is the metadata reported by the version command
+var buildInfo = BuildInfo{
+	Version:   "1.0.0",
+	BuildTime: "unknown",
+	GoVersion: "unknown",
+	Commit:    "unknown",
+}
+
+var versionShort bool
+
+// SetBuildInfo records build metadata and updates the root command's version string
+func SetBuildInfo(info BuildInfo) {
+	buildInfo = info
+	RootCmd.Version = fmt.Sprintf("%s (built %s with %s)", info.Version, info.BuildTime, info.GoVersion)
+}
+
 // versionCmd represents the version command
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
-	Long:  `Display the version information for QuickTodo CLI tool.`,
+	Long: `Display the version information for QuickTodo CLI tool.
+
+Examples:
+  quicktodo version
+  quicktodo version --short
+  quicktodo version --json`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if versionShort {
+			fmt.Println(buildInfo.Version)
+			return
+		}
+
+		if jsonOutput {
+			data, err := json.MarshalIndent(buildInfo, "", "  ")
+			if err != nil {
+				exitWithError(codeInternalError, "Error formatting JSON output: %v", err)
+			}
+			fmt.Println(string(data))
+			return
+		}
+
 		fmt.Printf("QuickTodo CLI v%s\n", RootCmd.Version)
 		fmt.Println("A simple CLI todo management tool for AI-assisted development workflows")
 	},
 }
 
 func init() {
+	versionCmd.Flags().BoolVar(&versionShort, "short", false, "Print only the version number")
+
 	RootCmd.AddCommand(versionCmd)
 }
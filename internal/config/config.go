@@ -3,8 +3,21 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables that override the config file, e.g. to run against a
+// throwaway data dir in tests or CI
+const (
+	EnvConfigPath      = "QUICKTODO_CONFIG"           // alternate config file
+	EnvDataDir         = "QUICKTODO_DATA_DIR"         // overrides data_dir
+	EnvDefaultPriority = "QUICKTODO_DEFAULT_PRIORITY" // overrides default_priority
+	EnvLockTimeout     = "QUICKTODO_LOCK_TIMEOUT"     // overrides lock_timeout, in seconds
 )
 
 // Config represents the global configuration
@@ -15,8 +28,124 @@ type Config struct {
 	DefaultPriority string `json:"default_priority"`
 	CreateBackups   bool   `json:"create_backups"`
 	MaxBackups      int    `json:"max_backups"`
+	ServeViewToken  string `json:"serve_view_token,omitempty"`
+	ServeWriteToken string `json:"serve_write_token,omitempty"`
+
+	// ServeHost and ServePort are the default listen address for serve; the
+	// --host and --port flags override them
+	ServeHost string `json:"serve_host,omitempty"`
+	ServePort int    `json:"serve_port,omitempty"`
+
+	// Backend is the storage backend for project databases. Only "json" is
+	// available; "sqlite" is reserved and rejected until it is implemented.
+	Backend string `json:"backend,omitempty"`
+
+	// HideDoneByDefault hides done tasks from list-tasks unless --all or
+	// --status done is given
+	HideDoneByDefault bool `json:"hide_done_by_default,omitempty"`
+
+	// DoneLastByDefault lists done tasks after all others in list-tasks
+	// unless --done-last=false is given
+	DoneLastByDefault bool `json:"done_last_by_default,omitempty"`
+
+	// TruncateDescription shortens descriptions in list-tasks' human output
+	// to this many characters unless --truncate-description is given; zero
+	// shows them in full
+	TruncateDescription int `json:"truncate_description,omitempty"`
+
+	// Hooks maps task lifecycle events (task_created, status_changed,
+	// task_completed) to shell command templates run after the mutation, and
+	// task_due to the command the reminders command runs for due tasks.
+	// Templates see only {{.Event}}, {{.Project}} and {{.ID}}; task text is
+	// passed as QUICKTODO_* environment variables and JSON on stdin.
+	Hooks map[string]string `json:"hooks,omitempty"`
+
+	// MaxTitleLength and MaxDescriptionLength bound task text, in characters
+	MaxTitleLength       int `json:"max_title_length"`
+	MaxDescriptionLength int `json:"max_description_length"`
+
+	// ClockSkewTolerance is how many seconds a task's updated_at may fall
+	// before its created_at, e.g. after moving data between machines whose
+	// clocks disagree. With LenientLoad, such tasks are repaired on load by
+	// setting updated_at to created_at.
+	ClockSkewTolerance int  `json:"clock_skew_tolerance"`
+	LenientLoad        bool `json:"lenient_load,omitempty"`
+
+	// AutoAssignOnStart assigns an unassigned task to the acting agent or user
+	// when set-task-status or mark-in-progress moves it into in_progress
+	AutoAssignOnStart bool `json:"auto_assign_on_start,omitempty"`
+
+	// WIPLimits caps how many tasks may be in a status at once, e.g.
+	// {"in_progress": 3}. Moving a task into a full status warns, or fails
+	// with --strict.
+	WIPLimits map[string]int `json:"wip_limits,omitempty"`
+
+	// BackupEveryNWrites takes a backup (see CreateBackups) only every N
+	// writes, counted by the database version; 0 or 1 backs up every write
+	BackupEveryNWrites int `json:"backup_every_n_writes,omitempty"`
+
+	// MaxUndoOperations is how many operations each project's operation log
+	// keeps for undo; older operations are dropped as new ones are recorded
+	MaxUndoOperations int `json:"max_undo_operations"`
+
+	// MaxOpenPerAssignee is how many open tasks one assignee may hold before
+	// the assignments command flags them; zero means no limit
+	MaxOpenPerAssignee int `json:"max_open_per_assignee,omitempty"`
+
+	// SavedFilters holds named list-tasks filters applied via --filter
+	SavedFilters map[string]SavedFilter `json:"saved_filters,omitempty"`
+
+	// Webhooks are URLs that task_created, task_updated and task_deleted
+	// notifications are POSTed to, e.g. Slack or Discord incoming webhooks
+	Webhooks []string `json:"webhooks,omitempty"`
+
+	// fileValues is the config as read from the file when environment
+	// variables overrode some of it, so that Save doesn't persist them
+	fileValues *Config
+}
+
+// SavedFilter is a named set of list-tasks filter values
+type SavedFilter struct {
+	Status     string `json:"status,omitempty"`
+	Priority   string `json:"priority,omitempty"`
+	AssignedTo string `json:"assigned_to,omitempty"`
 }
 
+// Merge returns the filter with any non-empty fields from override taking precedence
+func (f SavedFilter) Merge(override SavedFilter) SavedFilter {
+	merged := f
+	if override.Status != "" {
+		merged.Status = override.Status
+	}
+	if override.Priority != "" {
+		merged.Priority = override.Priority
+	}
+	if override.AssignedTo != "" {
+		merged.AssignedTo = override.AssignedTo
+	}
+	return merged
+}
+
+// IsEmpty reports whether the filter has no criteria
+func (f SavedFilter) IsEmpty() bool {
+	return f.Status == "" && f.Priority == "" && f.AssignedTo == ""
+}
+
+// Default task text limits
+const (
+	DefaultMaxTitleLength       = 200
+	DefaultMaxDescriptionLength = 10000
+)
+
+// DefaultServePort is the port serve listens on when none is configured
+const DefaultServePort = 8080
+
+// DefaultClockSkewTolerance is the default clock_skew_tolerance, in seconds
+const DefaultClockSkewTolerance = 5
+
+// DefaultMaxUndoOperations is the default max_undo_operations
+const DefaultMaxUndoOperations = 50
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	homeDir, err := os.UserHomeDir()
@@ -31,11 +160,22 @@ func DefaultConfig() *Config {
 		DefaultPriority: "medium",
 		CreateBackups:   true,
 		MaxBackups:      5,
+		Backend:         "json",
+
+		MaxTitleLength:       DefaultMaxTitleLength,
+		MaxDescriptionLength: DefaultMaxDescriptionLength,
+		ClockSkewTolerance:   DefaultClockSkewTolerance,
+		MaxUndoOperations:    DefaultMaxUndoOperations,
 	}
 }
 
-// GetConfigPath returns the path to the configuration file
+// GetConfigPath returns the path to the configuration file, which
+// QUICKTODO_CONFIG can point elsewhere
 func GetConfigPath() string {
+	if path := os.Getenv(EnvConfigPath); path != "" {
+		return path
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return ""
@@ -43,7 +183,9 @@ func GetConfigPath() string {
 	return filepath.Join(homeDir, ".config", "quicktodo", "config.json")
 }
 
-// Load loads the configuration from file or creates default if not exists
+// Load loads the configuration from file or creates default if not exists.
+// Settings set through environment variables (see EnvDataDir) are applied
+// after reading the file and before validation.
 func Load() (*Config, error) {
 	configPath := GetConfigPath()
 
@@ -53,6 +195,12 @@ func Load() (*Config, error) {
 		if err := config.Save(); err != nil {
 			return nil, fmt.Errorf("failed to create default config: %w", err)
 		}
+		if err := config.applyEnvOverrides(); err != nil {
+			return nil, err
+		}
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
 		return config, nil
 	}
 
@@ -67,6 +215,10 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	if err := config.applyEnvOverrides(); err != nil {
+		return nil, err
+	}
+
 	// Validate and set defaults for missing fields
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
@@ -75,25 +227,71 @@ func Load() (*Config, error) {
 	return &config, nil
 }
 
-// Save saves the configuration to file
+// applyEnvOverrides replaces settings with the values of their environment
+// variables, remembering the file's values for Save
+func (c *Config) applyEnvOverrides() error {
+	fileValues := *c
+	overridden := false
+
+	if dataDir := os.Getenv(EnvDataDir); dataDir != "" {
+		c.DataDir = dataDir
+		overridden = true
+	}
+
+	if priority := os.Getenv(EnvDefaultPriority); priority != "" {
+		c.DefaultPriority = strings.ToLower(strings.TrimSpace(priority))
+		overridden = true
+	}
+
+	if timeout := os.Getenv(EnvLockTimeout); timeout != "" {
+		seconds, err := strconv.Atoi(strings.TrimSpace(timeout))
+		if err != nil || seconds <= 0 {
+			return fmt.Errorf("invalid %s: %q (must be a positive number of seconds)", EnvLockTimeout, timeout)
+		}
+		c.LockTimeout = seconds
+		overridden = true
+	}
+
+	if overridden {
+		c.fileValues = &fileValues
+	}
+	return nil
+}
+
+// Save saves the configuration to file. Settings overridden by environment
+// variables are saved with their values from the file.
 func (c *Config) Save() error {
 	configPath := GetConfigPath()
 
+	toSave := c
+	if c.fileValues != nil {
+		restored := *c
+		restored.DataDir = c.fileValues.DataDir
+		restored.DefaultPriority = c.fileValues.DefaultPriority
+		restored.LockTimeout = c.fileValues.LockTimeout
+		toSave = &restored
+	}
+
 	// Create config directory if it doesn't exist
 	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
 	// Marshal config to JSON
-	data, err := json.MarshalIndent(c, "", "  ")
+	data, err := json.MarshalIndent(toSave, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	// Write to file, readable only by its owner: it may hold the serve
+	// tokens and webhook URLs. WriteFile keeps the mode of an existing file,
+	// so tighten that too.
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
+	if err := os.Chmod(configPath, 0600); err != nil {
+		return fmt.Errorf("failed to set config file permissions: %w", err)
+	}
 
 	return nil
 }
@@ -117,22 +315,107 @@ func (c *Config) Validate() error {
 	}
 
 	validPriorities := map[string]bool{
-		"low":    true,
-		"medium": true,
-		"high":   true,
+		"low":      true,
+		"medium":   true,
+		"high":     true,
+		"critical": true,
 	}
 
 	if !validPriorities[c.DefaultPriority] {
-		return fmt.Errorf("invalid default_priority: %s (must be low, medium, or high)", c.DefaultPriority)
+		return fmt.Errorf("invalid default_priority: %s (must be low, medium, high, or critical)", c.DefaultPriority)
 	}
 
 	if c.MaxBackups < 0 {
 		c.MaxBackups = 5
 	}
 
+	switch c.Backend {
+	case "":
+		c.Backend = "json"
+	case "json":
+	case "sqlite":
+		return fmt.Errorf("invalid backend: sqlite is not available in this build (use json)")
+	default:
+		return fmt.Errorf("invalid backend: %s (must be json)", c.Backend)
+	}
+
+	if c.MaxTitleLength <= 0 {
+		c.MaxTitleLength = DefaultMaxTitleLength
+	}
+
+	if c.MaxDescriptionLength <= 0 {
+		c.MaxDescriptionLength = DefaultMaxDescriptionLength
+	}
+
+	if c.ServePort < 0 || c.ServePort > 65535 {
+		return fmt.Errorf("invalid serve_port: %d (must be between 1 and 65535)", c.ServePort)
+	}
+
+	if c.ClockSkewTolerance <= 0 {
+		c.ClockSkewTolerance = DefaultClockSkewTolerance
+	}
+
+	if c.MaxUndoOperations <= 0 {
+		c.MaxUndoOperations = DefaultMaxUndoOperations
+	}
+
+	if c.BackupEveryNWrites < 0 {
+		return fmt.Errorf("invalid backup_every_n_writes: %d (must be zero or positive)", c.BackupEveryNWrites)
+	}
+
+	if c.TruncateDescription < 0 {
+		return fmt.Errorf("invalid truncate_description: %d (must be zero or positive)", c.TruncateDescription)
+	}
+
+	if c.MaxOpenPerAssignee < 0 {
+		return fmt.Errorf("invalid max_open_per_assignee: %d (must be zero or positive)", c.MaxOpenPerAssignee)
+	}
+
+	validStatuses := map[string]bool{
+		"pending":     true,
+		"in_progress": true,
+		"done":        true,
+	}
+
+	for status, limit := range c.WIPLimits {
+		if !validStatuses[status] {
+			return fmt.Errorf("invalid wip_limits status: %s (must be pending, in_progress, or done)", status)
+		}
+		if limit < 0 {
+			return fmt.Errorf("invalid wip_limits value for %s: %d (must be zero or positive)", status, limit)
+		}
+	}
+
+	for _, webhook := range c.Webhooks {
+		if err := ValidateWebhookURL(webhook); err != nil {
+			return fmt.Errorf("invalid webhooks entry: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// ValidateWebhookURL checks that a webhook is an absolute http or https URL
+func ValidateWebhookURL(webhook string) error {
+	parsed, err := url.Parse(webhook)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%q is not an http or https URL", webhook)
+	}
+	return nil
+}
+
+// WIPLimit returns the work-in-progress limit for a status; zero or an
+// unset status means no limit
+func (c *Config) WIPLimit(status string) (int, bool) {
+	limit := c.WIPLimits[status]
+	return limit, limit > 0
+}
+
+// ClockSkew returns the clock skew tolerance as a duration
+func (c *Config) ClockSkew() time.Duration {
+	return time.Duration(c.ClockSkewTolerance) * time.Second
+}
+
 // EnsureDataDir ensures the data directory exists
 func (c *Config) EnsureDataDir() error {
 	return os.MkdirAll(c.DataDir, 0755)
@@ -153,6 +436,39 @@ func (c *Config) GetProjectLockPath(projectName string) string {
 	return filepath.Join(c.DataDir, "locks", projectName+".lock")
 }
 
+// GetProjectsDir returns the directory holding project database files
+func (c *Config) GetProjectsDir() string {
+	return filepath.Join(c.DataDir, "projects")
+}
+
+// GetLocksDir returns the directory holding project lock files
+func (c *Config) GetLocksDir() string {
+	return filepath.Join(c.DataDir, "locks")
+}
+
+// GetProjectArchivePath returns the path to the file holding a project's
+// archived tasks
+func (c *Config) GetProjectArchivePath(projectName string) string {
+	return filepath.Join(c.DataDir, "archive", projectName+".json")
+}
+
+// GetProjectOpsPath returns the path to a project's operation log, the
+// ops.jsonl that undo reverts operations from
+func (c *Config) GetProjectOpsPath(projectName string) string {
+	return filepath.Join(c.DataDir, "ops", projectName+".jsonl")
+}
+
+// GetFocusPath returns the path to the file recording each agent's focused task
+func (c *Config) GetFocusPath() string {
+	return filepath.Join(c.DataDir, "focus.json")
+}
+
+// GetRotationPath returns the path to the file recording each project's
+// position in its assignee rotation
+func (c *Config) GetRotationPath() string {
+	return filepath.Join(c.DataDir, "rotation.json")
+}
+
 // EnsureAllDirectories ensures all required directories exist
 func (c *Config) EnsureAllDirectories() error {
 	dirs := []string{
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -172,4 +173,269 @@ func TestGetConfigPath(t *testing.T) {
 	if filepath.Base(configPath) != "config.json" {
 		t.Errorf("Expected config path to end with 'config.json', got '%s'", configPath)
 	}
-}
\ No newline at end of file
+}
+
+func TestSavedFiltersPersist(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	config.SavedFilters = map[string]SavedFilter{
+		"mywork": {Status: "in_progress", AssignedTo: "me"},
+	}
+	if err := config.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	filter, ok := loaded.SavedFilters["mywork"]
+	if !ok {
+		t.Fatalf("Expected saved filter 'mywork' after reload, got %v", loaded.SavedFilters)
+	}
+	if filter.Status != "in_progress" || filter.AssignedTo != "me" || filter.Priority != "" {
+		t.Errorf("Unexpected saved filter after reload: %+v", filter)
+	}
+}
+
+func TestSavedFilterMerge(t *testing.T) {
+	saved := SavedFilter{Status: "in_progress", AssignedTo: "me"}
+
+	merged := saved.Merge(SavedFilter{})
+	if merged != saved {
+		t.Errorf("Expected empty override to keep saved values, got %+v", merged)
+	}
+
+	merged = saved.Merge(SavedFilter{Status: "done", Priority: "high"})
+	if merged.Status != "done" {
+		t.Errorf("Expected command-line status to override, got '%s'", merged.Status)
+	}
+	if merged.Priority != "high" {
+		t.Errorf("Expected command-line priority to be added, got '%s'", merged.Priority)
+	}
+	if merged.AssignedTo != "me" {
+		t.Errorf("Expected saved assignee to be kept, got '%s'", merged.AssignedTo)
+	}
+}
+
+func TestWIPLimitsValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WIPLimits = map[string]int{"in_progress": 3, "done": 0}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid WIP limits, got %v", err)
+	}
+	if limit, ok := cfg.WIPLimit("in_progress"); !ok || limit != 3 {
+		t.Errorf("Expected in_progress limit 3, got %d, %v", limit, ok)
+	}
+	if _, ok := cfg.WIPLimit("done"); ok {
+		t.Error("Expected a zero limit to mean no limit")
+	}
+
+	cfg.WIPLimits = map[string]int{"blocked": 2}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for unknown status in wip_limits")
+	}
+
+	cfg.WIPLimits = map[string]int{"pending": -1}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for negative WIP limit")
+	}
+}
+
+func TestClockSkewToleranceDefault(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ClockSkewTolerance = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if cfg.ClockSkew() != DefaultClockSkewTolerance*time.Second {
+		t.Errorf("Expected default tolerance of %ds, got %s", DefaultClockSkewTolerance, cfg.ClockSkew())
+	}
+
+	cfg.ClockSkewTolerance = 30
+	if cfg.ClockSkew() != 30*time.Second {
+		t.Errorf("Expected 30s tolerance, got %s", cfg.ClockSkew())
+	}
+}
+
+func TestServePortValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	for _, valid := range []int{0, 1, 8080, 65535} {
+		cfg.ServePort = valid
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected serve_port %d to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []int{-1, 65536, 100000} {
+		cfg.ServePort = invalid
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Expected serve_port %d to be rejected", invalid)
+		}
+	}
+}
+
+func TestMaxOpenPerAssigneeValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxOpenPerAssignee = 4
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected a positive limit to be valid, got %v", err)
+	}
+
+	cfg.MaxOpenPerAssignee = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for negative max_open_per_assignee")
+	}
+}
+
+func TestTruncateDescriptionValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TruncateDescription = 80
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected a positive length to be valid, got %v", err)
+	}
+
+	cfg.TruncateDescription = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for negative truncate_description")
+	}
+}
+
+func TestBackupEveryNWritesValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.BackupEveryNWrites = 10
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected a positive cadence to be valid, got %v", err)
+	}
+
+	cfg.BackupEveryNWrites = -2
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for negative backup_every_n_writes")
+	}
+}
+
+func TestWebhooksValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Webhooks = []string{"https://hooks.slack.com/services/T0/B0/x", "http://localhost:9000/hook"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected http(s) webhooks to be valid, got %v", err)
+	}
+
+	for _, invalid := range []string{"hooks.slack.com/services", "ftp://example.com/hook", "https://", ""} {
+		cfg.Webhooks = []string{invalid}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Expected webhook %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestConfigEnvOverrides(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), "ci-config.json")
+	t.Setenv(EnvConfigPath, configPath)
+
+	if GetConfigPath() != configPath {
+		t.Fatalf("Expected %s to set the config path, got %s", EnvConfigPath, GetConfigPath())
+	}
+
+	fileDataDir := t.TempDir()
+	config := DefaultConfig()
+	config.DataDir = fileDataDir
+	if err := config.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	overrideDataDir := t.TempDir()
+	t.Setenv(EnvDataDir, overrideDataDir)
+	t.Setenv(EnvDefaultPriority, "High")
+	t.Setenv(EnvLockTimeout, "5")
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.DataDir != overrideDataDir || loaded.DefaultPriority != "high" || loaded.LockTimeout != 5 {
+		t.Errorf("Expected the environment to override the file, got %+v", loaded)
+	}
+
+	// Saving keeps the file's own values for overridden settings
+	loaded.SavedFilters = map[string]SavedFilter{"mine": {AssignedTo: "me"}}
+	if err := loaded.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	t.Setenv(EnvDataDir, "")
+	t.Setenv(EnvDefaultPriority, "")
+	t.Setenv(EnvLockTimeout, "")
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if reloaded.DataDir != fileDataDir || reloaded.DefaultPriority != "medium" || reloaded.LockTimeout != 30 {
+		t.Errorf("Expected overrides not to be saved, got %+v", reloaded)
+	}
+	if _, ok := reloaded.SavedFilters["mine"]; !ok {
+		t.Error("Expected other changes to be saved")
+	}
+}
+
+func TestConfigEnvOverridesValidate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvConfigPath, filepath.Join(t.TempDir(), "config.json"))
+
+	t.Setenv(EnvLockTimeout, "soon")
+	if _, err := Load(); err == nil {
+		t.Errorf("Expected an invalid %s to be rejected", EnvLockTimeout)
+	}
+
+	t.Setenv(EnvLockTimeout, "")
+	t.Setenv(EnvDefaultPriority, "urgent")
+	if _, err := Load(); err == nil {
+		t.Errorf("Expected an invalid %s to be rejected", EnvDefaultPriority)
+	}
+}
+
+func TestSaveKeepsConfigPrivate(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	t.Setenv(EnvConfigPath, configPath)
+
+	// A config written by an older version is readable by everyone
+	if err := os.WriteFile(configPath, []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	config.ServeWriteToken = "write-secret"
+	if err := config.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	info, err := os.Stat(configPath)
+	if err != nil {
+		t.Fatalf("Failed to stat config: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("Expected the config file to be private, got %v", mode)
+	}
+}
+
+func TestBackendValidate(t *testing.T) {
+	cfg := DefaultConfig()
+	if cfg.Backend != "json" {
+		t.Errorf("Expected the json backend by default, got %q", cfg.Backend)
+	}
+
+	cfg.Backend = ""
+	if err := cfg.Validate(); err != nil || cfg.Backend != "json" {
+		t.Errorf("Expected an empty backend to default to json, got %q, %v", cfg.Backend, err)
+	}
+
+	for _, backend := range []string{"sqlite", "postgres"} {
+		cfg.Backend = backend
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Expected backend %q to be rejected", backend)
+		}
+	}
+}
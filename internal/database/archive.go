@@ -0,0 +1,98 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"sort"
+	"time"
+)
+
+// TaskArchive holds the done tasks moved out of a project database by the
+// archive command
+type TaskArchive struct {
+	Project      string         `json:"project"`
+	Tasks        []*models.Task `json:"tasks"`
+	LastModified time.Time      `json:"last_modified"`
+}
+
+// LoadTaskArchive loads a project's archive from file; a missing file means
+// nothing has been archived yet
+func LoadTaskArchive(filePath, projectName string) (*TaskArchive, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return &TaskArchive{Project: projectName, Tasks: []*models.Task{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive file: %w", err)
+	}
+
+	var archive TaskArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("failed to parse archive file: %w", err)
+	}
+
+	if archive.Tasks == nil {
+		archive.Tasks = []*models.Task{}
+	}
+	for _, task := range archive.Tasks {
+		task.NormalizeTimestamps()
+	}
+
+	return &archive, nil
+}
+
+// Add appends tasks to the archive, keeping it in ID order
+func (a *TaskArchive) Add(tasks []*models.Task) {
+	a.Tasks = append(a.Tasks, tasks...)
+	sort.SliceStable(a.Tasks, func(i, j int) bool { return a.Tasks[i].ID < a.Tasks[j].ID })
+}
+
+// Get returns the archived task with the given ID
+func (a *TaskArchive) Get(id int) (*models.Task, bool) {
+	for _, task := range a.Tasks {
+		if task.ID == id {
+			return task, true
+		}
+	}
+	return nil, false
+}
+
+// Remove takes the task with the given ID out of the archive
+func (a *TaskArchive) Remove(id int) bool {
+	for i, task := range a.Tasks {
+		if task.ID == id {
+			a.Tasks = append(a.Tasks[:i], a.Tasks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Save saves the archive to file
+func (a *TaskArchive) Save(filePath string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	a.LastModified = time.Now().UTC()
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive: %w", err)
+	}
+
+	// Write to temporary file first, then rename for atomicity
+	tempPath := filePath + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, filePath); err != nil {
+		os.Remove(tempPath) // Clean up temp file
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"path/filepath"
+	"quicktodo/internal/models"
+	"testing"
+)
+
+func TestTaskArchiveSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive", "webapp.json")
+
+	archive, err := LoadTaskArchive(path, "webapp")
+	if err != nil {
+		t.Fatalf("LoadTaskArchive failed for a missing file: %v", err)
+	}
+	if archive.Project != "webapp" || len(archive.Tasks) != 0 {
+		t.Errorf("Expected an empty archive, got %+v", archive)
+	}
+
+	archive.Add([]*models.Task{models.NewTask(5, "Five"), models.NewTask(2, "Two")})
+	archive.Add([]*models.Task{models.NewTask(3, "Three")})
+	if err := archive.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadTaskArchive(path, "webapp")
+	if err != nil {
+		t.Fatalf("LoadTaskArchive failed: %v", err)
+	}
+	if len(loaded.Tasks) != 3 || loaded.Tasks[0].ID != 2 || loaded.Tasks[1].ID != 3 || loaded.Tasks[2].ID != 5 {
+		t.Fatalf("Expected archived tasks in ID order, got %+v", loaded.Tasks)
+	}
+
+	if task, ok := loaded.Get(3); !ok || task.Title != "Three" {
+		t.Errorf("Expected to find task #3, got %v, %v", task, ok)
+	}
+	if !loaded.Remove(3) || loaded.Remove(3) {
+		t.Error("Expected task #3 to be removed exactly once")
+	}
+	if _, ok := loaded.Get(3); ok {
+		t.Error("Expected task #3 gone after Remove")
+	}
+}
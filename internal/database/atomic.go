@@ -0,0 +1,82 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// TempPath is where WriteFileAtomic stages the new contents of filePath. It
+// is in the same directory so the rename never crosses filesystems.
+func TempPath(filePath string) string {
+	return filePath + ".tmp"
+}
+
+// WriteFileAtomic replaces filePath with data so that a crash leaves either
+// the old or the new contents, never a mix: the data is written and synced to
+// a temporary file, renamed over filePath, and the directory is synced so the
+// rename itself is durable.
+func WriteFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+	tempPath := TempPath(filePath)
+	file, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempPath) // Clean up temp file
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, filePath); err != nil {
+		os.Remove(tempPath) // Clean up temp file
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	return syncDir(filepath.Dir(filePath))
+}
+
+// syncDir flushes a directory's entries, making renames in it durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open directory: %w", err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("failed to sync directory: %w", err)
+	}
+	return nil
+}
+
+// RemoveOrphanedTemp deletes the temporary file of an interrupted
+// WriteFileAtomic of filePath if it is older than minAge. Younger files may
+// belong to a write in progress in another process and are left alone. It
+// reports whether a file was removed.
+func RemoveOrphanedTemp(filePath string, minAge time.Duration) (bool, error) {
+	tempPath := TempPath(filePath)
+	info, err := os.Stat(tempPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check temporary file: %w", err)
+	}
+	if time.Since(info.ModTime()) < minAge {
+		return false, nil
+	}
+
+	if err := os.Remove(tempPath); err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to remove orphaned temporary file: %w", err)
+	}
+	return true, nil
+}
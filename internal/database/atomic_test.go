@@ -0,0 +1,60 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("new"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new" {
+		t.Errorf("Expected the new contents, got %q", data)
+	}
+	if _, err := os.Stat(TempPath(path)); !os.IsNotExist(err) {
+		t.Errorf("Expected no temporary file to remain, got %v", err)
+	}
+}
+
+func TestRemoveOrphanedTemp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	if err := os.WriteFile(path, []byte("original"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	// A save that died between writing the temp file and renaming it
+	if err := os.WriteFile(TempPath(path), []byte("partial"), 0644); err != nil {
+		t.Fatalf("Failed to write temporary file: %v", err)
+	}
+
+	// A recent temp file may still be in use
+	if removed, err := RemoveOrphanedTemp(path, time.Minute); err != nil || removed {
+		t.Fatalf("Expected a fresh temp file to be kept, got %v, %v", removed, err)
+	}
+
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(TempPath(path), old, old); err != nil {
+		t.Fatalf("Failed to age temporary file: %v", err)
+	}
+	if removed, err := RemoveOrphanedTemp(path, time.Minute); err != nil || !removed {
+		t.Fatalf("Expected the orphaned temp file to be removed, got %v, %v", removed, err)
+	}
+	if _, err := os.Stat(TempPath(path)); !os.IsNotExist(err) {
+		t.Errorf("Expected the temporary file to be gone, got %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "original" {
+		t.Errorf("Expected the original file to be intact, got %q", data)
+	}
+
+	if removed, err := RemoveOrphanedTemp(path, time.Minute); err != nil || removed {
+		t.Errorf("Expected nothing to remove, got %v, %v", removed, err)
+	}
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupTimeLayout names backup files. It is fixed width, so lexical order of
+// backup names is chronological.
+const backupTimeLayout = "20060102T150405.000000000Z"
+
+// BackupDir returns the directory holding a project's database backups
+func BackupDir(dataDir, projectName string) string {
+	return filepath.Join(dataDir, "backups", projectName)
+}
+
+// BackupPath returns the file of a project's backup by name, with or without
+// the .json extension
+func BackupPath(dataDir, projectName, name string) string {
+	return filepath.Join(BackupDir(dataDir, projectName), strings.TrimSuffix(name, ".json")+".json")
+}
+
+// ValidateBackupName checks that a backup name given by the user names a
+// file in the backup directory rather than a path out of it
+func ValidateBackupName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid backup name '%s'", name)
+	}
+	return nil
+}
+
+// ListBackups returns the names of a project's backups, oldest first
+func ListBackups(dataDir, projectName string) ([]string, error) {
+	entries, err := os.ReadDir(BackupDir(dataDir, projectName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// BackupProjectDatabase copies a project database file into the project's
+// backup directory before it is overwritten, then prunes the oldest backups
+// beyond maxBackups (0 keeps all). The copy is written atomically and pruning
+// only starts once it is in place, so an interrupted backup never leaves a
+// partial file or fewer backups than before. It returns the backup name, or
+// "" when there is no database file to back up yet.
+func BackupProjectDatabase(dataDir, projectName, dbPath string, maxBackups int) (string, error) {
+	data, err := os.ReadFile(dbPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read project database: %w", err)
+	}
+
+	dir := BackupDir(dataDir, projectName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	name := time.Now().UTC().Format(backupTimeLayout)
+	if err := WriteFileAtomic(BackupPath(dataDir, projectName, name), data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	if maxBackups > 0 {
+		names, err := ListBackups(dataDir, projectName)
+		if err != nil {
+			return "", err
+		}
+		for i := 0; i < len(names)-maxBackups; i++ {
+			if err := os.Remove(BackupPath(dataDir, projectName, names[i])); err != nil {
+				return "", fmt.Errorf("failed to remove old backup: %w", err)
+			}
+		}
+	}
+
+	return name, nil
+}
+
+// BackupDue reports whether the database file at dbPath should be backed up
+// before it is next overwritten when backups are taken every n writes. Writes
+// are counted by the database version: a backup is due once the file's version
+// is at least n past the version in the newest backup, or when there is no
+// backup yet. An n of 1 or less, or a file without a version (git-friendly
+// databases don't record one), means every write. Files that can't be parsed
+// are backed up too, since that is when a copy matters most.
+func BackupDue(dataDir, projectName, dbPath string, n int) (bool, error) {
+	if n <= 1 {
+		return true, nil
+	}
+
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	names, err := ListBackups(dataDir, projectName)
+	if err != nil {
+		return false, err
+	}
+	if len(names) == 0 {
+		return true, nil
+	}
+
+	current := databaseVersion(dbPath)
+	last := databaseVersion(BackupPath(dataDir, projectName, names[len(names)-1]))
+	if current == 0 || last == 0 {
+		return true, nil
+	}
+
+	return current-last >= n, nil
+}
+
+// databaseVersion reads the version recorded in a database file, or 0 when
+// it has none or can't be read
+func databaseVersion(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return 0
+	}
+	return header.Version
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupDueCadence(t *testing.T) {
+	dataDir := t.TempDir()
+	dbPath := filepath.Join(dataDir, "projects", "demo.json")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		t.Fatalf("Failed to create projects dir: %v", err)
+	}
+
+	// Nothing to back up before the first write
+	if due, err := BackupDue(dataDir, "demo", dbPath, 3); err != nil || due {
+		t.Fatalf("Expected no backup without a database file, got %v, %v", due, err)
+	}
+
+	// Simulate writes of versions 1..10, backing up before each overwrite
+	var backedUp []int
+	for version := 1; version <= 10; version++ {
+		due, err := BackupDue(dataDir, "demo", dbPath, 3)
+		if err != nil {
+			t.Fatalf("BackupDue failed: %v", err)
+		}
+		if due {
+			name, err := BackupProjectDatabase(dataDir, "demo", dbPath, 0)
+			if err != nil {
+				t.Fatalf("BackupProjectDatabase failed: %v", err)
+			}
+			if name != "" {
+				backedUp = append(backedUp, databaseVersion(BackupPath(dataDir, "demo", name)))
+			}
+		}
+
+		data := fmt.Sprintf(`{"version": %d, "tasks": []}`, version)
+		if err := os.WriteFile(dbPath, []byte(data), 0644); err != nil {
+			t.Fatalf("Failed to write database: %v", err)
+		}
+	}
+
+	want := []int{1, 4, 7}
+	if fmt.Sprint(backedUp) != fmt.Sprint(want) {
+		t.Errorf("Expected backups of versions %v, got %v", want, backedUp)
+	}
+	if names, _ := ListBackups(dataDir, "demo"); len(names) != len(want) {
+		t.Errorf("Expected %d backup files, got %d", len(want), len(names))
+	}
+}
+
+func TestBackupDueEveryWrite(t *testing.T) {
+	dataDir := t.TempDir()
+	dbPath := filepath.Join(dataDir, "demo.json")
+	if err := os.WriteFile(dbPath, []byte(`{"version": 5}`), 0644); err != nil {
+		t.Fatalf("Failed to write database: %v", err)
+	}
+	if _, err := BackupProjectDatabase(dataDir, "demo", dbPath, 0); err != nil {
+		t.Fatalf("BackupProjectDatabase failed: %v", err)
+	}
+
+	for _, n := range []int{0, 1} {
+		if due, err := BackupDue(dataDir, "demo", dbPath, n); err != nil || !due {
+			t.Errorf("Expected every write backed up with n=%d, got %v, %v", n, due, err)
+		}
+	}
+
+	// Without a version (git-friendly) the cadence can't be tracked
+	if err := os.WriteFile(dbPath, []byte(`{"tasks": []}`), 0644); err != nil {
+		t.Fatalf("Failed to write database: %v", err)
+	}
+	if due, err := BackupDue(dataDir, "demo", dbPath, 10); err != nil || !due {
+		t.Errorf("Expected a backup for a database without a version, got %v, %v", due, err)
+	}
+}
@@ -0,0 +1,341 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Compaction categories reported by Compact
+const (
+	CompactBackups       = "backups"
+	CompactNotifications = "notifications"
+	CompactTempFiles     = "temp_files"
+	CompactUndoJournal   = "undo_journal"
+	CompactArchive       = "archived_tasks"
+)
+
+// CompactOptions controls what Compact removes from the data directory
+type CompactOptions struct {
+	DataDir    string
+	MaxBackups int           // backups kept per project; 0 keeps all
+	MinAge     time.Duration // notification and temp files younger than this are kept
+	DryRun     bool          // report what would be removed without deleting
+
+	MaxUndoOperations int           // operations kept in each undo journal; 0 keeps all
+	ArchiveRetention  time.Duration // archived tasks completed longer ago are dropped; 0 keeps all
+	Locks             *LockManager  // when set, each project is locked while its files are rewritten
+}
+
+// CompactCategory describes the files removed for one kind of residue. For
+// the undo journal and the archive, whose files are trimmed rather than
+// removed, Files lists the files rewritten and Entries the operations or
+// tasks dropped from them.
+type CompactCategory struct {
+	Name           string   `json:"name"`
+	Files          []string `json:"files"`
+	Entries        int      `json:"entries,omitempty"`
+	ReclaimedBytes int64    `json:"reclaimed_bytes"`
+}
+
+// CompactResult summarizes a compaction run. FileCount counts the files
+// removed and EntryCount the entries trimmed from the files kept.
+type CompactResult struct {
+	DryRun         bool               `json:"dry_run"`
+	Categories     []*CompactCategory `json:"categories"`
+	FileCount      int                `json:"file_count"`
+	EntryCount     int                `json:"entry_count"`
+	ReclaimedBytes int64              `json:"reclaimed_bytes"`
+}
+
+// Compact prunes backups beyond MaxBackups, notification files left behind when
+// no web server was running, and temporary files from interrupted writes. It
+// also trims each undo journal to MaxUndoOperations and drops archived tasks
+// completed before ArchiveRetention.
+func Compact(opts CompactOptions) (*CompactResult, error) {
+	result := &CompactResult{DryRun: opts.DryRun}
+	cutoff := time.Now().Add(-opts.MinAge)
+
+	backups, err := excessBackups(filepath.Join(opts.DataDir, "backups"), opts.MaxBackups)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := filesOlderThan(filepath.Join(opts.DataDir, "notifications"), ".json", cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	var tempFiles []string
+	for _, dir := range []string{opts.DataDir, filepath.Join(opts.DataDir, "projects")} {
+		files, err := filesOlderThan(dir, ".tmp", cutoff)
+		if err != nil {
+			return nil, err
+		}
+		tempFiles = append(tempFiles, files...)
+	}
+
+	for _, group := range []struct {
+		name  string
+		files []string
+	}{
+		{CompactBackups, backups},
+		{CompactNotifications, notifications},
+		{CompactTempFiles, tempFiles},
+	} {
+		category, err := removeFiles(group.name, group.files, opts.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		result.Categories = append(result.Categories, category)
+		result.FileCount += len(category.Files)
+		result.ReclaimedBytes += category.ReclaimedBytes
+	}
+
+	for _, trim := range []struct {
+		name string
+		dir  string
+		ext  string
+		fn   func(path, project string) ([]byte, []byte, int, error)
+	}{
+		{CompactUndoJournal, "ops", ".jsonl", func(path, project string) ([]byte, []byte, int, error) {
+			return trimOperations(path, opts.MaxUndoOperations)
+		}},
+		{CompactArchive, "archive", ".json", func(path, project string) ([]byte, []byte, int, error) {
+			return trimArchive(path, project, opts.ArchiveRetention)
+		}},
+	} {
+		category, err := trimFiles(trim.name, filepath.Join(opts.DataDir, trim.dir), trim.ext, opts, trim.fn)
+		if err != nil {
+			return nil, err
+		}
+		result.Categories = append(result.Categories, category)
+		result.EntryCount += category.Entries
+		result.ReclaimedBytes += category.ReclaimedBytes
+	}
+
+	return result, nil
+}
+
+// trimFiles applies trim to each project file in dir, named after its
+// project, and rewrites those it dropped entries from (unless DryRun). trim
+// returns the file's current and trimmed contents and the entries dropped.
+func trimFiles(name, dir, ext string, opts CompactOptions, trim func(path, project string) ([]byte, []byte, int, error)) (*CompactCategory, error) {
+	category := &CompactCategory{Name: name, Files: []string{}}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return category, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ext) {
+			continue
+		}
+		project := strings.TrimSuffix(entry.Name(), ext)
+		path := filepath.Join(dir, entry.Name())
+
+		if err := withProjectLock(opts, project, func() error {
+			before, after, dropped, err := trim(path, project)
+			if err != nil || dropped == 0 {
+				return err
+			}
+			if !opts.DryRun {
+				if err := WriteFileAtomic(path, after, 0644); err != nil {
+					return err
+				}
+			}
+			category.Files = append(category.Files, path)
+			category.Entries += dropped
+			category.ReclaimedBytes += int64(len(before) - len(after))
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return category, nil
+}
+
+// withProjectLock runs fn holding the project's lock when opts.Locks is set
+func withProjectLock(opts CompactOptions, project string, fn func() error) error {
+	if opts.Locks == nil || opts.DryRun {
+		return fn()
+	}
+
+	lockInfo, err := opts.Locks.AcquireLock(project)
+	if err != nil {
+		return fmt.Errorf("failed to lock project %s: %w", project, err)
+	}
+	defer opts.Locks.ReleaseLock(lockInfo)
+
+	return fn()
+}
+
+// trimOperations drops the oldest operations of the log at path beyond limit
+func trimOperations(path string, limit int) ([]byte, []byte, int, error) {
+	if limit <= 0 {
+		return nil, nil, 0, nil
+	}
+
+	ops, err := LoadOperations(path)
+	if err != nil || len(ops) <= limit {
+		return nil, nil, 0, err
+	}
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to read operation log: %w", err)
+	}
+	dropped := len(ops) - limit
+	after, err := encodeOperations(ops[dropped:])
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return before, after, dropped, nil
+}
+
+// trimArchive drops the archived tasks completed more than retention ago.
+// Tasks without a completion time count from their last update.
+func trimArchive(path, project string, retention time.Duration) ([]byte, []byte, int, error) {
+	if retention <= 0 {
+		return nil, nil, 0, nil
+	}
+
+	archive, err := LoadTaskArchive(path, project)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	cutoff := time.Now().Add(-retention)
+	kept := make([]*models.Task, 0, len(archive.Tasks))
+	for _, task := range archive.Tasks {
+		finished := task.UpdatedAt
+		if task.CompletedAt != nil {
+			finished = *task.CompletedAt
+		}
+		if !finished.Before(cutoff) {
+			kept = append(kept, task)
+		}
+	}
+	dropped := len(archive.Tasks) - len(kept)
+	if dropped == 0 {
+		return nil, nil, 0, nil
+	}
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to read archive file: %w", err)
+	}
+	archive.Tasks = kept
+	archive.LastModified = time.Now().UTC()
+	after, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to marshal archive: %w", err)
+	}
+
+	return before, after, dropped, nil
+}
+
+// excessBackups returns the oldest backups in each project directory beyond maxBackups.
+// Backup file names are timestamps, so lexical order is chronological.
+func excessBackups(backupRoot string, maxBackups int) ([]string, error) {
+	if maxBackups <= 0 {
+		return nil, nil
+	}
+
+	projects, err := os.ReadDir(backupRoot)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	var excess []string
+	for _, project := range projects {
+		if !project.IsDir() {
+			continue
+		}
+
+		dir := filepath.Join(backupRoot, project.Name())
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup directory: %w", err)
+		}
+
+		var names []string
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+
+		for i := 0; i < len(names)-maxBackups; i++ {
+			excess = append(excess, filepath.Join(dir, names[i]))
+		}
+	}
+
+	return excess, nil
+}
+
+// filesOlderThan lists files in dir with the given suffix last modified before cutoff
+func filesOlderThan(dir, suffix string, cutoff time.Time) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), suffix) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	return files, nil
+}
+
+// removeFiles deletes files (unless dryRun) and totals their size
+func removeFiles(name string, files []string, dryRun bool) (*CompactCategory, error) {
+	category := &CompactCategory{Name: name, Files: make([]string, 0, len(files))}
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+
+		if !dryRun {
+			if err := os.Remove(file); err != nil {
+				return nil, fmt.Errorf("failed to remove %s: %w", file, err)
+			}
+		}
+
+		category.Files = append(category.Files, file)
+		category.ReclaimedBytes += info.Size()
+	}
+
+	return category, nil
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"testing"
+	"time"
+)
+
+// writeAgedFile creates a file with the given content and modification time
+func writeAgedFile(t *testing.T, path, content string, age time.Duration) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set file time: %v", err)
+	}
+}
+
+func setupCompactDir(t *testing.T) string {
+	t.Helper()
+	dataDir := t.TempDir()
+
+	for _, name := range []string{"20240101T000000.json", "20240102T000000.json", "20240103T000000.json"} {
+		writeAgedFile(t, filepath.Join(dataDir, "backups", "demo", name), "{}", 0)
+	}
+	writeAgedFile(t, filepath.Join(dataDir, "notifications", "old.json"), "old", 2*time.Hour)
+	writeAgedFile(t, filepath.Join(dataDir, "notifications", "new.json"), "new", 0)
+	writeAgedFile(t, filepath.Join(dataDir, "projects", "demo.json.tmp"), "partial", 2*time.Hour)
+	writeAgedFile(t, filepath.Join(dataDir, "projects", "demo.json"), "{}", 2*time.Hour)
+
+	return dataDir
+}
+
+func TestCompactRemovesResidue(t *testing.T) {
+	dataDir := setupCompactDir(t)
+
+	result, err := Compact(CompactOptions{DataDir: dataDir, MaxBackups: 2, MinAge: time.Hour})
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+
+	if result.FileCount != 3 {
+		t.Errorf("Expected 3 files removed, got %d", result.FileCount)
+	}
+	if result.ReclaimedBytes != int64(len("{}")+len("old")+len("partial")) {
+		t.Errorf("Unexpected reclaimed bytes: %d", result.ReclaimedBytes)
+	}
+
+	removed := []string{
+		filepath.Join(dataDir, "backups", "demo", "20240101T000000.json"),
+		filepath.Join(dataDir, "notifications", "old.json"),
+		filepath.Join(dataDir, "projects", "demo.json.tmp"),
+	}
+	for _, path := range removed {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", path)
+		}
+	}
+
+	kept := []string{
+		filepath.Join(dataDir, "backups", "demo", "20240102T000000.json"),
+		filepath.Join(dataDir, "backups", "demo", "20240103T000000.json"),
+		filepath.Join(dataDir, "notifications", "new.json"),
+		filepath.Join(dataDir, "projects", "demo.json"),
+	}
+	for _, path := range kept {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to be kept: %v", path, err)
+		}
+	}
+}
+
+func TestCompactDryRun(t *testing.T) {
+	dataDir := setupCompactDir(t)
+
+	result, err := Compact(CompactOptions{DataDir: dataDir, MaxBackups: 2, MinAge: time.Hour, DryRun: true})
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+
+	if !result.DryRun || result.FileCount != 3 {
+		t.Errorf("Expected dry run reporting 3 files, got %+v", result)
+	}
+
+	for _, category := range result.Categories {
+		for _, path := range category.Files {
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("Dry run removed %s", path)
+			}
+		}
+	}
+}
+
+func TestCompactEmptyDataDir(t *testing.T) {
+	result, err := Compact(CompactOptions{DataDir: t.TempDir(), MaxBackups: 10, MinAge: time.Hour})
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if result.FileCount != 0 || len(result.Categories) != 5 {
+		t.Errorf("Expected nothing removed with all categories reported, got %+v", result)
+	}
+}
+
+func TestCompactTrimsUndoJournalAndArchive(t *testing.T) {
+	dataDir := t.TempDir()
+
+	opsPath := filepath.Join(dataDir, "ops", "demo.jsonl")
+	for _, command := range []string{"first", "second", "third"} {
+		op := &Operation{Command: command, Changes: []*TaskChange{{TaskID: 1, After: models.NewTask(1, command)}}}
+		if err := AppendOperation(opsPath, op, 0); err != nil {
+			t.Fatalf("AppendOperation failed: %v", err)
+		}
+	}
+
+	archivePath := filepath.Join(dataDir, "archive", "demo.json")
+	archive, _ := LoadTaskArchive(archivePath, "demo")
+	old, recent := models.NewTask(1, "Old"), models.NewTask(2, "Recent")
+	longAgo, now := time.Now().Add(-100*24*time.Hour), time.Now()
+	old.CompletedAt, recent.CompletedAt = &longAgo, &now
+	archive.Add([]*models.Task{old, recent})
+	if err := archive.Save(archivePath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	opts := CompactOptions{DataDir: dataDir, MaxUndoOperations: 2, ArchiveRetention: 30 * 24 * time.Hour}
+
+	opts.DryRun = true
+	result, err := Compact(opts)
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if result.EntryCount != 2 || result.ReclaimedBytes <= 0 {
+		t.Errorf("Expected a dry run reporting 2 entries, got %+v", result)
+	}
+	if ops, _ := LoadOperations(opsPath); len(ops) != 3 {
+		t.Errorf("Dry run trimmed the undo journal to %d operations", len(ops))
+	}
+
+	opts.DryRun = false
+	if result, err = Compact(opts); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	for _, category := range result.Categories {
+		switch category.Name {
+		case CompactUndoJournal, CompactArchive:
+			if category.Entries != 1 || len(category.Files) != 1 {
+				t.Errorf("Expected one entry trimmed from one file for %s, got %+v", category.Name, category)
+			}
+		}
+	}
+
+	if ops, _ := LoadOperations(opsPath); len(ops) != 2 || ops[0].Command != "second" {
+		t.Errorf("Expected the two most recent operations kept, got %+v", ops)
+	}
+	archive, err = LoadTaskArchive(archivePath, "demo")
+	if err != nil || len(archive.Tasks) != 1 || archive.Tasks[0].ID != 2 {
+		t.Errorf("Expected only the recent task left archived, got %+v, %v", archive, err)
+	}
+}
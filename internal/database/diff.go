@@ -0,0 +1,87 @@
+package database
+
+import (
+	"sort"
+
+	"quicktodo/internal/models"
+)
+
+// Diff describes how the tasks of one project database differ from another
+type Diff struct {
+	Added    []*models.Task `json:"added"`
+	Removed  []*models.Task `json:"removed"`
+	Modified []*TaskDiff    `json:"modified"`
+}
+
+// TaskDiff lists the fields of a task that changed between two databases
+type TaskDiff struct {
+	ID      int            `json:"id"`
+	Title   string         `json:"title"`
+	Changes []*FieldChange `json:"changes"`
+}
+
+// FieldChange is one field of a task with its value on each side
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// IsEmpty reports whether the databases hold the same tasks
+func (d *Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DiffDatabases compares the tasks of a and b by ID. Tasks only in b are added,
+// tasks only in a are removed, and tasks in both with differing fields are
+// modified. Each list is ordered by task ID.
+func DiffDatabases(a, b *models.ProjectDatabase) *Diff {
+	diff := &Diff{
+		Added:    []*models.Task{},
+		Removed:  []*models.Task{},
+		Modified: []*TaskDiff{},
+	}
+
+	before := make(map[int]*models.Task, len(a.Tasks))
+	for _, task := range a.Tasks {
+		before[task.ID] = task
+	}
+	after := make(map[int]*models.Task, len(b.Tasks))
+	for _, task := range b.Tasks {
+		after[task.ID] = task
+	}
+
+	for _, task := range b.Tasks {
+		old, exists := before[task.ID]
+		if !exists {
+			diff.Added = append(diff.Added, task)
+			continue
+		}
+
+		events := models.DiffTasks(old, task, "")
+		if len(events) == 0 {
+			continue
+		}
+		taskDiff := &TaskDiff{ID: task.ID, Title: task.Title}
+		for _, event := range events {
+			taskDiff.Changes = append(taskDiff.Changes, &FieldChange{
+				Field:  event.Field,
+				Before: event.Before,
+				After:  event.After,
+			})
+		}
+		diff.Modified = append(diff.Modified, taskDiff)
+	}
+
+	for _, task := range a.Tasks {
+		if _, exists := after[task.ID]; !exists {
+			diff.Removed = append(diff.Removed, task)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].ID < diff.Added[j].ID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ID < diff.Removed[j].ID })
+	sort.Slice(diff.Modified, func(i, j int) bool { return diff.Modified[i].ID < diff.Modified[j].ID })
+
+	return diff
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"quicktodo/internal/models"
+)
+
+func newDiffDatabase(t *testing.T, titles ...string) *models.ProjectDatabase {
+	t.Helper()
+
+	db := models.NewProjectDatabase(models.NewProject("diff-test", "/path/to/project"))
+	for _, title := range titles {
+		if err := db.AddTask(models.NewTask(db.NextID, title)); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+	return db
+}
+
+func TestDiffDatabasesIdentical(t *testing.T) {
+	a := newDiffDatabase(t, "First", "Second")
+	b := newDiffDatabase(t, "First", "Second")
+
+	diff := DiffDatabases(a, b)
+	if !diff.IsEmpty() {
+		t.Errorf("Expected no differences, got %+v", diff)
+	}
+}
+
+func TestDiffDatabasesAddRemoveModify(t *testing.T) {
+	a := newDiffDatabase(t, "Keep", "Change", "Drop")
+	b := newDiffDatabase(t, "Keep", "Change", "Drop")
+
+	changed, _ := b.GetTask(2)
+	changed.Title = "Changed"
+	changed.Status = models.StatusDone
+	if err := b.DeleteTask(3); err != nil {
+		t.Fatalf("Failed to delete task: %v", err)
+	}
+	if err := b.AddTask(models.NewTask(b.NextID, "New")); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+
+	diff := DiffDatabases(a, b)
+
+	if len(diff.Added) != 1 || diff.Added[0].ID != 4 {
+		t.Errorf("Expected task #4 added, got %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != 3 {
+		t.Errorf("Expected task #3 removed, got %+v", diff.Removed)
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0].ID != 2 {
+		t.Fatalf("Expected task #2 modified, got %+v", diff.Modified)
+	}
+
+	changes := diff.Modified[0].Changes
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 field changes, got %d", len(changes))
+	}
+	if changes[0].Field != "title" || changes[0].Before != "Change" || changes[0].After != "Changed" {
+		t.Errorf("Unexpected title change: %+v", changes[0])
+	}
+	if changes[1].Field != "status" || changes[1].Before != string(models.StatusPending) || changes[1].After != string(models.StatusDone) {
+		t.Errorf("Unexpected status change: %+v", changes[1])
+	}
+}
+
+func TestBackupProjectDatabase(t *testing.T) {
+	dataDir := t.TempDir()
+	dbPath := filepath.Join(dataDir, "projects", "demo.json")
+
+	// Nothing to back up before the first save
+	name, err := BackupProjectDatabase(dataDir, "demo", dbPath, 2)
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	if name != "" {
+		t.Errorf("Expected no backup for a missing database, got %s", name)
+	}
+
+	writeAgedFile(t, dbPath, `{"tasks":[]}`, 0)
+	for i := 0; i < 3; i++ {
+		if name, err = BackupProjectDatabase(dataDir, "demo", dbPath, 2); err != nil {
+			t.Fatalf("Backup failed: %v", err)
+		}
+	}
+
+	names, err := ListBackups(dataDir, "demo")
+	if err != nil {
+		t.Fatalf("Failed to list backups: %v", err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("Expected 2 backups kept, got %v", names)
+	}
+	if names[1] != name {
+		t.Errorf("Expected newest backup %s last, got %v", name, names)
+	}
+
+	data, err := os.ReadFile(BackupPath(dataDir, "demo", name+".json"))
+	if err != nil {
+		t.Fatalf("Failed to read backup: %v", err)
+	}
+	if string(data) != `{"tasks":[]}` {
+		t.Errorf("Backup content mismatch: %s", data)
+	}
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FocusState records the task each agent is currently working on. Focus is
+// kept per project, so an agent can have one focused task in every project.
+type FocusState struct {
+	// Agents maps agent ID to project name to that agent's focus
+	Agents map[string]map[string]*Focus `json:"agents"`
+}
+
+// Focus is an agent's current task in a project
+type Focus struct {
+	TaskID int       `json:"task_id"`
+	SetAt  time.Time `json:"set_at"`
+}
+
+// NewFocusState creates an empty focus state
+func NewFocusState() *FocusState {
+	return &FocusState{
+		Agents: make(map[string]map[string]*Focus),
+	}
+}
+
+// LoadFocusState loads the focus state from file; a missing file means no
+// agent has a focus yet
+func LoadFocusState(filePath string) (*FocusState, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return NewFocusState(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read focus file: %w", err)
+	}
+
+	var state FocusState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse focus file: %w", err)
+	}
+
+	if state.Agents == nil {
+		state.Agents = make(map[string]map[string]*Focus)
+	}
+
+	return &state, nil
+}
+
+// Save saves the focus state to file
+func (s *FocusState) Save(filePath string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal focus state: %w", err)
+	}
+
+	// Write to temporary file first, then rename for atomicity
+	tempPath := filePath + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, filePath); err != nil {
+		os.Remove(tempPath) // Clean up temp file
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns an agent's focus in a project
+func (s *FocusState) Get(agent, projectName string) (*Focus, bool) {
+	focus, exists := s.Agents[agent][projectName]
+	return focus, exists
+}
+
+// Set focuses an agent on a task in a project, replacing any earlier focus
+func (s *FocusState) Set(agent, projectName string, taskID int) *Focus {
+	if s.Agents[agent] == nil {
+		s.Agents[agent] = make(map[string]*Focus)
+	}
+
+	focus := &Focus{TaskID: taskID, SetAt: time.Now().UTC()}
+	s.Agents[agent][projectName] = focus
+	return focus
+}
+
+// Clear removes an agent's focus in a project and reports whether there was one
+func (s *FocusState) Clear(agent, projectName string) bool {
+	if _, exists := s.Agents[agent][projectName]; !exists {
+		return false
+	}
+
+	delete(s.Agents[agent], projectName)
+	if len(s.Agents[agent]) == 0 {
+		delete(s.Agents, agent)
+	}
+	return true
+}
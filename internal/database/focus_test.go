@@ -0,0 +1,65 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFocusStateSetGetClear(t *testing.T) {
+	state := NewFocusState()
+	state.Set("agent-1", "webapp", 3)
+	state.Set("agent-1", "api", 7)
+	state.Set("agent-2", "webapp", 5)
+
+	if focus, ok := state.Get("agent-1", "webapp"); !ok || focus.TaskID != 3 {
+		t.Errorf("Expected agent-1 focused on #3 in webapp, got %v, %v", focus, ok)
+	}
+	if focus, ok := state.Get("agent-2", "webapp"); !ok || focus.TaskID != 5 {
+		t.Errorf("Expected agent-2 focused on #5 in webapp, got %v, %v", focus, ok)
+	}
+	if _, ok := state.Get("agent-2", "api"); ok {
+		t.Error("Expected no focus for agent-2 in api")
+	}
+
+	// Refocusing replaces the earlier task
+	state.Set("agent-1", "webapp", 4)
+	if focus, _ := state.Get("agent-1", "webapp"); focus.TaskID != 4 {
+		t.Errorf("Expected refocus on #4, got #%d", focus.TaskID)
+	}
+
+	if !state.Clear("agent-1", "webapp") {
+		t.Error("Expected Clear to report a removed focus")
+	}
+	if state.Clear("agent-1", "webapp") {
+		t.Error("Expected a second Clear to report nothing removed")
+	}
+	if _, ok := state.Get("agent-1", "api"); !ok {
+		t.Error("Expected clearing one project to keep the agent's other focus")
+	}
+}
+
+func TestFocusStatePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "focus.json")
+
+	// A missing file is an empty state
+	state, err := LoadFocusState(path)
+	if err != nil {
+		t.Fatalf("LoadFocusState failed: %v", err)
+	}
+	if len(state.Agents) != 0 {
+		t.Errorf("Expected no focus in a new state, got %v", state.Agents)
+	}
+
+	state.Set("agent-1", "webapp", 3)
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadFocusState(path)
+	if err != nil {
+		t.Fatalf("LoadFocusState failed: %v", err)
+	}
+	if focus, ok := loaded.Get("agent-1", "webapp"); !ok || focus.TaskID != 3 || focus.SetAt.IsZero() {
+		t.Errorf("Expected persisted focus on #3, got %v, %v", focus, ok)
+	}
+}
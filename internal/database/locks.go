@@ -1,6 +1,8 @@
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,27 +14,96 @@ import (
 
 // LockManager manages file locks for database operations
 type LockManager struct {
-	lockDir string
-	timeout time.Duration
+	lockDir  string
+	timeout  time.Duration
+	hostname string
 }
 
 // NewLockManager creates a new lock manager
 func NewLockManager(lockDir string, timeoutSeconds int) *LockManager {
+	hostname, _ := os.Hostname()
 	return &LockManager{
-		lockDir: lockDir,
-		timeout: time.Duration(timeoutSeconds) * time.Second,
+		lockDir:  lockDir,
+		timeout:  time.Duration(timeoutSeconds) * time.Second,
+		hostname: hostname,
 	}
 }
 
+// StaleLockAge is how old a lock must be before it is treated as abandoned,
+// even if its holder still appears to be running
+const StaleLockAge = 5 * time.Minute
+
 // LockInfo contains information about a lock
 type LockInfo struct {
 	ProcessID int
 	CreatedAt time.Time
 	FilePath  string
+	Hostname  string // empty for locks written in the legacy two-line format
+	Command   string // the holder's command line; empty for older lock files
+	WorkDir   string // the holder's working directory; empty for older lock files
+}
+
+// Describe summarizes the holder's command line and working directory, or
+// returns "" for lock files that don't record them
+func (li *LockInfo) Describe() string {
+	switch {
+	case li.Command != "" && li.WorkDir != "":
+		return fmt.Sprintf("%s in %s", li.Command, li.WorkDir)
+	case li.Command != "":
+		return li.Command
+	default:
+		return li.WorkDir
+	}
+}
+
+// currentCommand is this process's command line as recorded in lock files,
+// on a single line
+func currentCommand() string {
+	args := append([]string{filepath.Base(os.Args[0])}, os.Args[1:]...)
+	return strings.Join(strings.Fields(strings.Join(args, " ")), " ")
+}
+
+// LockHeldError is returned when a project lock is still held by a live
+// process after the wait expires. Holder describes who has it.
+type LockHeldError struct {
+	Project string
+	Holder  *LockInfo
 }
 
-// AcquireLock attempts to acquire a lock for the given project
+func (e *LockHeldError) Error() string {
+	if holder := e.Holder.Describe(); holder != "" {
+		return fmt.Sprintf("project %s is locked by process %d (%s)", e.Project, e.Holder.ProcessID, holder)
+	}
+	return fmt.Sprintf("project %s is locked by process %d", e.Project, e.Holder.ProcessID)
+}
+
+// AcquireLock attempts to acquire a lock for the given project, waiting up to
+// the manager's timeout for a live holder to release it
 func (lm *LockManager) AcquireLock(projectName string) (*LockInfo, error) {
+	return lm.AcquireLockWithin(projectName, lm.timeout)
+}
+
+// AcquireLockWithin attempts to acquire a lock for the given project, waiting
+// up to wait for a live holder to release it. If the holder still has the lock
+// when the wait expires, the error is a *LockHeldError.
+func (lm *LockManager) AcquireLockWithin(projectName string, wait time.Duration) (*LockInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), wait)
+	defer cancel()
+	return lm.AcquireLockContext(ctx, projectName)
+}
+
+// Backoff between attempts to take a held lock
+const (
+	lockRetryMin = 10 * time.Millisecond
+	lockRetryMax = 500 * time.Millisecond
+)
+
+// AcquireLockContext attempts to acquire a lock for the given project,
+// retrying with backoff while a live holder has it until ctx is done. The lock
+// is always tried at least once. If ctx's deadline passes while the holder
+// still has the lock the error is a *LockHeldError; if ctx is cancelled the
+// error wraps context.Canceled.
+func (lm *LockManager) AcquireLockContext(ctx context.Context, projectName string) (*LockInfo, error) {
 	// Ensure lock directory exists
 	if err := os.MkdirAll(lm.lockDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create lock directory: %w", err)
@@ -40,46 +111,55 @@ func (lm *LockManager) AcquireLock(projectName string) (*LockInfo, error) {
 
 	lockPath := filepath.Join(lm.lockDir, projectName+".lock")
 
-	// Check for existing lock
-	if existingLock, err := lm.readLockFile(lockPath); err == nil {
-		// Check if the lock is stale
-		if time.Since(existingLock.CreatedAt) > 5*time.Minute {
-			// Remove stale lock
-			if err := os.Remove(lockPath); err != nil {
-				return nil, fmt.Errorf("failed to remove stale lock: %w", err)
-			}
-		} else {
-			// Check if process is still running
-			if lm.isProcessRunning(existingLock.ProcessID) {
-				return nil, fmt.Errorf("project %s is locked by process %d", projectName, existingLock.ProcessID)
+	// Create new lock
+	lockInfo := lm.newLockInfo(lockPath)
+
+	// Try to acquire lock, retrying until the context is done
+	var holder *LockInfo
+	backoff := lockRetryMin
+	for {
+		holder = nil
+
+		// Check for existing lock
+		if existingLock, err := lm.readLockFile(lockPath); err == nil {
+			if time.Since(existingLock.CreatedAt) > StaleLockAge {
+				// Remove stale lock
+				if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+					return nil, fmt.Errorf("failed to remove stale lock: %w", err)
+				}
+			} else if lm.isHolderRunning(existingLock) {
+				holder = existingLock
 			} else {
 				// Process is dead, remove lock
-				if err := os.Remove(lockPath); err != nil {
+				if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
 					return nil, fmt.Errorf("failed to remove orphaned lock: %w", err)
 				}
 			}
 		}
-	}
 
-	// Create new lock
-	lockInfo := &LockInfo{
-		ProcessID: os.Getpid(),
-		CreatedAt: time.Now(),
-		FilePath:  lockPath,
-	}
-
-	// Try to acquire lock with timeout
-	startTime := time.Now()
-	for time.Since(startTime) < lm.timeout {
-		if err := lm.writeLockFile(lockPath, lockInfo); err == nil {
-			return lockInfo, nil
+		if holder == nil {
+			lockInfo.CreatedAt = time.Now()
+			if err := lm.writeLockFile(lockPath, lockInfo); err == nil {
+				return lockInfo, nil
+			}
 		}
 
-		// Wait a bit before retrying
-		time.Sleep(100 * time.Millisecond)
+		// Wait a bit before retrying, backing off while the lock stays held
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil, fmt.Errorf("stopped waiting for the lock of project %s: %w", projectName, ctx.Err())
+			}
+			if holder != nil {
+				return nil, &LockHeldError{Project: projectName, Holder: holder}
+			}
+			return nil, fmt.Errorf("timeout acquiring lock for project %s", projectName)
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, lockRetryMax)
 	}
-
-	return nil, fmt.Errorf("timeout acquiring lock for project %s", projectName)
 }
 
 // ReleaseLock releases a lock
@@ -92,7 +172,7 @@ func (lm *LockManager) ReleaseLock(lockInfo *LockInfo) error {
 	if currentLock, err := lm.readLockFile(lockInfo.FilePath); err != nil {
 		// Lock file doesn't exist, consider it released
 		return nil
-	} else if currentLock.ProcessID != lockInfo.ProcessID {
+	} else if currentLock.ProcessID != lockInfo.ProcessID || currentLock.Hostname != lockInfo.Hostname {
 		return fmt.Errorf("lock is owned by different process")
 	}
 
@@ -104,6 +184,19 @@ func (lm *LockManager) ReleaseLock(lockInfo *LockInfo) error {
 	return nil
 }
 
+// newLockInfo describes a lock at lockPath held by this process
+func (lm *LockManager) newLockInfo(lockPath string) *LockInfo {
+	workDir, _ := os.Getwd()
+	return &LockInfo{
+		ProcessID: os.Getpid(),
+		CreatedAt: time.Now(),
+		FilePath:  lockPath,
+		Hostname:  lm.hostname,
+		Command:   currentCommand(),
+		WorkDir:   workDir,
+	}
+}
+
 // readLockFile reads lock information from file
 func (lm *LockManager) readLockFile(lockPath string) (*LockInfo, error) {
 	data, err := os.ReadFile(lockPath)
@@ -126,10 +219,22 @@ func (lm *LockManager) readLockFile(lockPath string) (*LockInfo, error) {
 		return nil, fmt.Errorf("invalid timestamp in lock file")
 	}
 
+	// The hostname, command and working directory lines were added later;
+	// lock files without them are still accepted
+	optional := func(index int) string {
+		if len(lines) > index {
+			return strings.TrimSpace(lines[index])
+		}
+		return ""
+	}
+
 	return &LockInfo{
 		ProcessID: processID,
 		CreatedAt: createdAt,
 		FilePath:  lockPath,
+		Hostname:  optional(2),
+		Command:   optional(3),
+		WorkDir:   optional(4),
 	}, nil
 }
 
@@ -142,11 +247,21 @@ func (lm *LockManager) writeLockFile(lockPath string, lockInfo *LockInfo) error
 	}
 	defer file.Close()
 
-	content := fmt.Sprintf("%d\n%s\n", lockInfo.ProcessID, lockInfo.CreatedAt.Format(time.RFC3339))
+	content := fmt.Sprintf("%d\n%s\n%s\n%s\n%s\n", lockInfo.ProcessID, lockInfo.CreatedAt.Format(time.RFC3339), lockInfo.Hostname, lockInfo.Command, lockInfo.WorkDir)
 	_, err = file.WriteString(content)
 	return err
 }
 
+// isHolderRunning checks if the process holding a lock is still running. A PID
+// is only meaningful on the host that wrote it, so locks held by another host
+// are assumed live and are only reclaimed once they time out.
+func (lm *LockManager) isHolderRunning(lockInfo *LockInfo) bool {
+	if lockInfo.Hostname != "" && lockInfo.Hostname != lm.hostname {
+		return true
+	}
+	return lm.isProcessRunning(lockInfo.ProcessID)
+}
+
 // isProcessRunning checks if a process is still running
 func (lm *LockManager) isProcessRunning(pid int) bool {
 	// Send signal 0 to check if process exists
@@ -192,7 +307,7 @@ func (lm *LockManager) CleanupStaleLocks(maxAge time.Duration) ([]string, error)
 			if err := os.Remove(lockPath); err == nil {
 				cleaned = append(cleaned, file.Name())
 			}
-		} else if !lm.isProcessRunning(lockInfo.ProcessID) {
+		} else if !lm.isHolderRunning(lockInfo) {
 			// Process is dead, remove lock
 			if err := os.Remove(lockPath); err == nil {
 				cleaned = append(cleaned, file.Name())
@@ -228,7 +343,7 @@ func (lm *LockManager) GetActiveLocks() (map[string]*LockInfo, error) {
 		}
 
 		// Only include active locks (process still running)
-		if lm.isProcessRunning(lockInfo.ProcessID) {
+		if lm.isHolderRunning(lockInfo) {
 			projectName := strings.TrimSuffix(file.Name(), ".lock")
 			locks[projectName] = lockInfo
 		}
@@ -237,6 +352,27 @@ func (lm *LockManager) GetActiveLocks() (map[string]*LockInfo, error) {
 	return locks, nil
 }
 
+// RemoveLock deletes the lock for the given project regardless of who holds
+// it and returns the removed holder, or nil if the project was not locked
+func (lm *LockManager) RemoveLock(projectName string) (*LockInfo, error) {
+	lockPath := filepath.Join(lm.lockDir, projectName+".lock")
+
+	holder, err := lm.readLockFile(lockPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		// An unreadable lock file is still removed
+		holder = &LockInfo{FilePath: lockPath}
+	}
+
+	if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove lock: %w", err)
+	}
+
+	return holder, nil
+}
+
 // ForceLock forcefully acquires a lock by removing any existing lock
 func (lm *LockManager) ForceLock(projectName string) (*LockInfo, error) {
 	lockPath := filepath.Join(lm.lockDir, projectName+".lock")
@@ -247,11 +383,7 @@ func (lm *LockManager) ForceLock(projectName string) (*LockInfo, error) {
 	}
 
 	// Create new lock
-	lockInfo := &LockInfo{
-		ProcessID: os.Getpid(),
-		CreatedAt: time.Now(),
-		FilePath:  lockPath,
-	}
+	lockInfo := lm.newLockInfo(lockPath)
 
 	if err := lm.writeLockFile(lockPath, lockInfo); err != nil {
 		return nil, fmt.Errorf("failed to create lock: %w", err)
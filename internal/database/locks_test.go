@@ -0,0 +1,224 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLockManager(t *testing.T, hostname string) *LockManager {
+	t.Helper()
+	lm := NewLockManager(t.TempDir(), 1)
+	lm.hostname = hostname
+	return lm
+}
+
+func writeRawLock(t *testing.T, lm *LockManager, projectName, content string) string {
+	t.Helper()
+	lockPath := filepath.Join(lm.lockDir, projectName+".lock")
+	if err := os.WriteFile(lockPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write lock file: %v", err)
+	}
+	return lockPath
+}
+
+func TestLockFileIncludesHostname(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+
+	lockInfo, err := lm.AcquireLock("project")
+	if err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+
+	data, err := os.ReadFile(lockInfo.FilePath)
+	if err != nil {
+		t.Fatalf("Failed to read lock file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 5 || lines[2] != "host-a" {
+		t.Fatalf("Expected PID, timestamp, hostname, command and directory lines, got %q", data)
+	}
+
+	parsed, err := lm.readLockFile(lockInfo.FilePath)
+	if err != nil {
+		t.Fatalf("readLockFile failed: %v", err)
+	}
+	if parsed.ProcessID != os.Getpid() || parsed.Hostname != "host-a" {
+		t.Errorf("Unexpected parsed lock: %+v", parsed)
+	}
+
+	if err := lm.ReleaseLock(lockInfo); err != nil {
+		t.Errorf("ReleaseLock failed: %v", err)
+	}
+}
+
+func TestLockFileIncludesCommand(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+	lockPath := writeRawLock(t, lm, "busy", fmt.Sprintf("%d\n%s\nhost-a\nquicktodo set-task-status 3 done\n/work/app\n", os.Getpid(), time.Now().Format(time.RFC3339)))
+
+	parsed, err := lm.readLockFile(lockPath)
+	if err != nil {
+		t.Fatalf("readLockFile failed: %v", err)
+	}
+	if parsed.Command != "quicktodo set-task-status 3 done" || parsed.WorkDir != "/work/app" {
+		t.Errorf("Unexpected parsed lock: %+v", parsed)
+	}
+
+	_, err = lm.AcquireLockWithin("busy", 0)
+	if err == nil || !strings.Contains(err.Error(), "(quicktodo set-task-status 3 done in /work/app)") {
+		t.Errorf("Expected the holder's command in the error, got %v", err)
+	}
+
+	// Our own locks record this process
+	lockInfo, err := lm.AcquireLock("mine")
+	if err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+	defer lm.ReleaseLock(lockInfo)
+	wd, _ := os.Getwd()
+	if lockInfo.Command == "" || lockInfo.WorkDir != wd {
+		t.Errorf("Expected the command and working directory to be recorded, got %+v", lockInfo)
+	}
+}
+
+func TestReadLegacyLockFile(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+	created := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	lockPath := writeRawLock(t, lm, "legacy", "1234\n"+created+"\n")
+
+	parsed, err := lm.readLockFile(lockPath)
+	if err != nil {
+		t.Fatalf("Expected legacy lock file to parse, got %v", err)
+	}
+	if parsed.ProcessID != 1234 || parsed.Hostname != "" {
+		t.Errorf("Unexpected parsed legacy lock: %+v", parsed)
+	}
+}
+
+func TestLegacyLockWithDeadProcessIsReclaimed(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+	writeRawLock(t, lm, "project", "999999\n"+time.Now().Format(time.RFC3339)+"\n")
+
+	lockInfo, err := lm.AcquireLock("project")
+	if err != nil {
+		t.Fatalf("Expected orphaned legacy lock to be reclaimed, got %v", err)
+	}
+	lm.ReleaseLock(lockInfo)
+}
+
+func TestForeignHostLockTrustsOnlyTimeout(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+
+	// A dead PID on another host says nothing about that host's processes
+	writeRawLock(t, lm, "fresh", "999999\n"+time.Now().Format(time.RFC3339)+"\nhost-b\n")
+	if _, err := lm.AcquireLock("fresh"); err == nil {
+		t.Error("Expected a fresh lock from another host to be respected")
+	}
+
+	stale := time.Now().Add(-10 * time.Minute).Format(time.RFC3339)
+	writeRawLock(t, lm, "stale", "999999\n"+stale+"\nhost-b\n")
+	lockInfo, err := lm.AcquireLock("stale")
+	if err != nil {
+		t.Fatalf("Expected a timed-out lock from another host to be reclaimed, got %v", err)
+	}
+	lm.ReleaseLock(lockInfo)
+}
+
+func TestCleanupStaleLocksRespectsForeignHosts(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+	now := time.Now().Format(time.RFC3339)
+	writeRawLock(t, lm, "local-dead", "999999\n"+now+"\nhost-a\n")
+	writeRawLock(t, lm, "remote", "999999\n"+now+"\nhost-b\n")
+
+	cleaned, err := lm.CleanupStaleLocks(5 * time.Minute)
+	if err != nil {
+		t.Fatalf("CleanupStaleLocks failed: %v", err)
+	}
+	if len(cleaned) != 1 || cleaned[0] != "local-dead.lock" {
+		t.Errorf("Expected only the local dead lock to be cleaned, got %v", cleaned)
+	}
+}
+
+func TestAcquireLockReportsLiveHolder(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+	writeRawLock(t, lm, "busy", fmt.Sprintf("%d\n%s\nhost-a\n", os.Getpid(), time.Now().Format(time.RFC3339)))
+
+	start := time.Now()
+	_, err := lm.AcquireLockWithin("busy", 300*time.Millisecond)
+	if err == nil {
+		t.Fatal("Expected lock held by a live process to be respected")
+	}
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("Expected to wait for the lock, gave up after %v", elapsed)
+	}
+
+	var held *LockHeldError
+	if !errors.As(err, &held) {
+		t.Fatalf("Expected a *LockHeldError, got %T: %v", err, err)
+	}
+	if held.Project != "busy" || held.Holder.ProcessID != os.Getpid() || held.Holder.Hostname != "host-a" {
+		t.Errorf("Unexpected holder info: %+v", held.Holder)
+	}
+}
+
+func TestAcquireLockWaitsForRelease(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+	lockPath := writeRawLock(t, lm, "busy", fmt.Sprintf("%d\n%s\nhost-a\n", os.Getpid(), time.Now().Format(time.RFC3339)))
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		os.Remove(lockPath)
+	}()
+
+	lockInfo, err := lm.AcquireLockWithin("busy", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Expected lock to be acquired once released, got %v", err)
+	}
+	lm.ReleaseLock(lockInfo)
+}
+
+func TestAcquireLockContextCancel(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+	writeRawLock(t, lm, "busy", fmt.Sprintf("%d\n%s\nhost-a\n", os.Getpid(), time.Now().Format(time.RFC3339)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := lm.AcquireLockContext(ctx, "busy")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the wait to be cancelled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected cancellation to stop the wait promptly, took %v", elapsed)
+	}
+}
+
+func TestRemoveLock(t *testing.T) {
+	lm := newTestLockManager(t, "host-a")
+
+	holder, err := lm.RemoveLock("missing")
+	if err != nil || holder != nil {
+		t.Errorf("Expected no-op for unlocked project, got %+v, %v", holder, err)
+	}
+
+	lockPath := writeRawLock(t, lm, "busy", fmt.Sprintf("%d\n%s\nhost-b\n", 4242, time.Now().Format(time.RFC3339)))
+	holder, err = lm.RemoveLock("busy")
+	if err != nil {
+		t.Fatalf("RemoveLock failed: %v", err)
+	}
+	if holder == nil || holder.ProcessID != 4242 || holder.Hostname != "host-b" {
+		t.Errorf("Expected removed holder info, got %+v", holder)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Error("Expected lock file to be removed")
+	}
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// MarkerDirName is the directory that marks a project root
+const MarkerDirName = ".quicktodo"
+
+// markerFileName is the file inside the marker directory naming the project
+const markerFileName = "project.json"
+
+// ProjectMarker is the content of a project root marker. Because it lives in
+// the project itself, it travels with clones and identifies the project by name
+// regardless of where the checkout sits on disk.
+type ProjectMarker struct {
+	Name string `json:"name"`
+}
+
+// WriteProjectMarker creates the marker directory in root naming the project
+func WriteProjectMarker(root, projectName string) error {
+	markerDir := filepath.Join(root, MarkerDirName)
+	if err := os.MkdirAll(markerDir, 0755); err != nil {
+		return fmt.Errorf("failed to create marker directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(ProjectMarker{Name: projectName}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal project marker: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(markerDir, markerFileName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write project marker: %w", err)
+	}
+
+	return nil
+}
+
+// ReadProjectMarker reads the marker in root, if there is one
+func ReadProjectMarker(root string) (*ProjectMarker, error) {
+	data, err := os.ReadFile(filepath.Join(root, MarkerDirName, markerFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var marker ProjectMarker
+	if err := json.Unmarshal(data, &marker); err != nil {
+		return nil, fmt.Errorf("failed to parse project marker in %s: %w", root, err)
+	}
+	if marker.Name == "" {
+		return nil, fmt.Errorf("project marker in %s has no name", root)
+	}
+
+	return &marker, nil
+}
+
+// FindProjectMarker walks up from start to the nearest directory containing a
+// valid project marker and returns that directory and its marker
+func FindProjectMarker(start string) (string, *ProjectMarker, bool) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", nil, false
+	}
+
+	for {
+		if marker, err := ReadProjectMarker(dir); err == nil {
+			return dir, marker, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil, false
+		}
+		dir = parent
+	}
+}
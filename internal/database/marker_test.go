@@ -0,0 +1,64 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProjectMarkerFromNestedDir(t *testing.T) {
+	root := t.TempDir()
+	if err := WriteProjectMarker(root, "marked-project"); err != nil {
+		t.Fatalf("WriteProjectMarker failed: %v", err)
+	}
+
+	nested := filepath.Join(root, "src", "pkg", "deep")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create nested dir: %v", err)
+	}
+
+	foundRoot, marker, found := FindProjectMarker(nested)
+	if !found {
+		t.Fatal("Expected marker to be found from nested dir")
+	}
+	if foundRoot != root || marker.Name != "marked-project" {
+		t.Errorf("Expected marker %s in %s, got %s in %s", "marked-project", root, marker.Name, foundRoot)
+	}
+}
+
+func TestFindProjectMarkerNotFound(t *testing.T) {
+	if _, _, found := FindProjectMarker(t.TempDir()); found {
+		t.Error("Expected no marker in an empty temp dir")
+	}
+}
+
+func TestGetProjectByPathUsesMarker(t *testing.T) {
+	registry := NewProjectRegistry()
+	if err := registry.RegisterProject("marked-project", "/original/checkout"); err != nil {
+		t.Fatalf("RegisterProject failed: %v", err)
+	}
+
+	// A second clone elsewhere carries the same marker
+	clone := t.TempDir()
+	if err := WriteProjectMarker(clone, "marked-project"); err != nil {
+		t.Fatalf("WriteProjectMarker failed: %v", err)
+	}
+	nested := filepath.Join(clone, "cmd")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create nested dir: %v", err)
+	}
+
+	project, exists := registry.GetProjectByPath(nested)
+	if !exists || project.Name != "marked-project" {
+		t.Errorf("Expected nested dir of clone to resolve to marked-project, got %v", project)
+	}
+
+	// Markers naming unregistered projects don't resolve
+	other := t.TempDir()
+	if err := WriteProjectMarker(other, "unknown-project"); err != nil {
+		t.Fatalf("WriteProjectMarker failed: %v", err)
+	}
+	if _, exists := registry.GetProjectByPath(other); exists {
+		t.Error("Expected unregistered marker name not to resolve")
+	}
+}
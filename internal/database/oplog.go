@@ -0,0 +1,210 @@
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"sort"
+	"time"
+)
+
+// Operation is one entry of a project's operation log: a command that
+// changed the project's tasks, with each changed task as it was before and
+// after the command, so that undo can revert it
+type Operation struct {
+	Command   string        `json:"command"`
+	Actor     string        `json:"actor,omitempty"`
+	Timestamp time.Time     `json:"timestamp"`
+	Changes   []*TaskChange `json:"changes"`
+}
+
+// TaskChange is one task changed by an operation. Before is nil for a task
+// the operation created, and After is nil for one it deleted.
+type TaskChange struct {
+	TaskID int          `json:"task_id"`
+	Before *models.Task `json:"before,omitempty"`
+	After  *models.Task `json:"after,omitempty"`
+}
+
+// Kind describes the change: "created", "deleted" or "updated"
+func (c *TaskChange) Kind() string {
+	switch {
+	case c.Before == nil:
+		return "created"
+	case c.After == nil:
+		return "deleted"
+	default:
+		return "updated"
+	}
+}
+
+// NewOperation compares the tasks of before, the project database as it was
+// last saved, with after, the database about to be saved. before is nil for
+// a new database. Tasks are compared by their JSON, so any change to a task
+// is recorded. It returns nil when no task changed.
+func NewOperation(command, actor string, before, after *models.ProjectDatabase) (*Operation, error) {
+	old := map[int]*models.Task{}
+	if before != nil {
+		for _, task := range before.Tasks {
+			old[task.ID] = task
+		}
+	}
+
+	var changes []*TaskChange
+	current := make(map[int]bool, len(after.Tasks))
+	for _, task := range after.Tasks {
+		current[task.ID] = true
+		previous, exists := old[task.ID]
+		if !exists {
+			changes = append(changes, &TaskChange{TaskID: task.ID, After: task})
+			continue
+		}
+		same, err := sameTask(previous, task)
+		if err != nil {
+			return nil, err
+		}
+		if !same {
+			changes = append(changes, &TaskChange{TaskID: task.ID, Before: previous, After: task})
+		}
+	}
+	for id, task := range old {
+		if !current[id] {
+			changes = append(changes, &TaskChange{TaskID: id, Before: task})
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].TaskID < changes[j].TaskID })
+
+	return &Operation{
+		Command:   command,
+		Actor:     actor,
+		Timestamp: time.Now().UTC(),
+		Changes:   changes,
+	}, nil
+}
+
+// sameTask reports whether a and b have the same JSON
+func sameTask(a, b *models.Task) (bool, error) {
+	aData, err := json.Marshal(a)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal task #%d: %w", a.ID, err)
+	}
+	bData, err := json.Marshal(b)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal task #%d: %w", b.ID, err)
+	}
+	return bytes.Equal(aData, bData), nil
+}
+
+// SameTask reports whether task is unchanged from want, e.g. whether a task
+// is still as an operation left it
+func SameTask(task, want *models.Task) bool {
+	same, err := sameTask(task, want)
+	return err == nil && same
+}
+
+// LoadOperations reads the operation log at filePath, oldest operation
+// first. The log is JSON Lines, one operation per line; a missing file means
+// no operations have been recorded.
+func LoadOperations(filePath string) ([]*Operation, error) {
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open operation log: %w", err)
+	}
+	defer file.Close()
+
+	var ops []*Operation
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(data)) > 0 {
+			var op Operation
+			if err := json.Unmarshal(data, &op); err != nil {
+				return nil, fmt.Errorf("failed to parse operation log line %d: %w", line, err)
+			}
+			for _, change := range op.Changes {
+				if change.Before != nil {
+					change.Before.NormalizeTimestamps()
+				}
+				if change.After != nil {
+					change.After.NormalizeTimestamps()
+				}
+			}
+			ops = append(ops, &op)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	return ops, nil
+}
+
+// AppendOperation adds op to the end of the operation log at filePath,
+// dropping the oldest operations so that at most limit are kept
+func AppendOperation(filePath string, op *Operation, limit int) error {
+	ops, err := LoadOperations(filePath)
+	if err != nil {
+		return err
+	}
+
+	ops = append(ops, op)
+	if limit > 0 && len(ops) > limit {
+		ops = ops[len(ops)-limit:]
+	}
+	return saveOperations(filePath, ops)
+}
+
+// PopOperation removes the most recent operation from the operation log at
+// filePath and returns it, or nil when the log is empty
+func PopOperation(filePath string) (*Operation, error) {
+	ops, err := LoadOperations(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	last := ops[len(ops)-1]
+	if err := saveOperations(filePath, ops[:len(ops)-1]); err != nil {
+		return nil, err
+	}
+	return last, nil
+}
+
+func saveOperations(filePath string, ops []*Operation) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := encodeOperations(ops)
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(filePath, data, 0644)
+}
+
+// encodeOperations renders ops as the JSON Lines of an operation log
+func encodeOperations(ops []*Operation) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, op := range ops {
+		data, err := json.Marshal(op)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal operation: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"path/filepath"
+	"quicktodo/internal/models"
+	"testing"
+)
+
+func TestNewOperation(t *testing.T) {
+	before := models.NewProjectDatabase(models.NewProject("test", "/path/to/project"))
+	for _, title := range []string{"Keep", "Change", "Delete"} {
+		if err := before.AddTask(models.NewTask(before.NextID, title)); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+
+	after, err := ReadProjectDatabase(writeTestDatabase(t, before))
+	if err != nil {
+		t.Fatalf("ReadProjectDatabase failed: %v", err)
+	}
+	if op, err := NewOperation("noop", "alice", before, after); err != nil || op != nil {
+		t.Fatalf("Expected no operation for an unchanged database, got %+v, %v", op, err)
+	}
+
+	changed, _ := after.GetTask(2)
+	if err := changed.UpdatePriority(models.PriorityHigh); err != nil {
+		t.Fatalf("UpdatePriority failed: %v", err)
+	}
+	if err := after.DeleteTask(3); err != nil {
+		t.Fatalf("DeleteTask failed: %v", err)
+	}
+	if err := after.AddTask(models.NewTask(after.NextID, "Create")); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	op, err := NewOperation("edit", "alice", before, after)
+	if err != nil || op == nil {
+		t.Fatalf("Expected an operation, got %+v, %v", op, err)
+	}
+	want := map[int]string{2: "updated", 3: "deleted", 4: "created"}
+	if len(op.Changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %+v", len(want), op.Changes)
+	}
+	for _, change := range op.Changes {
+		if want[change.TaskID] != change.Kind() {
+			t.Errorf("Expected task #%d to be %s, got %s", change.TaskID, want[change.TaskID], change.Kind())
+		}
+	}
+	if op.Changes[0].Before.Priority != models.PriorityMedium || op.Changes[0].After.Priority != models.PriorityHigh {
+		t.Errorf("Expected the priority change to be recorded, got %+v", op.Changes[0])
+	}
+
+	// A new database records every task as created
+	op, err = NewOperation("init", "alice", nil, before)
+	if err != nil || op == nil || len(op.Changes) != 3 || op.Changes[0].Kind() != "created" {
+		t.Errorf("Expected every task to be created, got %+v, %v", op, err)
+	}
+}
+
+func TestOperationLogBoundedAndPopped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ops", "test.jsonl")
+
+	if op, err := PopOperation(path); err != nil || op != nil {
+		t.Fatalf("Expected nothing to pop from a missing log, got %+v, %v", op, err)
+	}
+
+	for _, command := range []string{"first", "second", "third"} {
+		task := models.NewTask(1, command)
+		op := &Operation{Command: command, Changes: []*TaskChange{{TaskID: 1, After: task}}}
+		if err := AppendOperation(path, op, 2); err != nil {
+			t.Fatalf("AppendOperation failed: %v", err)
+		}
+	}
+
+	ops, err := LoadOperations(path)
+	if err != nil {
+		t.Fatalf("LoadOperations failed: %v", err)
+	}
+	if len(ops) != 2 || ops[0].Command != "second" || ops[1].Command != "third" {
+		t.Fatalf("Expected the two most recent operations, got %+v", ops)
+	}
+	if ops[1].Changes[0].After.Title != "third" {
+		t.Errorf("Expected the task to round-trip, got %+v", ops[1].Changes[0].After)
+	}
+
+	op, err := PopOperation(path)
+	if err != nil || op == nil || op.Command != "third" {
+		t.Fatalf("Expected to pop the most recent operation, got %+v, %v", op, err)
+	}
+	if ops, _ := LoadOperations(path); len(ops) != 1 || ops[0].Command != "second" {
+		t.Errorf("Expected one operation left, got %+v", ops)
+	}
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"testing"
+	"time"
+)
+
+func writeTestDatabase(t *testing.T, db *models.ProjectDatabase) string {
+	t.Helper()
+	data, err := db.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "test.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to write database: %v", err)
+	}
+	return path
+}
+
+func TestReadProjectDatabaseKeepsModelFields(t *testing.T) {
+	db := models.NewProjectDatabase(models.NewProject("test", "/path/to/project"))
+	first := models.NewTask(db.NextID, "First")
+	if err := db.AddTask(first); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	second := models.NewTask(db.NextID, "Second")
+	second.SetTags([]string{"backend"})
+	second.SetDependsOn([]int{first.ID})
+	if _, err := second.AddChecklistItem("Write tests"); err != nil {
+		t.Fatalf("AddChecklistItem failed: %v", err)
+	}
+	if _, err := second.AddComment("alice", "Needs review"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if _, err := second.StartTimer(time.Now().UTC()); err != nil {
+		t.Fatalf("StartTimer failed: %v", err)
+	}
+	if err := db.AddTask(second); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	loaded, err := ReadProjectDatabase(writeTestDatabase(t, db))
+	if err != nil {
+		t.Fatalf("ReadProjectDatabase failed: %v", err)
+	}
+
+	want, _ := db.ToJSON()
+	got, _ := loaded.ToJSON()
+	if !bytes.Equal(want, got) {
+		t.Errorf("Expected the database to round-trip unchanged\nwant: %s\ngot:  %s", want, got)
+	}
+	if task, err := loaded.GetTask(second.ID); err != nil || len(task.Checklist) != 1 || len(task.Comments) != 1 || task.RunningTimeEntry() == nil {
+		t.Errorf("Expected the rich task fields to load, got %+v, %v", task, err)
+	}
+}
+
+func TestReadProjectDatabaseGitFriendly(t *testing.T) {
+	db := models.NewProjectDatabase(models.NewProject("test", "/path/to/project"))
+	db.GitFriendly = true
+
+	path := writeTestDatabase(t, db)
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set modification time: %v", err)
+	}
+
+	loaded, err := ReadProjectDatabase(path)
+	if err != nil {
+		t.Fatalf("ReadProjectDatabase failed: %v", err)
+	}
+	if loaded.Version != 1 || !loaded.LastModified.Equal(modTime) {
+		t.Errorf("Expected metadata restored from the file, got version %d, last modified %v", loaded.Version, loaded.LastModified)
+	}
+
+	if _, err := ReadProjectDatabase(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing database file")
+	}
+}
@@ -90,6 +90,19 @@ func (r *ProjectRegistry) Save(filePath string) error {
 	return nil
 }
 
+// LockRegistry takes the lock guarding the registry file at filePath, so
+// that loading, changing and saving the registry doesn't interleave with
+// another process doing the same, waiting up to wait for a live holder. The
+// lock file sits next to the registry, apart from the project locks.
+func LockRegistry(filePath string, wait time.Duration) (*LockManager, *LockInfo, error) {
+	lockManager := NewLockManager(filepath.Dir(filePath), 0)
+	lockInfo, err := lockManager.AcquireLockWithin(filepath.Base(filePath), wait)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lockManager, lockInfo, nil
+}
+
 // RegisterProject registers a new project in the registry
 func (r *ProjectRegistry) RegisterProject(name, path string) error {
 	// Convert path to absolute path
@@ -112,8 +125,8 @@ func (r *ProjectRegistry) RegisterProject(name, path string) error {
 	projectInfo := &ProjectInfo{
 		Path:         absPath,
 		Name:         name,
-		CreatedAt:    time.Now(),
-		LastAccessed: time.Now(),
+		CreatedAt:    time.Now().UTC(),
+		LastAccessed: time.Now().UTC(),
 	}
 
 	// Add to registry
@@ -129,7 +142,9 @@ func (r *ProjectRegistry) GetProjectByName(name string) (*ProjectInfo, bool) {
 	return project, exists
 }
 
-// GetProjectByPath returns project info by path
+// GetProjectByPath returns project info by path. A directory registered at
+// exactly this path wins; otherwise the nearest .quicktodo marker above the
+// path names the project, so nested directories and other clones resolve to it.
 func (r *ProjectRegistry) GetProjectByPath(path string) (*ProjectInfo, bool) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
@@ -140,13 +155,19 @@ func (r *ProjectRegistry) GetProjectByPath(path string) (*ProjectInfo, bool) {
 		return r.Projects[projectName], true
 	}
 
+	if _, marker, found := FindProjectMarker(absPath); found {
+		if project, exists := r.Projects[marker.Name]; exists {
+			return project, true
+		}
+	}
+
 	return nil, false
 }
 
 // UpdateLastAccessed updates the last accessed time for a project
 func (r *ProjectRegistry) UpdateLastAccessed(name string) error {
 	if project, exists := r.Projects[name]; exists {
-		project.LastAccessed = time.Now()
+		project.LastAccessed = time.Now().UTC()
 		return nil
 	}
 	return fmt.Errorf("project %s not found", name)
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RotationState records, per project, where round-robin assignment resumes
+type RotationState struct {
+	Projects map[string]*RotationCursor `json:"projects"`
+}
+
+// RotationCursor is a project's position in its assignee rotation
+type RotationCursor struct {
+	// Next is the index in the assignee list of the next assignee. Editing the
+	// list can shift who that is; the index wraps if the list got shorter.
+	Next         int       `json:"next"`
+	LastAssignee string    `json:"last_assignee,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// NewRotationState creates an empty rotation state
+func NewRotationState() *RotationState {
+	return &RotationState{
+		Projects: make(map[string]*RotationCursor),
+	}
+}
+
+// LoadRotationState loads the rotation state from file; a missing file means
+// every project starts at the top of its list
+func LoadRotationState(filePath string) (*RotationState, error) {
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return NewRotationState(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rotation file: %w", err)
+	}
+
+	var state RotationState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse rotation file: %w", err)
+	}
+
+	if state.Projects == nil {
+		state.Projects = make(map[string]*RotationCursor)
+	}
+
+	return &state, nil
+}
+
+// Save saves the rotation state to file
+func (s *RotationState) Save(filePath string) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rotation state: %w", err)
+	}
+
+	// Write to temporary file first, then rename for atomicity
+	tempPath := filePath + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, filePath); err != nil {
+		os.Remove(tempPath) // Clean up temp file
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	return nil
+}
+
+// Next returns the project's next assignee from assignees and advances the
+// project's cursor past them
+func (s *RotationState) Next(projectName string, assignees []string) (string, error) {
+	if len(assignees) == 0 {
+		return "", fmt.Errorf("the assignee list is empty")
+	}
+
+	cursor := s.Projects[projectName]
+	if cursor == nil {
+		cursor = &RotationCursor{}
+		s.Projects[projectName] = cursor
+	}
+
+	index := cursor.Next % len(assignees)
+	if index < 0 {
+		index = 0
+	}
+
+	assignee := assignees[index]
+	cursor.Next = (index + 1) % len(assignees)
+	cursor.LastAssignee = assignee
+	cursor.UpdatedAt = time.Now().UTC()
+
+	return assignee, nil
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRotationStateCycles(t *testing.T) {
+	state := NewRotationState()
+	team := []string{"alice", "bob", "carol"}
+
+	var got []string
+	for i := 0; i < 5; i++ {
+		assignee, err := state.Next("webapp", team)
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		got = append(got, assignee)
+	}
+
+	want := []string{"alice", "bob", "carol", "alice", "bob"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected rotation %v, got %v", want, got)
+		}
+	}
+
+	// Projects rotate independently
+	if assignee, _ := state.Next("api", team); assignee != "alice" {
+		t.Errorf("Expected a new project to start at the top, got %s", assignee)
+	}
+
+	// A shorter list wraps the cursor instead of failing
+	if assignee, _ := state.Next("webapp", []string{"dave"}); assignee != "dave" {
+		t.Errorf("Expected the cursor to wrap into a shorter list, got %s", assignee)
+	}
+
+	if _, err := state.Next("webapp", nil); err == nil {
+		t.Error("Expected an empty assignee list to fail")
+	}
+}
+
+func TestRotationStatePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rotation.json")
+	team := []string{"alice", "bob"}
+
+	state, err := LoadRotationState(path)
+	if err != nil {
+		t.Fatalf("LoadRotationState failed: %v", err)
+	}
+	state.Next("webapp", team)
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := LoadRotationState(path)
+	if err != nil {
+		t.Fatalf("LoadRotationState failed: %v", err)
+	}
+	if assignee, _ := loaded.Next("webapp", team); assignee != "bob" {
+		t.Errorf("Expected the rotation to resume with bob, got %s", assignee)
+	}
+	if cursor := loaded.Projects["webapp"]; cursor.LastAssignee != "bob" {
+		t.Errorf("Expected last assignee bob, got %+v", cursor)
+	}
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+)
+
+// Storage backends selectable with the backend config setting
+const (
+	BackendJSON   = "json"
+	BackendSQLite = "sqlite"
+)
+
+// Store is the storage backend of one project's tasks. Stores don't lock:
+// callers hold the project lock around writes, as the commands do. Load and
+// Save move the whole database, for the commands that change several tasks
+// in one go.
+type Store interface {
+	Load() (*models.ProjectDatabase, error)
+	Save(db *models.ProjectDatabase) error
+	AddTask(task *models.Task) error
+	GetTask(id int) (*models.Task, error)
+	UpdateTask(task *models.Task) error
+	DeleteTask(id int) error
+	ListTasks(filter *models.TaskFilter) ([]*models.Task, error)
+}
+
+// OpenStore opens the store of a project database with the named backend.
+// Only the JSON backend is available so far. sqlite is reserved for a
+// database file next to the JSON one; the SQLite store and the migration of
+// existing JSON projects into it wait until the module can depend on
+// modernc.org/sqlite.
+func OpenStore(backend, filePath string) (Store, error) {
+	switch backend {
+	case "", BackendJSON:
+		return NewJSONStore(filePath), nil
+	case BackendSQLite:
+		return nil, fmt.Errorf("the %s backend is not available in this build", BackendSQLite)
+	default:
+		return nil, fmt.Errorf("unknown storage backend '%s'", backend)
+	}
+}
+
+// JSONStore keeps a project in a single JSON file, the format used by every
+// command. Each call reads the file, and writes replace it atomically.
+type JSONStore struct {
+	path string
+}
+
+// NewJSONStore creates a store for the project database at filePath
+func NewJSONStore(filePath string) *JSONStore {
+	return &JSONStore{path: filePath}
+}
+
+// Path returns the file the store reads and writes
+func (s *JSONStore) Path() string {
+	return s.path
+}
+
+// AddTask adds a task, which must have the database's next ID
+func (s *JSONStore) AddTask(task *models.Task) error {
+	return s.update(func(db *models.ProjectDatabase) error { return db.AddTask(task) })
+}
+
+// GetTask returns a copy of the task with id
+func (s *JSONStore) GetTask(id int) (*models.Task, error) {
+	db, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	task, err := db.GetTask(id)
+	if err != nil {
+		return nil, err
+	}
+	return task.Clone(), nil
+}
+
+// UpdateTask replaces the stored task with the same ID
+func (s *JSONStore) UpdateTask(task *models.Task) error {
+	return s.update(func(db *models.ProjectDatabase) error { return db.UpdateTask(task) })
+}
+
+// DeleteTask removes the task with id
+func (s *JSONStore) DeleteTask(id int) error {
+	return s.update(func(db *models.ProjectDatabase) error { return db.DeleteTask(id) })
+}
+
+// ListTasks returns copies of the tasks matching filter, in board order
+func (s *JSONStore) ListTasks(filter *models.TaskFilter) ([]*models.Task, error) {
+	db, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	return db.ListTasks(filter), nil
+}
+
+// Load reads the whole database
+func (s *JSONStore) Load() (*models.ProjectDatabase, error) {
+	return ReadProjectDatabase(s.path)
+}
+
+// Save replaces the stored database with db
+func (s *JSONStore) Save(db *models.ProjectDatabase) error {
+	data, err := db.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal database: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	return WriteFileAtomic(s.path, data, 0644)
+}
+
+// update applies change to the stored database and saves it
+func (s *JSONStore) update(change func(db *models.ProjectDatabase) error) error {
+	db, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if err := change(db); err != nil {
+		return err
+	}
+	return s.Save(db)
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"testing"
+)
+
+func TestJSONStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "projects", "test.json")
+	store := NewJSONStore(path)
+
+	if _, err := store.ListTasks(nil); err == nil {
+		t.Fatal("Expected an error for a missing database file")
+	}
+
+	db := models.NewProjectDatabase(models.NewProject("test", t.TempDir()))
+	data, err := db.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("Failed to seed database: %v", err)
+	}
+
+	for _, title := range []string{"First", "Second"} {
+		tasks, _ := store.ListTasks(nil)
+		if err := store.AddTask(models.NewTask(len(tasks)+1, title)); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+
+	task, err := store.GetTask(2)
+	if err != nil || task.Title != "Second" {
+		t.Fatalf("Expected task #2, got %+v, %v", task, err)
+	}
+	task.UpdateTitle("Second, renamed")
+	if err := store.UpdateTask(task); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	if err := store.DeleteTask(1); err != nil {
+		t.Fatalf("DeleteTask failed: %v", err)
+	}
+
+	tasks, err := store.ListTasks(nil)
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Second, renamed" {
+		t.Errorf("Expected only the renamed task to remain, got %+v", tasks)
+	}
+	if _, err := store.GetTask(1); err == nil {
+		t.Error("Expected the deleted task to be gone")
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	loaded.Project.Name = "renamed"
+	if err := store.Save(loaded); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if reloaded, err := store.Load(); err != nil || reloaded.Project.Name != "renamed" || len(reloaded.Tasks) != 1 {
+		t.Errorf("Expected the saved database back, got %+v, %v", reloaded, err)
+	}
+}
+
+func TestOpenStore(t *testing.T) {
+	for _, backend := range []string{"", BackendJSON} {
+		if store, err := OpenStore(backend, "db.json"); err != nil || store == nil {
+			t.Errorf("Expected a JSON store for %q, got %v", backend, err)
+		}
+	}
+	for _, backend := range []string{BackendSQLite, "postgres"} {
+		if _, err := OpenStore(backend, "db.json"); err == nil {
+			t.Errorf("Expected backend %q to be rejected", backend)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"quicktodo/internal/models"
+	"strconv"
+	"time"
+)
+
+// FormatCSV is the format name for a spreadsheet export of a project's tasks
+const FormatCSV = "csv"
+
+// CSVColumns are the columns written by WriteTasksCSV, in order
+var CSVColumns = []string{"id", "title", "description", "status", "priority", "assigned_to", "created_at", "updated_at"}
+
+// WriteTasksCSV writes tasks as CSV with a CSVColumns header row. Timestamps
+// are RFC3339 in UTC.
+func WriteTasksCSV(w io.Writer, tasks []*models.Task) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSVColumns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, task := range tasks {
+		record := []string{
+			strconv.Itoa(task.ID),
+			task.Title,
+			task.Description,
+			string(task.Status),
+			string(task.Priority),
+			task.AssignedTo,
+			task.CreatedAt.UTC().Format(time.RFC3339),
+			task.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write task #%d: %w", task.ID, err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
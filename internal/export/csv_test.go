@@ -0,0 +1,46 @@
+package export
+
+import (
+	"encoding/csv"
+	"quicktodo/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteTasksCSV(t *testing.T) {
+	task := newSampleTask(t)
+	task.UpdatedAt = time.Date(2025, 1, 12, 9, 30, 0, 0, time.UTC)
+
+	var b strings.Builder
+	if err := WriteTasksCSV(&b, []*models.Task{task, models.NewTask(8, "Plain")}); err != nil {
+		t.Fatalf("WriteTasksCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(b.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read back CSV: %v\n%s", err, b.String())
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected a header and 2 rows, got %d", len(records))
+	}
+	if strings.Join(records[0], ",") != strings.Join(CSVColumns, ",") {
+		t.Errorf("Unexpected header: %v", records[0])
+	}
+
+	expected := []string{
+		"7",
+		"Fix login <bug>",
+		"Users cannot log in.\nHappens on mobile & desktop.",
+		"in_progress",
+		"high",
+		"agent-1",
+		task.CreatedAt.UTC().Format(time.RFC3339),
+		"2025-01-12T09:30:00Z",
+	}
+	for i, value := range expected {
+		if records[1][i] != value {
+			t.Errorf("Column %s: expected %q, got %q", CSVColumns[i], value, records[1][i])
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"quicktodo/internal/models"
+	"strings"
+)
+
+// FormatHTML is the format name for an HTML table of a project's tasks
+const FormatHTML = "html"
+
+// htmlStyle styles the table written by WriteTasksHTML. Rules are scoped to
+// the wrapper's class so the fragment can be dropped into any page.
+const htmlStyle = `.quicktodo-tasks table { border-collapse: collapse; font-family: sans-serif; font-size: 14px; }
+.quicktodo-tasks caption { font-weight: bold; text-align: left; padding: 4px 0; }
+.quicktodo-tasks th, .quicktodo-tasks td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: top; }
+.quicktodo-tasks th { background: #f5f5f5; }
+.quicktodo-tasks .description { color: #666; font-size: 12px; }
+.quicktodo-tasks .status-done .title, .quicktodo-tasks .status-cancelled .title { color: #888; text-decoration: line-through; }
+.quicktodo-tasks .status-in_progress .status { color: #1a73e8; }
+.quicktodo-tasks .status-done .status { color: #188038; }
+.quicktodo-tasks .status-cancelled .status { color: #888; }
+.quicktodo-tasks .priority-critical .priority { color: #fff; background: #d93025; font-weight: bold; }
+.quicktodo-tasks .priority-high .priority { color: #d93025; font-weight: bold; }
+.quicktodo-tasks .priority-low .priority { color: #888; }`
+
+// HTMLColumns are the column headings written by WriteTasksHTML, in order
+var HTMLColumns = []string{"ID", "Title", "Status", "Priority", "Assigned to", "Due"}
+
+// WriteTasksHTML writes tasks as a self-contained HTML fragment: a table with
+// one row per task, in the given order, and a style element for it. Each row
+// has status-<status> and priority-<priority> classes so pages embedding the
+// fragment can restyle it.
+func WriteTasksHTML(w io.Writer, tasks []*models.Task, projectName string) error {
+	var b strings.Builder
+
+	b.WriteString("<div class=\"quicktodo-tasks\">\n")
+	fmt.Fprintf(&b, "<style>\n%s\n</style>\n", htmlStyle)
+	b.WriteString("<table>\n")
+	fmt.Fprintf(&b, "<caption>%s (%d tasks)</caption>\n", html.EscapeString(projectName), len(tasks))
+
+	b.WriteString("<thead>\n<tr>")
+	for _, column := range HTMLColumns {
+		fmt.Fprintf(&b, "<th>%s</th>", column)
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+
+	for _, task := range tasks {
+		fmt.Fprintf(&b, "<tr class=\"task status-%s priority-%s\">\n", htmlClass(string(task.Status)), htmlClass(string(task.Priority)))
+		fmt.Fprintf(&b, "<td class=\"id\">%d</td>\n", task.ID)
+
+		b.WriteString("<td class=\"title\">")
+		b.WriteString(html.EscapeString(task.Title))
+		if description := strings.TrimSpace(task.Description); description != "" {
+			lines := strings.Split(html.EscapeString(description), "\n")
+			fmt.Fprintf(&b, "<div class=\"description\">%s</div>", strings.Join(lines, "<br>"))
+		}
+		b.WriteString("</td>\n")
+
+		fmt.Fprintf(&b, "<td class=\"status\">%s</td>\n", html.EscapeString(string(task.Status)))
+		fmt.Fprintf(&b, "<td class=\"priority\">%s</td>\n", html.EscapeString(string(task.Priority)))
+		fmt.Fprintf(&b, "<td class=\"assigned-to\">%s</td>\n", html.EscapeString(task.AssignedTo))
+
+		due := ""
+		if task.DueDate != nil {
+			due = task.DueDate.Local().Format(dateLayout)
+		}
+		fmt.Fprintf(&b, "<td class=\"due\">%s</td>\n", due)
+		b.WriteString("</tr>\n")
+	}
+
+	b.WriteString("</tbody>\n</table>\n</div>\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write HTML: %w", err)
+	}
+	return nil
+}
+
+// htmlClass reduces a value to the characters allowed in a class name
+func htmlClass(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return -1
+		}
+	}, value)
+}
@@ -0,0 +1,55 @@
+package export
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestWriteTasksHTML(t *testing.T) {
+	done := models.NewTaskWithDetails(8, "Write release notes", "", models.PriorityLow)
+	done.Status = models.StatusDone
+	pending := models.NewTaskWithDetails(9, "Plan Q3 \"roadmap\"", "", models.PriorityMedium)
+
+	var b strings.Builder
+	if err := WriteTasksHTML(&b, []*models.Task{newSampleTask(t), done, pending}, "web & app"); err != nil {
+		t.Fatalf("WriteTasksHTML failed: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "tasks.html")
+	if *updateGolden {
+		if err := os.WriteFile(golden, []byte(b.String()), 0644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+	if b.String() != string(expected) {
+		t.Errorf("HTML doesn't match %s (run with -update to rewrite it):\n%s", golden, b.String())
+	}
+}
+
+func TestWriteTasksHTMLEmpty(t *testing.T) {
+	var b strings.Builder
+	if err := WriteTasksHTML(&b, nil, "webapp"); err != nil {
+		t.Fatalf("WriteTasksHTML failed: %v", err)
+	}
+
+	got := b.String()
+	if !strings.Contains(got, "<caption>webapp (0 tasks)</caption>") || !strings.Contains(got, "<tbody>\n</tbody>") {
+		t.Errorf("Expected an empty table, got:\n%s", got)
+	}
+}
+
+func TestHTMLClass(t *testing.T) {
+	if got := htmlClass("In_Progress\" onclick=x"); got != "in_progressonclickx" {
+		t.Errorf("Expected unsafe characters to be dropped, got %q", got)
+	}
+}
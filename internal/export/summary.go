@@ -0,0 +1,68 @@
+package export
+
+import (
+	"fmt"
+	"quicktodo/internal/models"
+	"strings"
+)
+
+// SummaryMarkdown renders a standup report as Markdown for pasting into chat:
+// a heading for the project, then a section each for completed, in-progress
+// and blocked or overdue tasks. sinceLabel completes the "Completed since"
+// heading, e.g. "yesterday".
+func SummaryMarkdown(report *models.StandupReport, projectName, sinceLabel string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "## Standup: %s\n", projectName)
+
+	fmt.Fprintf(&b, "\n### Completed since %s\n\n", sinceLabel)
+	for _, task := range report.Completed {
+		fmt.Fprintf(&b, "- %s\n", summaryTaskLine(task))
+	}
+	writeNone(&b, len(report.Completed))
+
+	b.WriteString("\n### In progress\n\n")
+	for _, task := range report.InProgress {
+		fmt.Fprintf(&b, "- %s\n", summaryTaskLine(task))
+	}
+	writeNone(&b, len(report.InProgress))
+
+	b.WriteString("\n### Blocked/overdue\n\n")
+	for _, item := range report.Blocked {
+		fmt.Fprintf(&b, "- %s — %s\n", summaryTaskLine(item.Task), strings.Join(SummaryReasons(item), "; "))
+	}
+	writeNone(&b, len(report.Blocked))
+
+	return b.String()
+}
+
+// SummaryReasons explains why a task is listed as blocked or overdue
+func SummaryReasons(item *models.StandupItem) []string {
+	var reasons []string
+	if item.Overdue {
+		reasons = append(reasons, "overdue since "+item.Task.DueDate.Local().Format(dateLayout))
+	}
+	if len(item.WaitingOn) > 0 {
+		ids := make([]string, len(item.WaitingOn))
+		for i, id := range item.WaitingOn {
+			ids[i] = fmt.Sprintf("#%d", id)
+		}
+		reasons = append(reasons, "waiting on "+strings.Join(ids, ", "))
+	}
+	return reasons
+}
+
+// summaryTaskLine is a task's ID and title, with its assignee when it has one
+func summaryTaskLine(task *models.Task) string {
+	line := fmt.Sprintf("#%d %s", task.ID, task.Title)
+	if task.AssignedTo != "" {
+		line += fmt.Sprintf(" (%s)", task.AssignedTo)
+	}
+	return line
+}
+
+func writeNone(b *strings.Builder, count int) {
+	if count == 0 {
+		b.WriteString("_None_\n")
+	}
+}
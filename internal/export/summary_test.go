@@ -0,0 +1,65 @@
+package export
+
+import (
+	"quicktodo/internal/models"
+	"testing"
+	"time"
+)
+
+func TestSummaryMarkdown(t *testing.T) {
+	done := models.NewTask(3, "Ship login page")
+	inProgress := models.NewTask(4, "Refactor sessions")
+	inProgress.AssignedTo = "agent-1"
+	due := time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local)
+	late := models.NewTask(5, "Renew certificates")
+	late.DueDate = &due
+	waiting := models.NewTask(6, "Deploy")
+
+	report := &models.StandupReport{
+		Completed:  []*models.Task{done},
+		InProgress: []*models.Task{inProgress},
+		Blocked: []*models.StandupItem{
+			{Task: late, Overdue: true},
+			{Task: waiting, WaitingOn: []int{4, 5}},
+		},
+	}
+
+	expected := "## Standup: webapp\n" +
+		"\n" +
+		"### Completed since yesterday\n" +
+		"\n" +
+		"- #3 Ship login page\n" +
+		"\n" +
+		"### In progress\n" +
+		"\n" +
+		"- #4 Refactor sessions (agent-1)\n" +
+		"\n" +
+		"### Blocked/overdue\n" +
+		"\n" +
+		"- #5 Renew certificates — overdue since 2025-03-10\n" +
+		"- #6 Deploy — waiting on #4, #5\n"
+
+	if got := SummaryMarkdown(report, "webapp", "yesterday"); got != expected {
+		t.Errorf("Unexpected Markdown:\n%s\nExpected:\n%s", got, expected)
+	}
+}
+
+func TestSummaryMarkdownEmpty(t *testing.T) {
+	expected := "## Standup: webapp\n" +
+		"\n" +
+		"### Completed since 2025-03-01 09:00\n" +
+		"\n" +
+		"_None_\n" +
+		"\n" +
+		"### In progress\n" +
+		"\n" +
+		"_None_\n" +
+		"\n" +
+		"### Blocked/overdue\n" +
+		"\n" +
+		"_None_\n"
+
+	if got := SummaryMarkdown(&models.StandupReport{}, "webapp", "2025-03-01 09:00"); got != expected {
+		t.Errorf("Unexpected Markdown:\n%s\nExpected:\n%s", got, expected)
+	}
+}
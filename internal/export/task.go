@@ -0,0 +1,122 @@
+// Package export renders QuickTodo tasks in formats meant for sharing outside
+// the tool, such as chat messages and pull request descriptions.
+package export
+
+import (
+	"fmt"
+	"quicktodo/internal/models"
+	"strings"
+)
+
+// Snippet formats supported by RenderTask
+const (
+	FormatMarkdown = "markdown"
+	FormatSlack    = "slack"
+)
+
+// Formats lists the supported snippet formats
+var Formats = []string{FormatMarkdown, FormatSlack}
+
+// dateLayout is used for the dates shown in snippets
+const dateLayout = "2006-01-02"
+
+// RenderTask renders a task from the named project as a snippet in format
+func RenderTask(format string, task *models.Task, projectName string) (string, error) {
+	switch format {
+	case FormatMarkdown:
+		return TaskMarkdown(task, projectName), nil
+	case FormatSlack:
+		return TaskSlack(task, projectName), nil
+	default:
+		return "", fmt.Errorf("unsupported format '%s'. Supported formats: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// field is one labelled line of task metadata
+type field struct {
+	label string
+	value string
+}
+
+// taskFields collects the metadata shown below a task's description. Values
+// are raw text; each renderer escapes and formats them itself.
+func taskFields(task *models.Task, projectName string) []field {
+	fields := []field{{"Project", projectName}}
+	if len(task.Tags) > 0 {
+		fields = append(fields, field{"Tags", strings.Join(task.Tags, ", ")})
+	}
+	if task.AssignedTo != "" {
+		fields = append(fields, field{"Assigned to", task.AssignedTo})
+	}
+	if task.StartDate != nil {
+		fields = append(fields, field{"Starts", task.StartDate.Local().Format(dateLayout)})
+	}
+	if task.DueDate != nil {
+		fields = append(fields, field{"Due", task.DueDate.Local().Format(dateLayout)})
+	}
+	if task.CompletedAt != nil {
+		fields = append(fields, field{"Completed", fmt.Sprintf("%s (%s)", task.CompletedAt.Local().Format(dateLayout), task.EffectiveResolution())})
+	}
+	fields = append(fields, field{"Created", task.CreatedAt.Local().Format(dateLayout)})
+	return fields
+}
+
+// TaskMarkdown renders a task as a Markdown block: the title as a heading,
+// status and priority badges, the description, then a metadata list
+func TaskMarkdown(task *models.Task, projectName string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "### #%d %s\n\n", task.ID, task.Title)
+	fmt.Fprintf(&b, "`%s` · `%s priority`\n", task.Status, task.Priority)
+
+	if description := strings.TrimSpace(task.Description); description != "" {
+		fmt.Fprintf(&b, "\n%s\n", description)
+	}
+
+	b.WriteString("\n")
+	for _, f := range taskFields(task, projectName) {
+		fmt.Fprintf(&b, "- **%s:** %s\n", f.label, f.value)
+	}
+
+	if len(task.Attachments) > 0 {
+		links := make([]string, len(task.Attachments))
+		for i, attachment := range task.Attachments {
+			links[i] = fmt.Sprintf("[%s](%s)", attachment.Name, attachment.URL)
+		}
+		fmt.Fprintf(&b, "- **Attachments:** %s\n", strings.Join(links, ", "))
+	}
+
+	return b.String()
+}
+
+// slackEscaper escapes the characters Slack treats as control sequences
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// TaskSlack renders a task as Slack mrkdwn: a bold title, status and
+// priority badges, the description as a quote, then one line per field
+func TaskSlack(task *models.Task, projectName string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "*#%d %s*\n", task.ID, slackEscaper.Replace(task.Title))
+	fmt.Fprintf(&b, "`%s` • `%s priority`\n", task.Status, task.Priority)
+
+	if description := strings.TrimSpace(task.Description); description != "" {
+		for _, line := range strings.Split(description, "\n") {
+			fmt.Fprintf(&b, ">%s\n", slackEscaper.Replace(line))
+		}
+	}
+
+	for _, f := range taskFields(task, projectName) {
+		fmt.Fprintf(&b, "*%s:* %s\n", f.label, slackEscaper.Replace(f.value))
+	}
+
+	if len(task.Attachments) > 0 {
+		links := make([]string, len(task.Attachments))
+		for i, attachment := range task.Attachments {
+			links[i] = fmt.Sprintf("<%s|%s>", attachment.URL, slackEscaper.Replace(attachment.Name))
+		}
+		fmt.Fprintf(&b, "*Attachments:* %s\n", strings.Join(links, ", "))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package export
+
+import (
+	"quicktodo/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSampleTask(t *testing.T) *models.Task {
+	t.Helper()
+
+	task := models.NewTaskWithDetails(7, "Fix login <bug>", "Users cannot log in.\nHappens on mobile & desktop.", models.PriorityHigh)
+	task.Status = models.StatusInProgress
+	task.Tags = []string{"auth", "backend"}
+	task.AssignedTo = "agent-1"
+	task.CreatedAt = time.Date(2025, 1, 10, 12, 0, 0, 0, time.Local)
+	due := time.Date(2025, 1, 31, 12, 0, 0, 0, time.Local)
+	task.DueDate = &due
+	if _, err := task.AddAttachment("pr", "https://github.com/org/repo/pull/42"); err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	return task
+}
+
+func TestTaskMarkdown(t *testing.T) {
+	expected := "### #7 Fix login <bug>\n" +
+		"\n" +
+		"`in_progress` · `high priority`\n" +
+		"\n" +
+		"Users cannot log in.\n" +
+		"Happens on mobile & desktop.\n" +
+		"\n" +
+		"- **Project:** webapp\n" +
+		"- **Tags:** auth, backend\n" +
+		"- **Assigned to:** agent-1\n" +
+		"- **Due:** 2025-01-31\n" +
+		"- **Created:** 2025-01-10\n" +
+		"- **Attachments:** [pr](https://github.com/org/repo/pull/42)\n"
+
+	if got := TaskMarkdown(newSampleTask(t), "webapp"); got != expected {
+		t.Errorf("Unexpected Markdown snippet:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestTaskMarkdownMinimal(t *testing.T) {
+	task := models.NewTask(1, "Write docs")
+
+	got := TaskMarkdown(task, "webapp")
+	if !strings.HasPrefix(got, "### #1 Write docs\n\n`pending` · `medium priority`\n\n- **Project:** webapp\n") {
+		t.Errorf("Unexpected Markdown snippet:\n%s", got)
+	}
+	for _, label := range []string{"Tags", "Assigned to", "Due", "Attachments"} {
+		if strings.Contains(got, "**"+label+":**") {
+			t.Errorf("Expected empty field %s to be omitted:\n%s", label, got)
+		}
+	}
+}
+
+func TestTaskSlack(t *testing.T) {
+	expected := "*#7 Fix login &lt;bug&gt;*\n" +
+		"`in_progress` • `high priority`\n" +
+		">Users cannot log in.\n" +
+		">Happens on mobile &amp; desktop.\n" +
+		"*Project:* webapp\n" +
+		"*Tags:* auth, backend\n" +
+		"*Assigned to:* agent-1\n" +
+		"*Due:* 2025-01-31\n" +
+		"*Created:* 2025-01-10\n" +
+		"*Attachments:* <https://github.com/org/repo/pull/42|pr>\n"
+
+	if got := TaskSlack(newSampleTask(t), "webapp"); got != expected {
+		t.Errorf("Unexpected Slack snippet:\n%s\nexpected:\n%s", got, expected)
+	}
+}
+
+func TestRenderTaskUnknownFormat(t *testing.T) {
+	if _, err := RenderTask("html", models.NewTask(1, "Write docs"), "webapp"); err == nil {
+		t.Error("Expected an unsupported format to be rejected")
+	}
+}
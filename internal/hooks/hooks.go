@@ -0,0 +1,139 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"quicktodo/internal/models"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Task lifecycle events that hooks can be attached to
+const (
+	EventTaskCreated   = "task_created"
+	EventStatusChanged = "status_changed"
+	EventTaskCompleted = "task_completed"
+	EventTaskDue       = "task_due"
+)
+
+// DefaultTimeout bounds how long a single hook command may run
+const DefaultTimeout = 10 * time.Second
+
+// ValidEvents returns all events hooks can be configured for
+func ValidEvents() []string {
+	return []string{EventTaskCreated, EventStatusChanged, EventTaskCompleted, EventTaskDue}
+}
+
+// Runner executes the hook commands configured for task lifecycle events
+type Runner struct {
+	hooks   map[string]string
+	timeout time.Duration
+}
+
+// templateData is exposed to hook command templates. It deliberately holds
+// nothing a task's text can reach: titles, descriptions and assignees come
+// from anyone who can create a task, including through serve, so they are
+// only passed in the environment and on stdin, never into the shell string.
+type templateData struct {
+	Event   string
+	Project string
+	ID      int
+}
+
+// NewRunner creates a hook runner for the given event-to-command mapping
+func NewRunner(hooks map[string]string) *Runner {
+	return &Runner{
+		hooks:   hooks,
+		timeout: DefaultTimeout,
+	}
+}
+
+// SetTimeout overrides the per-hook timeout
+func (r *Runner) SetTimeout(timeout time.Duration) {
+	r.timeout = timeout
+}
+
+// HasHook reports whether a command is configured for the event
+func (r *Runner) HasHook(event string) bool {
+	return r.hooks[event] != ""
+}
+
+// Run executes the hook configured for event, if any. The command template is
+// rendered with .Event, .Project and the task's .ID only, and run through the
+// shell with the task JSON on stdin and the main fields exported as
+// QUICKTODO_* environment variables. The command is killed when the timeout
+// elapses.
+func (r *Runner) Run(event string, task *models.Task, projectName string) error {
+	commandTemplate := r.hooks[event]
+	if commandTemplate == "" {
+		return nil
+	}
+
+	command, err := renderCommand(commandTemplate, templateData{Event: event, Project: projectName, ID: task.ID})
+	if err != nil {
+		return fmt.Errorf("failed to render %s hook: %w", event, err)
+	}
+
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task for %s hook: %w", event, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), hookEnv(event, task, projectName)...)
+	cmd.WaitDelay = time.Second
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s hook timed out after %s", event, r.timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("%s hook failed: %w (output: %s)", event, err, bytes.TrimSpace(output))
+	}
+
+	return nil
+}
+
+// renderCommand expands a hook command template
+func renderCommand(commandTemplate string, data templateData) (string, error) {
+	tmpl, err := template.New("hook").Funcs(template.FuncMap{"shellquote": shellQuote}).Parse(commandTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w (task fields other than .ID are only available as QUICKTODO_* variables)", err)
+	}
+
+	return buf.String(), nil
+}
+
+// shellQuote quotes s as a single shell word, e.g. {{shellquote .Project}}
+// for project names with spaces
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// hookEnv returns the environment variables describing the event and task
+func hookEnv(event string, task *models.Task, projectName string) []string {
+	return []string{
+		"QUICKTODO_EVENT=" + event,
+		"QUICKTODO_PROJECT=" + projectName,
+		"QUICKTODO_TASK_ID=" + strconv.Itoa(task.ID),
+		"QUICKTODO_TASK_TITLE=" + task.Title,
+		"QUICKTODO_TASK_STATUS=" + string(task.Status),
+		"QUICKTODO_TASK_PRIORITY=" + string(task.Priority),
+		"QUICKTODO_TASK_ASSIGNED_TO=" + task.AssignedTo,
+	}
+}
@@ -0,0 +1,148 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFakeHook writes a script that captures its stdin and environment
+func writeFakeHook(t *testing.T, dir string) string {
+	t.Helper()
+
+	script := filepath.Join(dir, "hook.sh")
+	content := "#!/bin/sh\ncat > \"$1/stdin.json\"\necho \"$QUICKTODO_EVENT $QUICKTODO_PROJECT $QUICKTODO_TASK_ID\" > \"$1/env.txt\"\n"
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil {
+		t.Fatalf("Failed to write hook script: %v", err)
+	}
+
+	return script
+}
+
+func TestRunnerPassesTaskOnStdinAndEnv(t *testing.T) {
+	dir := t.TempDir()
+	script := writeFakeHook(t, dir)
+
+	runner := NewRunner(map[string]string{
+		EventTaskCreated: script + " " + dir,
+	})
+
+	task := models.NewTaskWithDetails(7, "Hooked task", "desc", models.PriorityHigh)
+	if err := runner.Run(EventTaskCreated, task, "demo"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "stdin.json"))
+	if err != nil {
+		t.Fatalf("Hook did not capture stdin: %v", err)
+	}
+
+	var received models.Task
+	if err := json.Unmarshal(data, &received); err != nil {
+		t.Fatalf("Hook stdin is not task JSON: %v", err)
+	}
+	if received.ID != 7 || received.Title != "Hooked task" {
+		t.Errorf("Unexpected task on stdin: %+v", received)
+	}
+
+	env, err := os.ReadFile(filepath.Join(dir, "env.txt"))
+	if err != nil {
+		t.Fatalf("Hook did not capture env: %v", err)
+	}
+	if strings.TrimSpace(string(env)) != "task_created demo 7" {
+		t.Errorf("Unexpected hook environment: %q", env)
+	}
+}
+
+func TestRunnerRendersTemplate(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.txt")
+
+	runner := NewRunner(map[string]string{
+		EventStatusChanged: "echo {{shellquote .Project}}/{{.ID}}/$QUICKTODO_TASK_STATUS/{{.Event}} > " + out,
+	})
+
+	task := models.NewTask(3, "Templated")
+	task.Status = models.StatusInProgress
+	if err := runner.Run(EventStatusChanged, task, "my demo"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("Failed to read hook output: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != "my demo/3/in_progress/status_changed" {
+		t.Errorf("Unexpected rendered output: %q", data)
+	}
+}
+
+func TestRunnerKeepsTaskTextOutOfTheShell(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.txt")
+	pwned := filepath.Join(dir, "pwned")
+
+	// Task fields can't be interpolated into the command
+	runner := NewRunner(map[string]string{EventTaskCreated: "echo {{.Title}} > " + out})
+	task := models.NewTask(1, "$(touch "+pwned+")")
+	if err := runner.Run(EventTaskCreated, task, "demo"); err == nil {
+		t.Error("Expected .Title in a hook template to be rejected")
+	}
+
+	// and reach the hook as data, never as shell syntax
+	runner = NewRunner(map[string]string{EventTaskCreated: `printf '%s\n' "$QUICKTODO_TASK_TITLE" > ` + out})
+	for _, title := range []string{"$(touch " + pwned + ")", `x"; touch ` + pwned + `; "`, "`touch " + pwned + "`"} {
+		if err := runner.Run(EventTaskCreated, models.NewTask(1, title), "demo"); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		data, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("Failed to read hook output: %v", err)
+		}
+		if strings.TrimSuffix(string(data), "\n") != title {
+			t.Errorf("Expected the title verbatim, got %q", data)
+		}
+	}
+	if _, err := os.Stat(pwned); err == nil {
+		t.Error("Task title was run as a shell command")
+	}
+
+	if got := shellQuote("it's $(x)"); got != `'it'\''s $(x)'` {
+		t.Errorf("Unexpected quoting: %s", got)
+	}
+}
+
+func TestRunnerNoHookConfigured(t *testing.T) {
+	runner := NewRunner(nil)
+	if runner.HasHook(EventTaskCompleted) {
+		t.Error("Expected no hook configured")
+	}
+	if err := runner.Run(EventTaskCompleted, models.NewTask(1, "Task"), "demo"); err != nil {
+		t.Errorf("Expected no error without hooks, got %v", err)
+	}
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	runner := NewRunner(map[string]string{EventTaskCreated: "sleep 5"})
+	runner.SetTimeout(100 * time.Millisecond)
+
+	start := time.Now()
+	err := runner.Run(EventTaskCreated, models.NewTask(1, "Slow"), "demo")
+	if err == nil {
+		t.Fatal("Expected timeout error")
+	}
+	if time.Since(start) > 3*time.Second {
+		t.Errorf("Hook was not killed promptly")
+	}
+}
+
+func TestRunnerFailingHook(t *testing.T) {
+	runner := NewRunner(map[string]string{EventTaskCreated: "exit 3"})
+	if err := runner.Run(EventTaskCreated, models.NewTask(1, "Task"), "demo"); err == nil {
+		t.Error("Expected error from failing hook")
+	}
+}
@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"quicktodo/internal/models"
+	"strings"
+	"time"
+)
+
+// FormatCSV is the format name for a CSV file with the columns written by
+// export --format csv
+const FormatCSV = "csv"
+
+// CSVSkippedRow is a CSV row that was not imported
+type CSVSkippedRow struct {
+	Row    int    `json:"row"` // line number in the file, the header being row 1
+	Reason string `json:"reason"`
+}
+
+// CSVResult lists the outcome of a CSV import
+type CSVResult struct {
+	Created []*models.Task  `json:"created"`
+	Skipped []CSVSkippedRow `json:"skipped"`
+}
+
+// ImportCSV adds a task for each row of a CSV file. The first row is a header
+// naming the columns; they may come in any order, and only title is required.
+// The id column is ignored since imported tasks get new IDs. Rows with an
+// empty title or an invalid status, priority or timestamp are skipped with a
+// reason; a file that isn't valid CSV is an error.
+func ImportCSV(db *models.ProjectDatabase, r io.Reader, opts Options) (*CSVResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	// Spreadsheet programs often start the file with a byte order mark
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("CSV header has no title column")
+	}
+
+	result := &CSVResult{
+		Created: make([]*models.Task, 0),
+		Skipped: make([]CSVSkippedRow, 0),
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("invalid CSV on line %d: %w", parseErr.Line, parseErr.Err)
+			}
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		task, err := csvTask(db.NextID, field)
+		if err != nil {
+			result.Skipped = append(result.Skipped, CSVSkippedRow{Row: line, Reason: err.Error()})
+			continue
+		}
+
+		if err := db.AddTask(task); err != nil {
+			result.Skipped = append(result.Skipped, CSVSkippedRow{Row: line, Reason: err.Error()})
+			continue
+		}
+		db.RecordTaskCreated(task, opts.Actor)
+		result.Created = append(result.Created, task)
+	}
+
+	return result, nil
+}
+
+// csvTask builds a task from the fields of one CSV row
+func csvTask(id int, field func(name string) string) (*models.Task, error) {
+	title := models.SanitizeTitle(field("title"))
+	if title == "" {
+		return nil, fmt.Errorf("title is empty")
+	}
+
+	task := models.NewTaskWithDetails(id, title, field("description"), models.PriorityMedium)
+	task.AssignedTo = field("assigned_to")
+
+	if value := field("priority"); value != "" {
+		priority := models.NormalizePriority(value)
+		if !models.IsValidPriority(string(priority)) {
+			return nil, fmt.Errorf("invalid priority '%s'", value)
+		}
+		task.Priority = priority
+	}
+
+	if value := field("created_at"); value != "" {
+		created, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_at '%s'", value)
+		}
+		task.CreatedAt = created.UTC()
+	}
+
+	if value := field("status"); value != "" {
+		status := models.NormalizeStatus(value)
+		if !models.IsValidStatus(string(status)) {
+			return nil, fmt.Errorf("invalid status '%s'", value)
+		}
+		if err := task.UpdateStatus(status); err != nil {
+			return nil, err
+		}
+	}
+
+	// Set last so that the status change above doesn't overwrite it
+	task.UpdatedAt = task.CreatedAt
+	if value := field("updated_at"); value != "" {
+		updated, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid updated_at '%s'", value)
+		}
+		task.UpdatedAt = updated.UTC()
+	}
+
+	// The file doesn't say when the status last changed; pending tasks are
+	// assumed to have been pending since creation, others since their last
+	// update
+	task.StatusChangedAt = task.UpdatedAt
+	if task.Status == models.StatusPending {
+		task.StatusChangedAt = task.CreatedAt
+	}
+
+	return task, nil
+}
@@ -0,0 +1,97 @@
+package importer
+
+import (
+	"quicktodo/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportCSV(t *testing.T) {
+	db := newTestDatabase()
+	db.AddTask(models.NewTask(db.NextID, "Existing"))
+
+	input := "id,title,description,status,priority,assigned_to,created_at,updated_at\n" +
+		"7,Fix login,\"Users can't log in,\nsee \"\"auth\"\" logs\",in_progress,high,agent-1,2025-01-10T12:00:00Z,2025-01-11T08:00:00Z\n" +
+		"8,Write docs,,done,low,,,\n" +
+		"9,,No title,pending,low,,,\n" +
+		"10,Bad status,,blocked,low,,,\n" +
+		"11,Bad priority,,pending,urgent,,,\n" +
+		"12,Bad date,,pending,low,,yesterday,\n"
+
+	result, err := ImportCSV(db, strings.NewReader(input), Options{Actor: "tester"})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+
+	if len(result.Created) != 2 {
+		t.Fatalf("Expected 2 tasks created, got %d", len(result.Created))
+	}
+
+	task := result.Created[0]
+	if task.ID != 2 {
+		t.Errorf("Expected the id column to be ignored and task #2 created, got #%d", task.ID)
+	}
+	if task.Title != "Fix login" || task.Description != "Users can't log in,\nsee \"auth\" logs" {
+		t.Errorf("Unexpected title or description: %q %q", task.Title, task.Description)
+	}
+	if task.Status != models.StatusInProgress || task.Priority != models.PriorityHigh || task.AssignedTo != "agent-1" {
+		t.Errorf("Unexpected fields: %+v", task)
+	}
+	if !task.CreatedAt.Equal(time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)) || !task.UpdatedAt.Equal(time.Date(2025, 1, 11, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected timestamps: %v %v", task.CreatedAt, task.UpdatedAt)
+	}
+	if done := result.Created[1]; done.Status != models.StatusDone || done.CompletedAt == nil {
+		t.Errorf("Expected a completed task, got %+v", done)
+	}
+
+	expected := []CSVSkippedRow{
+		{5, "title is empty"}, // the quoted description above spans two lines
+		{6, "invalid status 'blocked'"},
+		{7, "invalid priority 'urgent'"},
+		{8, "invalid created_at 'yesterday'"},
+	}
+	if len(result.Skipped) != len(expected) {
+		t.Fatalf("Expected %d skipped rows, got %+v", len(expected), result.Skipped)
+	}
+	for i, skipped := range result.Skipped {
+		if skipped != expected[i] {
+			t.Errorf("Skipped row %d: expected %+v, got %+v", i, expected[i], skipped)
+		}
+	}
+
+	if len(db.Tasks) != 3 || len(db.History) != 2 {
+		t.Errorf("Expected 3 tasks and 2 creation events, got %d and %d", len(db.Tasks), len(db.History))
+	}
+}
+
+func TestImportCSVHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"empty file", "", "empty"},
+		{"no title column", "id,status\n1,done\n", "no title column"},
+		{"malformed", "title\n\"unterminated\n", "invalid CSV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportCSV(newTestDatabase(), strings.NewReader(tt.input), Options{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// Columns are matched by name, in any order
+	db := newTestDatabase()
+	result, err := ImportCSV(db, strings.NewReader("\ufeffPriority,Title\nhigh,Reordered\n"), Options{})
+	if err != nil {
+		t.Fatalf("ImportCSV failed: %v", err)
+	}
+	if len(result.Created) != 1 || result.Created[0].Title != "Reordered" || result.Created[0].Priority != models.PriorityHigh {
+		t.Errorf("Expected reordered columns to be read by name, got %+v", result.Created)
+	}
+}
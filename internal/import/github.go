@@ -0,0 +1,159 @@
+// Package importer converts task exports from other tools into QuickTodo tasks.
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"quicktodo/internal/models"
+	"strings"
+	"time"
+)
+
+// FormatGitHubJSON is the format name for a GitHub issues API export
+const FormatGitHubJSON = "github-json"
+
+// GitHubIssue is the subset of a GitHub issue object used for import
+type GitHubIssue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	State       string          `json:"state"`
+	Labels      []GitHubLabel   `json:"labels"`
+	Assignee    *GitHubUser     `json:"assignee"`
+	Assignees   []GitHubUser    `json:"assignees"`
+	PullRequest json.RawMessage `json:"pull_request,omitempty"`
+}
+
+// GitHubLabel is a label attached to an issue
+type GitHubLabel struct {
+	Name string `json:"name"`
+}
+
+// GitHubUser is a GitHub account referenced by an issue
+type GitHubUser struct {
+	Login string `json:"login"`
+}
+
+// Options controls how issues are merged into a project database
+type Options struct {
+	UpdateExisting bool   // refresh tasks previously imported from the same issue
+	Actor          string // recorded in task history
+}
+
+// Result lists the tasks touched by an import
+type Result struct {
+	Created []*models.Task `json:"created"`
+	Updated []*models.Task `json:"updated"`
+	Skipped []int          `json:"skipped"` // issue numbers that were not imported
+}
+
+// ParseGitHubIssues parses a JSON array of issues as returned by the GitHub API
+func ParseGitHubIssues(data []byte) ([]GitHubIssue, error) {
+	var issues []GitHubIssue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub issues export: %w", err)
+	}
+	return issues, nil
+}
+
+// ExternalID returns the identifier stored on tasks imported from the issue
+func (i GitHubIssue) ExternalID() string {
+	return fmt.Sprintf("github:%d", i.Number)
+}
+
+// IsPullRequest reports whether the entry is a pull request rather than an issue
+func (i GitHubIssue) IsPullRequest() bool {
+	return len(i.PullRequest) > 0 && string(i.PullRequest) != "null"
+}
+
+// AssigneeLogin returns the first assignee, if any
+func (i GitHubIssue) AssigneeLogin() string {
+	if len(i.Assignees) > 0 {
+		return i.Assignees[0].Login
+	}
+	if i.Assignee != nil {
+		return i.Assignee.Login
+	}
+	return ""
+}
+
+// LabelNames returns the issue's label names
+func (i GitHubIssue) LabelNames() []string {
+	var names []string
+	for _, label := range i.Labels {
+		if label.Name != "" {
+			names = append(names, label.Name)
+		}
+	}
+	return names
+}
+
+// ApplyGitHubIssue copies the issue fields onto a task. Open issues map to
+// pending unless the task is already in progress; closed issues map to done
+// unless the task was cancelled.
+func ApplyGitHubIssue(task *models.Task, issue GitHubIssue) {
+	task.Title = strings.TrimSpace(issue.Title)
+	task.Description = issue.Body
+	task.AssignedTo = issue.AssigneeLogin()
+	task.Tags = issue.LabelNames()
+	task.ExternalID = issue.ExternalID()
+
+	if strings.EqualFold(issue.State, "closed") {
+		if !task.IsCancelled() {
+			task.Status = models.StatusDone
+		}
+	} else if task.Status != models.StatusInProgress {
+		task.Status = models.StatusPending
+	}
+}
+
+// ImportGitHubIssues adds the issues to the database as tasks. Issues that
+// were imported before are skipped unless UpdateExisting is set, so repeated
+// imports of the same export don't create duplicates. Pull requests and
+// issues without a title are skipped.
+func ImportGitHubIssues(db *models.ProjectDatabase, issues []GitHubIssue, opts Options) (*Result, error) {
+	result := &Result{
+		Created: make([]*models.Task, 0),
+		Updated: make([]*models.Task, 0),
+		Skipped: make([]int, 0),
+	}
+
+	for _, issue := range issues {
+		if issue.IsPullRequest() || strings.TrimSpace(issue.Title) == "" {
+			result.Skipped = append(result.Skipped, issue.Number)
+			continue
+		}
+
+		if existing, ok := db.FindTaskByExternalID(issue.ExternalID()); ok {
+			if !opts.UpdateExisting {
+				result.Skipped = append(result.Skipped, issue.Number)
+				continue
+			}
+
+			before := existing.Clone()
+			ApplyGitHubIssue(existing, issue)
+			if len(models.DiffTasks(before, existing, opts.Actor)) == 0 {
+				result.Skipped = append(result.Skipped, issue.Number)
+				continue
+			}
+
+			existing.UpdatedAt = time.Now().UTC()
+			if err := db.UpdateTask(existing); err != nil {
+				return nil, fmt.Errorf("failed to update task for issue #%d: %w", issue.Number, err)
+			}
+			db.RecordTaskChanges(before, existing, opts.Actor)
+			result.Updated = append(result.Updated, existing)
+			continue
+		}
+
+		task := models.NewTask(db.NextID, issue.Title)
+		ApplyGitHubIssue(task, issue)
+		if err := db.AddTask(task); err != nil {
+			return nil, fmt.Errorf("failed to add task for issue #%d: %w", issue.Number, err)
+		}
+		db.RecordTaskCreated(task, opts.Actor)
+		result.Created = append(result.Created, task)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"testing"
+)
+
+func loadSampleIssues(t *testing.T) []GitHubIssue {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "github_issues.json"))
+	if err != nil {
+		t.Fatalf("Failed to read sample export: %v", err)
+	}
+
+	issues, err := ParseGitHubIssues(data)
+	if err != nil {
+		t.Fatalf("ParseGitHubIssues failed: %v", err)
+	}
+	return issues
+}
+
+func newTestDatabase() *models.ProjectDatabase {
+	return models.NewProjectDatabase(models.NewProject("test-project", "/tmp/test-project"))
+}
+
+func TestImportGitHubIssuesMapsFields(t *testing.T) {
+	db := newTestDatabase()
+
+	result, err := ImportGitHubIssues(db, loadSampleIssues(t), Options{Actor: "tester"})
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+
+	if len(result.Created) != 2 {
+		t.Fatalf("Expected 2 tasks created, got %d", len(result.Created))
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0] != 16 {
+		t.Errorf("Expected pull request #16 to be skipped, got %v", result.Skipped)
+	}
+
+	open := result.Created[0]
+	if open.Title != "Crash when config is missing" || open.Status != models.StatusPending {
+		t.Errorf("Unexpected open issue mapping: %+v", open)
+	}
+	if open.Description == "" {
+		t.Error("Expected issue body to become the description")
+	}
+	if open.AssignedTo != "octocat" {
+		t.Errorf("Expected first assignee 'octocat', got '%s'", open.AssignedTo)
+	}
+	if len(open.Tags) != 2 || !open.HasTag("bug") || !open.HasTag("p1") {
+		t.Errorf("Expected labels as tags, got %v", open.Tags)
+	}
+	if open.ExternalID != "github:12" {
+		t.Errorf("Expected external ID 'github:12', got '%s'", open.ExternalID)
+	}
+
+	closed := result.Created[1]
+	if closed.Status != models.StatusDone {
+		t.Errorf("Expected closed issue to be done, got '%s'", closed.Status)
+	}
+	if closed.Description != "" || closed.AssignedTo != "" {
+		t.Errorf("Expected empty description and assignee, got %+v", closed)
+	}
+
+	if len(db.History) != 2 {
+		t.Errorf("Expected creation events in history, got %d", len(db.History))
+	}
+}
+
+func TestImportGitHubIssuesSkipsDuplicates(t *testing.T) {
+	db := newTestDatabase()
+	issues := loadSampleIssues(t)
+
+	if _, err := ImportGitHubIssues(db, issues, Options{}); err != nil {
+		t.Fatalf("First import failed: %v", err)
+	}
+
+	result, err := ImportGitHubIssues(db, issues, Options{})
+	if err != nil {
+		t.Fatalf("Second import failed: %v", err)
+	}
+
+	if len(result.Created) != 0 || len(result.Updated) != 0 {
+		t.Errorf("Expected re-import to change nothing, got %+v", result)
+	}
+	if len(db.Tasks) != 2 {
+		t.Errorf("Expected 2 tasks after re-import, got %d", len(db.Tasks))
+	}
+}
+
+func TestImportGitHubIssuesUpdateExisting(t *testing.T) {
+	db := newTestDatabase()
+	issues := loadSampleIssues(t)
+
+	if _, err := ImportGitHubIssues(db, issues, Options{}); err != nil {
+		t.Fatalf("First import failed: %v", err)
+	}
+
+	// Work started locally before the issue was closed upstream
+	task, _ := db.FindTaskByExternalID("github:12")
+	task.Status = models.StatusInProgress
+
+	result, err := ImportGitHubIssues(db, issues, Options{UpdateExisting: true})
+	if err != nil {
+		t.Fatalf("Update import failed: %v", err)
+	}
+	if len(result.Updated) != 0 {
+		t.Errorf("Expected in-progress open issue to be left alone, got %d updates", len(result.Updated))
+	}
+	if task.Status != models.StatusInProgress {
+		t.Errorf("Expected in_progress to be preserved for open issue, got '%s'", task.Status)
+	}
+
+	issues[0].State = "closed"
+	issues[0].Title = "Crash when config file is missing"
+	result, err = ImportGitHubIssues(db, issues, Options{UpdateExisting: true, Actor: "tester"})
+	if err != nil {
+		t.Fatalf("Update import failed: %v", err)
+	}
+	if len(result.Updated) != 1 || len(result.Created) != 0 {
+		t.Fatalf("Expected 1 update and no creates, got %+v", result)
+	}
+	if task.Status != models.StatusDone || task.Title != "Crash when config file is missing" {
+		t.Errorf("Expected task refreshed from issue, got %+v", task)
+	}
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// AgeBucket counts the tasks created within an age range. Max of zero means
+// the bucket has no upper bound.
+type AgeBucket struct {
+	Label   string        `json:"label"`
+	Min     time.Duration `json:"-"`
+	Max     time.Duration `json:"-"`
+	Count   int           `json:"count"`
+	TaskIDs []int         `json:"task_ids"`
+}
+
+// DefaultAgeBuckets returns empty buckets for <1d, 1-7d, 7-30d and >30d
+func DefaultAgeBuckets() []AgeBucket {
+	const day = 24 * time.Hour
+	return []AgeBucket{
+		{Label: "<1d", Max: day},
+		{Label: "1-7d", Min: day, Max: 7 * day},
+		{Label: "7-30d", Min: 7 * day, Max: 30 * day},
+		{Label: ">30d", Min: 30 * day},
+	}
+}
+
+// BucketTasksByAge sorts tasks into the default age buckets by how long before
+// now they were created. With openOnly, done tasks are left out. Tasks created
+// after now (clock skew) count as less than a day old.
+func BucketTasksByAge(tasks []*Task, now time.Time, openOnly bool) []AgeBucket {
+	buckets := DefaultAgeBuckets()
+	for i := range buckets {
+		buckets[i].TaskIDs = []int{}
+	}
+
+	for _, task := range tasks {
+		if openOnly && task.IsClosed() {
+			continue
+		}
+
+		age := now.Sub(task.CreatedAt)
+		if age < 0 {
+			age = 0
+		}
+		for i := range buckets {
+			if age >= buckets[i].Min && (buckets[i].Max == 0 || age < buckets[i].Max) {
+				buckets[i].Count++
+				buckets[i].TaskIDs = append(buckets[i].TaskIDs, task.ID)
+				break
+			}
+		}
+	}
+
+	return buckets
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketTasksByAge(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for _, age := range []time.Duration{
+		time.Hour,           // #1 <1d
+		-time.Minute,        // #2 created in the future, <1d
+		24 * time.Hour,      // #3 1-7d, lower bound is inclusive
+		6 * 24 * time.Hour,  // #4 1-7d
+		7 * 24 * time.Hour,  // #5 7-30d
+		30 * 24 * time.Hour, // #6 >30d
+		90 * 24 * time.Hour, // #7 >30d
+	} {
+		task := NewTask(db.NextID, "Task")
+		task.CreatedAt = now.Add(-age)
+		if err := db.AddTask(task); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+	done, _ := db.GetTask(7)
+	done.UpdateStatus(StatusDone)
+
+	tests := []struct {
+		name     string
+		openOnly bool
+		want     map[string][]int
+	}{
+		{"all tasks", false, map[string][]int{"<1d": {1, 2}, "1-7d": {3, 4}, "7-30d": {5}, ">30d": {6, 7}}},
+		{"open only", true, map[string][]int{"<1d": {1, 2}, "1-7d": {3, 4}, "7-30d": {5}, ">30d": {6}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := BucketTasksByAge(db.Tasks, now, tt.openOnly)
+			if len(buckets) != 4 {
+				t.Fatalf("Expected 4 buckets, got %d", len(buckets))
+			}
+			for _, bucket := range buckets {
+				want := tt.want[bucket.Label]
+				if bucket.Count != len(want) || len(bucket.TaskIDs) != len(want) {
+					t.Errorf("Bucket %s: expected tasks %v, got %v", bucket.Label, want, bucket.TaskIDs)
+					continue
+				}
+				for i, id := range want {
+					if bucket.TaskIDs[i] != id {
+						t.Errorf("Bucket %s: expected tasks %v, got %v", bucket.Label, want, bucket.TaskIDs)
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBucketTasksByAgeEmpty(t *testing.T) {
+	buckets := BucketTasksByAge(nil, time.Now(), false)
+	for _, bucket := range buckets {
+		if bucket.Count != 0 || bucket.TaskIDs == nil {
+			t.Errorf("Expected empty bucket %s with a non-nil ID list, got %+v", bucket.Label, bucket)
+		}
+	}
+}
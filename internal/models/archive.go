@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ArchiveDoneTasks removes the done tasks completed before cutoff, and the
+// cancelled tasks last updated before it, from the database and returns them
+// in ID order. Done tasks without a completion time are judged by when they
+// were last updated.
+func (db *ProjectDatabase) ArchiveDoneTasks(cutoff time.Time) []*Task {
+	var archived []*Task
+	kept := db.Tasks[:0]
+	for _, task := range db.Tasks {
+		completedAt := task.UpdatedAt
+		if task.CompletedAt != nil {
+			completedAt = *task.CompletedAt
+		}
+		if task.IsClosed() && completedAt.Before(cutoff) {
+			archived = append(archived, task)
+			continue
+		}
+		kept = append(kept, task)
+	}
+
+	if len(archived) == 0 {
+		return nil
+	}
+
+	db.Tasks = kept
+	db.Reindex()
+	db.LastModified = time.Now().UTC()
+	db.Version++
+	db.Project.UpdateTaskCount(len(db.Tasks))
+
+	sort.SliceStable(archived, func(i, j int) bool { return archived[i].ID < archived[j].ID })
+	return archived
+}
+
+// RestoreTask puts a previously archived task back into the database under
+// its own ID
+func (db *ProjectDatabase) RestoreTask(task *Task) error {
+	if err := task.Validate(); err != nil {
+		return fmt.Errorf("invalid task: %w", err)
+	}
+	if _, exists := db.taskIndex()[task.ID]; exists {
+		return fmt.Errorf("task #%d already exists", task.ID)
+	}
+
+	// Keep tasks in ID order
+	i := sort.Search(len(db.Tasks), func(i int) bool { return db.Tasks[i].ID > task.ID })
+	db.Tasks = append(db.Tasks, nil)
+	copy(db.Tasks[i+1:], db.Tasks[i:])
+	db.Tasks[i] = task
+	db.Reindex()
+
+	if task.ID >= db.NextID {
+		db.NextID = task.ID + 1
+	}
+	db.LastModified = time.Now().UTC()
+	db.Version++
+	db.Project.UpdateTaskCount(len(db.Tasks))
+
+	return nil
+}
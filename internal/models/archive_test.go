@@ -0,0 +1,74 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArchiveDoneTasks(t *testing.T) {
+	now := time.Now().UTC()
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for _, age := range []time.Duration{-1, 40 * 24 * time.Hour, 2 * time.Hour, 60 * 24 * time.Hour} {
+		task := NewTask(db.NextID, "Task")
+		if age >= 0 {
+			task.UpdateStatus(StatusDone)
+			completedAt := now.Add(-age)
+			task.CompletedAt = &completedAt
+		}
+		if err := db.AddTask(task); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+	version := db.Version
+
+	archived := db.ArchiveDoneTasks(now.Add(-30 * 24 * time.Hour))
+	if ids := taskIDs(archived); len(ids) != 2 || ids[0] != 2 || ids[1] != 4 {
+		t.Fatalf("Expected tasks #2 and #4 archived, got %v", ids)
+	}
+	if ids := taskIDs(db.Tasks); len(ids) != 2 || ids[0] != 1 || ids[1] != 3 {
+		t.Errorf("Expected tasks #1 and #3 kept, got %v", ids)
+	}
+	if _, err := db.GetTask(2); err == nil {
+		t.Error("Expected archived task #2 to be gone from the index")
+	}
+	if db.Version != version+1 || db.Project.TaskCount != 2 {
+		t.Errorf("Expected version bump and task count 2, got version %d, count %d", db.Version, db.Project.TaskCount)
+	}
+
+	if archived := db.ArchiveDoneTasks(now.Add(-30 * 24 * time.Hour)); archived != nil {
+		t.Errorf("Expected nothing left to archive, got %v", taskIDs(archived))
+	}
+}
+
+func TestRestoreTask(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for i := 0; i < 3; i++ {
+		task := NewTask(db.NextID, "Task")
+		task.UpdateStatus(StatusDone)
+		db.AddTask(task)
+	}
+	archived := db.ArchiveDoneTasks(time.Now().Add(time.Hour))
+	if len(archived) != 3 {
+		t.Fatalf("Expected 3 tasks archived, got %d", len(archived))
+	}
+	db.AddTask(NewTask(db.NextID, "New"))
+
+	for _, task := range []*Task{archived[2], archived[0]} {
+		if err := db.RestoreTask(task); err != nil {
+			t.Fatalf("RestoreTask failed: %v", err)
+		}
+	}
+	if ids := taskIDs(db.Tasks); len(ids) != 3 || ids[0] != 1 || ids[1] != 3 || ids[2] != 4 {
+		t.Errorf("Expected tasks in ID order [1 3 4], got %v", ids)
+	}
+	if task, err := db.GetTask(3); err != nil || task != archived[2] {
+		t.Errorf("Expected restored task #3 in the index, got %v", err)
+	}
+	if db.NextID != 5 {
+		t.Errorf("Expected next ID to stay 5, got %d", db.NextID)
+	}
+
+	if err := db.RestoreTask(archived[0]); err == nil {
+		t.Error("Expected restoring an existing ID to fail")
+	}
+}
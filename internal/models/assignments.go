@@ -0,0 +1,119 @@
+package models
+
+import "sort"
+
+// AssigneeLoad is the work held by one assignee, across one or more
+// projects. Open counts the pending and in-progress tasks, and Projects the
+// open tasks in each project.
+type AssigneeLoad struct {
+	Assignee   string         `json:"assignee"`
+	Open       int            `json:"open"`
+	Pending    int            `json:"pending"`
+	InProgress int            `json:"in_progress"`
+	Done       int            `json:"done"`
+	Cancelled  int            `json:"cancelled"`
+	Projects   map[string]int `json:"projects"`
+	OverLimit  bool           `json:"over_limit"`
+}
+
+// NewAssigneeLoad creates an empty load for assignee
+func NewAssigneeLoad(assignee string) *AssigneeLoad {
+	return &AssigneeLoad{Assignee: assignee, Projects: make(map[string]int)}
+}
+
+// Count adds a task of the named project to the load
+func (l *AssigneeLoad) Count(projectName string, task *Task) {
+	switch task.Status {
+	case StatusDone:
+		l.Done++
+		return
+	case StatusCancelled:
+		l.Cancelled++
+		return
+	case StatusInProgress:
+		l.InProgress++
+	default:
+		l.Pending++
+	}
+	l.Open++
+	l.Projects[projectName]++
+}
+
+// AssignmentReport summarizes tasks by assignee against a per-assignee limit
+// on open tasks. A limit of zero or less means no limit. Unassigned is the
+// number of open unassigned tasks; UnassignedLoad breaks them down by status.
+type AssignmentReport struct {
+	Limit          int             `json:"limit"`
+	Assignees      []*AssigneeLoad `json:"assignees"`
+	Unassigned     int             `json:"unassigned"`
+	UnassignedLoad *AssigneeLoad   `json:"unassigned_load"`
+	OverLimit      []string        `json:"over_limit"`
+}
+
+// BuildAssignmentReport counts the tasks of each assignee in the given
+// projects, keyed by project name, in a single pass over each project. Done
+// and cancelled tasks are counted but don't add to the open load. Assignees are ordered by
+// open task count, highest first, then by name.
+func BuildAssignmentReport(projects map[string]*ProjectDatabase, limit int) *AssignmentReport {
+	report := &AssignmentReport{
+		Limit:          limit,
+		Assignees:      []*AssigneeLoad{},
+		UnassignedLoad: NewAssigneeLoad(""),
+		OverLimit:      []string{},
+	}
+
+	loads := make(map[string]*AssigneeLoad)
+	for projectName, db := range projects {
+		for _, task := range db.Tasks {
+			if task.AssignedTo == "" {
+				report.UnassignedLoad.Count(projectName, task)
+				continue
+			}
+
+			load, exists := loads[task.AssignedTo]
+			if !exists {
+				load = NewAssigneeLoad(task.AssignedTo)
+				loads[task.AssignedTo] = load
+				report.Assignees = append(report.Assignees, load)
+			}
+			load.Count(projectName, task)
+		}
+	}
+	report.Unassigned = report.UnassignedLoad.Open
+
+	sort.Slice(report.Assignees, func(i, j int) bool {
+		a, b := report.Assignees[i], report.Assignees[j]
+		if a.Open != b.Open {
+			return a.Open > b.Open
+		}
+		return a.Assignee < b.Assignee
+	})
+
+	for _, load := range report.Assignees {
+		if limit > 0 && load.Open > limit {
+			load.OverLimit = true
+			report.OverLimit = append(report.OverLimit, load.Assignee)
+		}
+	}
+
+	return report
+}
+
+// BuildAssigneeLoad counts the tasks of one assignee in the given projects and
+// returns them by project name, in board order. Projects without any of the
+// assignee's tasks are left out.
+func BuildAssigneeLoad(assignee string, projects map[string]*ProjectDatabase, limit int) (*AssigneeLoad, map[string][]*Task) {
+	load := NewAssigneeLoad(assignee)
+	tasks := make(map[string][]*Task)
+	for projectName, db := range projects {
+		matched := db.ListTasks(&TaskFilter{AssignedTo: &assignee})
+		for _, task := range matched {
+			load.Count(projectName, task)
+		}
+		if len(matched) > 0 {
+			tasks[projectName] = matched
+		}
+	}
+	load.OverLimit = limit > 0 && load.Open > limit
+	return load, tasks
+}
@@ -0,0 +1,86 @@
+package models
+
+import "testing"
+
+func TestBuildAssignmentReportSkewed(t *testing.T) {
+	newDB := func(assignees ...string) *ProjectDatabase {
+		db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+		for _, assignee := range assignees {
+			task := NewTask(db.NextID, "Task")
+			task.AssignedTo = assignee
+			if err := db.AddTask(task); err != nil {
+				t.Fatalf("AddTask failed: %v", err)
+			}
+		}
+		return db
+	}
+
+	alpha := newDB("agent-a", "agent-a", "agent-a", "agent-b", "")
+	beta := newDB("agent-a", "agent-a", "agent-c", "agent-b")
+
+	// Done tasks don't count towards anyone's load
+	done, _ := beta.GetTask(4)
+	done.UpdateStatus(StatusDone)
+	started, _ := alpha.GetTask(1)
+	started.UpdateStatus(StatusInProgress)
+
+	report := BuildAssignmentReport(map[string]*ProjectDatabase{"alpha": alpha, "beta": beta}, 3)
+
+	if len(report.Assignees) != 3 {
+		t.Fatalf("Expected 3 assignees, got %d", len(report.Assignees))
+	}
+	top := report.Assignees[0]
+	if top.Assignee != "agent-a" || top.Open != 5 || top.InProgress != 1 || !top.OverLimit {
+		t.Errorf("Expected agent-a with 5 open tasks over the limit, got %+v", top)
+	}
+	if top.Projects["alpha"] != 3 || top.Projects["beta"] != 2 {
+		t.Errorf("Unexpected per-project counts: %v", top.Projects)
+	}
+	if report.Assignees[1].Assignee != "agent-b" || report.Assignees[1].Open != 1 || report.Assignees[1].OverLimit {
+		t.Errorf("Expected agent-b with 1 open task, got %+v", report.Assignees[1])
+	}
+	if report.Assignees[2].Assignee != "agent-c" {
+		t.Errorf("Expected ties ordered by name, got %+v", report.Assignees[2])
+	}
+	if report.Assignees[1].Done != 1 || report.Assignees[1].Pending != 1 {
+		t.Errorf("Expected agent-b's done task counted separately, got %+v", report.Assignees[1])
+	}
+	if report.Unassigned != 1 || report.UnassignedLoad.Pending != 1 {
+		t.Errorf("Expected 1 unassigned task, got %d", report.Unassigned)
+	}
+	if len(report.OverLimit) != 1 || report.OverLimit[0] != "agent-a" {
+		t.Errorf("Expected only agent-a over the limit, got %v", report.OverLimit)
+	}
+
+	// Without a limit nobody is flagged
+	report = BuildAssignmentReport(map[string]*ProjectDatabase{"alpha": alpha}, 0)
+	if len(report.OverLimit) != 0 || report.Assignees[0].OverLimit {
+		t.Errorf("Expected no flags without a limit, got %v", report.OverLimit)
+	}
+}
+
+func TestBuildAssigneeLoad(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for _, assignee := range []string{"agent-a", "agent-b", "agent-a", "agent-a"} {
+		task := NewTask(db.NextID, "Task")
+		task.AssignedTo = assignee
+		if err := db.AddTask(task); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+	done, _ := db.GetTask(3)
+	done.UpdateStatus(StatusDone)
+
+	load, tasks := BuildAssigneeLoad("agent-a", map[string]*ProjectDatabase{"alpha": db}, 1)
+	if load.Open != 2 || load.Pending != 2 || load.Done != 1 || !load.OverLimit {
+		t.Errorf("Expected agent-a with 2 open and 1 done task over the limit, got %+v", load)
+	}
+	if got := taskIDs(tasks["alpha"]); len(got) != 3 || got[0] != 1 || got[1] != 3 || got[2] != 4 {
+		t.Errorf("Expected tasks 1, 3 and 4, got %v", got)
+	}
+
+	load, tasks = BuildAssigneeLoad("nobody", map[string]*ProjectDatabase{"alpha": db}, 0)
+	if load.Open != 0 || load.Done != 0 || len(tasks) != 0 {
+		t.Errorf("Expected no tasks for an unknown assignee, got %+v %v", load, tasks)
+	}
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// Attachment links a task to an external resource such as a pull request,
+// design doc or screenshot. Only the link is stored, never the content.
+type Attachment struct {
+	Name    string    `json:"name"`
+	URL     string    `json:"url"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// ValidateAttachmentURL performs a minimal sanity check: the URL must be an
+// http, https or file URL, and web links must name a host
+func ValidateAttachmentURL(rawURL string) error {
+	if strings.ContainsAny(rawURL, " \t\n") {
+		return fmt.Errorf("invalid URL '%s': must not contain whitespace", rawURL)
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL '%s': %v", rawURL, err)
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "":
+		return fmt.Errorf("invalid URL '%s': missing scheme such as https://", rawURL)
+	case "http", "https":
+		if parsed.Host == "" {
+			return fmt.Errorf("invalid URL '%s': missing host", rawURL)
+		}
+	case "file":
+		if parsed.Path == "" {
+			return fmt.Errorf("invalid URL '%s': missing path", rawURL)
+		}
+	default:
+		return fmt.Errorf("invalid URL '%s': unsupported scheme '%s' (use http, https or file)", rawURL, parsed.Scheme)
+	}
+
+	return nil
+}
+
+// DefaultAttachmentName derives a name from the last path segment of the URL,
+// falling back to its host
+func DefaultAttachmentName(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	if base := path.Base(parsed.Path); base != "." && base != "/" {
+		return base
+	}
+	if parsed.Host != "" {
+		return parsed.Host
+	}
+	return rawURL
+}
+
+// GetAttachment returns the attachment with the given name, if any
+func (t *Task) GetAttachment(name string) (*Attachment, bool) {
+	for i := range t.Attachments {
+		if t.Attachments[i].Name == name {
+			return &t.Attachments[i], true
+		}
+	}
+	return nil, false
+}
+
+// AddAttachment links a URL to the task under a unique name and updates the timestamp
+func (t *Task) AddAttachment(name, rawURL string) (*Attachment, error) {
+	name = strings.TrimSpace(name)
+	rawURL = strings.TrimSpace(rawURL)
+
+	if err := ValidateAttachmentURL(rawURL); err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = DefaultAttachmentName(rawURL)
+	}
+	if _, exists := t.GetAttachment(name); exists {
+		return nil, fmt.Errorf("task #%d already has an attachment named '%s'", t.ID, name)
+	}
+
+	now := time.Now().UTC()
+	t.Attachments = append(t.Attachments, Attachment{Name: name, URL: rawURL, AddedAt: now})
+	t.UpdatedAt = now
+	return &t.Attachments[len(t.Attachments)-1], nil
+}
+
+// RemoveAttachment removes the named attachment and updates the timestamp
+func (t *Task) RemoveAttachment(name string) (*Attachment, error) {
+	for i, attachment := range t.Attachments {
+		if attachment.Name == name {
+			t.Attachments = append(t.Attachments[:i:i], t.Attachments[i+1:]...)
+			if len(t.Attachments) == 0 {
+				t.Attachments = nil
+			}
+			t.UpdatedAt = time.Now().UTC()
+			return &attachment, nil
+		}
+	}
+	return nil, fmt.Errorf("task #%d has no attachment named '%s'", t.ID, name)
+}
+
+// attachmentNames lists attachment names for history entries
+func attachmentNames(attachments []Attachment) string {
+	names := make([]string, len(attachments))
+	for i, attachment := range attachments {
+		names[i] = attachment.Name
+	}
+	return strings.Join(names, ",")
+}
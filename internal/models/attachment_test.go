@@ -0,0 +1,102 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateAttachmentURL(t *testing.T) {
+	valid := []string{
+		"https://github.com/org/repo/pull/42",
+		"http://localhost:8080/design",
+		"file:///home/me/screenshot.png",
+	}
+	for _, rawURL := range valid {
+		if err := ValidateAttachmentURL(rawURL); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", rawURL, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"github.com/org/repo",
+		"https://",
+		"https://example.com/has space",
+		"javascript:alert(1)",
+	}
+	for _, rawURL := range invalid {
+		if err := ValidateAttachmentURL(rawURL); err == nil {
+			t.Errorf("Expected %q to be rejected", rawURL)
+		}
+	}
+}
+
+func TestTaskAttachments(t *testing.T) {
+	task := NewTask(1, "Review auth flow")
+
+	pr, err := task.AddAttachment("pr", "https://github.com/org/repo/pull/42")
+	if err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	if pr.Name != "pr" || pr.AddedAt.IsZero() {
+		t.Errorf("Unexpected attachment: %+v", pr)
+	}
+
+	doc, err := task.AddAttachment("", "https://docs.example.com/design/auth.md")
+	if err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	if doc.Name != "auth.md" {
+		t.Errorf("Expected name derived from the URL, got %q", doc.Name)
+	}
+
+	if _, err := task.AddAttachment("pr", "https://github.com/org/repo/pull/43"); err == nil {
+		t.Error("Expected error for duplicate attachment name")
+	}
+	if _, err := task.AddAttachment("bad", "not a url"); err == nil {
+		t.Error("Expected error for invalid URL")
+	}
+
+	// Attachments round-trip through JSON
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded Task
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(decoded.Attachments) != 2 || decoded.Attachments[0].URL != pr.URL || !decoded.Attachments[1].AddedAt.Equal(doc.AddedAt) {
+		t.Errorf("Attachments did not round-trip: %+v", decoded.Attachments)
+	}
+
+	// Clones don't share the attachment slice
+	clone := task.Clone()
+	clone.Attachments[0].Name = "changed"
+	if task.Attachments[0].Name != "pr" {
+		t.Error("Expected Clone to copy attachments")
+	}
+
+	before := task.Clone()
+	if _, err := task.RemoveAttachment("pr"); err != nil {
+		t.Fatalf("RemoveAttachment failed: %v", err)
+	}
+	if len(task.Attachments) != 1 || task.Attachments[0].Name != "auth.md" {
+		t.Errorf("Expected only auth.md to remain, got %+v", task.Attachments)
+	}
+	if _, err := task.RemoveAttachment("pr"); err == nil {
+		t.Error("Expected error detaching a missing attachment")
+	}
+
+	events := DiffTasks(before, task, "tester")
+	if len(events) != 1 || events[0].Field != "attachments" || events[0].Before != "pr,auth.md" || events[0].After != "auth.md" {
+		t.Errorf("Expected an attachments history event, got %+v", events)
+	}
+
+	if _, err := task.RemoveAttachment("auth.md"); err != nil {
+		t.Fatalf("RemoveAttachment failed: %v", err)
+	}
+	if task.Attachments != nil {
+		t.Error("Expected attachments to be nil once empty so they are omitted from JSON")
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import "fmt"
+
+// sortByPosition puts tasks in board order: tasks placed on the board by
+// position, then the rest by ID
+func sortByPosition(tasks []*Task) {
+	sorter := &TaskSorter{Field: "position"}
+	sorter.Sort(tasks)
+}
+
+// ReorderColumn saves the order of a board column: the listed tasks, which
+// must all have status, take the top positions in the given order, and the
+// column's other tasks follow in their current order. UpdatedAt is left
+// alone since moving a card doesn't change the task.
+func (db *ProjectDatabase) ReorderColumn(status Status, ids []int) error {
+	if !IsValidStatus(string(status)) {
+		return fmt.Errorf("invalid status: %s", status)
+	}
+
+	listed := make(map[int]bool, len(ids))
+	column := make([]*Task, 0, len(ids))
+	for _, id := range ids {
+		if listed[id] {
+			return fmt.Errorf("task #%d is listed more than once", id)
+		}
+		listed[id] = true
+
+		task, err := db.GetTask(id)
+		if err != nil {
+			return err
+		}
+		if task.Status != status {
+			return fmt.Errorf("task #%d is %s, not %s", id, task.Status, status)
+		}
+		column = append(column, task)
+	}
+
+	var rest []*Task
+	for _, task := range db.Tasks {
+		if task.Status == status && !listed[task.ID] {
+			rest = append(rest, task)
+		}
+	}
+	sortByPosition(rest)
+
+	for i, task := range append(column, rest...) {
+		task.Position = i + 1
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestReorderColumn(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for _, title := range []string{"One", "Two", "Three", "Four", "Five"} {
+		if err := db.AddTask(NewTask(db.NextID, title)); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+	done, _ := db.GetTask(5)
+	done.UpdateStatus(StatusDone)
+	updatedAt := done.UpdatedAt
+
+	if err := db.ReorderColumn(StatusPending, []int{3, 1}); err != nil {
+		t.Fatalf("ReorderColumn failed: %v", err)
+	}
+
+	// Listed tasks first, then the rest of the column in ID order, with the
+	// done column untouched
+	if got := taskIDs(db.ListTasks(nil)); fmt.Sprint(got) != "[3 1 2 4 5]" {
+		t.Errorf("Expected board order [3 1 2 4 5], got %v", got)
+	}
+	if done.Position != 0 || !done.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("Expected other columns to be left alone, got %+v", done)
+	}
+
+	// Moving a card within the column keeps the others' relative order
+	if err := db.ReorderColumn(StatusPending, []int{4}); err != nil {
+		t.Fatalf("ReorderColumn failed: %v", err)
+	}
+	if got := taskIDs(db.ListTasks(nil)); fmt.Sprint(got) != "[4 3 1 2 5]" {
+		t.Errorf("Expected board order [4 3 1 2 5], got %v", got)
+	}
+
+	// Changing status sends a task to the end of its new column
+	moved, _ := db.GetTask(4)
+	moved.UpdateStatus(StatusInProgress)
+	if moved.Position != 0 {
+		t.Errorf("Expected a status change to clear the position, got %d", moved.Position)
+	}
+}
+
+func TestReorderColumnRejects(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for _, title := range []string{"One", "Two"} {
+		if err := db.AddTask(NewTask(db.NextID, title)); err != nil {
+			t.Fatalf("Failed to add task: %v", err)
+		}
+	}
+	task, _ := db.GetTask(2)
+	task.UpdateStatus(StatusInProgress)
+
+	for name, tt := range map[string]struct {
+		status Status
+		ids    []int
+	}{
+		"unknown status":     {"sideways", []int{1}},
+		"unknown task":       {StatusPending, []int{9}},
+		"duplicate task":     {StatusPending, []int{1, 1}},
+		"task not in column": {StatusPending, []int{1, 2}},
+	} {
+		if err := db.ReorderColumn(tt.status, tt.ids); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	for _, task := range db.Tasks {
+		if task.Position != 0 {
+			t.Errorf("Expected a rejected reorder to change nothing, #%d is at %d", task.ID, task.Position)
+		}
+	}
+}
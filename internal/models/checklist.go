@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChecklistItem is one step of a task's checklist. Unlike a subtask it has no
+// status, owner or history of its own.
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// ChecklistProgress counts the checked items of a task's checklist
+type ChecklistProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// ChecklistProgress reports how many checklist items are done
+func (t *Task) ChecklistProgress() ChecklistProgress {
+	progress := ChecklistProgress{Total: len(t.Checklist)}
+	for _, item := range t.Checklist {
+		if item.Done {
+			progress.Done++
+		}
+	}
+	return progress
+}
+
+// AddChecklistItem appends an unchecked item and updates the timestamp
+func (t *Task) AddChecklistItem(text string) (*ChecklistItem, error) {
+	text = SanitizeTitle(text)
+	if text == "" {
+		return nil, fmt.Errorf("checklist item text cannot be empty")
+	}
+
+	t.Checklist = append(t.Checklist, ChecklistItem{Text: text})
+	t.UpdatedAt = time.Now().UTC()
+	return &t.Checklist[len(t.Checklist)-1], nil
+}
+
+// ToggleChecklistItem flips the item at a 1-based index, as numbered by
+// display-task, and updates the timestamp
+func (t *Task) ToggleChecklistItem(index int) (*ChecklistItem, error) {
+	if index < 1 || index > len(t.Checklist) {
+		if len(t.Checklist) == 0 {
+			return nil, fmt.Errorf("task #%d has no checklist items", t.ID)
+		}
+		return nil, fmt.Errorf("checklist item %d not found; task #%d has items 1-%d", index, t.ID, len(t.Checklist))
+	}
+
+	item := &t.Checklist[index-1]
+	item.Done = !item.Done
+	t.UpdatedAt = time.Now().UTC()
+	return item, nil
+}
+
+// checklistSummary renders a checklist for history entries
+func checklistSummary(checklist []ChecklistItem) string {
+	items := make([]string, len(checklist))
+	for i, item := range checklist {
+		mark := "[ ]"
+		if item.Done {
+			mark = "[x]"
+		}
+		items[i] = mark + " " + item.Text
+	}
+	return strings.Join(items, "; ")
+}
+
+// MarshalJSON adds the computed checklist_progress to tasks with a checklist,
+// and total_time_seconds to tasks with time entries
+func (t Task) MarshalJSON() ([]byte, error) {
+	type taskFields Task
+	if len(t.Checklist) == 0 && len(t.TimeEntries) == 0 {
+		return json.Marshal(taskFields(t))
+	}
+
+	var progress *ChecklistProgress
+	if len(t.Checklist) > 0 {
+		computed := t.ChecklistProgress()
+		progress = &computed
+	}
+	var totalSeconds *int64
+	if len(t.TimeEntries) > 0 {
+		seconds := int64(t.TotalTime(time.Now()).Seconds())
+		totalSeconds = &seconds
+	}
+	return json.Marshal(struct {
+		taskFields
+		ChecklistProgress *ChecklistProgress `json:"checklist_progress,omitempty"`
+		TotalTimeSeconds  *int64             `json:"total_time_seconds,omitempty"`
+	}{taskFields(t), progress, totalSeconds})
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTaskChecklistAddAndToggle(t *testing.T) {
+	task := NewTask(1, "Ship release")
+
+	if _, err := task.AddChecklistItem("  "); err == nil {
+		t.Error("Expected an empty item to be rejected")
+	}
+	if _, err := task.ToggleChecklistItem(1); err == nil {
+		t.Error("Expected toggling an empty checklist to fail")
+	}
+
+	for _, text := range []string{"Tag version", "Publish notes", "Announce"} {
+		if _, err := task.AddChecklistItem(text); err != nil {
+			t.Fatalf("Failed to add item: %v", err)
+		}
+	}
+
+	item, err := task.ToggleChecklistItem(2)
+	if err != nil {
+		t.Fatalf("Failed to toggle item: %v", err)
+	}
+	if !item.Done || item.Text != "Publish notes" {
+		t.Errorf("Expected item 2 checked, got %+v", item)
+	}
+
+	if progress := task.ChecklistProgress(); progress != (ChecklistProgress{Done: 1, Total: 3}) {
+		t.Errorf("Expected 1/3 done, got %+v", progress)
+	}
+
+	// Toggling again unchecks
+	if item, _ := task.ToggleChecklistItem(2); item.Done {
+		t.Error("Expected a second toggle to uncheck the item")
+	}
+
+	for _, index := range []int{0, 4, -1} {
+		if _, err := task.ToggleChecklistItem(index); err == nil {
+			t.Errorf("Expected index %d to be out of range", index)
+		}
+	}
+}
+
+func TestTaskChecklistRoundTrip(t *testing.T) {
+	task := NewTask(1, "Ship release")
+	task.AddChecklistItem("Tag version")
+	task.AddChecklistItem("Publish notes")
+	task.ToggleChecklistItem(1)
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("Failed to marshal task: %v", err)
+	}
+	if !strings.Contains(string(data), `"checklist_progress":{"done":1,"total":2}`) {
+		t.Errorf("Expected computed checklist_progress in JSON, got %s", data)
+	}
+
+	var loaded Task
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Failed to unmarshal task: %v", err)
+	}
+	if len(loaded.Checklist) != 2 || !loaded.Checklist[0].Done || loaded.Checklist[1].Done {
+		t.Errorf("Checklist did not round-trip: %+v", loaded.Checklist)
+	}
+	if loaded.Title != task.Title || loaded.ID != task.ID {
+		t.Errorf("Task fields did not round-trip: %+v", loaded)
+	}
+
+	// Tasks without a checklist carry no progress
+	data, _ = json.Marshal(NewTask(2, "Plain"))
+	if strings.Contains(string(data), "checklist") {
+		t.Errorf("Expected no checklist fields for a plain task, got %s", data)
+	}
+}
+
+func TestTaskChecklistHistory(t *testing.T) {
+	task := NewTask(1, "Ship release")
+	before := task.Clone()
+	task.AddChecklistItem("Tag version")
+
+	events := DiffTasks(before, task, "tester")
+	if len(events) != 1 || events[0].Field != "checklist" || events[0].After != "[ ] Tag version" {
+		t.Errorf("Expected one checklist event, got %+v", events)
+	}
+
+	// Clone must not share the checklist
+	clone := task.Clone()
+	clone.Checklist[0].Done = true
+	if task.Checklist[0].Done {
+		t.Error("Expected Clone to copy the checklist")
+	}
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Comment is a note added to a task over time. Unlike the description,
+// comments are never edited, so they keep the task's context as it changes.
+type Comment struct {
+	Author    string    `json:"author,omitempty"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddComment appends a comment by author and updates the timestamp
+func (t *Task) AddComment(author, text string) (*Comment, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("comment text cannot be empty")
+	}
+
+	now := time.Now().UTC()
+	t.Comments = append(t.Comments, Comment{Author: strings.TrimSpace(author), Text: text, CreatedAt: now})
+	t.UpdatedAt = now
+	return &t.Comments[len(t.Comments)-1], nil
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAddComment(t *testing.T) {
+	task := NewTask(1, "Commented")
+	before := task.UpdatedAt
+
+	if _, err := task.AddComment("alice", "   "); err == nil {
+		t.Error("Expected an empty comment to be rejected")
+	}
+
+	comment, err := task.AddComment(" alice ", " Waiting on review ")
+	if err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	if comment.Author != "alice" || comment.Text != "Waiting on review" || comment.CreatedAt.IsZero() {
+		t.Errorf("Unexpected comment: %+v", comment)
+	}
+	if task.UpdatedAt.Before(before) {
+		t.Error("Expected UpdatedAt to be refreshed")
+	}
+
+	if _, err := task.AddComment("", "Second"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+	clone := task.Clone()
+	clone.Comments[0].Text = "changed"
+	if task.Comments[0].Text != "Waiting on review" {
+		t.Error("Expected Clone to copy the comments")
+	}
+}
+
+func TestCommentsJSONRoundTrip(t *testing.T) {
+	task := NewTask(1, "Commented")
+	task.AddComment("alice", "First")
+	task.AddComment("bob", "Second")
+
+	data, err := task.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var decoded Task
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if len(decoded.Comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %d", len(decoded.Comments))
+	}
+	for i, comment := range decoded.Comments {
+		original := task.Comments[i]
+		if comment.Author != original.Author || comment.Text != original.Text || !comment.CreatedAt.Equal(original.CreatedAt) {
+			t.Errorf("Comment %d changed in the round trip: %+v != %+v", i, comment, original)
+		}
+	}
+}
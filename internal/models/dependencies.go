@@ -0,0 +1,125 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SetDependsOn replaces the IDs of the tasks this task depends on, sorted and
+// without duplicates, and updates the timestamp. Check the IDs with
+// ProjectDatabase.ValidateDependencies first.
+func (t *Task) SetDependsOn(ids []int) {
+	t.DependsOn = normalizeTaskIDs(ids)
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// ValidateDependencies checks that the task with taskID may depend on ids:
+// every ID must be an existing task other than taskID itself, and the new
+// edges must not close a cycle. taskID need not exist yet, as when creating
+// a task.
+func (db *ProjectDatabase) ValidateDependencies(taskID int, ids []int) error {
+	index := db.taskIndex()
+	for _, id := range ids {
+		if id == taskID {
+			return fmt.Errorf("task #%d cannot depend on itself", taskID)
+		}
+		if _, exists := index[id]; !exists {
+			return fmt.Errorf("dependency #%d not found", id)
+		}
+	}
+
+	// A cycle exists if taskID is reachable from one of its new dependencies
+	visited := make(map[int]bool)
+	var path []int
+	var reaches func(id int) bool
+	reaches = func(id int) bool {
+		if id == taskID {
+			return true
+		}
+		if visited[id] {
+			return false
+		}
+		visited[id] = true
+
+		task, exists := index[id]
+		if !exists {
+			return false
+		}
+		for _, next := range task.DependsOn {
+			path = append(path, next)
+			if reaches(next) {
+				return true
+			}
+			path = path[:len(path)-1]
+		}
+		return false
+	}
+
+	for _, id := range ids {
+		path = []int{id}
+		if reaches(id) {
+			cycle := []string{"#" + strconv.Itoa(taskID)}
+			for _, step := range path {
+				cycle = append(cycle, "#"+strconv.Itoa(step))
+			}
+			return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	return nil
+}
+
+// UnmetDependencies returns the IDs of the task's dependencies that are not
+// closed yet. Dependencies on tasks that have since been deleted or cancelled
+// are ignored.
+func (db *ProjectDatabase) UnmetDependencies(task *Task) []int {
+	index := db.taskIndex()
+	var unmet []int
+	for _, id := range task.DependsOn {
+		if dependency, exists := index[id]; exists && !dependency.IsClosed() {
+			unmet = append(unmet, id)
+		}
+	}
+	return unmet
+}
+
+// GetBlockedTasks returns copies of the open tasks that have dependencies
+// which are not all done
+func (db *ProjectDatabase) GetBlockedTasks() []*Task {
+	var blocked []*Task
+	for _, task := range db.Tasks {
+		if !task.IsClosed() && len(db.UnmetDependencies(task)) > 0 {
+			blocked = append(blocked, task.Clone())
+		}
+	}
+	return blocked
+}
+
+// normalizeTaskIDs sorts ids and drops duplicates
+func normalizeTaskIDs(ids []int) []int {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	sorted := append([]int(nil), ids...)
+	sort.Ints(sorted)
+	unique := sorted[:1]
+	for _, id := range sorted[1:] {
+		if id != unique[len(unique)-1] {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// formatTaskIDs renders task IDs for history entries
+func formatTaskIDs(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func newDependencyDB(t *testing.T, count int) *ProjectDatabase {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for i := 0; i < count; i++ {
+		if err := db.AddTask(NewTask(db.NextID, "Task")); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+	return db
+}
+
+func TestValidateDependencies(t *testing.T) {
+	db := newDependencyDB(t, 4)
+
+	// 1 -> 2 -> 3
+	task1, _ := db.GetTask(1)
+	task1.SetDependsOn([]int{2})
+	task2, _ := db.GetTask(2)
+	task2.SetDependsOn([]int{3})
+
+	tests := []struct {
+		name    string
+		taskID  int
+		deps    []int
+		wantErr string
+	}{
+		{"independent task", 4, []int{1, 3}, ""},
+		{"new task", 5, []int{1}, ""},
+		{"self", 4, []int{4}, "cannot depend on itself"},
+		{"missing", 4, []int{9}, "#9 not found"},
+		{"direct cycle", 3, []int{2}, "cycle: #3 -> #2 -> #3"},
+		{"indirect cycle", 3, []int{1}, "cycle: #3 -> #1 -> #2 -> #3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := db.ValidateDependencies(tt.taskID, tt.deps)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSetDependsOnNormalizes(t *testing.T) {
+	task := NewTask(1, "Task")
+	task.SetDependsOn([]int{5, 3, 5})
+	if len(task.DependsOn) != 2 || task.DependsOn[0] != 3 || task.DependsOn[1] != 5 {
+		t.Errorf("Expected [3 5], got %v", task.DependsOn)
+	}
+
+	task.SetDependsOn(nil)
+	if task.DependsOn != nil {
+		t.Errorf("Expected dependencies cleared, got %v", task.DependsOn)
+	}
+}
+
+func TestGetBlockedTasks(t *testing.T) {
+	db := newDependencyDB(t, 4)
+
+	blocked, _ := db.GetTask(1)
+	blocked.SetDependsOn([]int{2, 3})
+	unblocked, _ := db.GetTask(4)
+	unblocked.SetDependsOn([]int{3})
+	done, _ := db.GetTask(3)
+	done.UpdateStatus(StatusDone)
+
+	if unmet := db.UnmetDependencies(blocked); len(unmet) != 1 || unmet[0] != 2 {
+		t.Errorf("Expected task #1 blocked by #2, got %v", unmet)
+	}
+
+	tasks := db.GetBlockedTasks()
+	if len(tasks) != 1 || tasks[0].ID != 1 {
+		t.Fatalf("Expected only task #1 blocked, got %v", taskIDs(tasks))
+	}
+
+	// Deleted dependencies no longer block
+	if err := db.DeleteTask(2); err != nil {
+		t.Fatalf("DeleteTask failed: %v", err)
+	}
+	if tasks := db.GetBlockedTasks(); len(tasks) != 0 {
+		t.Errorf("Expected no blocked tasks, got %v", taskIDs(tasks))
+	}
+}
@@ -0,0 +1,187 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TaskEvent records a single change made to a task
+type TaskEvent struct {
+	TaskID    int       `json:"task_id"`
+	Field     string    `json:"field"`
+	Before    string    `json:"before"`
+	After     string    `json:"after"`
+	Actor     string    `json:"actor"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// History event fields for lifecycle events that aren't plain field changes
+const (
+	EventFieldCreated = "created"
+	EventFieldDeleted = "deleted"
+	EventFieldNote    = "note"
+)
+
+// DiffTasks returns one event per field that differs between before and after
+func DiffTasks(before, after *Task, actor string) []*TaskEvent {
+	var events []*TaskEvent
+	now := time.Now().UTC()
+
+	add := func(field, oldValue, newValue string) {
+		if oldValue == newValue {
+			return
+		}
+		events = append(events, &TaskEvent{
+			TaskID:    after.ID,
+			Field:     field,
+			Before:    oldValue,
+			After:     newValue,
+			Actor:     actor,
+			Timestamp: now,
+		})
+	}
+
+	add("title", before.Title, after.Title)
+	add("description", before.Description, after.Description)
+	add("status", string(before.Status), string(after.Status))
+	add("priority", string(before.Priority), string(after.Priority))
+	add("assigned_to", before.AssignedTo, after.AssignedTo)
+	add("tags", strings.Join(before.Tags, ","), strings.Join(after.Tags, ","))
+	add("start_date", formatOptionalTime(before.StartDate), formatOptionalTime(after.StartDate))
+	add("due_date", formatOptionalTime(before.DueDate), formatOptionalTime(after.DueDate))
+	add("resolution", string(before.Resolution), string(after.Resolution))
+	add("attachments", attachmentNames(before.Attachments), attachmentNames(after.Attachments))
+	add("checklist", checklistSummary(before.Checklist), checklistSummary(after.Checklist))
+	add("depends_on", formatTaskIDs(before.DependsOn), formatTaskIDs(after.DependsOn))
+	add("recurrence", before.Recurrence, after.Recurrence)
+
+	return events
+}
+
+// formatOptionalTime renders an optional timestamp for history entries
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// RecordTaskCreated appends a creation event for a task
+func (db *ProjectDatabase) RecordTaskCreated(task *Task, actor string) {
+	db.History = append(db.History, &TaskEvent{
+		TaskID:    task.ID,
+		Field:     EventFieldCreated,
+		Before:    "",
+		After:     task.Title,
+		Actor:     actor,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// RecordTaskChanges appends an event for every field changed between before and after
+func (db *ProjectDatabase) RecordTaskChanges(before, after *Task, actor string) {
+	db.History = append(db.History, DiffTasks(before, after, actor)...)
+}
+
+// RecordTaskNote appends a free-form note to a task's history
+func (db *ProjectDatabase) RecordTaskNote(task *Task, note, actor string) {
+	db.History = append(db.History, &TaskEvent{
+		TaskID:    task.ID,
+		Field:     EventFieldNote,
+		Before:    "",
+		After:     note,
+		Actor:     actor,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// RecordTaskDeleted appends a deletion event for a task
+func (db *ProjectDatabase) RecordTaskDeleted(task *Task, actor string) {
+	db.History = append(db.History, &TaskEvent{
+		TaskID:    task.ID,
+		Field:     EventFieldDeleted,
+		Before:    task.Title,
+		After:     "",
+		Actor:     actor,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// GetTaskHistory returns a task's events in chronological order, optionally
+// restricted to events after since
+func (db *ProjectDatabase) GetTaskHistory(taskID int, since *time.Time) []*TaskEvent {
+	events := make([]*TaskEvent, 0)
+	for _, event := range db.History {
+		if event.TaskID != taskID {
+			continue
+		}
+		if since != nil && !event.Timestamp.After(*since) {
+			continue
+		}
+		copied := *event
+		events = append(events, &copied)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+
+	return events
+}
+
+// eventFields lists the fields that history events can record
+var eventFields = []string{
+	"title", "description", "status", "priority", "assigned_to", "tags",
+	"start_date", "due_date", "resolution", "attachments", "checklist", "depends_on",
+	"recurrence",
+	EventFieldCreated, EventFieldDeleted, EventFieldNote,
+}
+
+// IsValidEventField checks if a field name can appear in task history
+func IsValidEventField(field string) bool {
+	for _, valid := range eventFields {
+		if field == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// EventFields returns the field names that can appear in task history
+func EventFields() []string {
+	return append([]string(nil), eventFields...)
+}
+
+// FilterEventsByField keeps only events for one of fields; no fields keeps all
+func FilterEventsByField(events []*TaskEvent, fields []string) []*TaskEvent {
+	if len(fields) == 0 {
+		return events
+	}
+
+	filtered := make([]*TaskEvent, 0, len(events))
+	for _, event := range events {
+		for _, field := range fields {
+			if event.Field == field {
+				filtered = append(filtered, event)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// PageEvents returns up to limit events starting at offset. A limit of 0 or
+// less means no limit; an offset past the end returns no events.
+func PageEvents(events []*TaskEvent, offset, limit int) []*TaskEvent {
+	if offset >= len(events) {
+		return make([]*TaskEvent, 0)
+	}
+	if offset > 0 {
+		events = events[offset:]
+	}
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events
+}
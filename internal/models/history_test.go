@@ -0,0 +1,179 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDiffTasks(t *testing.T) {
+	before := NewTaskWithDetails(1, "Original", "desc", PriorityLow)
+	after := before.Clone()
+	after.Title = "Renamed"
+	after.Status = StatusInProgress
+
+	events := DiffTasks(before, after, "agent-1")
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	if events[0].Field != "title" || events[0].Before != "Original" || events[0].After != "Renamed" {
+		t.Errorf("Unexpected title event: %+v", events[0])
+	}
+
+	if events[1].Field != "status" || events[1].Before != "pending" || events[1].After != "in_progress" {
+		t.Errorf("Unexpected status event: %+v", events[1])
+	}
+
+	if events[0].Actor != "agent-1" {
+		t.Errorf("Expected actor 'agent-1', got '%s'", events[0].Actor)
+	}
+}
+
+func TestGetTaskHistoryChronological(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	base := time.Now().Add(-time.Hour)
+
+	// Insert out of order to make sure the result is sorted
+	db.History = []*TaskEvent{
+		{TaskID: 1, Field: "status", Before: "in_progress", After: "done", Actor: "a", Timestamp: base.Add(20 * time.Minute)},
+		{TaskID: 1, Field: EventFieldCreated, After: "Task", Actor: "a", Timestamp: base},
+		{TaskID: 2, Field: "title", Before: "x", After: "y", Actor: "b", Timestamp: base.Add(5 * time.Minute)},
+		{TaskID: 1, Field: "status", Before: "pending", After: "in_progress", Actor: "a", Timestamp: base.Add(10 * time.Minute)},
+	}
+
+	events := db.GetTaskHistory(1, nil)
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events for task 1, got %d", len(events))
+	}
+
+	for i := 1; i < len(events); i++ {
+		if events[i].Timestamp.Before(events[i-1].Timestamp) {
+			t.Errorf("Events not in chronological order at index %d", i)
+		}
+	}
+
+	if events[0].Field != EventFieldCreated {
+		t.Errorf("Expected first event to be creation, got '%s'", events[0].Field)
+	}
+
+	since := base.Add(10 * time.Minute)
+	recent := db.GetTaskHistory(1, &since)
+	if len(recent) != 1 || recent[0].After != "done" {
+		t.Errorf("Expected only the completion event after since, got %+v", recent)
+	}
+}
+
+func TestTaskEventJSONFieldNames(t *testing.T) {
+	event := &TaskEvent{TaskID: 3, Field: "priority", Before: "low", After: "high", Actor: "web", Timestamp: time.Now()}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("JSON marshal failed: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("JSON unmarshal failed: %v", err)
+	}
+
+	for _, field := range []string{"task_id", "field", "before", "after", "actor", "timestamp"} {
+		if _, ok := decoded[field]; !ok {
+			t.Errorf("Expected JSON field '%s' in %s", field, data)
+		}
+	}
+}
+
+// richHistory returns a database whose task 1 was created, moved through every
+// status twice, retitled and noted, one minute apart
+func richHistory() *ProjectDatabase {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	base := time.Now().Add(-time.Hour)
+
+	changes := [][3]string{
+		{EventFieldCreated, "", "Task"},
+		{"status", "pending", "in_progress"},
+		{"title", "Task", "Renamed task"},
+		{"status", "in_progress", "done"},
+		{EventFieldNote, "", "shipped"},
+		{"status", "done", "pending"},
+		{"status", "pending", "in_progress"},
+		{"status", "in_progress", "done"},
+	}
+	for i, change := range changes {
+		db.History = append(db.History, &TaskEvent{
+			TaskID: 1, Field: change[0], Before: change[1], After: change[2],
+			Actor: "a", Timestamp: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	db.History = append(db.History, &TaskEvent{TaskID: 2, Field: "status", Before: "pending", After: "done", Actor: "b", Timestamp: base})
+	return db
+}
+
+func TestFilterEventsByField(t *testing.T) {
+	events := richHistory().GetTaskHistory(1, nil)
+
+	statuses := FilterEventsByField(events, []string{"status"})
+	if len(statuses) != 5 {
+		t.Fatalf("Expected 5 status events, got %d", len(statuses))
+	}
+	for _, event := range statuses {
+		if event.Field != "status" {
+			t.Errorf("Expected only status events, got %s", event.Field)
+		}
+	}
+	if statuses[0].After != "in_progress" || statuses[4].After != "done" {
+		t.Errorf("Expected status events in chronological order, got %s ... %s", statuses[0].After, statuses[4].After)
+	}
+
+	if got := FilterEventsByField(events, []string{"title", EventFieldNote}); len(got) != 2 {
+		t.Errorf("Expected title and note events, got %d", len(got))
+	}
+	if got := FilterEventsByField(events, nil); len(got) != len(events) {
+		t.Errorf("Expected no fields to keep all %d events, got %d", len(events), len(got))
+	}
+}
+
+func TestPageEvents(t *testing.T) {
+	events := richHistory().GetTaskHistory(1, nil)
+
+	tests := []struct {
+		offset, limit int
+		first, count  int
+	}{
+		{0, 0, 0, 8},
+		{0, 3, 0, 3},
+		{3, 3, 3, 3},
+		{6, 3, 6, 2},
+		{8, 3, 0, 0},
+		{20, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		page := PageEvents(events, tt.offset, tt.limit)
+		if len(page) != tt.count {
+			t.Errorf("PageEvents(offset=%d, limit=%d) returned %d events, expected %d", tt.offset, tt.limit, len(page), tt.count)
+			continue
+		}
+		if tt.count > 0 && page[0] != events[tt.first] {
+			t.Errorf("PageEvents(offset=%d, limit=%d) started at the wrong event", tt.offset, tt.limit)
+		}
+	}
+
+	// Paging applies after filtering
+	statuses := PageEvents(FilterEventsByField(events, []string{"status"}), 1, 2)
+	if len(statuses) != 2 || statuses[0].After != "done" || statuses[1].After != "pending" {
+		t.Errorf("Unexpected filtered page: %+v, %+v", statuses[0], statuses[1])
+	}
+}
+
+func TestIsValidEventField(t *testing.T) {
+	for _, field := range []string{"status", "assigned_to", EventFieldNote, EventFieldCreated} {
+		if !IsValidEventField(field) {
+			t.Errorf("Expected %s to be a valid history field", field)
+		}
+	}
+	if IsValidEventField("stauts") {
+		t.Error("Expected a misspelled field to be invalid")
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// NextActionable picks the task agentID should work on next at now: the
+// highest-priority pending task, oldest first among equals, that has reached
+// its start date, isn't waiting on unfinished dependencies and is either
+// unassigned or assigned to agentID. An empty agentID only considers
+// unassigned tasks. It returns a copy of the task, or nil if nothing is
+// actionable.
+func (db *ProjectDatabase) NextActionable(agentID string, now time.Time) *Task {
+	var next *Task
+	for _, task := range db.Tasks {
+		if task.Status != StatusPending || !task.IsStarted(now) {
+			continue
+		}
+		if task.AssignedTo != "" && task.AssignedTo != agentID {
+			continue
+		}
+		if len(db.UnmetDependencies(task)) > 0 {
+			continue
+		}
+		if next == nil || moreActionable(task, next) {
+			next = task
+		}
+	}
+
+	if next == nil {
+		return nil
+	}
+	return next.Clone()
+}
+
+// moreActionable orders candidates for NextActionable: higher priority, then
+// older, then lower ID
+func moreActionable(a, b *Task) bool {
+	if wa, wb := priorityWeight(a.Priority), priorityWeight(b.Priority); wa != wb {
+		return wa > wb
+	}
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextActionable(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	add := func(title string, priority Priority, age time.Duration) *Task {
+		task := NewTaskWithDetails(db.NextID, title, "", priority)
+		task.CreatedAt = now.Add(-age)
+		if err := db.AddTask(task); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+		return task
+	}
+
+	if db.NextActionable("", now) != nil {
+		t.Error("Expected nothing actionable in an empty project")
+	}
+
+	add("Newer medium", PriorityMedium, time.Hour)
+	add("Older medium", PriorityMedium, 48*time.Hour)
+	blocker := add("Blocker", PriorityLow, time.Hour)
+	blocked := add("Blocked high", PriorityHigh, time.Hour)
+	blocked.DependsOn = []int{blocker.ID}
+	theirs := add("Someone else's high", PriorityHigh, time.Hour)
+	theirs.AssignedTo = "other-agent"
+	later := add("Future high", PriorityHigh, time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+	later.StartDate = &tomorrow
+	started := add("Started high", PriorityHigh, time.Hour)
+	started.UpdateStatus(StatusInProgress)
+
+	if next := db.NextActionable("", now); next == nil || next.Title != "Older medium" {
+		t.Errorf("Expected the older medium task, got %+v", next)
+	}
+
+	mine := add("My high", PriorityHigh, time.Minute)
+	mine.AssignedTo = "claude"
+	if next := db.NextActionable("claude", now); next == nil || next.ID != mine.ID {
+		t.Errorf("Expected claude's own high-priority task, got %+v", next)
+	}
+	if next := db.NextActionable("", now); next == nil || next.Title != "Older medium" {
+		t.Errorf("Expected claude's task to be skipped without an agent, got %+v", next)
+	}
+
+	// Finishing the blocker unblocks the high-priority task
+	blocker.UpdateStatus(StatusDone)
+	if next := db.NextActionable("", now); next == nil || next.ID != blocked.ID {
+		t.Errorf("Expected the unblocked task, got %+v", next)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"time"
 )
 
@@ -15,27 +16,46 @@ type Project struct {
 	LastAccessed time.Time `json:"last_accessed"`
 	TaskCount    int       `json:"task_count"`
 	Description  string    `json:"description"`
+	// DefaultTags are added to every task created in the project
+	DefaultTags []string `json:"default_tags,omitempty"`
 }
 
 // ProjectDatabase represents the complete project database structure
 type ProjectDatabase struct {
-	Project      *Project  `json:"project"`
-	Tasks        []*Task   `json:"tasks"`
-	NextID       int       `json:"next_id"`
-	LastModified time.Time `json:"last_modified"`
-	Version      int       `json:"version"`
+	Project      *Project     `json:"project"`
+	Tasks        []*Task      `json:"tasks"`
+	NextID       int          `json:"next_id"`
+	LastModified time.Time    `json:"last_modified"`
+	Version      int          `json:"version"`
+	History      []*TaskEvent `json:"history,omitempty"`
+
+	// GitFriendly saves the database in a form suited to committing to git:
+	// tasks sorted by ID and the volatile last_modified and version omitted,
+	// so saves that change nothing produce identical files
+	GitFriendly bool `json:"git_friendly,omitempty"`
+
+	// index maps task IDs to tasks so lookups don't scan Tasks. Tasks stays
+	// the source of truth: the index is rebuilt when it falls out of step, and
+	// code that replaces entries in Tasks directly should call Reindex.
+	index map[int]*Task
 }
 
 // ProjectSummary provides a summary of project statistics
 type ProjectSummary struct {
-	Project         *Project         `json:"project"`
-	TaskCount       int              `json:"task_count"`
-	StatusCounts    map[Status]int   `json:"status_counts"`
-	PriorityCounts  map[Priority]int `json:"priority_counts"`
-	CompletedTasks  int              `json:"completed_tasks"`
-	PendingTasks    int              `json:"pending_tasks"`
-	InProgressTasks int              `json:"in_progress_tasks"`
-	LastTaskUpdate  time.Time        `json:"last_task_update"`
+	Project             *Project           `json:"project"`
+	TaskCount           int                `json:"task_count"`
+	StatusCounts        map[Status]int     `json:"status_counts"`
+	PriorityCounts      map[Priority]int   `json:"priority_counts"`
+	ResolutionCounts    map[Resolution]int `json:"resolution_counts"`
+	CompletedTasks      int                `json:"completed_tasks"`
+	CancelledTasks      int                `json:"cancelled_tasks"`
+	PendingTasks        int                `json:"pending_tasks"`
+	InProgressTasks     int                `json:"in_progress_tasks"`
+	LastTaskUpdate      time.Time          `json:"last_task_update"`
+	CompletionPercent   float64            `json:"completion_percent"`
+	AverageOpenAgeHours float64            `json:"average_open_age_hours"`
+	OldestOpenTask      *Task              `json:"oldest_open_task"`
+	LastUpdatedTask     *Task              `json:"last_updated_task"`
 }
 
 // NewProject creates a new project with default values
@@ -48,8 +68,8 @@ func NewProject(name, path string) *Project {
 	return &Project{
 		Name:         name,
 		Path:         absPath,
-		CreatedAt:    time.Now(),
-		LastAccessed: time.Now(),
+		CreatedAt:    time.Now().UTC(),
+		LastAccessed: time.Now().UTC(),
 		TaskCount:    0,
 		Description:  "",
 	}
@@ -93,7 +113,7 @@ func (p *Project) Validate() error {
 
 // UpdateLastAccessed updates the last accessed timestamp
 func (p *Project) UpdateLastAccessed() {
-	p.LastAccessed = time.Now()
+	p.LastAccessed = time.Now().UTC()
 }
 
 // UpdateTaskCount updates the task count
@@ -147,6 +167,52 @@ func (p *Project) Clone() *Project {
 		LastAccessed: p.LastAccessed,
 		TaskCount:    p.TaskCount,
 		Description:  p.Description,
+		DefaultTags:  append([]string(nil), p.DefaultTags...),
+	}
+}
+
+// AddDefaultTag adds a tag to the project's default tags, reporting whether
+// it was new
+func (p *Project) AddDefaultTag(tag string) bool {
+	for _, existing := range p.DefaultTags {
+		if existing == tag {
+			return false
+		}
+	}
+	p.DefaultTags = append(p.DefaultTags, tag)
+	return true
+}
+
+// RemoveDefaultTag removes a tag from the project's default tags, reporting
+// whether it was there
+func (p *Project) RemoveDefaultTag(tag string) bool {
+	for i, existing := range p.DefaultTags {
+		if existing == tag {
+			p.DefaultTags = append(p.DefaultTags[:i:i], p.DefaultTags[i+1:]...)
+			if len(p.DefaultTags) == 0 {
+				p.DefaultTags = nil
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyDefaultTags adds the project's default tags that a new task doesn't
+// already carry, after its own tags
+func (db *ProjectDatabase) ApplyDefaultTags(task *Task) {
+	if db.Project == nil || len(db.Project.DefaultTags) == 0 {
+		return
+	}
+
+	tags := append([]string(nil), task.Tags...)
+	for _, tag := range db.Project.DefaultTags {
+		if !task.HasTag(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) != len(task.Tags) {
+		task.SetTags(tags)
 	}
 }
 
@@ -166,13 +232,19 @@ func NewProjectDatabase(project *Project) *ProjectDatabase {
 		Project:      project,
 		Tasks:        make([]*Task, 0),
 		NextID:       1,
-		LastModified: time.Now(),
+		LastModified: time.Now().UTC(),
 		Version:      1,
 	}
 }
 
 // Validate validates the project database structure
 func (db *ProjectDatabase) Validate() error {
+	return db.ValidateWithClockSkew(DefaultClockSkewTolerance)
+}
+
+// ValidateWithClockSkew validates the database, allowing each task's
+// updated_at to fall up to tolerance before its created_at
+func (db *ProjectDatabase) ValidateWithClockSkew(tolerance time.Duration) error {
 	if db.Project == nil {
 		return fmt.Errorf("project cannot be nil")
 	}
@@ -199,7 +271,7 @@ func (db *ProjectDatabase) Validate() error {
 			return fmt.Errorf("task at index %d is nil", i)
 		}
 
-		if err := task.Validate(); err != nil {
+		if err := task.ValidateWithClockSkew(tolerance); err != nil {
 			return fmt.Errorf("invalid task at index %d: %w", i, err)
 		}
 	}
@@ -223,24 +295,63 @@ func (db *ProjectDatabase) AddTask(task *Task) error {
 
 	// Add to tasks
 	db.Tasks = append(db.Tasks, task)
+	if db.index != nil {
+		db.index[task.ID] = task
+	}
 
 	// Update metadata
-	db.LastModified = time.Now()
+	db.LastModified = time.Now().UTC()
 	db.Version++
 	db.Project.UpdateTaskCount(len(db.Tasks))
 
 	return nil
 }
 
+// Reindex rebuilds the task ID index from Tasks. When IDs repeat, the first
+// task with the ID wins, matching a scan of Tasks.
+func (db *ProjectDatabase) Reindex() {
+	db.index = make(map[int]*Task, len(db.Tasks))
+	for _, task := range db.Tasks {
+		if task == nil {
+			continue
+		}
+		if _, exists := db.index[task.ID]; !exists {
+			db.index[task.ID] = task
+		}
+	}
+}
+
+// taskIndex returns the task ID index, rebuilding it if Tasks was changed
+// without going through the database methods
+func (db *ProjectDatabase) taskIndex() map[int]*Task {
+	if db.index == nil || len(db.index) != len(db.Tasks) {
+		db.Reindex()
+	}
+	return db.index
+}
+
 // GetTask retrieves a task by ID
 func (db *ProjectDatabase) GetTask(id int) (*Task, error) {
+	if task, ok := db.taskIndex()[id]; ok {
+		return task, nil
+	}
+
+	return nil, fmt.Errorf("task with ID %d not found", id)
+}
+
+// FindTaskByExternalID returns the task imported from the given external ID
+func (db *ProjectDatabase) FindTaskByExternalID(externalID string) (*Task, bool) {
+	if externalID == "" {
+		return nil, false
+	}
+
 	for _, task := range db.Tasks {
-		if task.ID == id {
-			return task, nil
+		if task.ExternalID == externalID {
+			return task, true
 		}
 	}
 
-	return nil, fmt.Errorf("task with ID %d not found", id)
+	return nil, false
 }
 
 // UpdateTask updates a task in the database
@@ -257,7 +368,10 @@ func (db *ProjectDatabase) UpdateTask(task *Task) error {
 	for i, existingTask := range db.Tasks {
 		if existingTask.ID == task.ID {
 			db.Tasks[i] = task
-			db.LastModified = time.Now()
+			if db.index != nil {
+				db.index[task.ID] = task
+			}
+			db.LastModified = time.Now().UTC()
 			db.Version++
 			return nil
 		}
@@ -272,9 +386,12 @@ func (db *ProjectDatabase) DeleteTask(id int) error {
 		if task.ID == id {
 			// Remove task from slice
 			db.Tasks = append(db.Tasks[:i], db.Tasks[i+1:]...)
+			if db.index != nil {
+				delete(db.index, id)
+			}
 
 			// Update metadata
-			db.LastModified = time.Now()
+			db.LastModified = time.Now().UTC()
 			db.Version++
 			db.Project.UpdateTaskCount(len(db.Tasks))
 
@@ -285,7 +402,8 @@ func (db *ProjectDatabase) DeleteTask(id int) error {
 	return fmt.Errorf("task with ID %d not found", id)
 }
 
-// ListTasks returns all tasks, optionally filtered
+// ListTasks returns all tasks, optionally filtered, in board order: tasks
+// placed on the board by position (see ReorderColumn), then the rest by ID
 func (db *ProjectDatabase) ListTasks(filter *TaskFilter) []*Task {
 	if filter == nil {
 		// Return all tasks
@@ -293,6 +411,7 @@ func (db *ProjectDatabase) ListTasks(filter *TaskFilter) []*Task {
 		for i, task := range db.Tasks {
 			tasks[i] = task.Clone()
 		}
+		sortByPosition(tasks)
 		return tasks
 	}
 
@@ -304,6 +423,7 @@ func (db *ProjectDatabase) ListTasks(filter *TaskFilter) []*Task {
 		}
 	}
 
+	sortByPosition(filteredTasks)
 	return filteredTasks
 }
 
@@ -322,19 +442,32 @@ func (db *ProjectDatabase) SearchTasks(query string) []*Task {
 
 // GetSummary returns a summary of the project
 func (db *ProjectDatabase) GetSummary() *ProjectSummary {
+	return db.GetSummaryAt(time.Now().UTC())
+}
+
+// GetSummaryAt returns a summary of the project with task ages measured at now
+func (db *ProjectDatabase) GetSummaryAt(now time.Time) *ProjectSummary {
+	return SummarizeTasks(db.Project, db.Tasks, now)
+}
+
+// SummarizeTasks returns a summary of some of a project's tasks, e.g. those
+// list-tasks shows, with task ages measured at now
+func SummarizeTasks(project *Project, tasks []*Task, now time.Time) *ProjectSummary {
 	summary := &ProjectSummary{
-		Project:         db.Project.Clone(),
-		TaskCount:       len(db.Tasks),
-		StatusCounts:    make(map[Status]int),
-		PriorityCounts:  make(map[Priority]int),
-		CompletedTasks:  0,
-		PendingTasks:    0,
-		InProgressTasks: 0,
-		LastTaskUpdate:  time.Time{},
+		Project:          project.Clone(),
+		TaskCount:        len(tasks),
+		StatusCounts:     make(map[Status]int),
+		PriorityCounts:   make(map[Priority]int),
+		ResolutionCounts: make(map[Resolution]int),
+		CompletedTasks:   0,
+		PendingTasks:     0,
+		InProgressTasks:  0,
+		LastTaskUpdate:   time.Time{},
 	}
 
 	// Calculate statistics
-	for _, task := range db.Tasks {
+	var openAge time.Duration
+	for _, task := range tasks {
 		// Count by status
 		summary.StatusCounts[task.Status]++
 
@@ -345,24 +478,112 @@ func (db *ProjectDatabase) GetSummary() *ProjectSummary {
 		switch task.Status {
 		case StatusDone:
 			summary.CompletedTasks++
+			summary.ResolutionCounts[task.EffectiveResolution()]++
 		case StatusPending:
 			summary.PendingTasks++
 		case StatusInProgress:
 			summary.InProgressTasks++
+		case StatusCancelled:
+			summary.CancelledTasks++
 		}
 
 		// Track latest update
 		if task.UpdatedAt.After(summary.LastTaskUpdate) {
 			summary.LastTaskUpdate = task.UpdatedAt
+			summary.LastUpdatedTask = task
+		}
+
+		// Track the age of open work
+		if !task.IsClosed() {
+			openAge += now.Sub(task.CreatedAt)
+			if summary.OldestOpenTask == nil || task.CreatedAt.Before(summary.OldestOpenTask.CreatedAt) {
+				summary.OldestOpenTask = task
+			}
 		}
 	}
 
+	// Cancelled tasks are no longer work to complete
+	if planned := summary.TaskCount - summary.CancelledTasks; planned > 0 {
+		summary.CompletionPercent = float64(summary.CompletedTasks) * 100 / float64(planned)
+	}
+	if open := summary.PendingTasks + summary.InProgressTasks; open > 0 {
+		summary.AverageOpenAgeHours = openAge.Hours() / float64(open)
+	}
+
+	// Hand out copies so the summary doesn't alias the database
+	if summary.OldestOpenTask != nil {
+		summary.OldestOpenTask = summary.OldestOpenTask.Clone()
+	}
+	if summary.LastUpdatedTask != nil {
+		summary.LastUpdatedTask = summary.LastUpdatedTask.Clone()
+	}
+
 	return summary
 }
 
-// ToJSON converts the project database to JSON
+// RepairClockSkew fixes tasks whose updated_at falls before created_at by no
+// more than tolerance and returns how many were changed
+func (db *ProjectDatabase) RepairClockSkew(tolerance time.Duration) int {
+	repaired := 0
+	for _, task := range db.Tasks {
+		if task != nil && task.RepairClockSkew(tolerance) {
+			repaired++
+		}
+	}
+	return repaired
+}
+
+// NormalizeTimestamps converts all project, task and history timestamps to UTC
+func (db *ProjectDatabase) NormalizeTimestamps() {
+	db.LastModified = db.LastModified.UTC()
+	if db.Project != nil {
+		db.Project.CreatedAt = db.Project.CreatedAt.UTC()
+		db.Project.LastAccessed = db.Project.LastAccessed.UTC()
+	}
+	for _, task := range db.Tasks {
+		if task != nil {
+			task.NormalizeTimestamps()
+		}
+	}
+	for _, event := range db.History {
+		event.Timestamp = event.Timestamp.UTC()
+	}
+}
+
+// ToJSON converts the project database to JSON. Git-friendly databases are
+// written deterministically; see GitFriendly.
 func (db *ProjectDatabase) ToJSON() ([]byte, error) {
-	return json.MarshalIndent(db, "", "  ")
+	if !db.GitFriendly {
+		return json.MarshalIndent(db, "", "  ")
+	}
+
+	// projectDatabaseFields drops the methods so the wrapper below doesn't
+	// recurse into ToJSON; its own fields shadow the volatile metadata
+	type projectDatabaseFields ProjectDatabase
+	snapshot := projectDatabaseFields(*db)
+	snapshot.Tasks = append([]*Task(nil), db.Tasks...)
+	sort.SliceStable(snapshot.Tasks, func(i, j int) bool { return snapshot.Tasks[i].ID < snapshot.Tasks[j].ID })
+
+	data, err := json.MarshalIndent(struct {
+		*projectDatabaseFields
+		LastModified *time.Time `json:"last_modified,omitempty"`
+		Version      int        `json:"version,omitempty"`
+	}{projectDatabaseFields: &snapshot}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// RestoreMetadata fills in metadata that git-friendly saves omit, using
+// modTime (normally the file's modification time) as the last change
+func (db *ProjectDatabase) RestoreMetadata(modTime time.Time) {
+	if db.Version < 1 {
+		db.Version = 1
+	}
+	if db.LastModified.IsZero() {
+		db.LastModified = modTime.UTC()
+	}
 }
 
 // Helper function for case-insensitive substring matching
@@ -2,6 +2,8 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -343,6 +345,84 @@ func TestProjectDatabaseGetSummary(t *testing.T) {
 	}
 }
 
+func TestProjectDatabaseGetSummaryCancelled(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	tasks := make([]*Task, 4)
+	for i := range tasks {
+		tasks[i] = NewTask(i+1, fmt.Sprintf("Task %d", i+1))
+		db.AddTask(tasks[i])
+	}
+	tasks[1].UpdateStatus(StatusDone)
+	tasks[2].UpdateStatus(StatusCancelled)
+
+	summary := db.GetSummary()
+	if summary.CancelledTasks != 1 || summary.StatusCounts[StatusCancelled] != 1 {
+		t.Errorf("Expected 1 cancelled task, got %d (status counts %v)", summary.CancelledTasks, summary.StatusCounts)
+	}
+	if summary.PendingTasks != 2 || summary.CompletedTasks != 1 {
+		t.Errorf("Expected 2 pending and 1 done, got %d and %d", summary.PendingTasks, summary.CompletedTasks)
+	}
+	// Cancelled tasks are left out of the completion percentage
+	if summary.CompletionPercent < 33.3 || summary.CompletionPercent > 33.4 {
+		t.Errorf("Expected 1 of 3 planned tasks complete, got %.1f%%", summary.CompletionPercent)
+	}
+
+	// A cancelled dependency no longer blocks
+	tasks[3].SetDependsOn([]int{3})
+	if unmet := db.UnmetDependencies(tasks[3]); len(unmet) != 0 {
+		t.Errorf("Expected a cancelled dependency to be met, got %v", unmet)
+	}
+	tasks[3].SetDependsOn([]int{1, 3})
+	if unmet := db.UnmetDependencies(tasks[3]); len(unmet) != 1 || unmet[0] != 1 {
+		t.Errorf("Expected only the pending dependency to be unmet, got %v", unmet)
+	}
+}
+
+func TestProjectDatabaseGetSummaryAt(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+
+	old := NewTask(1, "Old")
+	old.CreatedAt = now.Add(-72 * time.Hour)
+	old.UpdatedAt = now.Add(-48 * time.Hour)
+	recent := NewTask(2, "Recent")
+	recent.Status = StatusInProgress
+	recent.CreatedAt = now.Add(-24 * time.Hour)
+	recent.UpdatedAt = now.Add(-time.Hour)
+	done := NewTask(3, "Done")
+	done.Status = StatusDone
+	done.CreatedAt = now.Add(-240 * time.Hour)
+	done.UpdatedAt = now.Add(-2 * time.Hour)
+	done.Priority = PriorityHigh
+	db.Tasks = []*Task{old, recent, done}
+
+	summary := db.GetSummaryAt(now)
+
+	if summary.CompletionPercent < 33.3 || summary.CompletionPercent > 33.4 {
+		t.Errorf("Expected completion of one in three, got %.2f", summary.CompletionPercent)
+	}
+	if summary.AverageOpenAgeHours != 48 {
+		t.Errorf("Expected average open age of 48 hours, got %.2f", summary.AverageOpenAgeHours)
+	}
+	if summary.OldestOpenTask == nil || summary.OldestOpenTask.ID != 1 {
+		t.Errorf("Expected task 1 as the oldest open task, got %+v", summary.OldestOpenTask)
+	}
+	if summary.LastUpdatedTask == nil || summary.LastUpdatedTask.ID != 2 {
+		t.Errorf("Expected task 2 as the last updated task, got %+v", summary.LastUpdatedTask)
+	}
+
+	// The summary holds copies, not the database's tasks
+	summary.OldestOpenTask.Title = "Changed"
+	if old.Title != "Old" {
+		t.Error("Expected the summary not to alias database tasks")
+	}
+
+	empty := NewProjectDatabase(NewProject("empty", "/path/to/empty")).GetSummaryAt(now)
+	if empty.CompletionPercent != 0 || empty.AverageOpenAgeHours != 0 || empty.OldestOpenTask != nil || empty.LastUpdatedTask != nil {
+		t.Errorf("Expected zero values for an empty project, got %+v", empty)
+	}
+}
+
 func TestProjectDatabaseValidation(t *testing.T) {
 	project := NewProject("test-project", "/path/to/project")
 	db := NewProjectDatabase(project)
@@ -421,4 +501,172 @@ func TestProjectDatabaseJSON(t *testing.T) {
 // Helper function for tests
 func stringPtr(s string) *string {
 	return &s
-}
\ No newline at end of file
+}
+func TestProjectDatabaseSummaryResolutions(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/tmp/test"))
+
+	fixed := NewTask(1, "Fixed")
+	fixed.UpdateStatus(StatusDone)
+	dup := NewTask(1, "Duplicate")
+	dup.Complete(ResolutionDuplicate)
+	db.AddTask(fixed)
+	db.AddTask(dup)
+	db.AddTask(NewTask(1, "Open"))
+
+	summary := db.GetSummary()
+	if summary.ResolutionCounts[ResolutionDone] != 1 || summary.ResolutionCounts[ResolutionDuplicate] != 1 {
+		t.Errorf("Unexpected resolution counts: %v", summary.ResolutionCounts)
+	}
+}
+
+func TestProjectDatabaseGitFriendlyJSON(t *testing.T) {
+	db := NewProjectDatabase(NewProject("git-project", "/tmp/git-project"))
+	db.GitFriendly = true
+	db.AddTask(NewTask(db.NextID, "First"))
+	db.AddTask(NewTask(db.NextID, "Second"))
+	db.AddTask(NewTask(db.NextID, "Third"))
+
+	first, err := db.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+
+	// Same tasks in a different order, with the volatile metadata bumped as a
+	// no-op save would
+	db.Tasks[0], db.Tasks[2] = db.Tasks[2], db.Tasks[0]
+	db.Version += 5
+	db.LastModified = db.LastModified.Add(time.Hour)
+
+	second, err := db.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("Expected byte-identical saves, got:\n%s\n---\n%s", first, second)
+	}
+	if strings.Contains(string(first), "last_modified") || strings.Contains(string(first), `"version"`) {
+		t.Errorf("Expected volatile metadata to be omitted, got:\n%s", first)
+	}
+	if strings.Index(string(first), "First") > strings.Index(string(first), "Third") {
+		t.Error("Expected tasks to be sorted by ID")
+	}
+
+	// Git-friendly files still load
+	var loaded ProjectDatabase
+	if err := json.Unmarshal(first, &loaded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	modTime := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	loaded.RestoreMetadata(modTime)
+	if err := loaded.Validate(); err != nil {
+		t.Fatalf("Expected restored database to validate, got %v", err)
+	}
+	if !loaded.GitFriendly || len(loaded.Tasks) != 3 || !loaded.LastModified.Equal(modTime) || loaded.Version != 1 {
+		t.Errorf("Unexpected loaded database: git_friendly=%v tasks=%d last_modified=%v version=%d",
+			loaded.GitFriendly, len(loaded.Tasks), loaded.LastModified, loaded.Version)
+	}
+
+	// The regular format is unchanged
+	db.GitFriendly = false
+	regular, _ := db.ToJSON()
+	if !strings.Contains(string(regular), "last_modified") {
+		t.Error("Expected regular saves to keep last_modified")
+	}
+}
+
+func TestProjectDatabaseTaskIndexConsistency(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for i := 0; i < 5; i++ {
+		db.AddTask(NewTask(db.NextID, fmt.Sprintf("Task %d", i+1)))
+	}
+
+	// Lookups agree with the slice
+	assertIndexMatchesTasks := func(t *testing.T) {
+		t.Helper()
+		for _, task := range db.Tasks {
+			got, err := db.GetTask(task.ID)
+			if err != nil || got != task {
+				t.Errorf("GetTask(%d) returned %v, %v; expected the stored task", task.ID, got, err)
+			}
+		}
+	}
+	assertIndexMatchesTasks(t)
+
+	// Deleting drops the task from lookups and keeps the rest in order
+	if err := db.DeleteTask(3); err != nil {
+		t.Fatalf("DeleteTask failed: %v", err)
+	}
+	if _, err := db.GetTask(3); err == nil {
+		t.Error("Expected deleted task to be gone from the index")
+	}
+	if ids := [4]int{db.Tasks[0].ID, db.Tasks[1].ID, db.Tasks[2].ID, db.Tasks[3].ID}; ids != [4]int{1, 2, 4, 5} {
+		t.Errorf("Expected slice order 1, 2, 4, 5 after delete, got %v", ids)
+	}
+	assertIndexMatchesTasks(t)
+
+	// Replacing a task swaps it in both places
+	replacement := NewTask(4, "Replaced")
+	if err := db.UpdateTask(replacement); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	if got, _ := db.GetTask(4); got != replacement || db.Tasks[2] != replacement {
+		t.Error("Expected UpdateTask to replace the task in the index and slice")
+	}
+
+	// New tasks are indexed, including after deletions
+	db.AddTask(NewTask(db.NextID, "Task 6"))
+	assertIndexMatchesTasks(t)
+
+	// Direct edits to the slice are picked up once the sizes disagree, or by Reindex
+	db.Tasks = db.Tasks[1:]
+	if _, err := db.GetTask(1); err == nil {
+		t.Error("Expected a task removed from the slice to be gone from the index")
+	}
+	db.Tasks[0] = NewTask(2, "Swapped")
+	db.Reindex()
+	assertIndexMatchesTasks(t)
+
+	// The index never reaches the saved file
+	data, err := db.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if strings.Contains(string(data), "index") {
+		t.Errorf("Expected the task index to stay out of the JSON, got %s", data)
+	}
+}
+
+func BenchmarkProjectDatabaseGetTask(b *testing.B) {
+	db := NewProjectDatabase(NewProject("bench-project", "/path/to/project"))
+	for i := 0; i < 5000; i++ {
+		db.AddTask(NewTask(db.NextID, fmt.Sprintf("Task %d", i+1)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := db.GetTask(i%5000 + 1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestApplyDefaultTags(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test", "/tmp/test"))
+	if !db.Project.AddDefaultTag("backend") || !db.Project.AddDefaultTag("api") || db.Project.AddDefaultTag("api") {
+		t.Fatalf("Expected each default tag to be added once, got %v", db.Project.DefaultTags)
+	}
+
+	task := NewTask(1, "Tagged")
+	task.Tags = []string{"api", "urgent"}
+	db.ApplyDefaultTags(task)
+	if strings.Join(task.Tags, ",") != "api,urgent,backend" {
+		t.Errorf("Expected own tags followed by missing defaults, got %v", task.Tags)
+	}
+
+	if !db.Project.RemoveDefaultTag("backend") || db.Project.RemoveDefaultTag("backend") {
+		t.Errorf("Expected backend to be removed once")
+	}
+	if clone := db.Project.Clone(); strings.Join(clone.DefaultTags, ",") != "api" {
+		t.Errorf("Expected clone to keep the default tags, got %v", clone.DefaultTags)
+	}
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Task recurrences
+const (
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+)
+
+// ValidRecurrences returns all recurrences a task can have
+func ValidRecurrences() []string {
+	return []string{RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly}
+}
+
+// IsValidRecurrence checks if a recurrence is valid; empty means none
+func IsValidRecurrence(recurrence string) bool {
+	switch recurrence {
+	case "", RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	default:
+		return false
+	}
+}
+
+// NormalizeRecurrence lowercases a recurrence and maps "none" to empty
+func NormalizeRecurrence(recurrence string) string {
+	recurrence = strings.ToLower(strings.TrimSpace(recurrence))
+	if recurrence == "none" {
+		return ""
+	}
+	return recurrence
+}
+
+// SetRecurrence sets or clears (empty) how the task repeats and updates the
+// timestamp
+func (t *Task) SetRecurrence(recurrence string) error {
+	if !IsValidRecurrence(recurrence) {
+		return fmt.Errorf("invalid recurrence: %s", recurrence)
+	}
+	t.Recurrence = recurrence
+	t.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// advanceRecurrence moves t forward by one recurrence interval. Monthly
+// recurrences stay in the next month, so the 31st is followed by the last day
+// of a shorter month.
+func advanceRecurrence(t time.Time, recurrence string) time.Time {
+	switch recurrence {
+	case RecurrenceDaily:
+		return t.AddDate(0, 0, 1)
+	case RecurrenceWeekly:
+		return t.AddDate(0, 0, 7)
+	default:
+		next := t.AddDate(0, 1, 0)
+		if next.Day() != t.Day() {
+			// Went past the end of the month; back up to its last day
+			next = next.AddDate(0, 0, -next.Day())
+		}
+		return next
+	}
+}
+
+// NextOccurrence returns the next instance of a recurring task: a fresh
+// pending copy whose due date (and start date, if any) is one recurrence
+// interval later. A task without a due date gets one an interval after it was
+// completed. The copy keeps this task's ID until AddTask gives it its own.
+func (t *Task) NextOccurrence() (*Task, error) {
+	if t.Recurrence == "" {
+		return nil, fmt.Errorf("task #%d does not recur", t.ID)
+	}
+	if !IsValidRecurrence(t.Recurrence) {
+		return nil, fmt.Errorf("invalid recurrence: %s", t.Recurrence)
+	}
+
+	base := t.UpdatedAt
+	if t.CompletedAt != nil {
+		base = *t.CompletedAt
+	}
+	if t.DueDate != nil {
+		base = *t.DueDate
+	}
+	due := advanceRecurrence(base, t.Recurrence).UTC()
+
+	next := NewTaskWithDetails(t.ID, t.Title, t.Description, t.Priority)
+	next.AssignedTo = t.AssignedTo
+	next.Tags = append([]string(nil), t.Tags...)
+	next.Recurrence = t.Recurrence
+	next.DueDate = &due
+	if t.StartDate != nil {
+		start := advanceRecurrence(*t.StartDate, t.Recurrence).UTC()
+		next.StartDate = &start
+	}
+	for _, item := range t.Checklist {
+		next.Checklist = append(next.Checklist, ChecklistItem{Text: item.Text})
+	}
+
+	return next, nil
+}
+
+// AddNextOccurrence adds the next instance of a recurring task to the
+// database, leaving the original as it is, and returns the new task
+func (db *ProjectDatabase) AddNextOccurrence(task *Task) (*Task, error) {
+	next, err := task.NextOccurrence()
+	if err != nil {
+		return nil, err
+	}
+	if err := db.AddTask(next); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskNextOccurrence(t *testing.T) {
+	due := time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC)
+	start := due.Add(-48 * time.Hour)
+
+	task := NewTaskWithDetails(3, "Send invoices", "For all clients", PriorityHigh)
+	task.AssignedTo = "alice"
+	task.SetTags([]string{"billing"})
+	task.DueDate = &due
+	task.StartDate = &start
+	task.Checklist = []ChecklistItem{{Text: "Export hours", Done: true}}
+	task.Recurrence = RecurrenceMonthly
+	if err := task.Complete(ResolutionDone); err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+
+	next, err := task.NextOccurrence()
+	if err != nil {
+		t.Fatalf("NextOccurrence failed: %v", err)
+	}
+	if next.Status != StatusPending || next.CompletedAt != nil || next.Resolution != "" {
+		t.Errorf("Expected a pending task, got %+v", next)
+	}
+	if next.Title != task.Title || next.Priority != PriorityHigh || next.AssignedTo != "alice" || next.Recurrence != RecurrenceMonthly {
+		t.Errorf("Expected the task details to be copied, got %+v", next)
+	}
+	if want := time.Date(2025, 2, 28, 17, 0, 0, 0, time.UTC); !next.DueDate.Equal(want) {
+		t.Errorf("Expected due date %s, got %s", want, next.DueDate)
+	}
+	if want := time.Date(2025, 2, 28, 17, 0, 0, 0, time.UTC); !next.StartDate.Equal(want) {
+		t.Errorf("Expected start date %s, got %s", want, next.StartDate)
+	}
+	if len(next.Checklist) != 1 || next.Checklist[0].Done {
+		t.Errorf("Expected an unchecked checklist, got %+v", next.Checklist)
+	}
+	if task.Status != StatusDone || !task.DueDate.Equal(due) {
+		t.Errorf("Expected the original task to be left alone, got %+v", task)
+	}
+
+	// Without a due date the next one is due an interval after completion
+	undated := NewTask(4, "Water plants")
+	undated.Recurrence = RecurrenceDaily
+	undated.UpdateStatus(StatusDone)
+	next, err = undated.NextOccurrence()
+	if err != nil {
+		t.Fatalf("NextOccurrence failed: %v", err)
+	}
+	if want := undated.CompletedAt.Add(24 * time.Hour); !next.DueDate.Equal(want) {
+		t.Errorf("Expected due date %s, got %s", want, next.DueDate)
+	}
+
+	if _, err := NewTask(5, "Once").NextOccurrence(); err == nil {
+		t.Error("Expected an error for a task that doesn't recur")
+	}
+}
+
+func TestAddNextOccurrence(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	task := NewTask(db.NextID, "Weekly review")
+	task.Recurrence = RecurrenceWeekly
+	if err := db.AddTask(task); err != nil {
+		t.Fatalf("Failed to add task: %v", err)
+	}
+	task.UpdateStatus(StatusDone)
+
+	next, err := db.AddNextOccurrence(task)
+	if err != nil {
+		t.Fatalf("AddNextOccurrence failed: %v", err)
+	}
+	if next.ID != 2 || db.NextID != 3 || len(db.Tasks) != 2 {
+		t.Errorf("Expected the next occurrence to be added as #2, got #%d with %d tasks", next.ID, len(db.Tasks))
+	}
+
+	task.Recurrence = "yearly"
+	if err := task.Validate(); err == nil {
+		t.Error("Expected an invalid recurrence to fail validation")
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// NeedsReminder checks if the task is open, due before now plus window (or
+// already overdue) and hasn't been reminded about its current due date
+func (t *Task) NeedsReminder(now time.Time, window time.Duration) bool {
+	return t.DueDate != nil && !t.IsClosed() && t.RemindedAt == nil && !t.DueDate.After(now.Add(window))
+}
+
+// MarkReminded records that a reminder about the current due date was sent.
+// It leaves UpdatedAt alone since the task itself didn't change.
+func (t *Task) MarkReminded(at time.Time) {
+	at = at.UTC()
+	t.RemindedAt = &at
+}
+
+// GetTasksNeedingReminder returns the tasks that need a reminder at now, in
+// due date order
+func (db *ProjectDatabase) GetTasksNeedingReminder(now time.Time, window time.Duration) []*Task {
+	var tasks []*Task
+	for _, task := range db.Tasks {
+		if task.NeedsReminder(now, window) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].DueDate.Before(*tasks[j].DueDate) })
+	return tasks
+}
+
+// sameTime compares two optional timestamps
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
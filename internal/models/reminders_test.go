@@ -0,0 +1,58 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetTasksNeedingReminder(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	due := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+
+	soon := NewTask(1, "Due soon")
+	soon.DueDate = due(6 * time.Hour)
+	overdue := NewTask(2, "Overdue")
+	overdue.DueDate = due(-48 * time.Hour)
+	later := NewTask(3, "Due later")
+	later.DueDate = due(10 * 24 * time.Hour)
+	done := NewTask(4, "Done")
+	done.DueDate = due(time.Hour)
+	done.Status = StatusDone
+	undated := NewTask(5, "No due date")
+	db.Tasks = []*Task{soon, overdue, later, done, undated}
+
+	tasks := db.GetTasksNeedingReminder(now, 24*time.Hour)
+	if ids := taskIDs(tasks); len(ids) != 2 || ids[0] != 2 || ids[1] != 1 {
+		t.Fatalf("Expected the overdue then the due-soon task, got %v", ids)
+	}
+
+	// A reminded task isn't reminded again, and marking it leaves UpdatedAt alone
+	updatedAt := soon.UpdatedAt
+	soon.MarkReminded(now)
+	if soon.RemindedAt == nil || !soon.RemindedAt.Equal(now) || !soon.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("Expected RemindedAt set without touching UpdatedAt, got %+v", soon)
+	}
+	if ids := taskIDs(db.GetTasksNeedingReminder(now, 24*time.Hour)); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("Expected only the overdue task after reminding, got %v", ids)
+	}
+
+	// Moving the due date makes the task eligible again; setting the same one doesn't
+	soon.SetDueDate(soon.DueDate)
+	if soon.RemindedAt == nil {
+		t.Error("Expected an unchanged due date to keep the reminder")
+	}
+	soon.SetDueDate(due(12 * time.Hour))
+	if soon.RemindedAt != nil || !soon.NeedsReminder(now, 24*time.Hour) {
+		t.Error("Expected a new due date to clear the reminder")
+	}
+
+	clone := soon.Clone()
+	soon.MarkReminded(now)
+	if clone.RemindedAt != nil || soon.RemindedAt == nil {
+		t.Error("Expected Clone to copy RemindedAt rather than share it")
+	}
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// SearchResult is a task matched by a search query
+type SearchResult struct {
+	Project string `json:"project"`
+	Score   int    `json:"score"`
+	Task    *Task  `json:"task"`
+}
+
+// Search relevance weights; higher scores rank first
+const (
+	scoreTitleExact   = 100
+	scoreTitlePrefix  = 75
+	scoreTitleContain = 50
+	scoreTagExact     = 30
+	scoreDescription  = 20
+)
+
+// ScoreTask rates how well a task matches a case-insensitive query. Title
+// matches outrank tag matches, which outrank description matches. A score of
+// zero means the task does not match.
+func ScoreTask(task *Task, query string) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return 0
+	}
+
+	score := 0
+	title := strings.ToLower(task.Title)
+	switch {
+	case title == query:
+		score += scoreTitleExact
+	case strings.HasPrefix(title, query):
+		score += scoreTitlePrefix
+	case strings.Contains(title, query):
+		score += scoreTitleContain
+	}
+
+	for _, tag := range task.Tags {
+		if strings.ToLower(tag) == query {
+			score += scoreTagExact
+			break
+		}
+	}
+
+	if strings.Contains(strings.ToLower(task.Description), query) {
+		score += scoreDescription
+	}
+
+	return score
+}
+
+// RankSearchResults orders results by score, then priority, then most recently updated
+func RankSearchResults(results []SearchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if pa, pb := priorityWeight(a.Task.Priority), priorityWeight(b.Task.Priority); pa != pb {
+			return pa > pb
+		}
+		return a.Task.UpdatedAt.After(b.Task.UpdatedAt)
+	})
+}
+
+// TruncateSearchResults caps results at max (0 or less means no cap) and
+// reports whether any were dropped. Rank first so the best matches survive.
+func TruncateSearchResults(results []SearchResult, max int) ([]SearchResult, bool) {
+	if max <= 0 || len(results) <= max {
+		return results, false
+	}
+	return results[:max], true
+}
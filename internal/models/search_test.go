@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScoreTask(t *testing.T) {
+	task := NewTaskWithDetails(1, "Fix login bug", "Users cannot log in with email", PriorityHigh)
+	task.Tags = []string{"auth"}
+
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{"fix login bug", scoreTitleExact},
+		{"FIX", scoreTitlePrefix},
+		{"login", scoreTitleContain},
+		{"auth", scoreTagExact},
+		{"email", scoreDescription},
+		{"log", scoreTitleContain + scoreDescription},
+		{"payments", 0},
+		{"  ", 0},
+	}
+
+	for _, tt := range tests {
+		if got := ScoreTask(task, tt.query); got != tt.expected {
+			t.Errorf("ScoreTask(%q) = %d, expected %d", tt.query, got, tt.expected)
+		}
+	}
+}
+
+func TestRankAndTruncateSearchResults(t *testing.T) {
+	now := time.Now()
+	low := NewTaskWithDetails(1, "a", "", PriorityLow)
+	high := NewTaskWithDetails(2, "b", "", PriorityHigh)
+	recent := NewTaskWithDetails(3, "c", "", PriorityLow)
+	recent.UpdatedAt = now.Add(time.Hour)
+	best := NewTaskWithDetails(4, "d", "", PriorityLow)
+
+	results := []SearchResult{
+		{Project: "p", Score: 20, Task: low},
+		{Project: "p", Score: 20, Task: high},
+		{Project: "p", Score: 20, Task: recent},
+		{Project: "p", Score: 100, Task: best},
+	}
+	RankSearchResults(results)
+
+	order := []int{results[0].Task.ID, results[1].Task.ID, results[2].Task.ID, results[3].Task.ID}
+	expected := []int{4, 2, 3, 1}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("Expected ranking %v, got %v", expected, order)
+		}
+	}
+
+	capped, truncated := TruncateSearchResults(results, 2)
+	if !truncated || len(capped) != 2 || capped[0].Task.ID != 4 {
+		t.Errorf("Expected top 2 results with truncation, got %d (truncated=%v)", len(capped), truncated)
+	}
+
+	all, truncated := TruncateSearchResults(results, 0)
+	if truncated || len(all) != 4 {
+		t.Errorf("Expected no cap with max 0, got %d (truncated=%v)", len(all), truncated)
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultSimilarityThreshold is the title similarity at which two tasks are
+// considered likely duplicates
+const DefaultSimilarityThreshold = 0.5
+
+// SimilarTask is an existing task whose title resembles another title
+type SimilarTask struct {
+	Score float64 `json:"score"`
+	Task  *Task   `json:"task"`
+}
+
+// titleTokens splits a title into its distinct lowercase words
+func titleTokens(title string) map[string]bool {
+	tokens := make(map[string]bool)
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, word := range words {
+		tokens[word] = true
+	}
+	return tokens
+}
+
+// TitleSimilarity rates how alike two titles are from 0 to 1 as the share of
+// words they have in common, ignoring case and punctuation
+func TitleSimilarity(a, b string) float64 {
+	tokensA, tokensB := titleTokens(a), titleTokens(b)
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for token := range tokensA {
+		if tokensB[token] {
+			shared++
+		}
+	}
+
+	union := len(tokensA) + len(tokensB) - shared
+	return float64(shared) / float64(union)
+}
+
+// SimilarTasks returns the tasks whose titles score at least threshold against
+// title, most similar first
+func (db *ProjectDatabase) SimilarTasks(title string, threshold float64) []SimilarTask {
+	var similar []SimilarTask
+	for _, task := range db.Tasks {
+		score := TitleSimilarity(title, task.Title)
+		if score > 0 && score >= threshold {
+			similar = append(similar, SimilarTask{Score: score, Task: task})
+		}
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool {
+		if similar[i].Score != similar[j].Score {
+			return similar[i].Score > similar[j].Score
+		}
+		return similar[i].Task.ID < similar[j].Task.ID
+	})
+	return similar
+}
@@ -0,0 +1,48 @@
+package models
+
+import "testing"
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"Fix login bug", "fix login bug", 1},
+		{"Fix login bug", "Fix the login bug!", 0.75},
+		{"Fix login bug", "Write docs", 0},
+		{"", "Fix login bug", 0},
+	}
+
+	for _, tt := range tests {
+		if got := TitleSimilarity(tt.a, tt.b); got != tt.expected {
+			t.Errorf("TitleSimilarity(%q, %q) = %v, expected %v", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestSimilarTasks(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for _, title := range []string{"Fix login bug", "Write API docs", "Fix the login bug on mobile", "fix login-bug"} {
+		if err := db.AddTask(NewTask(db.NextID, title)); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+
+	similar := db.SimilarTasks("Fix login bug", DefaultSimilarityThreshold)
+	if len(similar) != 3 {
+		t.Fatalf("Expected 3 similar tasks, got %d", len(similar))
+	}
+	if similar[0].Task.ID != 1 || similar[1].Task.ID != 4 || similar[2].Task.ID != 3 {
+		t.Errorf("Expected tasks 1, 4, 3 in order, got %d, %d, %d", similar[0].Task.ID, similar[1].Task.ID, similar[2].Task.ID)
+	}
+	if similar[2].Score != 0.5 {
+		t.Errorf("Expected near-duplicate score 0.5, got %v", similar[2].Score)
+	}
+
+	if similar := db.SimilarTasks("Fix login bug", 0.9); len(similar) != 2 {
+		t.Errorf("Expected 2 exact-word matches at threshold 0.9, got %d", len(similar))
+	}
+	if similar := db.SimilarTasks("Deploy release", DefaultSimilarityThreshold); len(similar) != 0 {
+		t.Errorf("Expected no similar tasks, got %d", len(similar))
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// StandupItem is an open task in a standup report that needs attention
+type StandupItem struct {
+	Task      *Task `json:"task"`
+	Overdue   bool  `json:"overdue,omitempty"`
+	WaitingOn []int `json:"waiting_on,omitempty"`
+}
+
+// StandupReport groups a project's tasks for a standup: what was completed
+// since a point in time, what is in progress, and what is blocked or overdue
+type StandupReport struct {
+	Since      time.Time      `json:"since"`
+	Completed  []*Task        `json:"completed"`
+	InProgress []*Task        `json:"in_progress"`
+	Blocked    []*StandupItem `json:"blocked"`
+}
+
+// StandupReport builds a standup report at now. Open tasks that are overdue or
+// waiting on unfinished dependencies are listed under Blocked only, even when
+// they are in progress, so each task appears once. Completed tasks are in
+// completion order; the other sections are in board order.
+func (db *ProjectDatabase) StandupReport(since, now time.Time) *StandupReport {
+	report := &StandupReport{
+		Since:      since,
+		Completed:  []*Task{},
+		InProgress: []*Task{},
+		Blocked:    []*StandupItem{},
+	}
+
+	for _, task := range db.ListTasks(nil) {
+		if task.IsClosed() {
+			if task.IsComplete() && task.CompletedAt != nil && !task.CompletedAt.Before(since) {
+				report.Completed = append(report.Completed, task)
+			}
+			continue
+		}
+
+		item := &StandupItem{Task: task, Overdue: task.IsOverdue(now), WaitingOn: db.UnmetDependencies(task)}
+		switch {
+		case item.Overdue || len(item.WaitingOn) > 0:
+			report.Blocked = append(report.Blocked, item)
+		case task.IsInProgress():
+			report.InProgress = append(report.InProgress, task)
+		}
+	}
+
+	sort.SliceStable(report.Completed, func(i, j int) bool {
+		return report.Completed[i].CompletedAt.Before(*report.Completed[j].CompletedAt)
+	})
+	return report
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// newStandupDB builds a project with one task for each standup section, and
+// some that belong in none
+func newStandupDB(t *testing.T, now time.Time) *ProjectDatabase {
+	t.Helper()
+
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	add := func(title string, status Status) *Task {
+		task := NewTask(db.NextID, title)
+		task.Status = status
+		if err := db.AddTask(task); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+		return task
+	}
+	at := func(d time.Duration) *time.Time {
+		when := now.Add(d)
+		return &when
+	}
+
+	add("Done yesterday", StatusDone).CompletedAt = at(-20 * time.Hour)
+	add("Done last week", StatusDone).CompletedAt = at(-7 * 24 * time.Hour)
+	add("Done this morning", StatusDone).CompletedAt = at(-2 * time.Hour)
+	add("Working on it", StatusInProgress)
+	add("Not started", StatusPending)
+	add("Late and in progress", StatusInProgress).DueDate = at(-24 * time.Hour)
+	add("Waiting", StatusPending).SetDependsOn([]int{4})
+	add("Due later", StatusPending).DueDate = at(24 * time.Hour)
+	return db
+}
+
+func TestStandupReport(t *testing.T) {
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC)
+	db := newStandupDB(t, now)
+
+	report := db.StandupReport(now.Add(-24*time.Hour), now)
+
+	if ids := taskIDs(report.Completed); len(ids) != 2 || ids[0] != 1 || ids[1] != 3 {
+		t.Errorf("Expected #1 then #3 completed, got %v", ids)
+	}
+	if ids := taskIDs(report.InProgress); len(ids) != 1 || ids[0] != 4 {
+		t.Errorf("Expected only #4 in progress, got %v", ids)
+	}
+
+	if len(report.Blocked) != 2 {
+		t.Fatalf("Expected 2 blocked tasks, got %d", len(report.Blocked))
+	}
+	late, waiting := report.Blocked[0], report.Blocked[1]
+	if late.Task.ID != 6 || !late.Overdue || len(late.WaitingOn) != 0 {
+		t.Errorf("Expected #6 overdue, got %+v", late)
+	}
+	if waiting.Task.ID != 7 || waiting.Overdue || len(waiting.WaitingOn) != 1 || waiting.WaitingOn[0] != 4 {
+		t.Errorf("Expected #7 waiting on #4, got %+v", waiting)
+	}
+}
+
+func TestStandupReportEmpty(t *testing.T) {
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	report := db.StandupReport(time.Now().Add(-24*time.Hour), time.Now())
+	if report.Completed == nil || report.InProgress == nil || report.Blocked == nil {
+		t.Errorf("Expected empty sections to be empty lists, got %+v", report)
+	}
+}
@@ -3,21 +3,39 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // Task represents a task in the system
 type Task struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      Status    `json:"status"`
-	Priority    Priority  `json:"priority"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	AssignedTo  string    `json:"assigned_to"`
-	LockedBy    string    `json:"locked_by"`
-	LockedAt    time.Time `json:"locked_at"`
+	ID              int             `json:"id"`
+	Title           string          `json:"title"`
+	Description     string          `json:"description"`
+	Status          Status          `json:"status"`
+	Priority        Priority        `json:"priority"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	AssignedTo      string          `json:"assigned_to"`
+	LockedBy        string          `json:"locked_by"`
+	LockedAt        time.Time       `json:"locked_at"`
+	Tags            []string        `json:"tags,omitempty"`
+	ExternalID      string          `json:"external_id,omitempty"` // identifier in an external tracker, e.g. github:42
+	StartDate       *time.Time      `json:"start_date,omitempty"`  // not actionable before this time
+	DueDate         *time.Time      `json:"due_date,omitempty"`
+	CompletedAt     *time.Time      `json:"completed_at,omitempty"`
+	Resolution      Resolution      `json:"resolution,omitempty"`
+	Attachments     []Attachment    `json:"attachments,omitempty"`
+	Checklist       []ChecklistItem `json:"checklist,omitempty"`
+	Comments        []Comment       `json:"comments,omitempty"`
+	TimeEntries     []TimeEntry     `json:"time_entries,omitempty"`
+	DependsOn       []int           `json:"depends_on,omitempty"`  // IDs of tasks that must be done first
+	StatusChangedAt time.Time       `json:"status_changed_at"`     // when the task last moved to its current status
+	RemindedAt      *time.Time      `json:"reminded_at,omitempty"` // when a reminder about the current due date was sent
+	Recurrence      string          `json:"recurrence,omitempty"`  // daily, weekly or monthly; completing the task creates the next one
+	Position        int             `json:"position,omitempty"`    // place in its status column on the board, from 1; 0 if never placed
 }
 
 // Status represents task status
@@ -28,32 +46,65 @@ const (
 	StatusPending    Status = "pending"
 	StatusInProgress Status = "in_progress"
 	StatusDone       Status = "done"
+	StatusCancelled  Status = "cancelled"
 )
 
 // Priority represents task priority
 type Priority string
 
-// Task priorities
+// Task priorities. Critical was added after the other three, for incidents;
+// databases written before it still load unchanged, but a database holding a
+// critical task fails validation in older versions of quicktodo.
 const (
-	PriorityLow    Priority = "low"
-	PriorityMedium Priority = "medium"
-	PriorityHigh   Priority = "high"
+	PriorityLow      Priority = "low"
+	PriorityMedium   Priority = "medium"
+	PriorityHigh     Priority = "high"
+	PriorityCritical Priority = "critical"
 )
 
+// Resolution records why a task was closed
+type Resolution string
+
+// Task resolutions
+const (
+	ResolutionDone      Resolution = "done"
+	ResolutionWontFix   Resolution = "wontfix"
+	ResolutionDuplicate Resolution = "duplicate"
+)
+
+// DefaultClockSkewTolerance is how far updated_at may fall before created_at,
+// e.g. for data written on machines whose clocks disagree slightly
+const DefaultClockSkewTolerance = 5 * time.Second
+
 // ValidStatuses returns a slice of all valid statuses
 func ValidStatuses() []Status {
-	return []Status{StatusPending, StatusInProgress, StatusDone}
+	return []Status{StatusPending, StatusInProgress, StatusDone, StatusCancelled}
 }
 
 // ValidPriorities returns a slice of all valid priorities
 func ValidPriorities() []Priority {
-	return []Priority{PriorityLow, PriorityMedium, PriorityHigh}
+	return []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical}
+}
+
+// ValidResolutions returns a slice of all valid resolutions
+func ValidResolutions() []Resolution {
+	return []Resolution{ResolutionDone, ResolutionWontFix, ResolutionDuplicate}
+}
+
+// IsValidResolution checks if a resolution is valid
+func IsValidResolution(resolution string) bool {
+	switch Resolution(resolution) {
+	case ResolutionDone, ResolutionWontFix, ResolutionDuplicate:
+		return true
+	default:
+		return false
+	}
 }
 
 // IsValidStatus checks if a status is valid
 func IsValidStatus(status string) bool {
 	switch Status(status) {
-	case StatusPending, StatusInProgress, StatusDone:
+	case StatusPending, StatusInProgress, StatusDone, StatusCancelled:
 		return true
 	default:
 		return false
@@ -63,47 +114,128 @@ func IsValidStatus(status string) bool {
 // IsValidPriority checks if a priority is valid
 func IsValidPriority(priority string) bool {
 	switch Priority(priority) {
-	case PriorityLow, PriorityMedium, PriorityHigh:
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical:
 		return true
 	default:
 		return false
 	}
 }
 
+// statusAliases maps synonyms people commonly type to canonical statuses
+var statusAliases = map[string]Status{
+	"todo":       StatusPending,
+	"to_do":      StatusPending,
+	"open":       StatusPending,
+	"wip":        StatusInProgress,
+	"inprogress": StatusInProgress,
+	"started":    StatusInProgress,
+	"doing":      StatusInProgress,
+	"complete":   StatusDone,
+	"completed":  StatusDone,
+	"finished":   StatusDone,
+	"closed":     StatusDone,
+	"canceled":   StatusCancelled,
+	"abandoned":  StatusCancelled,
+}
+
+// priorityAliases maps abbreviations and synonyms to canonical priorities
+var priorityAliases = map[string]Priority{
+	"crit":   PriorityCritical,
+	"hi":     PriorityHigh,
+	"h":      PriorityHigh,
+	"med":    PriorityMedium,
+	"mid":    PriorityMedium,
+	"m":      PriorityMedium,
+	"normal": PriorityMedium,
+	"lo":     PriorityLow,
+	"l":      PriorityLow,
+}
+
+// normalizeInput lowercases user input and treats hyphens and spaces as
+// underscores, so "In-Progress" and "in progress" read as "in_progress"
+func normalizeInput(input string) string {
+	input = strings.ToLower(strings.TrimSpace(input))
+	return strings.NewReplacer("-", "_", " ", "_").Replace(input)
+}
+
+// NormalizeStatus maps user input to a canonical status, ignoring case and
+// accepting common synonyms such as "wip" or "completed". Input it does not
+// recognize is returned normalized but unchanged otherwise, so IsValidStatus
+// still rejects it.
+func NormalizeStatus(input string) Status {
+	normalized := normalizeInput(input)
+	if status, ok := statusAliases[normalized]; ok {
+		return status
+	}
+	return Status(normalized)
+}
+
+// NormalizePriority maps user input to a canonical priority, ignoring case
+// and accepting abbreviations such as "hi" or "med"
+func NormalizePriority(input string) Priority {
+	normalized := normalizeInput(input)
+	if priority, ok := priorityAliases[normalized]; ok {
+		return priority
+	}
+	return Priority(normalized)
+}
+
+// SanitizeTitle strips control characters (including newlines and tabs) from a
+// title and trims surrounding whitespace
+func SanitizeTitle(title string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, title)
+	return strings.TrimSpace(cleaned)
+}
+
 // NewTask creates a new task with default values
 func NewTask(id int, title string) *Task {
+	now := time.Now().UTC()
 	return &Task{
-		ID:          id,
-		Title:       title,
-		Description: "",
-		Status:      StatusPending,
-		Priority:    PriorityMedium,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		AssignedTo:  "",
-		LockedBy:    "",
-		LockedAt:    time.Time{},
+		ID:              id,
+		Title:           title,
+		Description:     "",
+		Status:          StatusPending,
+		Priority:        PriorityMedium,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		StatusChangedAt: now,
+		AssignedTo:      "",
+		LockedBy:        "",
+		LockedAt:        time.Time{},
 	}
 }
 
 // NewTaskWithDetails creates a new task with specified details
 func NewTaskWithDetails(id int, title, description string, priority Priority) *Task {
+	now := time.Now().UTC()
 	return &Task{
-		ID:          id,
-		Title:       title,
-		Description: description,
-		Status:      StatusPending,
-		Priority:    priority,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		AssignedTo:  "",
-		LockedBy:    "",
-		LockedAt:    time.Time{},
+		ID:              id,
+		Title:           title,
+		Description:     description,
+		Status:          StatusPending,
+		Priority:        priority,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		StatusChangedAt: now,
+		AssignedTo:      "",
+		LockedBy:        "",
+		LockedAt:        time.Time{},
 	}
 }
 
-// Validate validates the task fields
+// Validate validates the task fields using the default clock skew tolerance
 func (t *Task) Validate() error {
+	return t.ValidateWithClockSkew(DefaultClockSkewTolerance)
+}
+
+// ValidateWithClockSkew validates the task, accepting an updated_at up to
+// tolerance before created_at to absorb clock differences between machines
+func (t *Task) ValidateWithClockSkew(tolerance time.Duration) error {
 	if t.ID <= 0 {
 		return fmt.Errorf("task ID must be positive")
 	}
@@ -128,25 +260,86 @@ func (t *Task) Validate() error {
 		return fmt.Errorf("updated_at cannot be zero")
 	}
 
-	if t.UpdatedAt.Before(t.CreatedAt) {
-		return fmt.Errorf("updated_at cannot be before created_at")
+	if t.UpdatedAt.Before(t.CreatedAt.Add(-tolerance)) {
+		return fmt.Errorf("updated_at cannot be before created_at (off by %s, clock skew tolerance is %s)", t.CreatedAt.Sub(t.UpdatedAt), tolerance)
+	}
+
+	if !IsValidRecurrence(t.Recurrence) {
+		return fmt.Errorf("invalid recurrence: %s", t.Recurrence)
 	}
 
+	return t.ValidateSchedule()
+}
+
+// ValidateSchedule checks that a task with both a start and a due date
+// doesn't start after it is due
+func (t *Task) ValidateSchedule() error {
+	if t.StartDate != nil && t.DueDate != nil && t.StartDate.After(*t.DueDate) {
+		return fmt.Errorf("start date %s is after due date %s",
+			t.StartDate.Local().Format("2006-01-02 15:04"), t.DueDate.Local().Format("2006-01-02 15:04"))
+	}
 	return nil
 }
 
+// RepairClockSkew moves an updated_at that falls before created_at by no more
+// than tolerance up to created_at, and reports whether it did
+func (t *Task) RepairClockSkew(tolerance time.Duration) bool {
+	if !t.UpdatedAt.Before(t.CreatedAt) || t.CreatedAt.Sub(t.UpdatedAt) > tolerance {
+		return false
+	}
+	t.UpdatedAt = t.CreatedAt
+	return true
+}
+
 // UpdateStatus updates the task status and timestamp
 func (t *Task) UpdateStatus(status Status) error {
 	if !IsValidStatus(string(status)) {
 		return fmt.Errorf("invalid status: %s", status)
 	}
 
+	now := time.Now().UTC()
+	if status == StatusDone && t.CompletedAt == nil {
+		t.CompletedAt = &now
+	} else if status != StatusDone {
+		// Reopening a task clears its completion details
+		t.CompletedAt = nil
+		t.Resolution = ""
+	}
+
+	if t.Status != status {
+		t.StatusChangedAt = now
+		// The task joins the end of its new board column
+		t.Position = 0
+	}
 	t.Status = status
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = now
+
+	return nil
+}
+
+// Complete marks the task done with the given resolution
+func (t *Task) Complete(resolution Resolution) error {
+	if !IsValidResolution(string(resolution)) {
+		return fmt.Errorf("invalid resolution: %s", resolution)
+	}
+
+	if err := t.UpdateStatus(StatusDone); err != nil {
+		return err
+	}
+	t.Resolution = resolution
 
 	return nil
 }
 
+// EffectiveResolution returns the task's resolution, treating done tasks
+// closed without one as resolved "done"
+func (t *Task) EffectiveResolution() Resolution {
+	if t.Resolution == "" && t.IsComplete() {
+		return ResolutionDone
+	}
+	return t.Resolution
+}
+
 // UpdatePriority updates the task priority and timestamp
 func (t *Task) UpdatePriority(priority Priority) error {
 	if !IsValidPriority(string(priority)) {
@@ -154,7 +347,7 @@ func (t *Task) UpdatePriority(priority Priority) error {
 	}
 
 	t.Priority = priority
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = time.Now().UTC()
 
 	return nil
 }
@@ -166,7 +359,7 @@ func (t *Task) UpdateTitle(title string) error {
 	}
 
 	t.Title = title
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = time.Now().UTC()
 
 	return nil
 }
@@ -174,19 +367,59 @@ func (t *Task) UpdateTitle(title string) error {
 // UpdateDescription updates the task description and timestamp
 func (t *Task) UpdateDescription(description string) {
 	t.Description = description
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = time.Now().UTC()
 }
 
 // AssignTo assigns the task to an agent or user
 func (t *Task) AssignTo(assignee string) {
 	t.AssignedTo = assignee
-	t.UpdatedAt = time.Now()
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// SetTags replaces the task's tags and updates the timestamp
+func (t *Task) SetTags(tags []string) {
+	t.Tags = tags
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// SetDueDate sets or clears (nil) the task's due date and updates the
+// timestamp. A new due date gets its own reminder.
+func (t *Task) SetDueDate(due *time.Time) {
+	if due != nil {
+		utc := due.UTC()
+		due = &utc
+	}
+	if !sameTime(t.DueDate, due) {
+		t.RemindedAt = nil
+	}
+	t.DueDate = due
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// SetStartDate sets or clears (nil) the task's start date and updates the timestamp
+func (t *Task) SetStartDate(start *time.Time) {
+	if start != nil {
+		utc := start.UTC()
+		start = &utc
+	}
+	t.StartDate = start
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// HasTag checks if the task carries the given tag
+func (t *Task) HasTag(tag string) bool {
+	for _, existing := range t.Tags {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
 }
 
 // Lock locks the task for exclusive access
 func (t *Task) Lock(processID string) {
 	t.LockedBy = processID
-	t.LockedAt = time.Now()
+	t.LockedAt = time.Now().UTC()
 }
 
 // Unlock unlocks the task
@@ -217,16 +450,84 @@ func (t *Task) IsStale() bool {
 // Clone creates a copy of the task
 func (t *Task) Clone() *Task {
 	return &Task{
-		ID:          t.ID,
-		Title:       t.Title,
-		Description: t.Description,
-		Status:      t.Status,
-		Priority:    t.Priority,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
-		AssignedTo:  t.AssignedTo,
-		LockedBy:    t.LockedBy,
-		LockedAt:    t.LockedAt,
+		ID:              t.ID,
+		Title:           t.Title,
+		Description:     t.Description,
+		Status:          t.Status,
+		Priority:        t.Priority,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+		AssignedTo:      t.AssignedTo,
+		LockedBy:        t.LockedBy,
+		LockedAt:        t.LockedAt,
+		Tags:            append([]string(nil), t.Tags...),
+		ExternalID:      t.ExternalID,
+		StartDate:       cloneTime(t.StartDate),
+		DueDate:         cloneTime(t.DueDate),
+		CompletedAt:     cloneTime(t.CompletedAt),
+		Resolution:      t.Resolution,
+		Attachments:     append([]Attachment(nil), t.Attachments...),
+		Checklist:       append([]ChecklistItem(nil), t.Checklist...),
+		Comments:        append([]Comment(nil), t.Comments...),
+		TimeEntries:     cloneTimeEntries(t.TimeEntries),
+		DependsOn:       append([]int(nil), t.DependsOn...),
+		StatusChangedAt: t.StatusChangedAt,
+		RemindedAt:      cloneTime(t.RemindedAt),
+		Recurrence:      t.Recurrence,
+		Position:        t.Position,
+	}
+}
+
+// cloneTime copies an optional timestamp
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
+
+// NormalizeTimestamps converts the task's timestamps to UTC so that data
+// written on machines in different time zones compares and displays
+// consistently. Tasks saved before status changes were tracked are treated as
+// having had their status since creation.
+func (t *Task) NormalizeTimestamps() {
+	t.CreatedAt = t.CreatedAt.UTC()
+	t.UpdatedAt = t.UpdatedAt.UTC()
+	t.LockedAt = t.LockedAt.UTC()
+	if t.StatusChangedAt.IsZero() {
+		t.StatusChangedAt = t.CreatedAt
+	}
+	t.StatusChangedAt = t.StatusChangedAt.UTC()
+	if t.StartDate != nil {
+		start := t.StartDate.UTC()
+		t.StartDate = &start
+	}
+	if t.DueDate != nil {
+		due := t.DueDate.UTC()
+		t.DueDate = &due
+	}
+	if t.CompletedAt != nil {
+		completed := t.CompletedAt.UTC()
+		t.CompletedAt = &completed
+	}
+	if t.RemindedAt != nil {
+		reminded := t.RemindedAt.UTC()
+		t.RemindedAt = &reminded
+	}
+	for i := range t.Attachments {
+		t.Attachments[i].AddedAt = t.Attachments[i].AddedAt.UTC()
+	}
+	for i := range t.Comments {
+		t.Comments[i].CreatedAt = t.Comments[i].CreatedAt.UTC()
+	}
+	for i := range t.TimeEntries {
+		entry := &t.TimeEntries[i]
+		entry.Start = entry.Start.UTC()
+		if entry.End != nil {
+			end := entry.End.UTC()
+			entry.End = &end
+		}
 	}
 }
 
@@ -241,6 +542,7 @@ func FromJSON(data []byte) (*Task, error) {
 	if err := json.Unmarshal(data, &task); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal task: %w", err)
 	}
+	task.NormalizeTimestamps()
 
 	if err := task.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid task data: %w", err)
@@ -259,6 +561,50 @@ func (t *Task) IsComplete() bool {
 	return t.Status == StatusDone
 }
 
+// IsCancelled checks if the task was abandoned rather than completed
+func (t *Task) IsCancelled() bool {
+	return t.Status == StatusCancelled
+}
+
+// IsClosed checks if the task needs no more work: it is done or cancelled
+func (t *Task) IsClosed() bool {
+	return t.IsComplete() || t.IsCancelled()
+}
+
+// IsStarted checks if the task is actionable at now: it has no start date or
+// the start date is not after now
+func (t *Task) IsStarted(now time.Time) bool {
+	return t.StartDate == nil || !t.StartDate.After(now)
+}
+
+// InStatusSince returns when the task moved to its current status, falling
+// back to its creation time when that was never recorded
+func (t *Task) InStatusSince() time.Time {
+	if t.StatusChangedAt.IsZero() {
+		return t.CreatedAt
+	}
+	return t.StatusChangedAt
+}
+
+// TimeInStatus returns how long the task has had its current status as of now
+func (t *Task) TimeInStatus(now time.Time) time.Duration {
+	if since := t.InStatusSince(); now.After(since) {
+		return now.Sub(since)
+	}
+	return 0
+}
+
+// IsStuck checks if the task is open and its status hasn't changed since
+// cutoff
+func (t *Task) IsStuck(cutoff time.Time) bool {
+	return !t.IsClosed() && t.InStatusSince().Before(cutoff)
+}
+
+// IsOverdue checks if the task has a due date before now and is not closed
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.DueDate != nil && !t.IsClosed() && t.DueDate.Before(now)
+}
+
 // IsPending checks if the task is pending
 func (t *Task) IsPending() bool {
 	return t.Status == StatusPending
@@ -291,10 +637,16 @@ func (t *Task) GetAge() string {
 
 // TaskFilter represents filter criteria for tasks
 type TaskFilter struct {
-	Status     *Status
-	Priority   *Priority
-	AssignedTo *string
-	LockedBy   *string
+	Status         *Status
+	Priority       *Priority
+	AssignedTo     *string
+	LockedBy       *string
+	HideDone       bool       // exclude done tasks unless Status explicitly asks for them
+	ChangedSince   *time.Time // only tasks updated strictly after this time
+	OverdueAt      *time.Time // only tasks overdue as of this time
+	StartedAt      *time.Time // only tasks whose start date has been reached by this time
+	CompletedSince *time.Time // only done tasks completed at or after this time
+	StuckSince     *time.Time // only open tasks whose status hasn't changed since this time
 }
 
 // Matches checks if a task matches the filter criteria
@@ -315,53 +667,131 @@ func (f *TaskFilter) Matches(task *Task) bool {
 		return false
 	}
 
+	if f.HideDone && f.Status == nil && f.CompletedSince == nil && task.IsClosed() {
+		return false
+	}
+
+	if f.ChangedSince != nil && !task.UpdatedAt.After(*f.ChangedSince) {
+		return false
+	}
+
+	if f.OverdueAt != nil && !task.IsOverdue(*f.OverdueAt) {
+		return false
+	}
+
+	if f.StartedAt != nil && !task.IsStarted(*f.StartedAt) {
+		return false
+	}
+
+	if f.StuckSince != nil && !task.IsStuck(*f.StuckSince) {
+		return false
+	}
+
+	if f.CompletedSince != nil && (!task.IsComplete() || task.CompletedAt == nil || task.CompletedAt.Before(*f.CompletedSince)) {
+		return false
+	}
+
 	return true
 }
 
-// TaskSorter defines how tasks should be sorted
+// sortFields lists the fields TaskSorter can sort by
+var sortFields = []string{"id", "title", "status", "priority", "created_at", "updated_at", "due_date", "position"}
+
+// IsValidSortField checks if tasks can be sorted by a field
+func IsValidSortField(field string) bool {
+	for _, valid := range sortFields {
+		if field == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// SortFields returns the field names TaskSorter can sort by
+func SortFields() []string {
+	return append([]string(nil), sortFields...)
+}
+
+// TaskSorter defines how tasks should be sorted. Ascending order is the
+// natural one for each field: lowest ID, title A-Z, status in workflow order
+// (pending first), most urgent priority, earliest time and top of the board
+// first.
 type TaskSorter struct {
-	Field string // "id", "title", "status", "priority", "created_at", "updated_at"
-	Desc  bool   // true for descending order
+	Field    string // one of SortFields
+	Desc     bool   // true to reverse the natural order
+	DoneLast bool   // put done tasks after all others, whatever the field
 }
 
-// Sort sorts a slice of tasks according to the sorter criteria
+// Sort sorts a slice of tasks according to the sorter criteria. Tasks that
+// compare equal keep their relative order.
 func (s *TaskSorter) Sort(tasks []*Task) {
 	if len(tasks) <= 1 {
 		return
 	}
 
-	// Simple bubble sort for now - can be optimized later
-	for i := 0; i < len(tasks)-1; i++ {
-		for j := 0; j < len(tasks)-i-1; j++ {
-			if s.shouldSwap(tasks[j], tasks[j+1]) {
-				tasks[j], tasks[j+1] = tasks[j+1], tasks[j]
-			}
-		}
-	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return s.less(tasks[i], tasks[j])
+	})
+}
+
+// less reports whether t1 sorts strictly before t2. shouldSwap(a, b) says b
+// may come before a, which also holds for ties, so t1 goes first only when
+// shouldSwap puts it ahead of t2 and not the other way round.
+func (s *TaskSorter) less(t1, t2 *Task) bool {
+	return s.shouldSwap(t2, t1) && !s.shouldSwap(t1, t2)
 }
 
 // shouldSwap determines if two tasks should be swapped based on sort criteria
 func (s *TaskSorter) shouldSwap(t1, t2 *Task) bool {
+	// Done tasks trail the rest before the field is even looked at
+	if s.DoneLast && t1.IsClosed() != t2.IsClosed() {
+		return t1.IsClosed()
+	}
+
+	// Tasks without a due date go last in either direction
+	if s.Field == "due_date" && (t1.DueDate == nil) != (t2.DueDate == nil) {
+		return t1.DueDate == nil
+	}
+
+	// So do tasks that were never placed on the board
+	if s.Field == "position" && (t1.Position == 0) != (t2.Position == 0) {
+		return t1.Position == 0
+	}
+
 	var result bool
 
+	// result says t1 goes first in ascending order
 	switch s.Field {
 	case "id":
-		result = t1.ID > t2.ID
+		result = t1.ID < t2.ID
 	case "title":
-		result = t1.Title > t2.Title
+		result = t1.Title < t2.Title
 	case "status":
-		result = string(t1.Status) > string(t2.Status)
+		result = statusWeight(t1.Status) < statusWeight(t2.Status)
 	case "priority":
 		// Priority sorting: high > medium > low
 		p1 := priorityWeight(t1.Priority)
 		p2 := priorityWeight(t2.Priority)
 		result = p1 > p2
 	case "created_at":
-		result = t1.CreatedAt.After(t2.CreatedAt)
+		result = t1.CreatedAt.Before(t2.CreatedAt)
 	case "updated_at":
-		result = t1.UpdatedAt.After(t2.UpdatedAt)
+		result = t1.UpdatedAt.Before(t2.UpdatedAt)
+	case "due_date":
+		if t1.DueDate == nil {
+			result = t1.ID < t2.ID
+		} else {
+			result = t1.DueDate.Before(*t2.DueDate)
+		}
+	case "position":
+		// Top of the board first, then by ID
+		if t1.Position == t2.Position {
+			result = t1.ID < t2.ID
+		} else {
+			result = t1.Position < t2.Position
+		}
 	default:
-		result = t1.ID > t2.ID // Default to ID sorting
+		result = t1.ID < t2.ID // Default to ID sorting
 	}
 
 	if s.Desc {
@@ -374,6 +804,8 @@ func (s *TaskSorter) shouldSwap(t1, t2 *Task) bool {
 // priorityWeight returns a numeric weight for priority comparison
 func priorityWeight(priority Priority) int {
 	switch priority {
+	case PriorityCritical:
+		return 4
 	case PriorityHigh:
 		return 3
 	case PriorityMedium:
@@ -384,3 +816,13 @@ func priorityWeight(priority Priority) int {
 		return 0
 	}
 }
+
+// statusWeight returns a status's place in the workflow, for sorting
+func statusWeight(status Status) int {
+	for i, valid := range ValidStatuses() {
+		if status == valid {
+			return i
+		}
+	}
+	return len(ValidStatuses())
+}
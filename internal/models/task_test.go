@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -121,6 +122,7 @@ func TestIsValidStatus(t *testing.T) {
 		{"pending", true},
 		{"in_progress", true},
 		{"done", true},
+		{"cancelled", true},
 		{"invalid", false},
 		{"", false},
 		{"PENDING", false}, // case sensitive
@@ -143,6 +145,7 @@ func TestIsValidPriority(t *testing.T) {
 		{"low", true},
 		{"medium", true},
 		{"high", true},
+		{"critical", true},
 		{"invalid", false},
 		{"", false},
 		{"LOW", false}, // case sensitive
@@ -157,6 +160,69 @@ func TestIsValidPriority(t *testing.T) {
 	}
 }
 
+func TestNormalizeStatus(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Status
+	}{
+		{"pending", StatusPending},
+		{"PENDING", StatusPending},
+		{"todo", StatusPending},
+		{"To-Do", StatusPending},
+		{"in_progress", StatusInProgress},
+		{"in-progress", StatusInProgress},
+		{"In Progress", StatusInProgress},
+		{"WIP", StatusInProgress},
+		{"done", StatusDone},
+		{"DONE", StatusDone},
+		{"complete", StatusDone},
+		{"completed", StatusDone},
+		{" done ", StatusDone},
+		{"cancelled", StatusCancelled},
+		{"Canceled", StatusCancelled},
+		{"bogus", Status("bogus")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeStatus(tt.input); got != tt.want {
+				t.Errorf("NormalizeStatus(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if IsValidStatus(string(NormalizeStatus("bogus"))) {
+		t.Error("Expected unknown input to stay invalid after normalizing")
+	}
+}
+
+func TestNormalizePriority(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Priority
+	}{
+		{"high", PriorityHigh},
+		{"HIGH", PriorityHigh},
+		{"hi", PriorityHigh},
+		{"medium", PriorityMedium},
+		{"med", PriorityMedium},
+		{"Normal", PriorityMedium},
+		{"low", PriorityLow},
+		{"lo", PriorityLow},
+		{"critical", PriorityCritical},
+		{"crit", PriorityCritical},
+		{"urgent", Priority("urgent")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizePriority(tt.input); got != tt.want {
+				t.Errorf("NormalizePriority(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTaskStatusUpdates(t *testing.T) {
 	task := NewTask(1, "Test Task")
 	originalTime := task.UpdatedAt
@@ -367,4 +433,624 @@ func TestTaskGetAge(t *testing.T) {
 	if len(age) < 3 {
 		t.Errorf("Expected meaningful age string, got '%s'", age)
 	}
-}
\ No newline at end of file
+}
+func TestTaskFilterHideDone(t *testing.T) {
+	pending := NewTask(1, "Pending task")
+	done := NewTask(2, "Done task")
+	done.Status = StatusDone
+
+	filter := &TaskFilter{HideDone: true}
+	if !filter.Matches(pending) {
+		t.Error("Expected pending task to match when hiding done tasks")
+	}
+	if filter.Matches(done) {
+		t.Error("Expected done task to be hidden by default")
+	}
+
+	// An explicit status filter overrides the default hiding
+	status := StatusDone
+	filter.Status = &status
+	if !filter.Matches(done) {
+		t.Error("Expected --status done to show done tasks explicitly")
+	}
+}
+
+func TestTaskCancelled(t *testing.T) {
+	task := NewTask(1, "Abandoned")
+	if err := task.Complete(ResolutionWontFix); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if err := task.UpdateStatus(StatusCancelled); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+
+	if !task.IsCancelled() || !task.IsClosed() || task.IsComplete() {
+		t.Errorf("Expected a cancelled task to be closed but not complete, got status %s", task.Status)
+	}
+	if task.CompletedAt != nil || task.Resolution != "" {
+		t.Errorf("Expected cancelling to clear the completion details, got %v, %q", task.CompletedAt, task.Resolution)
+	}
+
+	past := time.Now().UTC().Add(-time.Hour)
+	task.DueDate = &past
+	if task.IsOverdue(time.Now()) || task.NeedsReminder(time.Now(), time.Hour) {
+		t.Error("Expected a cancelled task not to be overdue or need a reminder")
+	}
+	if (&TaskFilter{HideDone: true}).Matches(task) {
+		t.Error("Expected --hide-done to hide cancelled tasks")
+	}
+
+	// Reopening makes it open work again
+	if err := task.UpdateStatus(StatusPending); err != nil {
+		t.Fatalf("UpdateStatus failed: %v", err)
+	}
+	if task.IsClosed() || !task.IsOverdue(time.Now()) {
+		t.Error("Expected a reopened task to be open and overdue again")
+	}
+}
+
+func TestTaskTimestampsUTC(t *testing.T) {
+	task := NewTask(1, "UTC task")
+	if task.CreatedAt.Location() != time.UTC || task.UpdatedAt.Location() != time.UTC {
+		t.Errorf("Expected new task timestamps in UTC, got %v / %v", task.CreatedAt.Location(), task.UpdatedAt.Location())
+	}
+
+	task.UpdateStatus(StatusInProgress)
+	if task.UpdatedAt.Location() != time.UTC {
+		t.Errorf("Expected updated_at in UTC after update, got %v", task.UpdatedAt.Location())
+	}
+}
+
+func TestTaskTimestampsAcrossDSTBoundary(t *testing.T) {
+	// Fall-back transition: 01:30 EDT happens before 01:10 EST even though
+	// the wall clock reads earlier the second time round
+	edt := time.FixedZone("EDT", -4*60*60)
+	est := time.FixedZone("EST", -5*60*60)
+	created := time.Date(2024, 11, 3, 1, 30, 0, 0, edt)
+	updated := time.Date(2024, 11, 3, 1, 10, 0, 0, est)
+
+	data := fmt.Sprintf(`{"id":1,"title":"DST task","status":"pending","priority":"medium","created_at":%q,"updated_at":%q}`,
+		created.Format(time.RFC3339), updated.Format(time.RFC3339))
+
+	task, err := FromJSON([]byte(data))
+	if err != nil {
+		t.Fatalf("Expected task across DST boundary to be valid, got %v", err)
+	}
+
+	if task.CreatedAt.Location() != time.UTC || task.UpdatedAt.Location() != time.UTC {
+		t.Errorf("Expected parsed timestamps normalized to UTC")
+	}
+	if !task.CreatedAt.Equal(created) || !task.UpdatedAt.Equal(updated) {
+		t.Errorf("Normalization changed the instants: %v / %v", task.CreatedAt, task.UpdatedAt)
+	}
+	if got := task.UpdatedAt.Format("15:04"); got != "06:10" {
+		t.Errorf("Expected updated_at 06:10 UTC, got %s", got)
+	}
+	if !task.UpdatedAt.After(task.CreatedAt) {
+		t.Error("Expected updated_at after created_at once compared in UTC")
+	}
+}
+
+func TestTaskFilterChangedSince(t *testing.T) {
+	since := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	before := NewTask(1, "Before")
+	before.UpdatedAt = since.Add(-time.Second)
+	atBoundary := NewTask(2, "At boundary")
+	atBoundary.UpdatedAt = since
+	after := NewTask(3, "After")
+	after.UpdatedAt = since.Add(time.Nanosecond)
+
+	filter := &TaskFilter{ChangedSince: &since}
+	if filter.Matches(before) {
+		t.Error("Expected task updated before the cutoff to be excluded")
+	}
+	if filter.Matches(atBoundary) {
+		t.Error("Expected task updated exactly at the cutoff to be excluded")
+	}
+	if !filter.Matches(after) {
+		t.Error("Expected task updated after the cutoff to match")
+	}
+}
+
+func TestTaskFilterOverdue(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	pastDue := now.Add(-time.Second)
+	dueNow := now
+	future := now.Add(time.Second)
+
+	noDue := NewTask(1, "No due date")
+	late := NewTask(2, "Late")
+	late.SetDueDate(&pastDue)
+	atBoundary := NewTask(3, "Due right now")
+	atBoundary.SetDueDate(&dueNow)
+	upcoming := NewTask(4, "Upcoming")
+	upcoming.SetDueDate(&future)
+	lateButDone := NewTask(5, "Late but done")
+	lateButDone.SetDueDate(&pastDue)
+	lateButDone.UpdateStatus(StatusDone)
+
+	filter := &TaskFilter{OverdueAt: &now}
+	expected := map[*Task]bool{
+		noDue:       false,
+		late:        true,
+		atBoundary:  false,
+		upcoming:    false,
+		lateButDone: false,
+	}
+	for task, want := range expected {
+		if got := filter.Matches(task); got != want {
+			t.Errorf("Overdue filter on %q: got %v, expected %v", task.Title, got, want)
+		}
+	}
+}
+
+func TestTaskFilterCompletedSince(t *testing.T) {
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	completedAt := func(title string, at time.Time) *Task {
+		task := NewTask(1, title)
+		task.UpdateStatus(StatusDone)
+		task.CompletedAt = &at
+		return task
+	}
+
+	before := completedAt("Before", since.Add(-time.Second))
+	atBoundary := completedAt("At boundary", since)
+	after := completedAt("After", since.Add(time.Hour))
+	reopened := completedAt("Reopened", since.Add(time.Hour))
+	reopened.Status = StatusPending
+	legacy := NewTask(2, "Done without completed_at")
+	legacy.Status = StatusDone
+
+	// HideDone must not hide what --completed-since asks for
+	filter := &TaskFilter{CompletedSince: &since, HideDone: true}
+	expected := map[*Task]bool{
+		before:     false,
+		atBoundary: true,
+		after:      true,
+		reopened:   false,
+		legacy:     false,
+	}
+	for task, want := range expected {
+		if got := filter.Matches(task); got != want {
+			t.Errorf("Completed-since filter on %q: got %v, expected %v", task.Title, got, want)
+		}
+	}
+}
+
+func TestTaskCompleteWithResolution(t *testing.T) {
+	task := NewTask(1, "Duplicate report")
+
+	if err := task.Complete(ResolutionDuplicate); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if task.Status != StatusDone || task.Resolution != ResolutionDuplicate {
+		t.Errorf("Expected done/duplicate, got %s/%s", task.Status, task.Resolution)
+	}
+	if task.CompletedAt == nil {
+		t.Fatal("Expected completed_at to be set")
+	}
+
+	if err := task.Complete("abandoned"); err == nil {
+		t.Error("Expected error for invalid resolution")
+	}
+
+	// Reopening clears the completion details
+	task.UpdateStatus(StatusPending)
+	if task.CompletedAt != nil || task.Resolution != "" {
+		t.Errorf("Expected completion cleared on reopen, got %v/%s", task.CompletedAt, task.Resolution)
+	}
+
+	// Plain status changes to done count as resolved "done"
+	task.UpdateStatus(StatusDone)
+	if task.EffectiveResolution() != ResolutionDone {
+		t.Errorf("Expected effective resolution done, got %s", task.EffectiveResolution())
+	}
+}
+
+func TestSanitizeTitle(t *testing.T) {
+	tests := map[string]string{
+		"Plain title":            "Plain title",
+		"  padded  ":             "padded",
+		"bell\a and\x00 nul":     "bell and nul",
+		"multi\nline\ttitle\r":   "multilinetitle",
+		"escape \x1b[31mred":     "escape [31mred",
+		"unicode ✓ stays":        "unicode ✓ stays",
+		"\x00\x01\x02":           "",
+	}
+
+	for input, expected := range tests {
+		if got := SanitizeTitle(input); got != expected {
+			t.Errorf("SanitizeTitle(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestTaskValidateClockSkew(t *testing.T) {
+	tolerance := 5 * time.Second
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	inside := NewTask(1, "Just inside")
+	inside.CreatedAt = created
+	inside.UpdatedAt = created.Add(-tolerance)
+	if err := inside.ValidateWithClockSkew(tolerance); err != nil {
+		t.Errorf("Expected skew at the tolerance to be accepted, got %v", err)
+	}
+
+	outside := NewTask(2, "Just outside")
+	outside.CreatedAt = created
+	outside.UpdatedAt = created.Add(-tolerance - time.Millisecond)
+	if err := outside.ValidateWithClockSkew(tolerance); err == nil {
+		t.Error("Expected skew beyond the tolerance to be rejected")
+	}
+
+	if err := inside.ValidateWithClockSkew(0); err == nil {
+		t.Error("Expected any skew to be rejected with zero tolerance")
+	}
+}
+
+func TestTaskRepairClockSkew(t *testing.T) {
+	tolerance := 5 * time.Second
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	inside := NewTask(1, "Just inside")
+	inside.CreatedAt = created
+	inside.UpdatedAt = created.Add(-tolerance)
+	if !inside.RepairClockSkew(tolerance) || !inside.UpdatedAt.Equal(created) {
+		t.Errorf("Expected updated_at moved to created_at, got %s", inside.UpdatedAt)
+	}
+
+	outside := NewTask(2, "Just outside")
+	outside.CreatedAt = created
+	outside.UpdatedAt = created.Add(-tolerance - time.Millisecond)
+	if outside.RepairClockSkew(tolerance) || outside.UpdatedAt.Equal(created) {
+		t.Error("Expected skew beyond the tolerance to be left alone")
+	}
+
+	ordered := NewTask(3, "No skew")
+	ordered.CreatedAt = created
+	ordered.UpdatedAt = created.Add(time.Minute)
+	if ordered.RepairClockSkew(tolerance) {
+		t.Error("Expected a task without skew to be left alone")
+	}
+}
+
+func TestTaskSorterDueDate(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	dated := func(id, days int) *Task {
+		task := NewTask(id, fmt.Sprintf("Task %d", id))
+		due := base.AddDate(0, 0, days)
+		task.DueDate = &due
+		return task
+	}
+
+	// The two directions order dated tasks oppositely
+	earliestFirst := make(map[bool]bool)
+	for _, desc := range []bool{false, true} {
+		tasks := []*Task{NewTask(1, "Undated"), dated(2, 5), NewTask(3, "Also undated"), dated(4, 1), dated(5, 3)}
+		sorter := &TaskSorter{Field: "due_date", Desc: desc}
+		sorter.Sort(tasks)
+
+		if tasks[3].DueDate != nil || tasks[4].DueDate != nil {
+			t.Errorf("desc=%v: expected undated tasks last, got %v", desc, taskIDs(tasks))
+		}
+		first, last := tasks[0].DueDate, tasks[2].DueDate
+		if first == nil || last == nil || tasks[1].DueDate == nil {
+			t.Fatalf("desc=%v: expected dated tasks first, got %v", desc, taskIDs(tasks))
+		}
+		if !first.Before(*tasks[1].DueDate) || !tasks[1].DueDate.Before(*last) {
+			if !first.After(*tasks[1].DueDate) || !tasks[1].DueDate.After(*last) {
+				t.Errorf("desc=%v: dated tasks not ordered by due date, got %v", desc, taskIDs(tasks))
+			}
+		}
+		earliestFirst[desc] = first.Before(*last)
+	}
+	if earliestFirst[false] == earliestFirst[true] {
+		t.Error("Expected Desc to reverse the order of dated tasks")
+	}
+}
+
+func taskIDs(tasks []*Task) []int {
+	ids := make([]int, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func TestTaskIsStartedBoundary(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	task := NewTask(1, "Scheduled")
+
+	if !task.IsStarted(start) {
+		t.Error("Expected a task without a start date to be started")
+	}
+
+	task.SetStartDate(&start)
+	if task.IsStarted(start.Add(-time.Nanosecond)) {
+		t.Error("Expected the task not started just before its start date")
+	}
+	if !task.IsStarted(start) {
+		t.Error("Expected the task started exactly at its start date")
+	}
+
+	now := start.Add(-time.Hour)
+	filter := &TaskFilter{StartedAt: &now}
+	if filter.Matches(task) {
+		t.Error("Expected the scheduling filter to hide a task that starts later")
+	}
+	now = start.Add(time.Hour)
+	if !filter.Matches(task) {
+		t.Error("Expected the scheduling filter to show a task that has started")
+	}
+}
+
+func TestTaskValidateSchedule(t *testing.T) {
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+
+	task := NewTask(1, "Scheduled")
+	task.SetStartDate(&day)
+	task.SetDueDate(&next)
+	if err := task.Validate(); err != nil {
+		t.Errorf("Expected start before due to be valid, got %v", err)
+	}
+
+	task.SetDueDate(&day)
+	if err := task.Validate(); err != nil {
+		t.Errorf("Expected start equal to due to be valid, got %v", err)
+	}
+
+	task.SetStartDate(&next)
+	if err := task.ValidateSchedule(); err == nil {
+		t.Error("Expected start after due to be rejected")
+	}
+	if err := task.Validate(); err == nil {
+		t.Error("Expected Validate to reject start after due")
+	}
+
+	task.SetDueDate(nil)
+	if err := task.Validate(); err != nil {
+		t.Errorf("Expected a start date alone to be valid, got %v", err)
+	}
+}
+
+// bubbleSortTasks is the original TaskSorter.Sort, kept as a reference for the
+// ordering the faster implementation must preserve
+func bubbleSortTasks(s *TaskSorter, tasks []*Task) {
+	for i := 0; i < len(tasks)-1; i++ {
+		for j := 0; j < len(tasks)-i-1; j++ {
+			if s.shouldSwap(tasks[j], tasks[j+1]) {
+				tasks[j], tasks[j+1] = tasks[j+1], tasks[j]
+			}
+		}
+	}
+}
+
+// sortableTasks builds n tasks with varied priorities, statuses, timestamps
+// and due dates
+func sortableTasks(n int) []*Task {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	priorities := []Priority{PriorityLow, PriorityHigh, PriorityMedium}
+	statuses := []Status{StatusDone, StatusPending, StatusInProgress}
+
+	tasks := make([]*Task, n)
+	for i := range tasks {
+		// Spread IDs out of order so every field has work to do
+		id := (i*7919)%n + 1
+		task := NewTask(id, fmt.Sprintf("Task %05d", (i*104729)%n))
+		task.Priority = priorities[i%len(priorities)]
+		task.Status = statuses[(i/2)%len(statuses)]
+		task.CreatedAt = base.Add(time.Duration((i*31)%n) * time.Minute)
+		task.UpdatedAt = task.CreatedAt.Add(time.Duration(i%5) * time.Hour)
+		if i%4 != 0 {
+			due := base.AddDate(0, 0, (i*13)%97)
+			task.DueDate = &due
+		}
+		if i%3 != 0 {
+			task.Position = (i*17)%50 + 1
+		}
+		tasks[i] = task
+	}
+	return tasks
+}
+
+func TestTaskSorterMatchesBubbleSort(t *testing.T) {
+	for _, field := range []string{"id", "title", "status", "priority", "created_at", "updated_at", "due_date", "position", "unknown"} {
+		for _, desc := range []bool{false, true} {
+			sorter := &TaskSorter{Field: field, Desc: desc}
+			got := sortableTasks(300)
+			want := sortableTasks(300)
+
+			sorter.Sort(got)
+			bubbleSortTasks(sorter, want)
+
+			// Ties may be ordered differently, but every position must
+			// hold a task that compares equal to the reference
+			for i := range got {
+				if sorter.less(got[i], want[i]) || sorter.less(want[i], got[i]) {
+					t.Errorf("%s desc=%v: position %d holds #%d, reference has #%d", field, desc, i, got[i].ID, want[i].ID)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestTaskSorterStable(t *testing.T) {
+	tasks := []*Task{NewTask(1, "A"), NewTask(2, "B"), NewTask(3, "C"), NewTask(4, "D")}
+	tasks[0].Priority, tasks[1].Priority, tasks[2].Priority, tasks[3].Priority = PriorityLow, PriorityHigh, PriorityLow, PriorityHigh
+
+	sorter := &TaskSorter{Field: "priority"}
+	sorter.Sort(tasks)
+
+	if got := taskIDs(tasks); fmt.Sprint(got) != "[2 4 1 3]" {
+		t.Errorf("Expected high before low with ties in input order, got %v", got)
+	}
+}
+
+func TestTaskSorterCriticalFirst(t *testing.T) {
+	tasks := []*Task{NewTask(1, "A"), NewTask(2, "B"), NewTask(3, "C"), NewTask(4, "D")}
+	tasks[0].Priority, tasks[1].Priority, tasks[2].Priority, tasks[3].Priority = PriorityHigh, PriorityLow, PriorityCritical, PriorityMedium
+
+	sorter := &TaskSorter{Field: "priority"}
+	sorter.Sort(tasks)
+
+	if got := taskIDs(tasks); fmt.Sprint(got) != "[3 1 4 2]" {
+		t.Errorf("Expected critical, high, medium, low, got %v", got)
+	}
+}
+
+func TestTaskSorterNaturalOrder(t *testing.T) {
+	tasks := []*Task{NewTask(2, "Bravo"), NewTask(3, "Alpha"), NewTask(1, "Charlie")}
+	tasks[0].Status, tasks[1].Status, tasks[2].Status = StatusDone, StatusPending, StatusCancelled
+
+	for _, tc := range []struct {
+		field string
+		desc  bool
+		want  string
+	}{
+		{"id", false, "[1 2 3]"},
+		{"id", true, "[3 2 1]"},
+		{"title", false, "[3 2 1]"},
+		{"status", false, "[3 2 1]"},
+		{"status", true, "[1 2 3]"},
+	} {
+		sorter := &TaskSorter{Field: tc.field, Desc: tc.desc}
+		sorter.Sort(tasks)
+		if got := fmt.Sprint(taskIDs(tasks)); got != tc.want {
+			t.Errorf("%s desc=%v: expected %s, got %s", tc.field, tc.desc, tc.want, got)
+		}
+	}
+
+	if !IsValidSortField("updated_at") || IsValidSortField("size") {
+		t.Error("Expected only the sorter's fields to be valid")
+	}
+}
+
+func TestTaskSorterDoneLast(t *testing.T) {
+	for _, field := range []string{"id", "title", "status", "priority", "created_at", "updated_at", "due_date", "position"} {
+		for _, desc := range []bool{false, true} {
+			tasks := sortableTasks(60)
+			sorter := &TaskSorter{Field: field, Desc: desc, DoneLast: true}
+			sorter.Sort(tasks)
+
+			firstDone := -1
+			for i, task := range tasks {
+				if task.IsComplete() && firstDone < 0 {
+					firstDone = i
+				}
+				if !task.IsComplete() && firstDone >= 0 {
+					t.Errorf("%s desc=%v: open task #%d at %d after done task at %d", field, desc, task.ID, i, firstDone)
+					break
+				}
+			}
+			if firstDone <= 0 {
+				t.Fatalf("%s desc=%v: expected open tasks followed by done ones, first done at %d", field, desc, firstDone)
+			}
+
+			// Within each group the primary field still decides
+			plain := &TaskSorter{Field: field, Desc: desc}
+			for i := 1; i < len(tasks); i++ {
+				if i != firstDone && plain.less(tasks[i], tasks[i-1]) {
+					t.Errorf("%s desc=%v: #%d and #%d out of order", field, desc, tasks[i-1].ID, tasks[i].ID)
+					break
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkTaskSorterSort(b *testing.B) {
+	for _, field := range []string{"id", "priority", "due_date"} {
+		b.Run(field, func(b *testing.B) {
+			sorter := &TaskSorter{Field: field}
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tasks := sortableTasks(10000)
+				b.StartTimer()
+				sorter.Sort(tasks)
+			}
+		})
+	}
+}
+
+func BenchmarkTaskSorterBubbleSort(b *testing.B) {
+	sorter := &TaskSorter{Field: "id"}
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tasks := sortableTasks(10000)
+		b.StartTimer()
+		bubbleSortTasks(sorter, tasks)
+	}
+}
+
+func TestTaskStatusChangedAt(t *testing.T) {
+	task := NewTask(1, "Task")
+	if !task.StatusChangedAt.Equal(task.CreatedAt) {
+		t.Errorf("Expected new task's status change time to be its creation time")
+	}
+
+	past := time.Now().UTC().Add(-48 * time.Hour)
+	task.StatusChangedAt = past
+
+	// Edits that keep the status don't reset it
+	task.UpdateStatus(StatusPending)
+	if !task.StatusChangedAt.Equal(past) {
+		t.Errorf("Expected status change time kept when the status is unchanged, got %v", task.StatusChangedAt)
+	}
+
+	task.UpdateStatus(StatusInProgress)
+	if !task.StatusChangedAt.After(past) {
+		t.Errorf("Expected status change time updated, got %v", task.StatusChangedAt)
+	}
+
+	// Tasks saved before the field existed fall back to their creation time
+	legacy, err := FromJSON([]byte(`{"id":2,"title":"Old","status":"in_progress","priority":"low",` +
+		`"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-03-01T00:00:00Z"}`))
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if !legacy.StatusChangedAt.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected status change time initialized from created_at, got %v", legacy.StatusChangedAt)
+	}
+}
+
+func TestTaskFilterStuckSince(t *testing.T) {
+	now := time.Now().UTC()
+	db := NewProjectDatabase(NewProject("test-project", "/path/to/project"))
+	for _, spec := range []struct {
+		status Status
+		since  time.Duration
+	}{
+		{StatusInProgress, 5 * 24 * time.Hour}, // #1 stuck
+		{StatusInProgress, time.Hour},          // #2 recently started
+		{StatusPending, 4 * 24 * time.Hour},    // #3 stuck
+		{StatusDone, 10 * 24 * time.Hour},      // #4 done, never stuck
+		{StatusPending, 3 * 24 * time.Hour},    // #5 exactly at the cutoff
+	} {
+		task := NewTask(db.NextID, "Task")
+		task.UpdateStatus(spec.status)
+		task.StatusChangedAt = now.Add(-spec.since)
+		if err := db.AddTask(task); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+
+	cutoff := now.Add(-3 * 24 * time.Hour)
+	tasks := db.ListTasks(&TaskFilter{StuckSince: &cutoff})
+	if ids := taskIDs(tasks); len(ids) != 2 || ids[0] != 1 || ids[1] != 3 {
+		t.Errorf("Expected tasks #1 and #3 stuck, got %v", ids)
+	}
+
+	inProgress := StatusInProgress
+	tasks = db.ListTasks(&TaskFilter{StuckSince: &cutoff, Status: &inProgress})
+	if ids := taskIDs(tasks); len(ids) != 1 || ids[0] != 1 {
+		t.Errorf("Expected only task #1 stuck in progress, got %v", ids)
+	}
+
+	if d := tasks[0].TimeInStatus(now); d != 5*24*time.Hour {
+		t.Errorf("Expected 5 days in status, got %v", d)
+	}
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeEntry is one stretch of work on a task, opened by start and closed by
+// stop. End is nil while the entry is running.
+type TimeEntry struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// Duration is how long the entry ran, up to now if it is still running
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.End != nil {
+		end = *e.End
+	}
+	if end.After(e.Start) {
+		return end.Sub(e.Start)
+	}
+	return 0
+}
+
+// RunningTimeEntry returns the task's open time entry, or nil if its timer
+// isn't running
+func (t *Task) RunningTimeEntry() *TimeEntry {
+	if len(t.TimeEntries) == 0 || t.TimeEntries[len(t.TimeEntries)-1].End != nil {
+		return nil
+	}
+	return &t.TimeEntries[len(t.TimeEntries)-1]
+}
+
+// StartTimer opens a time entry at now and updates the timestamp. A task has
+// at most one running entry.
+func (t *Task) StartTimer(now time.Time) (*TimeEntry, error) {
+	if running := t.RunningTimeEntry(); running != nil {
+		return nil, fmt.Errorf("task #%d is already running since %s; stop it first", t.ID, running.Start.Local().Format("2006-01-02 15:04"))
+	}
+
+	now = now.UTC()
+	t.TimeEntries = append(t.TimeEntries, TimeEntry{Start: now})
+	t.UpdatedAt = now
+	return &t.TimeEntries[len(t.TimeEntries)-1], nil
+}
+
+// StopTimer closes the running time entry at now and updates the timestamp
+func (t *Task) StopTimer(now time.Time) (*TimeEntry, error) {
+	running := t.RunningTimeEntry()
+	if running == nil {
+		return nil, fmt.Errorf("task #%d is not running; start it first", t.ID)
+	}
+
+	now = now.UTC()
+	running.End = &now
+	t.UpdatedAt = now
+	return running, nil
+}
+
+// TotalTime sums the durations of the task's time entries, counting a running
+// entry up to now
+func (t *Task) TotalTime(now time.Time) time.Duration {
+	var total time.Duration
+	for _, entry := range t.TimeEntries {
+		total += entry.Duration(now)
+	}
+	return total
+}
+
+// cloneTimeEntries copies time entries, including their end times
+func cloneTimeEntries(entries []TimeEntry) []TimeEntry {
+	if entries == nil {
+		return nil
+	}
+	copied := make([]TimeEntry, len(entries))
+	for i, entry := range entries {
+		copied[i] = TimeEntry{Start: entry.Start, End: cloneTime(entry.End)}
+	}
+	return copied
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTaskTimer(t *testing.T) {
+	task := NewTask(1, "Timed")
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	if _, err := task.StopTimer(start); err == nil {
+		t.Error("Expected stopping a task that isn't running to fail")
+	}
+	if _, err := task.StartTimer(start); err != nil {
+		t.Fatalf("StartTimer failed: %v", err)
+	}
+	if _, err := task.StartTimer(start.Add(time.Minute)); err == nil {
+		t.Error("Expected starting a running task to fail")
+	}
+	if got := task.TotalTime(start.Add(10 * time.Minute)); got != 10*time.Minute {
+		t.Errorf("Expected a running entry to count up to now, got %v", got)
+	}
+
+	entry, err := task.StopTimer(start.Add(30 * time.Minute))
+	if err != nil {
+		t.Fatalf("StopTimer failed: %v", err)
+	}
+	if entry.Duration(start.Add(time.Hour)) != 30*time.Minute || task.RunningTimeEntry() != nil {
+		t.Errorf("Expected a closed 30 minute entry, got %+v", entry)
+	}
+
+	task.StartTimer(start.Add(2 * time.Hour))
+	task.StopTimer(start.Add(2*time.Hour + 15*time.Minute))
+	if got := task.TotalTime(start.Add(5 * time.Hour)); got != 45*time.Minute {
+		t.Errorf("Expected 45 minutes over two entries, got %v", got)
+	}
+
+	clone := task.Clone()
+	*clone.TimeEntries[0].End = start
+	if task.TimeEntries[0].End.Equal(start) {
+		t.Error("Expected Clone to copy the time entries")
+	}
+}
+
+func TestTaskTimerJSON(t *testing.T) {
+	task := NewTask(1, "Timed")
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	task.StartTimer(start)
+	task.StopTimer(start.Add(90 * time.Second))
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"total_time_seconds":90`) {
+		t.Errorf("Expected computed total_time_seconds in JSON, got %s", data)
+	}
+
+	var decoded Task
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(decoded.TimeEntries) != 1 || decoded.TimeEntries[0].End == nil {
+		t.Errorf("Expected the time entry to round-trip, got %+v", decoded.TimeEntries)
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+// WIPState describes a status's occupancy against its work-in-progress limit
+type WIPState struct {
+	Status    Status `json:"status"`
+	Limit     int    `json:"limit"`
+	Count     int    `json:"count"`
+	AtLimit   bool   `json:"at_limit"`
+	OverLimit bool   `json:"over_limit"`
+}
+
+// CountByStatus returns how many tasks are in the given status, not counting
+// the task with excludeID (use 0 to count every task)
+func (db *ProjectDatabase) CountByStatus(status Status, excludeID int) int {
+	count := 0
+	for _, task := range db.Tasks {
+		if task.Status == status && task.ID != excludeID {
+			count++
+		}
+	}
+	return count
+}
+
+// WIPStates reports each limited status's task count against its limit, in
+// board order. Statuses without a positive limit are omitted.
+func (db *ProjectDatabase) WIPStates(limits map[string]int) []WIPState {
+	states := make([]WIPState, 0)
+	for _, status := range []Status{StatusPending, StatusInProgress, StatusDone} {
+		limit := limits[string(status)]
+		if limit <= 0 {
+			continue
+		}
+
+		count := db.CountByStatus(status, 0)
+		states = append(states, WIPState{
+			Status:    status,
+			Limit:     limit,
+			Count:     count,
+			AtLimit:   count >= limit,
+			OverLimit: count > limit,
+		})
+	}
+	return states
+}
+
+// WIPLimitReached reports whether moving the task with taskID into status
+// would exceed limit, along with the number of other tasks already there.
+// A limit of zero or less never blocks.
+func (db *ProjectDatabase) WIPLimitReached(taskID int, status Status, limit int) (int, bool) {
+	count := db.CountByStatus(status, taskID)
+	return count, limit > 0 && count >= limit
+}
@@ -0,0 +1,54 @@
+package models
+
+import "testing"
+
+func newWIPTestDatabase(t *testing.T, statuses ...Status) *ProjectDatabase {
+	t.Helper()
+	db := NewProjectDatabase(NewProject("wip", "/tmp/wip"))
+	for _, status := range statuses {
+		task := NewTask(db.NextID, "Task")
+		task.UpdateStatus(status)
+		if err := db.AddTask(task); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+	return db
+}
+
+func TestWIPLimitReached(t *testing.T) {
+	// Tasks 1-2 in progress, task 3 pending
+	db := newWIPTestDatabase(t, StatusInProgress, StatusInProgress, StatusPending)
+
+	if count, reached := db.WIPLimitReached(3, StatusInProgress, 3); reached || count != 2 {
+		t.Errorf("Under limit: expected 2 and not reached, got %d, %v", count, reached)
+	}
+	if count, reached := db.WIPLimitReached(3, StatusInProgress, 2); !reached || count != 2 {
+		t.Errorf("At limit: expected 2 and reached, got %d, %v", count, reached)
+	}
+
+	// A task already in the status doesn't count against itself
+	if _, reached := db.WIPLimitReached(1, StatusInProgress, 2); reached {
+		t.Error("Expected a task already in the status not to count against its own move")
+	}
+
+	if _, reached := db.WIPLimitReached(3, StatusInProgress, 0); reached {
+		t.Error("Expected a zero limit never to block")
+	}
+}
+
+func TestWIPStates(t *testing.T) {
+	db := newWIPTestDatabase(t, StatusInProgress, StatusInProgress, StatusInProgress, StatusPending)
+
+	states := db.WIPStates(map[string]int{"in_progress": 2, "pending": 5, "done": 0})
+	if len(states) != 2 {
+		t.Fatalf("Expected states for the two limited statuses, got %+v", states)
+	}
+
+	pending, inProgress := states[0], states[1]
+	if pending.Status != StatusPending || pending.Count != 1 || pending.AtLimit || pending.OverLimit {
+		t.Errorf("Unexpected pending state: %+v", pending)
+	}
+	if inProgress.Status != StatusInProgress || inProgress.Count != 3 || !inProgress.AtLimit || !inProgress.OverLimit {
+		t.Errorf("Unexpected in_progress state: %+v", inProgress)
+	}
+}
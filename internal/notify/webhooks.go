@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"quicktodo/internal/config"
+	"quicktodo/internal/models"
+	"sync"
+	"time"
+)
+
+// Webhook delivery: each URL gets webhookAttempts tries of webhookTimeout
+// each, waiting webhookRetryDelay after the first failure and twice as long
+// after each one that follows. Variables so tests can shorten them.
+var (
+	webhookAttempts   = 3
+	webhookTimeout    = 3 * time.Second
+	webhookRetryDelay = 250 * time.Millisecond
+)
+
+// webhookPayload is the body POSTed to webhooks: the notification, plus a
+// one-line summary under the keys Slack ("text") and Discord ("content")
+// display, so their incoming webhooks accept it as is
+type webhookPayload struct {
+	NotificationMessage
+	Text    string `json:"text"`
+	Content string `json:"content"`
+}
+
+// NotifyWebhooks POSTs a notification to every webhook in the config. The
+// URLs are called concurrently; the error lists those that still failed
+// after retrying.
+func NotifyWebhooks(cfg *config.Config, msgType string, data interface{}, projectName string) error {
+	if len(cfg.Webhooks) == 0 {
+		return nil
+	}
+
+	summary := webhookSummary(msgType, data, projectName)
+	body, err := json.Marshal(webhookPayload{
+		NotificationMessage: NotificationMessage{
+			Type:      msgType,
+			Data:      data,
+			Project:   projectName,
+			Timestamp: time.Now().UTC(),
+		},
+		Text:    summary,
+		Content: summary,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	client := &http.Client{Timeout: webhookTimeout}
+	errs := make([]error, len(cfg.Webhooks))
+	var wg sync.WaitGroup
+	for i, url := range cfg.Webhooks {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			if err := postWebhook(client, url, body); err != nil {
+				errs[i] = fmt.Errorf("webhook %s: %w", url, err)
+			}
+		}(i, url)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// postWebhook POSTs body to url, retrying network errors, rate limiting and
+// server errors. Other client errors aren't retried: the request won't
+// improve.
+func postWebhook(client *http.Client, url string, body []byte) error {
+	delay := webhookRetryDelay
+	var err error
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+
+		var retry bool
+		if retry, err = sendWebhook(client, url, body); err == nil || !retry {
+			return err
+		}
+	}
+	return fmt.Errorf("%w (after %d attempts)", err, webhookAttempts)
+}
+
+// sendWebhook makes one delivery attempt and reports whether a failure is
+// worth retrying
+func sendWebhook(client *http.Client, url string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "quicktodo")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("unexpected status %s", resp.Status)
+}
+
+// webhookSummary describes a notification in one line, e.g.
+// "[myproject] task_updated: #3 Fix login (in_progress)"
+func webhookSummary(msgType string, data interface{}, projectName string) string {
+	subject := ""
+	switch value := data.(type) {
+	case *models.Task:
+		subject = fmt.Sprintf("#%d %s (%s)", value.ID, value.Title, value.Status)
+	case map[string]interface{}:
+		subject = fmt.Sprintf("#%v %v", value["id"], value["title"])
+	}
+
+	if subject == "" {
+		return fmt.Sprintf("[%s] %s", projectName, msgType)
+	}
+	return fmt.Sprintf("[%s] %s: %s", projectName, msgType, subject)
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"quicktodo/internal/config"
+	"quicktodo/internal/models"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func shortenWebhookRetries(t *testing.T) {
+	attempts, timeout, delay := webhookAttempts, webhookTimeout, webhookRetryDelay
+	webhookTimeout, webhookRetryDelay = time.Second, time.Millisecond
+	t.Cleanup(func() {
+		webhookAttempts, webhookTimeout, webhookRetryDelay = attempts, timeout, delay
+	})
+}
+
+func TestNotifyWebhooksRetries(t *testing.T) {
+	shortenWebhookRetries(t)
+
+	var calls atomic.Int32
+	var payload webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{Webhooks: []string{server.URL}}
+	task := &models.Task{ID: 4, Title: "Fix login", Status: models.StatusInProgress}
+	if err := NotifyWebhooks(cfg, "task_updated", task, "app"); err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls.Load())
+	}
+	if payload.Type != "task_updated" || payload.Project != "app" {
+		t.Errorf("Expected the notification message, got %+v", payload.NotificationMessage)
+	}
+	if want := "[app] task_updated: #4 Fix login (in_progress)"; payload.Text != want || payload.Content != want {
+		t.Errorf("Expected summary %q, got text %q and content %q", want, payload.Text, payload.Content)
+	}
+}
+
+func TestNotifyWebhooksFailures(t *testing.T) {
+	shortenWebhookRetries(t)
+
+	var calls atomic.Int32
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer rejecting.Close()
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ok.Close()
+
+	cfg := &config.Config{Webhooks: []string{rejecting.URL, ok.URL}}
+	err := NotifyWebhooks(cfg, "task_deleted", map[string]interface{}{"id": 2, "title": "Old"}, "app")
+	if err == nil || !strings.Contains(err.Error(), rejecting.URL) || strings.Contains(err.Error(), ok.URL) {
+		t.Fatalf("Expected only the rejecting webhook to fail, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected a client error not to be retried, got %d attempts", calls.Load())
+	}
+
+	if err := NotifyWebhooks(&config.Config{}, "task_deleted", nil, "app"); err != nil {
+		t.Errorf("Expected no webhooks to be a no-op, got %v", err)
+	}
+}
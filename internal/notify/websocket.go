@@ -2,6 +2,7 @@ package notify
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -23,7 +24,7 @@ type NotificationMessage struct {
 // NotifyWebServer sends a notification to any running web server instances
 func NotifyWebServer(cfg *config.Config, msgType string, data interface{}, projectName string) error {
 	// Try to send via HTTP to running server first
-	if err := sendHTTPNotification(msgType, data, projectName); err == nil {
+	if err := sendHTTPNotification(msgType, data, projectName, cfg.ServeWriteToken, cfg.ServePort); err == nil {
 		return nil
 	}
 	
@@ -32,15 +33,18 @@ func NotifyWebServer(cfg *config.Config, msgType string, data interface{}, proje
 }
 
 // sendHTTPNotification tries to send notification to running web server
-func sendHTTPNotification(msgType string, data interface{}, projectName string) error {
-	// Try common development ports
+func sendHTTPNotification(msgType string, data interface{}, projectName string, token string, configuredPort int) error {
+	// Try the configured serve port, then common development ports
 	ports := []int{8080, 3000, 8000, 8086, 9000, 8001, 8008}
+	if configuredPort != 0 {
+		ports = append([]int{configuredPort}, ports...)
+	}
 	
 	notification := NotificationMessage{
 		Type:      msgType,
 		Data:      data,
 		Project:   projectName,
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 	}
 	
 	jsonData, err := json.Marshal(notification)
@@ -50,7 +54,15 @@ func sendHTTPNotification(msgType string, data interface{}, projectName string)
 	
 	for _, port := range ports {
 		url := fmt.Sprintf("http://localhost:%d/api/notify", port)
-		resp, err := http.Post(url, "application/json", strings.NewReader(string(jsonData)))
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(string(jsonData)))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
 		if err == nil && resp.StatusCode == http.StatusOK {
 			resp.Body.Close()
 			return nil
@@ -74,7 +86,7 @@ func writeNotificationFile(cfg *config.Config, msgType string, data interface{},
 		Type:      msgType,
 		Data:      data,
 		Project:   projectName,
-		Timestamp: time.Now(),
+		Timestamp: time.Now().UTC(),
 	}
 	
 	jsonData, err := json.Marshal(notification)
@@ -89,14 +101,22 @@ func writeNotificationFile(cfg *config.Config, msgType string, data interface{},
 	return os.WriteFile(filePath, jsonData, 0644)
 }
 
+// notifyAll sends a notification to the web server and the configured webhooks
+func notifyAll(cfg *config.Config, msgType string, data interface{}, projectName string) error {
+	return errors.Join(
+		NotifyWebServer(cfg, msgType, data, projectName),
+		NotifyWebhooks(cfg, msgType, data, projectName),
+	)
+}
+
 // NotifyTaskCreated sends a task creation notification
 func NotifyTaskCreated(cfg *config.Config, task *models.Task, projectName string) error {
-	return NotifyWebServer(cfg, "task_created", task, projectName)
+	return notifyAll(cfg, "task_created", task, projectName)
 }
 
 // NotifyTaskUpdated sends a task update notification
 func NotifyTaskUpdated(cfg *config.Config, task *models.Task, projectName string) error {
-	return NotifyWebServer(cfg, "task_updated", task, projectName)
+	return notifyAll(cfg, "task_updated", task, projectName)
 }
 
 // NotifyTaskDeleted sends a task deletion notification
@@ -105,5 +125,5 @@ func NotifyTaskDeleted(cfg *config.Config, taskID int, title string, projectName
 		"id":    taskID,
 		"title": title,
 	}
-	return NotifyWebServer(cfg, "task_deleted", data, projectName)
+	return notifyAll(cfg, "task_deleted", data, projectName)
 }
\ No newline at end of file
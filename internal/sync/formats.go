@@ -0,0 +1,168 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TODO file formats selectable with the sync_format setting
+const (
+	FormatClaude   = "claude"
+	FormatMarkdown = "markdown"
+	FormatGeneric  = "generic"
+)
+
+// TodoFormatter renders TODO items in one file format and parses them back,
+// so the TODO file can be read again after it is written
+type TodoFormatter interface {
+	Format(items map[string]*TodoItem) ([]byte, error)
+	Parse(data []byte) (map[string]*TodoItem, error)
+}
+
+// FormatterFor returns the formatter of a sync format. An empty name is the
+// claude format, which was the only one before formats could be chosen.
+func FormatterFor(name string) (TodoFormatter, error) {
+	switch name {
+	case "", FormatClaude:
+		return claudeFormatter{}, nil
+	case FormatMarkdown:
+		return markdownFormatter{}, nil
+	case FormatGeneric:
+		return genericFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown sync format '%s'. Valid formats: %s, %s, %s", name, FormatClaude, FormatMarkdown, FormatGeneric)
+	}
+}
+
+// claudeFormatter writes items as a JSON object keyed by item ID, the file
+// format read by the Claude integration
+type claudeFormatter struct{}
+
+func (claudeFormatter) Format(items map[string]*TodoItem) ([]byte, error) {
+	return json.MarshalIndent(items, "", "  ")
+}
+
+func (claudeFormatter) Parse(data []byte) (map[string]*TodoItem, error) {
+	var items map[string]*TodoItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// genericFormatter writes items as a plain JSON array, ordered by project and
+// task ID, for tools that don't expect a keyed object
+type genericFormatter struct{}
+
+func (genericFormatter) Format(items map[string]*TodoItem) ([]byte, error) {
+	return json.MarshalIndent(sortedTodoItems(items), "", "  ")
+}
+
+func (genericFormatter) Parse(data []byte) (map[string]*TodoItem, error) {
+	var list []*TodoItem
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	items := make(map[string]*TodoItem, len(list))
+	for _, item := range list {
+		if item != nil && item.ID != "" {
+			items[item.ID] = item
+		}
+	}
+	return items, nil
+}
+
+// markdownFormatter writes items as a Markdown checklist with a section per
+// project: [ ] pending, [/] in progress, [x] completed. Each line ends with a
+// comment holding the item ID and priority so the file can be parsed back;
+// lines without one, such as items added by hand, are ignored.
+type markdownFormatter struct{}
+
+var markdownTodoLine = regexp.MustCompile(`^- \[([ /xX])\] (.*) <!-- quicktodo:(\S+) priority:(\S+) -->$`)
+
+func (markdownFormatter) Format(items map[string]*TodoItem) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("# AI TODOs\n")
+
+	project := ""
+	for i, item := range sortedTodoItems(items) {
+		if i == 0 || item.ProjectName != project {
+			project = item.ProjectName
+			fmt.Fprintf(&b, "\n## %s\n\n", project)
+		}
+
+		mark := " "
+		switch item.Status {
+		case "in_progress":
+			mark = "/"
+		case "completed":
+			mark = "x"
+		}
+		content := strings.Join(strings.Fields(item.Content), " ")
+		fmt.Fprintf(&b, "- [%s] %s <!-- quicktodo:%s priority:%s -->\n", mark, content, item.ID, item.Priority)
+	}
+	return []byte(b.String()), nil
+}
+
+func (markdownFormatter) Parse(data []byte) (map[string]*TodoItem, error) {
+	items := make(map[string]*TodoItem)
+	project := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if heading, ok := strings.CutPrefix(line, "## "); ok {
+			project = strings.TrimSpace(heading)
+			continue
+		}
+
+		match := markdownTodoLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		status := "pending"
+		switch match[1] {
+		case "/":
+			status = "in_progress"
+		case "x", "X":
+			status = "completed"
+		}
+		items[match[3]] = &TodoItem{
+			ID:          match[3],
+			Content:     match[2],
+			Status:      status,
+			Priority:    match[4],
+			ProjectName: project,
+		}
+	}
+	return items, nil
+}
+
+// sortedTodoItems orders items by project, then by task ID
+func sortedTodoItems(items map[string]*TodoItem) []*TodoItem {
+	sorted := make([]*TodoItem, 0, len(items))
+	for _, item := range items {
+		if item != nil {
+			sorted = append(sorted, item)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.ProjectName != b.ProjectName {
+			return a.ProjectName < b.ProjectName
+		}
+		if na, nb := todoTaskNumber(a), todoTaskNumber(b); na != nb {
+			return na < nb
+		}
+		return a.ID < b.ID
+	})
+	return sorted
+}
+
+// todoTaskNumber is the task ID at the end of an item's projectName-ID key
+func todoTaskNumber(item *TodoItem) int {
+	n, _ := strconv.Atoi(item.ID[strings.LastIndex(item.ID, "-")+1:])
+	return n
+}
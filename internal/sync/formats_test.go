@@ -0,0 +1,66 @@
+package sync
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormattersRoundTrip(t *testing.T) {
+	items := map[string]*TodoItem{
+		"alpha-10": {ID: "alpha-10", Content: "#10 Ship it", Status: "completed", Priority: "high", ProjectName: "alpha"},
+		"alpha-2":  {ID: "alpha-2", Content: "#2 Write docs", Status: "in_progress", Priority: "low", ProjectName: "alpha"},
+		"beta-1":   {ID: "beta-1", Content: "#1 Plan", Status: "pending", Priority: "medium", ProjectName: "beta"},
+	}
+
+	for _, name := range []string{FormatClaude, FormatMarkdown, FormatGeneric} {
+		formatter, err := FormatterFor(name)
+		if err != nil {
+			t.Fatalf("FormatterFor(%s) failed: %v", name, err)
+		}
+		data, err := formatter.Format(items)
+		if err != nil {
+			t.Fatalf("%s: Format failed: %v", name, err)
+		}
+		parsed, err := formatter.Parse(data)
+		if err != nil {
+			t.Fatalf("%s: Parse failed: %v", name, err)
+		}
+		if len(parsed) != len(items) {
+			t.Fatalf("%s: expected %d items back, got %d from %s", name, len(items), len(parsed), data)
+		}
+		for id, want := range items {
+			got := parsed[id]
+			if got == nil || got.Content != want.Content || got.Status != want.Status || got.Priority != want.Priority || got.ProjectName != want.ProjectName {
+				t.Errorf("%s: item %s came back as %+v", name, id, got)
+			}
+		}
+	}
+
+	if _, err := FormatterFor("yaml"); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+func TestMarkdownFormat(t *testing.T) {
+	items := map[string]*TodoItem{
+		"alpha-10": {ID: "alpha-10", Content: "#10 Ship it", Status: "completed", Priority: "high", ProjectName: "alpha"},
+		"alpha-2":  {ID: "alpha-2", Content: "#2 Write docs", Status: "in_progress", Priority: "low", ProjectName: "alpha"},
+	}
+
+	data, err := markdownFormatter{}.Format(items)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	text := string(data)
+	docs := strings.Index(text, "- [/] #2 Write docs")
+	ship := strings.Index(text, "- [x] #10 Ship it")
+	if !strings.Contains(text, "## alpha") || docs < 0 || ship < docs {
+		t.Errorf("Expected a checklist ordered by task ID, got:\n%s", text)
+	}
+
+	// Lines added by hand without an ID are ignored
+	parsed, err := markdownFormatter{}.Parse(append(data, "- [ ] Something else\n"...))
+	if err != nil || len(parsed) != 2 {
+		t.Errorf("Expected only the synced items to be parsed, got %v, %v", parsed, err)
+	}
+}
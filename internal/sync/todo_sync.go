@@ -6,6 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"quicktodo/internal/models"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -20,6 +23,7 @@ type TodoSyncConfig struct {
 	SyncOnDelete   bool   `json:"sync_on_delete"`
 	AgentID        string `json:"agent_id"`
 	LastSyncTime   time.Time `json:"last_sync_time"`
+	SyncFormat     string `json:"sync_format,omitempty"` // claude (the default), markdown or generic
 }
 
 // TodoItem represents a simplified TODO item for AI tracking
@@ -164,14 +168,120 @@ func (m *TodoSyncManager) SyncFromQuickTodo(tasks []*models.Task, projectName st
 	return m.saveSyncConfig()
 }
 
+// TodoStatusChange is a task whose status was changed in the TODO file
+type TodoStatusChange struct {
+	TodoID    string        `json:"todo_id"`
+	TaskID    int           `json:"task_id"`
+	OldStatus models.Status `json:"old_status"`
+	NewStatus models.Status `json:"new_status"`
+}
+
+// TodoImport is what the TODO file says has changed in a project's tasks
+type TodoImport struct {
+	Changes []*TodoStatusChange `json:"changes"`
+	Missing []string            `json:"missing"` // TODO item IDs with no matching task
+}
+
+// ImportFromTodoFile re-reads the TODO file and compares the project's items
+// with its tasks, matched on the projectName-ID key. Items whose status
+// differs from their task's are reported as changes to apply back; items
+// whose task no longer exists are listed as missing and otherwise ignored, as
+// are items with a status that isn't recognized.
+func (m *TodoSyncManager) ImportFromTodoFile(projectName string, tasks []*models.Task) (*TodoImport, error) {
+	if !m.enabled {
+		return nil, fmt.Errorf("TODO synchronization is disabled")
+	}
+	if err := m.loadTodoItems(); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*models.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	result := &TodoImport{Changes: []*TodoStatusChange{}, Missing: []string{}}
+	for todoID, item := range m.todoItems {
+		rest, ok := strings.CutPrefix(todoID, projectName+"-")
+		if !ok {
+			continue
+		}
+		taskID, err := strconv.Atoi(rest)
+		if err != nil {
+			continue
+		}
+
+		task, exists := byID[taskID]
+		if !exists || item == nil {
+			result.Missing = append(result.Missing, todoID)
+			continue
+		}
+
+		// The item still shows what was pushed; TODO lists have no cancelled
+		// status, so a cancelled task's item reads completed
+		if item.Status == mapTaskStatusToTodoStatus(task.Status) {
+			continue
+		}
+		status := models.NormalizeStatus(item.Status)
+		if !models.IsValidStatus(string(status)) || status == task.Status {
+			continue
+		}
+		result.Changes = append(result.Changes, &TodoStatusChange{
+			TodoID:    todoID,
+			TaskID:    taskID,
+			OldStatus: task.Status,
+			NewStatus: status,
+		})
+	}
+
+	sort.Slice(result.Changes, func(i, j int) bool { return result.Changes[i].TaskID < result.Changes[j].TaskID })
+	sort.Strings(result.Missing)
+	return result, nil
+}
+
 // GetTodoItems returns all TODO items
 func (m *TodoSyncManager) GetTodoItems() map[string]*TodoItem {
 	return m.todoItems
 }
 
-// GetTodoItemsAsJSON returns TODO items formatted for Claude's TodoWrite tool
+// ProjectTodoCounts counts a project's synchronized TODO items by status
+type ProjectTodoCounts struct {
+	Pending    int `json:"pending"`
+	InProgress int `json:"in_progress"`
+	Completed  int `json:"completed"`
+}
+
+// GetProjectBreakdown returns per-project counts of TODO items by status
+func (m *TodoSyncManager) GetProjectBreakdown() map[string]*ProjectTodoCounts {
+	breakdown := make(map[string]*ProjectTodoCounts)
+	for _, item := range m.todoItems {
+		counts, ok := breakdown[item.ProjectName]
+		if !ok {
+			counts = &ProjectTodoCounts{}
+			breakdown[item.ProjectName] = counts
+		}
+
+		switch item.Status {
+		case "in_progress":
+			counts.InProgress++
+		case "completed":
+			counts.Completed++
+		default:
+			counts.Pending++
+		}
+	}
+	return breakdown
+}
+
+// IsEnabled reports whether synchronization is enabled
+func (m *TodoSyncManager) IsEnabled() bool {
+	return m.enabled
+}
+
+// GetTodoItemsAsJSON returns TODO items formatted for Claude's TodoWrite tool,
+// along with a per-project breakdown and the sync settings
 func (m *TodoSyncManager) GetTodoItemsAsJSON() ([]byte, error) {
-	var todos []map[string]interface{}
+	todos := make([]map[string]interface{}, 0, len(m.todoItems))
 	
 	for _, item := range m.todoItems {
 		todo := map[string]interface{}{
@@ -187,9 +297,55 @@ func (m *TodoSyncManager) GetTodoItemsAsJSON() ([]byte, error) {
 		"todos": todos,
 		"last_sync": m.config.LastSyncTime,
 		"project_count": len(m.getProjectCounts()),
+		"projects": m.GetProjectBreakdown(),
+		"sync_config": map[string]interface{}{
+			"enabled":        m.enabled,
+			"auto_sync":      m.config.AutoSync,
+			"sync_on_create": m.config.SyncOnCreate,
+			"sync_on_edit":   m.config.SyncOnEdit,
+			"sync_on_status": m.config.SyncOnStatus,
+			"sync_on_delete": m.config.SyncOnDelete,
+		},
+		"sync_format": m.Format(),
 	}, "", "  ")
 }
 
+// Format returns the configured TODO file format
+func (m *TodoSyncManager) Format() string {
+	if m.config.SyncFormat == "" {
+		return FormatClaude
+	}
+	return m.config.SyncFormat
+}
+
+// RenderTodoItems renders the TODO items in the named format, or the
+// configured one when format is empty
+func (m *TodoSyncManager) RenderTodoItems(format string) ([]byte, error) {
+	if format == "" {
+		format = m.Format()
+	}
+	formatter, err := FormatterFor(format)
+	if err != nil {
+		return nil, err
+	}
+	return formatter.Format(m.todoItems)
+}
+
+// SetFormat changes the TODO file format and rewrites the file in it
+func (m *TodoSyncManager) SetFormat(format string) error {
+	if _, err := FormatterFor(format); err != nil {
+		return err
+	}
+	m.config.SyncFormat = format
+	if err := m.saveSyncConfig(); err != nil {
+		return err
+	}
+	if !m.enabled {
+		return nil
+	}
+	return m.saveTodoItems()
+}
+
 // Enable enables TODO synchronization
 func (m *TodoSyncManager) Enable() error {
 	m.enabled = true
@@ -212,7 +368,8 @@ func mapTaskStatusToTodoStatus(status models.Status) string {
 		return "pending"
 	case models.StatusInProgress:
 		return "in_progress"
-	case models.StatusDone:
+	case models.StatusDone, models.StatusCancelled:
+		// TODO lists have no cancelled status; either way the task is closed
 		return "completed"
 	default:
 		return "pending"
@@ -221,7 +378,8 @@ func mapTaskStatusToTodoStatus(status models.Status) string {
 
 func mapTaskPriorityToTodoPriority(priority models.Priority) string {
 	switch priority {
-	case models.PriorityHigh:
+	case models.PriorityCritical, models.PriorityHigh:
+		// TODO lists only know three priorities; critical is their highest
 		return "high"
 	case models.PriorityMedium:
 		return "medium"
@@ -253,10 +411,17 @@ func (m *TodoSyncManager) loadTodoItems() error {
 		return fmt.Errorf("failed to read TODO file: %w", err)
 	}
 
-	var items map[string]*TodoItem
-	if err := json.Unmarshal(data, &items); err != nil {
+	formatter, err := FormatterFor(m.config.SyncFormat)
+	if err != nil {
+		return err
+	}
+	items, err := formatter.Parse(data)
+	if err != nil {
 		return fmt.Errorf("failed to parse TODO file: %w", err)
 	}
+	if items == nil {
+		items = make(map[string]*TodoItem)
+	}
 
 	m.todoItems = items
 	return nil
@@ -272,7 +437,11 @@ func (m *TodoSyncManager) saveTodoItems() error {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(m.todoItems, "", "  ")
+	formatter, err := FormatterFor(m.config.SyncFormat)
+	if err != nil {
+		return err
+	}
+	data, err := formatter.Format(m.todoItems)
 	if err != nil {
 		return fmt.Errorf("failed to marshal TODO items: %w", err)
 	}
@@ -325,5 +494,6 @@ func defaultSyncConfig() *TodoSyncConfig {
 		SyncOnDelete: true,
 		AgentID:      "",
 		LastSyncTime: time.Now(),
+		SyncFormat:   FormatClaude,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,129 @@
+package sync
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"quicktodo/internal/models"
+	"testing"
+)
+
+func newTestSyncManager(t *testing.T) *TodoSyncManager {
+	t.Helper()
+	config := defaultSyncConfig()
+	config.Enabled = true
+	config.TodoFilePath = filepath.Join(t.TempDir(), "ai_todos.json")
+	return &TodoSyncManager{
+		config:    config,
+		todoItems: make(map[string]*TodoItem),
+		enabled:   true,
+	}
+}
+
+func TestGetTodoItemsAsJSONProjectBreakdown(t *testing.T) {
+	m := newTestSyncManager(t)
+	m.config.SyncOnDelete = false
+
+	alpha := []*models.Task{
+		models.NewTask(1, "Alpha pending"),
+		models.NewTask(2, "Alpha in progress"),
+		models.NewTask(3, "Alpha done"),
+		models.NewTask(4, "Alpha done too"),
+	}
+	alpha[1].UpdateStatus(models.StatusInProgress)
+	alpha[2].UpdateStatus(models.StatusDone)
+	alpha[3].UpdateStatus(models.StatusDone)
+
+	beta := []*models.Task{
+		models.NewTask(1, "Beta pending"),
+		models.NewTask(2, "Beta pending too"),
+	}
+
+	if err := m.SyncFromQuickTodo(alpha, "alpha"); err != nil {
+		t.Fatalf("SyncFromQuickTodo failed: %v", err)
+	}
+	if err := m.SyncFromQuickTodo(beta, "beta"); err != nil {
+		t.Fatalf("SyncFromQuickTodo failed: %v", err)
+	}
+
+	data, err := m.GetTodoItemsAsJSON()
+	if err != nil {
+		t.Fatalf("GetTodoItemsAsJSON failed: %v", err)
+	}
+
+	var status struct {
+		Todos        []map[string]interface{}     `json:"todos"`
+		ProjectCount int                          `json:"project_count"`
+		Projects     map[string]ProjectTodoCounts `json:"projects"`
+		SyncConfig   map[string]bool              `json:"sync_config"`
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		t.Fatalf("Failed to parse status JSON: %v\n%s", err, data)
+	}
+
+	if len(status.Todos) != 6 || status.ProjectCount != 2 {
+		t.Errorf("Expected 6 flat todos across 2 projects, got %d across %d", len(status.Todos), status.ProjectCount)
+	}
+
+	expected := map[string]ProjectTodoCounts{
+		"alpha": {Pending: 1, InProgress: 1, Completed: 2},
+		"beta":  {Pending: 2},
+	}
+	for project, want := range expected {
+		if got := status.Projects[project]; got != want {
+			t.Errorf("Project %s: got %+v, expected %+v", project, got, want)
+		}
+	}
+
+	if !status.SyncConfig["enabled"] || !status.SyncConfig["sync_on_create"] || status.SyncConfig["sync_on_delete"] {
+		t.Errorf("Unexpected sync config flags: %v", status.SyncConfig)
+	}
+}
+
+func TestImportFromTodoFile(t *testing.T) {
+	m := newTestSyncManager(t)
+
+	tasks := []*models.Task{
+		models.NewTask(1, "Unchanged"),
+		models.NewTask(2, "Finished elsewhere"),
+		models.NewTask(3, "Started elsewhere"),
+		models.NewTask(4, "Deleted later"),
+	}
+	if err := m.SyncFromQuickTodo(tasks, "alpha"); err != nil {
+		t.Fatalf("SyncFromQuickTodo failed: %v", err)
+	}
+	if err := m.OnTaskCreated(models.NewTask(2, "Other project"), "alpha-beta"); err != nil {
+		t.Fatalf("OnTaskCreated failed: %v", err)
+	}
+
+	// Another tool edits the TODO file
+	m.todoItems["alpha-2"].Status = "completed"
+	m.todoItems["alpha-3"].Status = "in_progress"
+	m.todoItems["alpha-beta-2"].Status = "completed"
+	m.todoItems["alpha-9"] = &TodoItem{ID: "alpha-9", Content: "#9 Unknown", Status: "completed", ProjectName: "alpha"}
+	if err := m.saveTodoItems(); err != nil {
+		t.Fatalf("saveTodoItems failed: %v", err)
+	}
+	m.todoItems = map[string]*TodoItem{}
+
+	result, err := m.ImportFromTodoFile("alpha", tasks[:3])
+	if err != nil {
+		t.Fatalf("ImportFromTodoFile failed: %v", err)
+	}
+	if len(result.Changes) != 2 {
+		t.Fatalf("Expected 2 status changes, got %+v", result.Changes)
+	}
+	if c := result.Changes[0]; c.TaskID != 2 || c.OldStatus != models.StatusPending || c.NewStatus != models.StatusDone {
+		t.Errorf("Unexpected first change: %+v", c)
+	}
+	if c := result.Changes[1]; c.TaskID != 3 || c.NewStatus != models.StatusInProgress {
+		t.Errorf("Unexpected second change: %+v", c)
+	}
+	if len(result.Missing) != 2 || result.Missing[0] != "alpha-4" || result.Missing[1] != "alpha-9" {
+		t.Errorf("Expected the items without tasks to be missing, got %v", result.Missing)
+	}
+
+	m.enabled = false
+	if _, err := m.ImportFromTodoFile("alpha", tasks); err == nil {
+		t.Error("Expected an error while synchronization is disabled")
+	}
+}
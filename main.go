@@ -1,8 +1,6 @@
 package main
 
 import (
-	"fmt"
-	"os"
 	"quicktodo/cmd"
 	"quicktodo/internal/commands"
 )
@@ -12,14 +10,19 @@ var (
 	Version   = "dev"
 	BuildTime = "unknown"
 	GoVersion = "unknown"
+	Commit    = "unknown"
 )
 
 func main() {
 	// Set version information in the root command
-	commands.RootCmd.Version = fmt.Sprintf("%s (built %s with %s)", Version, BuildTime, GoVersion)
+	commands.SetBuildInfo(commands.BuildInfo{
+		Version:   Version,
+		BuildTime: BuildTime,
+		GoVersion: GoVersion,
+		Commit:    Commit,
+	})
 
 	if err := cmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		commands.ExitWithError(err)
 	}
 }